@@ -21,12 +21,14 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/tektoncd/pipeline/cmd/entrypoint/subcommands"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/credentials"
 	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
 	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
@@ -38,38 +40,27 @@ var (
 	ep                  = flag.String("entrypoint", "", "Original specified entrypoint to execute")
 	waitFiles           = flag.String("wait_file", "", "Comma-separated list of paths to wait for")
 	waitFileContent     = flag.Bool("wait_file_content", false, "If specified, expect wait_file to have content")
+	sidecarReadyFiles   = flag.String("sidecar_ready_file", "", "Comma-separated list of paths projecting the readiness of the sidecars to wait for, expected to have content")
 	postFile            = flag.String("post_file", "", "If specified, file to write upon completion")
 	terminationPath     = flag.String("termination_path", "/tekton/termination", "If specified, file to write upon termination")
 	results             = flag.String("results", "", "If specified, list of file names that might contain task results")
 	timeout             = flag.Duration("timeout", time.Duration(0), "If specified, sets timeout for step")
 	breakpointOnFailure = flag.Bool("breakpoint_on_failure", false, "If specified, expect steps to not skip on failure")
+	breakpointBefore    = flag.Bool("breakpoint_before_step", false, "If specified, pause the step before running it until the breakpoint is exited")
+	breakpointAfter     = flag.Bool("breakpoint_after_step", false, "If specified, pause the step after running it until the breakpoint is exited")
 	onError             = flag.String("on_error", "", "Set to \"continue\" to ignore an error and continue when a container terminates with a non-zero exit code."+
 		" Set to \"stopAndFail\" to declare a failure with a step error and stop executing the rest of the steps.")
+	retries             = flag.Int("retries", 0, "If specified, the number of times the command is run again when it exits with a non-zero exit code")
+	when                = flag.String("when", "", "If specified, JSON list of when expressions that must all evaluate to true for the step to run")
 	stepMetadataDir     = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
 	stepMetadataDirLink = flag.String("step_metadata_dir_link", "", "creates a symbolic link to the specified step_metadata_dir e.g. /tekton/steps/<step-index>/")
+	structuredLogs      = flag.Bool("structured_logs", false, "If specified, prefix each line of the output of the step with its name and a timestamp, and tee it to the step_metadata_dir")
 )
 
 const (
 	defaultWaitPollingInterval = time.Second
-	breakpointExitSuffix       = ".breakpointexit"
 )
 
-func checkForBreakpointOnFailure(e entrypoint.Entrypointer, breakpointExitPostFile string) {
-	if e.BreakpointOnFailure {
-		if waitErr := e.Waiter.Wait(breakpointExitPostFile, false, false); waitErr != nil {
-			log.Println("error occurred while waiting for " + breakpointExitPostFile + " : " + waitErr.Error())
-		}
-		// get exitcode from .breakpointexit
-		exitCode, readErr := e.BreakpointExitCode(breakpointExitPostFile)
-		// if readErr exists, the exitcode with default to 0 as we would like
-		// to encourage to continue running the next steps in the taskRun
-		if readErr != nil {
-			log.Println("error occurred while reading breakpoint exit code : " + readErr.Error())
-		}
-		os.Exit(exitCode)
-	}
-}
-
 func main() {
 	// Add credential flags originally introduced with our legacy credentials helper
 	// image (creds-init).
@@ -99,22 +90,41 @@ func main() {
 		}
 	}
 
+	var whenExpressions v1beta1.WhenExpressions
+	if *when != "" {
+		var err error
+		if whenExpressions, err = entrypoint.UnmarshalWhen([]byte(*when)); err != nil {
+			log.Fatalf("Error parsing the when expressions %q: %v", *when, err)
+		}
+	}
+
+	runner := &realRunner{}
+	if *structuredLogs && *stepMetadataDir != "" {
+		runner.stepName = filepath.Base(*stepMetadataDir)
+		runner.logFile = filepath.Join(*stepMetadataDir, stepLogFile)
+	}
+
 	e := entrypoint.Entrypointer{
-		Entrypoint:          *ep,
-		WaitFiles:           strings.Split(*waitFiles, ","),
-		WaitFileContent:     *waitFileContent,
-		PostFile:            *postFile,
-		TerminationPath:     *terminationPath,
-		Args:                flag.Args(),
-		Waiter:              &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
-		Runner:              &realRunner{},
-		PostWriter:          &realPostWriter{},
-		Results:             strings.Split(*results, ","),
-		Timeout:             timeout,
-		BreakpointOnFailure: *breakpointOnFailure,
-		OnError:             *onError,
-		StepMetadataDir:     *stepMetadataDir,
-		StepMetadataDirLink: *stepMetadataDirLink,
+		Entrypoint:           *ep,
+		WaitFiles:            strings.Split(*waitFiles, ","),
+		WaitFileContent:      *waitFileContent,
+		SidecarReadyFiles:    strings.Split(*sidecarReadyFiles, ","),
+		PostFile:             *postFile,
+		TerminationPath:      *terminationPath,
+		Args:                 flag.Args(),
+		Waiter:               &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
+		Runner:               runner,
+		PostWriter:           &realPostWriter{},
+		Results:              strings.Split(*results, ","),
+		Timeout:              timeout,
+		BreakpointOnFailure:  *breakpointOnFailure,
+		BreakpointBeforeStep: *breakpointBefore,
+		BreakpointAfterStep:  *breakpointAfter,
+		Retries:              *retries,
+		OnError:              *onError,
+		When:                 whenExpressions,
+		StepMetadataDir:      *stepMetadataDir,
+		StepMetadataDirLink:  *stepMetadataDirLink,
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
@@ -124,11 +134,13 @@ func main() {
 	}
 
 	if err := e.Go(); err != nil {
-		breakpointExitPostFile := e.PostFile + breakpointExitSuffix
 		switch t := err.(type) {
 		case skipError:
 			log.Print("Skipping step because a previous step failed")
 			os.Exit(1)
+		case entrypoint.BreakpointExitError:
+			log.Print(err.Error())
+			os.Exit(t.ExitCode)
 		case termination.MessageLengthError:
 			log.Print(err.Error())
 			os.Exit(1)
@@ -140,7 +152,6 @@ func main() {
 			// in both cases has an ExitStatus() method with the
 			// same signature.
 			if status, ok := t.Sys().(syscall.WaitStatus); ok {
-				checkForBreakpointOnFailure(e, breakpointExitPostFile)
 				// ignore a step error i.e. do not exit if a container terminates with a non-zero exit code when onError is set to "continue"
 				if e.OnError != entrypoint.ContinueOnError {
 					os.Exit(status.ExitStatus())
@@ -151,7 +162,6 @@ func main() {
 				log.Fatalf("Error executing command (ExitError): %v", err)
 			}
 		default:
-			checkForBreakpointOnFailure(e, breakpointExitPostFile)
 			log.Fatalf("Error executing command: %v", err)
 		}
 	}
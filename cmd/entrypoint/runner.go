@@ -34,27 +34,48 @@ import (
 // realRunner actually runs commands.
 type realRunner struct {
 	signals chan os.Signal
+	// stepName prefixes each line of the output of the command along with a
+	// timestamp, and the prefixed output is teed to logFile, if any. The
+	// output is left untouched when stepName is empty.
+	stepName string
+	logFile  string
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
 
-func (rr *realRunner) Run(ctx context.Context, args ...string) error {
+func (rr *realRunner) Run(ctx context.Context, args ...string) (err error) {
 	if len(args) == 0 {
 		return nil
 	}
 	name, args := args[0], args[1:]
 
-	// Receive system signals on "rr.signals"
+	// Receive system signals on "rr.signals". The channel is closed once the
+	// command exits, so a new one is made for each run of a retried step.
 	if rr.signals == nil {
 		rr.signals = make(chan os.Signal, 1)
 	}
-	defer close(rr.signals)
-	signal.Notify(rr.signals)
-	defer signal.Reset()
+	signals := rr.signals
+	defer func() {
+		signal.Reset()
+		close(signals)
+		rr.signals = nil
+	}()
+	signal.Notify(signals)
 
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
+	if rr.stepName != "" {
+		var closeLogs func() error
+		if closeLogs, err = stepLogPipes(cmd, rr.stepName, rr.logFile); err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := closeLogs(); err == nil {
+				err = cerr
+			}
+		}()
+	}
 	// dedicated PID group used to forward signals to
 	// main process and all children
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
@@ -73,7 +94,7 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 
 	// Goroutine for signals forwarding
 	go func() {
-		for s := range rr.signals {
+		for s := range signals {
 			// Forward signal to main process and all children
 			if s != syscall.SIGCHLD {
 				_ = syscall.Kill(-cmd.Process.Pid, s.(syscall.Signal))
@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -23,6 +26,18 @@ func TestRealRunnerSignalForwarding(t *testing.T) {
 	}
 }
 
+// TestRealRunnerRunTwice tests that the same runner can run a command again after a first run,
+// as it does when a step is retried, and still receive signals.
+func TestRealRunnerRunTwice(t *testing.T) {
+	rr := realRunner{}
+	if err := rr.Run(context.Background(), "true"); err != nil {
+		t.Fatalf("unexpected error received on the first run: %v", err)
+	}
+	if err := rr.Run(context.Background(), "sh", "-c", "sleep 0.1 & wait"); err != nil {
+		t.Fatalf("unexpected error received on the second run: %v", err)
+	}
+}
+
 // TestRealRunnerTimeout tests whether cmd is killed after a millisecond even though it's supposed to sleep for 10 milliseconds.
 func TestRealRunnerTimeout(t *testing.T) {
 	rr := realRunner{}
@@ -37,3 +52,29 @@ func TestRealRunnerTimeout(t *testing.T) {
 		t.Fatalf("step didn't timeout")
 	}
 }
+
+// TestRealRunnerStructuredLogsBackgroundProcess tests that a command which leaves a process running in the
+// background returns when it exits, with its output in the log file of the step.
+func TestRealRunnerStructuredLogsBackgroundProcess(t *testing.T) {
+	dir, err := ioutil.TempDir("", "step-logs")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	rr := realRunner{stepName: "step-foo", logFile: filepath.Join(dir, stepLogFile)}
+
+	start := time.Now()
+	if err := rr.Run(context.Background(), "sh", "-c", "sleep 30 & echo hello"); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the runner to return once the command exited, but it took %s", elapsed)
+	}
+	b, err := ioutil.ReadFile(rr.logFile)
+	if err != nil {
+		t.Fatalf("error reading the log file: %v", err)
+	}
+	if !strings.HasPrefix(string(b), "[step-foo] ") || !strings.HasSuffix(string(b), " hello\n") {
+		t.Errorf("expected the output of the command in the log file, but got %q", b)
+	}
+}
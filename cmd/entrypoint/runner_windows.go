@@ -31,11 +31,16 @@ import (
 
 // realRunner actually runs commands.
 type realRunner struct {
+	// stepName prefixes each line of the output of the command along with a
+	// timestamp, and the prefixed output is teed to logFile, if any. The
+	// output is left untouched when stepName is empty.
+	stepName string
+	logFile  string
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
 
-func (rr *realRunner) Run(ctx context.Context, args ...string) error {
+func (rr *realRunner) Run(ctx context.Context, args ...string) (err error) {
 	if len(args) == 0 {
 		return nil
 	}
@@ -44,6 +49,17 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
+	if rr.stepName != "" {
+		var closeLogs func() error
+		if closeLogs, err = stepLogPipes(cmd, rr.stepName, rr.logFile); err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := closeLogs(); err == nil {
+				err = cerr
+			}
+		}()
+	}
 
 	// Run the defined command
 	if err := cmd.Run(); err != nil {
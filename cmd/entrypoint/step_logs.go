@@ -0,0 +1,234 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+const (
+	// stepLogFile is the name of the file in the step metadata dir to which
+	// the structured output of the step is teed.
+	stepLogFile = "output.log"
+	// stepLogTimeFormat is RFC3339 with milliseconds.
+	stepLogTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+	// maxStepLogLineLength is the length past which a line that doesn't end
+	// yet is written anyway, so that the output isn't held back indefinitely.
+	maxStepLogLineLength = 64 * 1024
+	// stepLogDrainTimeout is how long the output of the step is still copied
+	// for once its command exits, when a process the command left running in
+	// the background keeps the output open.
+	stepLogDrainTimeout = time.Second
+)
+
+// stepLogWriter prefixes each line written to it with the name of the step
+// and a timestamp, and writes the prefixed lines to out and to the log file.
+type stepLogWriter struct {
+	stepName string
+	out      io.Writer
+	logFile  io.Writer
+	// mu is shared by the writers of the stdout and stderr of a step, so that
+	// their lines don't interleave in the log file.
+	mu  *sync.Mutex
+	now func() time.Time
+	// bufMu guards buf, since the logs can be flushed while the output of a
+	// background process is still being copied.
+	bufMu sync.Mutex
+	buf   []byte
+}
+
+func (w *stepLogWriter) Write(p []byte) (int, error) {
+	w.bufMu.Lock()
+	defer w.bufMu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			if len(w.buf) < maxStepLogLineLength {
+				return len(p), nil
+			}
+			i = maxStepLogLineLength - 1
+		}
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush writes the last line, if it doesn't end with a newline.
+func (w *stepLogWriter) Flush() error {
+	w.bufMu.Lock()
+	defer w.bufMu.Unlock()
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLine(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *stepLogWriter) writeLine(line []byte) error {
+	var prefixed bytes.Buffer
+	fmt.Fprintf(&prefixed, "[%s] %s ", w.stepName, w.now().UTC().Format(stepLogTimeFormat))
+	prefixed.Write(line)
+	if line[len(line)-1] != '\n' {
+		prefixed.WriteByte('\n')
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.out.Write(prefixed.Bytes()); err != nil {
+		return err
+	}
+	if w.logFile != nil {
+		if _, err := w.logFile.Write(prefixed.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stepLogWriters returns the writers of the structured stdout and stderr of
+// the step, and a function which flushes them and closes the log file. The
+// log file is appended to, since the command of a step with retries is run
+// more than once.
+func stepLogWriters(stepName, logFile string) (*stepLogWriter, *stepLogWriter, func() error, error) {
+	var f *os.File
+	if logFile != "" {
+		var err error
+		if f, err = os.OpenFile(logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644); err != nil {
+			return nil, nil, nil, fmt.Errorf("error opening the log file of the step: %w", err)
+		}
+	}
+	mu := &sync.Mutex{}
+	stdout := &stepLogWriter{stepName: stepName, out: os.Stdout, mu: mu, now: time.Now}
+	stderr := &stepLogWriter{stepName: stepName, out: os.Stderr, mu: mu, now: time.Now}
+	if f != nil {
+		stdout.logFile = f
+		stderr.logFile = f
+	}
+	closeLogs := func() error {
+		err := stdout.Flush()
+		if ferr := stderr.Flush(); err == nil {
+			err = ferr
+		}
+		if f != nil {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+		return err
+	}
+	return stdout, stderr, closeLogs, nil
+}
+
+// stepLogPipes connects the stdout and stderr of cmd to the step log writers
+// through pipes. exec.Cmd copies the output written to writers which aren't
+// files until every process holding the output closes it, so cmd.Wait would
+// not return while a process left running in the background by the command,
+// like `dockerd &`, is alive. The returned function is called once the command
+// exited: it waits for the output to be copied, for at most
+// stepLogDrainTimeout, then flushes and closes the logs.
+func stepLogPipes(cmd *exec.Cmd, stepName, logFile string) (func() error, error) {
+	stdout, stderr, closeLogs, err := stepLogWriters(stepName, logFile)
+	if err != nil {
+		return nil, err
+	}
+	var readers, writers []*os.File
+	closeAll := func(files []*os.File) {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}
+	for i := 0; i < 2; i++ {
+		r, w, err := os.Pipe()
+		if err != nil {
+			closeAll(readers)
+			closeAll(writers)
+			_ = closeLogs()
+			return nil, fmt.Errorf("error creating the pipes of the output of the step: %w", err)
+		}
+		readers = append(readers, r)
+		writers = append(writers, w)
+	}
+
+	copyErrs := make(chan error, 2)
+	var wg sync.WaitGroup
+	for i, w := range []*stepLogWriter{stdout, stderr} {
+		wg.Add(1)
+		go func(r io.Reader, w io.Writer) {
+			defer wg.Done()
+			copyErrs <- drain(w, r)
+		}(readers[i], w)
+	}
+	copied := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(copied)
+	}()
+
+	cmd.Stdout = writers[0]
+	cmd.Stderr = writers[1]
+	return func() error {
+		// The processes of the step hold their own copies of the pipes, so the
+		// copies end once all of them exited.
+		closeAll(writers)
+		select {
+		case <-copied:
+		case <-time.After(stepLogDrainTimeout):
+		}
+		closeAll(readers)
+		var err error
+		for done := false; !done; {
+			select {
+			case cerr := <-copyErrs:
+				if err == nil {
+					err = cerr
+				}
+			default:
+				done = true
+			}
+		}
+		if cerr := closeLogs(); err == nil {
+			err = cerr
+		}
+		return err
+	}, nil
+}
+
+// drain copies r to w until r is closed, and keeps reading r after an error
+// writing to w, so that the processes writing to r never block on it.
+func drain(w io.Writer, r io.Reader) error {
+	var werr error
+	buf := make([]byte, 32*1024)
+	for {
+		n, rerr := r.Read(buf)
+		if n > 0 && werr == nil {
+			_, werr = w.Write(buf[:n])
+		}
+		if rerr != nil {
+			return werr
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestStepLogWriter(t *testing.T) {
+	now := func() time.Time { return time.Date(2022, 3, 4, 5, 6, 7, 890000000, time.UTC) }
+	for _, c := range []struct {
+		desc   string
+		writes []string
+		want   string
+	}{{
+		desc:   "lines",
+		writes: []string{"hello\nworld\n"},
+		want:   "[step-foo] 2022-03-04T05:06:07.890Z hello\n[step-foo] 2022-03-04T05:06:07.890Z world\n",
+	}, {
+		desc:   "lines split across writes",
+		writes: []string{"hel", "lo\nwor", "ld\n"},
+		want:   "[step-foo] 2022-03-04T05:06:07.890Z hello\n[step-foo] 2022-03-04T05:06:07.890Z world\n",
+	}, {
+		desc:   "last line without newline",
+		writes: []string{"hello\n::progress::50%"},
+		want:   "[step-foo] 2022-03-04T05:06:07.890Z hello\n[step-foo] 2022-03-04T05:06:07.890Z ::progress::50%\n",
+	}, {
+		desc:   "empty lines",
+		writes: []string{"\n\n"},
+		want:   "[step-foo] 2022-03-04T05:06:07.890Z \n[step-foo] 2022-03-04T05:06:07.890Z \n",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			var out, logFile bytes.Buffer
+			w := &stepLogWriter{stepName: "step-foo", out: &out, logFile: &logFile, mu: &sync.Mutex{}, now: now}
+			for _, s := range c.writes {
+				if n, err := w.Write([]byte(s)); err != nil || n != len(s) {
+					t.Fatalf("Write(%q) = %d, %v", s, n, err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+			if d := cmp.Diff(c.want, out.String()); d != "" {
+				t.Errorf("Unexpected output %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(c.want, logFile.String()); d != "" {
+				t.Errorf("Unexpected log file content %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepLogWriterLongLine(t *testing.T) {
+	var out bytes.Buffer
+	w := &stepLogWriter{stepName: "step-foo", out: &out, mu: &sync.Mutex{}, now: time.Now}
+	if _, err := w.Write([]byte(strings.Repeat("a", maxStepLogLineLength+10))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	// The line is written without waiting for its end.
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 1 || !strings.HasSuffix(lines[0], " "+strings.Repeat("a", maxStepLogLineLength)) {
+		t.Errorf("Expected a line of %d characters to be written but got %d lines", maxStepLogLineLength, len(lines))
+	}
+	if len(w.buf) != 10 {
+		t.Errorf("Expected the remaining 10 characters to be buffered but got %d", len(w.buf))
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestDrain(t *testing.T) {
+	var out bytes.Buffer
+	if err := drain(&out, strings.NewReader("hello\n")); err != nil {
+		t.Fatalf("drain() = %v", err)
+	}
+	if out.String() != "hello\n" {
+		t.Errorf("expected the input to be copied, but got %q", out.String())
+	}
+
+	// The input is read to the end after an error writing it, and the error is returned.
+	in := strings.NewReader(strings.Repeat("x", 100*1024))
+	if err := drain(failingWriter{}, in); err == nil || err.Error() != "disk full" {
+		t.Errorf("expected the write error, but got %v", err)
+	}
+	if in.Len() != 0 {
+		t.Errorf("expected the input to be read to the end, but %d bytes are left", in.Len())
+	}
+}
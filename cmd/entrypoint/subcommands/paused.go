@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+// PausedCommand is the readiness probe of the steps with breakpoints, which succeeds only while the
+// step is paused at a breakpoint
+const PausedCommand = entrypoint.PausedCommand
+
+// paused returns the breakpoint at which a step is paused, from the paused file it writes while it's
+// paused, and an error if the step isn't paused.
+func paused(pausedFile string) (string, error) {
+	breakpoint, err := ioutil.ReadFile(pausedFile)
+	if os.IsNotExist(err) {
+		return "", errors.New("step isn't paused")
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading paused file %q: %w", pausedFile, err)
+	}
+	return string(breakpoint), nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPaused(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "paused-test-*")
+	if err != nil {
+		t.Fatalf("error creating temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+	pausedFile := filepath.Join(tmp, "0.paused")
+
+	err = Process([]string{PausedCommand, pausedFile})
+	if _, ok := err.(SubcommandError); !ok {
+		t.Fatalf("expected the step without paused file not to be paused but got %v", err)
+	}
+	if want := "paused error: step isn't paused"; err.Error() != want {
+		t.Errorf("expected error %q but got %q", want, err.Error())
+	}
+
+	if err := ioutil.WriteFile(pausedFile, []byte("before"), 0666); err != nil {
+		t.Fatalf("error writing paused file: %v", err)
+	}
+	err = Process([]string{PausedCommand, pausedFile})
+	if _, ok := err.(SubcommandSuccessful); !ok {
+		t.Fatalf("expected the step with paused file to be paused but got %v", err)
+	}
+	if want := "Step is paused at breakpoint before"; err.Error() != want {
+		t.Errorf("expected message %q but got %q", want, err.Error())
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+const SidecarLogResultsCommand = "sidecar-log-results"
+
+// sidecarLogResultsWaitPollingInterval is how often the post file of the last
+// Step is checked for.
+var sidecarLogResultsWaitPollingInterval = time.Second
+
+// sidecarLogResults waits until the Step that writes postFile is done, then
+// reads the named results from resultsDir and prints each of them as a JSON
+// line to out, so that they can be read back from the container logs. An error
+// is returned if the Step isn't done within timeout, which happens when it is
+// killed before it can write postFile. A zero timeout waits forever.
+func sidecarLogResults(resultsDir, postFile, resultNames string, timeout time.Duration, out io.Writer) error {
+	start := time.Now()
+	for {
+		if _, err := os.Stat(postFile); err == nil {
+			break
+		}
+		if _, err := os.Stat(postFile + ".err"); err == nil {
+			break
+		}
+		if timeout > 0 && time.Since(start) >= timeout {
+			return fmt.Errorf("timed out after %s waiting for %s", timeout, postFile)
+		}
+		time.Sleep(sidecarLogResultsWaitPollingInterval)
+	}
+
+	encoder := json.NewEncoder(out)
+	for _, name := range strings.Split(resultNames, ",") {
+		if name == "" {
+			continue
+		}
+		value, err := ioutil.ReadFile(filepath.Join(resultsDir, name))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("error reading result %q: %w", name, err)
+		}
+		if err := encoder.Encode(v1beta1.PipelineResourceResult{
+			Key:        name,
+			Value:      string(value),
+			ResultType: v1beta1.TaskRunResultType,
+		}); err != nil {
+			return fmt.Errorf("error writing result %q: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestSidecarLogResults(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		postFile string
+		want     string
+	}{{
+		name:     "last step succeeded",
+		postFile: "0",
+		want: `{"key":"large","value":"` + strings.Repeat("a", 5000) + `","type":"TaskRunResult"}
+{"key":"small","value":"hello","type":"TaskRunResult"}
+`,
+	}, {
+		name:     "last step failed",
+		postFile: "0.err",
+		want: `{"key":"large","value":"` + strings.Repeat("a", 5000) + `","type":"TaskRunResult"}
+{"key":"small","value":"hello","type":"TaskRunResult"}
+`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp, err := ioutil.TempDir("", "sidecar-log-results-test-*")
+			if err != nil {
+				t.Fatalf("error creating temp directory: %v", err)
+			}
+			defer os.RemoveAll(tmp)
+			resultsDir := filepath.Join(tmp, "results")
+			if err := os.Mkdir(resultsDir, 0755); err != nil {
+				t.Fatalf("error creating results directory: %v", err)
+			}
+			for name, value := range map[string]string{"large": strings.Repeat("a", 5000), "small": "hello"} {
+				if err := ioutil.WriteFile(filepath.Join(resultsDir, name), []byte(value), 0644); err != nil {
+					t.Fatalf("error writing result %q: %v", name, err)
+				}
+			}
+			if err := ioutil.WriteFile(filepath.Join(tmp, tc.postFile), nil, 0644); err != nil {
+				t.Fatalf("error writing post file: %v", err)
+			}
+
+			out := &bytes.Buffer{}
+			if err := sidecarLogResults(resultsDir, filepath.Join(tmp, "0"), "large,small,missing", 0, out); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, out.String()); d != "" {
+				t.Errorf("sidecarLogResults() diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSidecarLogResultsWaitsForPostFile(t *testing.T) {
+	original := sidecarLogResultsWaitPollingInterval
+	sidecarLogResultsWaitPollingInterval = 10 * time.Millisecond
+	defer func() { sidecarLogResultsWaitPollingInterval = original }()
+
+	tmp, err := ioutil.TempDir("", "sidecar-log-results-test-*")
+	if err != nil {
+		t.Fatalf("error creating temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+	postFile := filepath.Join(tmp, "0")
+
+	done := make(chan error)
+	out := &bytes.Buffer{}
+	go func() {
+		done <- sidecarLogResults(tmp, postFile, "result", 0, out)
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("sidecarLogResults returned before the post file was written: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(tmp, "result"), []byte("value"), 0644); err != nil {
+		t.Fatalf("error writing result: %v", err)
+	}
+	if err := ioutil.WriteFile(postFile, nil, 0644); err != nil {
+		t.Fatalf("error writing post file: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("sidecarLogResults did not return after the post file was written")
+	}
+	if want := `{"key":"result","value":"value","type":"TaskRunResult"}` + "\n"; out.String() != want {
+		t.Errorf("sidecarLogResults() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestSidecarLogResultsTimesOut(t *testing.T) {
+	original := sidecarLogResultsWaitPollingInterval
+	sidecarLogResultsWaitPollingInterval = 10 * time.Millisecond
+	defer func() { sidecarLogResultsWaitPollingInterval = original }()
+
+	tmp, err := ioutil.TempDir("", "sidecar-log-results-test-*")
+	if err != nil {
+		t.Fatalf("error creating temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	out := &bytes.Buffer{}
+	if err := sidecarLogResults(tmp, filepath.Join(tmp, "0"), "result", 50*time.Millisecond, out); err == nil {
+		t.Fatal("expected an error when the post file is never written")
+	}
+	if out.Len() != 0 {
+		t.Errorf("sidecarLogResults() wrote %q, want nothing", out.String())
+	}
+}
@@ -18,6 +18,8 @@ package subcommands
 
 import (
 	"fmt"
+	"os"
+	"time"
 )
 
 type SubcommandSuccessful struct {
@@ -70,6 +72,35 @@ func Process(args []string) error {
 			}
 			return SubcommandSuccessful{message: fmt.Sprintf("Decoded script %s", src)}
 		}
+	case SidecarLogResultsCommand:
+		// If invoked in "sidecar-log-results" mode
+		// (`entrypoint sidecar-log-results <results-dir> <post-file> <result-names> <timeout>`),
+		// wait for the last Step to be done and print its results to stdout.
+		// This is used to read results that don't fit in the termination message.
+		if len(args) == 5 {
+			resultsDir, postFile, resultNames := args[1], args[2], args[3]
+			timeout, err := time.ParseDuration(args[4])
+			if err != nil {
+				return SubcommandError{subcommand: SidecarLogResultsCommand, message: err.Error()}
+			}
+			if err := sidecarLogResults(resultsDir, postFile, resultNames, timeout, os.Stdout); err != nil {
+				return SubcommandError{subcommand: SidecarLogResultsCommand, message: err.Error()}
+			}
+			return SubcommandSuccessful{message: fmt.Sprintf("Wrote results from %s", resultsDir)}
+		}
+	case PausedCommand:
+		// If invoked in "paused" mode (`entrypoint paused <paused-file>`),
+		// succeed only while the step is paused at a breakpoint. This is the
+		// readiness probe of the steps with breakpoints, which reports the
+		// paused steps.
+		if len(args) == 2 {
+			pausedFile := args[1]
+			breakpoint, err := paused(pausedFile)
+			if err != nil {
+				return SubcommandError{subcommand: PausedCommand, message: err.Error()}
+			}
+			return SubcommandSuccessful{message: fmt.Sprintf("Step is paused at breakpoint %s", breakpoint)}
+		}
 	default:
 	}
 	return nil
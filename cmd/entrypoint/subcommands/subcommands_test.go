@@ -67,4 +67,8 @@ func TestProcessIgnoresNonSubcommands(t *testing.T) {
 	if err := Process([]string{DecodeScriptCommand, "foo.txt", "bar.txt"}); err != nil {
 		t.Errorf("unexpected error processing decode-script command with invalid number of args: %v", err)
 	}
+
+	if err := Process([]string{SidecarLogResultsCommand, "/tekton/results"}); err != nil {
+		t.Errorf("unexpected error processing sidecar-log-results command with invalid number of args: %v", err)
+	}
 }
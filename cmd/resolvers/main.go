@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"log"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/git"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/signals"
+)
+
+func main() {
+	// Git credentials are passed with the flags of the legacy credentials helper
+	// (creds-init), for example -basic-git=my-secret=https://github.com, and read
+	// from the secrets mounted under /tekton/creds-secrets.
+	gitcreds.AddFlags(flag.CommandLine)
+	cfg := injection.ParseAndGetRESTConfigOrDie()
+
+	home, err := homedir.Dir()
+	if err != nil {
+		log.Fatalf("Error getting the home directory: %v", err)
+	}
+	if err := gitcreds.NewBuilder().Write(home); err != nil {
+		log.Fatalf("Error writing git credentials: %v", err)
+	}
+
+	sharedmain.MainWithConfig(signals.NewContext(), "tekton-pipelines-resolvers", cfg,
+		framework.NewController(git.NewResolver()),
+	)
+}
@@ -172,7 +172,7 @@ func PipelineResult(name, value, description string) PipelineSpecOp {
 	return func(ps *v1alpha1.PipelineSpec) {
 		pResult := &v1beta1.PipelineResult{
 			Name:        name,
-			Value:       value,
+			Value:       *v1beta1.NewArrayOrString(value),
 			Description: description,
 		}
 		ps.Results = append(ps.Results, *pResult)
@@ -184,7 +184,7 @@ func PipelineRunResult(name, value string) PipelineRunStatusOp {
 	return func(s *v1alpha1.PipelineRunStatus) {
 		pResult := &v1beta1.PipelineRunResult{
 			Name:  name,
-			Value: value,
+			Value: *v1beta1.NewArrayOrString(value),
 		}
 		s.PipelineResults = append(s.PipelineResults, *pResult)
 	}
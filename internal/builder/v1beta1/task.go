@@ -370,7 +370,7 @@ func TaskRunResult(name, value string) TaskRunStatusOp {
 	return func(s *v1beta1.TaskRunStatus) {
 		s.TaskRunResults = append(s.TaskRunResults, v1beta1.TaskRunResult{
 			Name:  name,
-			Value: value,
+			Value: *v1beta1.NewArrayOrString(value),
 		})
 	}
 }
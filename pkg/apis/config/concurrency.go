@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ConcurrencyRule limits the number of PipelineRuns it matches that run at once
+// in a namespace. The PipelineRuns above the limit are queued.
+// +k8s:deepcopy-gen=true
+type ConcurrencyRule struct {
+	// Pipeline matches the PipelineRuns of the named Pipeline.
+	Pipeline string `json:"pipeline,omitempty"`
+	// Selector matches the PipelineRuns that have all of these labels.
+	Selector map[string]string `json:"selector,omitempty"`
+	// Limit is the number of matching PipelineRuns that can run at once in a namespace.
+	Limit int `json:"limit"`
+}
+
+// Matches returns true if a PipelineRun of the named Pipeline with the given
+// labels matches the rule.
+func (r ConcurrencyRule) Matches(pipelineName string, labels map[string]string) bool {
+	if r.Pipeline != "" && r.Pipeline != pipelineName {
+		return false
+	}
+	for k, v := range r.Selector {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Concurrency holds the concurrency rules for PipelineRuns, keyed by name.
+// +k8s:deepcopy-gen=true
+type Concurrency struct {
+	Rules map[string]ConcurrencyRule
+}
+
+// GetConcurrencyConfigName returns the name of the configmap containing the
+// concurrency rules for PipelineRuns.
+func GetConcurrencyConfigName() string {
+	if e := os.Getenv("CONFIG_CONCURRENCY_NAME"); e != "" {
+		return e
+	}
+	return "config-concurrency"
+}
+
+// RuleNames returns the names of the rules in a stable order.
+func (cfg *Concurrency) RuleNames() []string {
+	names := make([]string, 0, len(cfg.Rules))
+	for name := range cfg.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewConcurrencyFromMap returns a Concurrency given a map corresponding to a ConfigMap.
+// Each key names a rule and its value is the YAML of the rule. Keys starting with
+// an underscore, such as "_example", are ignored.
+func NewConcurrencyFromMap(cfgMap map[string]string) (*Concurrency, error) {
+	tc := Concurrency{
+		Rules: map[string]ConcurrencyRule{},
+	}
+	for name, value := range cfgMap {
+		if strings.HasPrefix(name, "_") {
+			continue
+		}
+		var rule ConcurrencyRule
+		if err := yaml.Unmarshal([]byte(value), &rule); err != nil {
+			return nil, fmt.Errorf("failed to parse concurrency rule %q: %w", name, err)
+		}
+		if rule.Pipeline == "" && len(rule.Selector) == 0 {
+			return nil, fmt.Errorf("concurrency rule %q must set a pipeline or a selector", name)
+		}
+		if rule.Limit < 1 {
+			return nil, fmt.Errorf("concurrency rule %q must have a limit of at least 1, got %d", name, rule.Limit)
+		}
+		tc.Rules[name] = rule
+	}
+	return &tc, nil
+}
+
+// NewConcurrencyFromConfigMap returns a Concurrency for the given configmap
+func NewConcurrencyFromConfigMap(config *corev1.ConfigMap) (*Concurrency, error) {
+	return NewConcurrencyFromMap(config.Data)
+}
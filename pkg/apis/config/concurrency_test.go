@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewConcurrencyFromConfigMap(t *testing.T) {
+	expectedConfig := &config.Concurrency{
+		Rules: map[string]config.ConcurrencyRule{
+			"deploy": {
+				Pipeline: "deploy",
+				Limit:    1,
+			},
+			"nightly": {
+				Selector: map[string]string{"team": "infra"},
+				Limit:    2,
+			},
+		},
+	}
+	verifyConfigFileWithExpectedConcurrencyConfig(t, config.GetConcurrencyConfigName(), expectedConfig)
+}
+
+func TestNewConcurrencyFromEmptyConfigMap(t *testing.T) {
+	expectedConfig := &config.Concurrency{
+		Rules: map[string]config.ConcurrencyRule{},
+	}
+	verifyConfigFileWithExpectedConcurrencyConfig(t, "config-concurrency-empty", expectedConfig)
+}
+
+func TestNewConcurrencyConfigMapErrors(t *testing.T) {
+	for _, tc := range []struct {
+		fileName string
+	}{{
+		fileName: "config-concurrency-invalid-limit",
+	}, {
+		fileName: "config-concurrency-invalid-rule",
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			if _, err := config.NewConcurrencyFromConfigMap(cm); err == nil {
+				t.Error("expected error but received nil")
+			}
+		})
+	}
+}
+
+func TestConcurrencyRuleMatches(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		rule         config.ConcurrencyRule
+		pipelineName string
+		labels       map[string]string
+		want         bool
+	}{{
+		name:         "pipeline matches",
+		rule:         config.ConcurrencyRule{Pipeline: "deploy"},
+		pipelineName: "deploy",
+		want:         true,
+	}, {
+		name:         "pipeline doesn't match",
+		rule:         config.ConcurrencyRule{Pipeline: "deploy"},
+		pipelineName: "build",
+		want:         false,
+	}, {
+		name:   "selector matches",
+		rule:   config.ConcurrencyRule{Selector: map[string]string{"env": "prod"}},
+		labels: map[string]string{"env": "prod", "team": "infra"},
+		want:   true,
+	}, {
+		name:   "selector doesn't match",
+		rule:   config.ConcurrencyRule{Selector: map[string]string{"env": "prod", "team": "infra"}},
+		labels: map[string]string{"env": "prod"},
+		want:   false,
+	}, {
+		name:         "pipeline and selector match",
+		rule:         config.ConcurrencyRule{Pipeline: "deploy", Selector: map[string]string{"env": "prod"}},
+		pipelineName: "deploy",
+		labels:       map[string]string{"env": "prod"},
+		want:         true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rule.Matches(tc.pipelineName, tc.labels); got != tc.want {
+				t.Errorf("Matches() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func verifyConfigFileWithExpectedConcurrencyConfig(t *testing.T, fileName string, expectedConfig *config.Concurrency) {
+	cm := test.ConfigMapFromTestFile(t, fileName)
+	if c, err := config.NewConcurrencyFromConfigMap(cm); err == nil {
+		if d := cmp.Diff(expectedConfig, c); d != "" {
+			t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+		}
+	} else {
+		t.Errorf("NewConcurrencyFromConfigMap(actual) = %v", err)
+	}
+}
@@ -28,17 +28,18 @@ import (
 )
 
 const (
-	DefaultTimeoutMinutes          = 60
-	NoTimeoutDuration              = 0 * time.Minute
-	defaultTimeoutMinutesKey       = "default-timeout-minutes"
-	defaultServiceAccountKey       = "default-service-account"
-	DefaultServiceAccountValue     = "default"
-	defaultManagedByLabelValueKey  = "default-managed-by-label-value"
-	DefaultManagedByLabelValue     = "tekton-pipelines"
-	defaultPodTemplateKey          = "default-pod-template"
-	defaultCloudEventsSinkKey      = "default-cloud-events-sink"
-	DefaultCloudEventSinkValue     = ""
-	defaultTaskRunWorkspaceBinding = "default-task-run-workspace-binding"
+	DefaultTimeoutMinutes           = 60
+	NoTimeoutDuration               = 0 * time.Minute
+	defaultTimeoutMinutesKey        = "default-timeout-minutes"
+	defaultServiceAccountKey        = "default-service-account"
+	DefaultServiceAccountValue      = "default"
+	defaultManagedByLabelValueKey   = "default-managed-by-label-value"
+	DefaultManagedByLabelValue      = "tekton-pipelines"
+	defaultPodTemplateKey           = "default-pod-template"
+	defaultCloudEventsSinkKey       = "default-cloud-events-sink"
+	DefaultCloudEventSinkValue      = ""
+	defaultTaskRunWorkspaceBinding  = "default-task-run-workspace-binding"
+	defaultPendingTimeoutMinutesKey = "default-pending-timeout-minutes"
 )
 
 // Defaults holds the default configurations
@@ -50,6 +51,7 @@ type Defaults struct {
 	DefaultPodTemplate             *pod.Template
 	DefaultCloudEventsSink         string
 	DefaultTaskRunWorkspaceBinding string
+	DefaultPendingTimeoutMinutes   int
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -76,7 +78,8 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultManagedByLabelValue == cfg.DefaultManagedByLabelValue &&
 		other.DefaultPodTemplate.Equals(cfg.DefaultPodTemplate) &&
 		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
-		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding
+		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
+		other.DefaultPendingTimeoutMinutes == cfg.DefaultPendingTimeoutMinutes
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -119,6 +122,14 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 	if bindingYAML, ok := cfgMap[defaultTaskRunWorkspaceBinding]; ok {
 		tc.DefaultTaskRunWorkspaceBinding = bindingYAML
 	}
+
+	if defaultPendingTimeoutMin, ok := cfgMap[defaultPendingTimeoutMinutesKey]; ok {
+		timeout, err := strconv.ParseInt(defaultPendingTimeoutMin, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultPendingTimeoutMinutesKey)
+		}
+		tc.DefaultPendingTimeoutMinutes = int(timeout)
+	}
 	return &tc, nil
 }
 
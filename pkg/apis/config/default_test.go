@@ -36,9 +36,10 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 	testCases := []testCase{
 		{
 			expectedConfig: &config.Defaults{
-				DefaultTimeoutMinutes:      50,
-				DefaultServiceAccount:      "tekton",
-				DefaultManagedByLabelValue: "something-else",
+				DefaultTimeoutMinutes:        50,
+				DefaultServiceAccount:        "tekton",
+				DefaultManagedByLabelValue:   "something-else",
+				DefaultPendingTimeoutMinutes: 5,
 			},
 			fileName: config.GetDefaultsConfigName(),
 		},
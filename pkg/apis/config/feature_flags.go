@@ -26,28 +26,40 @@ import (
 )
 
 const (
-	StableAPIFields                         = "stable"
-	AlphaAPIFields                          = "alpha"
-	disableHomeEnvOverwriteKey              = "disable-home-env-overwrite"
-	disableWorkingDirOverwriteKey           = "disable-working-directory-overwrite"
-	disableAffinityAssistantKey             = "disable-affinity-assistant"
-	disableCredsInitKey                     = "disable-creds-init"
-	runningInEnvWithInjectedSidecarsKey     = "running-in-environment-with-injected-sidecars"
-	requireGitSSHSecretKnownHostsKey        = "require-git-ssh-secret-known-hosts" // nolint: gosec
-	enableTektonOCIBundles                  = "enable-tekton-oci-bundles"
-	enableCustomTasks                       = "enable-custom-tasks"
-	enableAPIFields                         = "enable-api-fields"
-	scopeWhenExpressionsToTask              = "scope-when-expressions-to-task"
-	DefaultDisableHomeEnvOverwrite          = true
-	DefaultDisableWorkingDirOverwrite       = true
-	DefaultDisableAffinityAssistant         = false
-	DefaultDisableCredsInit                 = false
-	DefaultRunningInEnvWithInjectedSidecars = true
-	DefaultRequireGitSSHSecretKnownHosts    = false
-	DefaultEnableTektonOciBundles           = false
-	DefaultEnableCustomTasks                = false
-	DefaultScopeWhenExpressionsToTask       = false
-	DefaultEnableAPIFields                  = StableAPIFields
+	StableAPIFields                          = "stable"
+	AlphaAPIFields                           = "alpha"
+	disableHomeEnvOverwriteKey               = "disable-home-env-overwrite"
+	disableWorkingDirOverwriteKey            = "disable-working-directory-overwrite"
+	disableAffinityAssistantKey              = "disable-affinity-assistant"
+	disableCredsInitKey                      = "disable-creds-init"
+	runningInEnvWithInjectedSidecarsKey      = "running-in-environment-with-injected-sidecars"
+	requireGitSSHSecretKnownHostsKey         = "require-git-ssh-secret-known-hosts" // nolint: gosec
+	enableTektonOCIBundles                   = "enable-tekton-oci-bundles"
+	enableCustomTasks                        = "enable-custom-tasks"
+	enableAPIFields                          = "enable-api-fields"
+	scopeWhenExpressionsToTask               = "scope-when-expressions-to-task"
+	resultExtractionMethod                   = "results-from"
+	maxResultSize                            = "max-result-size"
+	sidecarFailureMessageLines               = "sidecar-failure-message-lines"
+	enableStructuredStepLogs                 = "enable-structured-step-logs"
+	enableStepProgress                       = "enable-step-progress"
+	ResultExtractionMethodTerminationMessage = "termination-message"
+	ResultExtractionMethodSidecarLogs        = "sidecar-logs"
+	DefaultDisableHomeEnvOverwrite           = true
+	DefaultDisableWorkingDirOverwrite        = true
+	DefaultDisableAffinityAssistant          = false
+	DefaultDisableCredsInit                  = false
+	DefaultRunningInEnvWithInjectedSidecars  = true
+	DefaultRequireGitSSHSecretKnownHosts     = false
+	DefaultEnableTektonOciBundles            = false
+	DefaultEnableCustomTasks                 = false
+	DefaultScopeWhenExpressionsToTask        = false
+	DefaultEnableAPIFields                   = StableAPIFields
+	DefaultResultExtractionMethod            = ResultExtractionMethodTerminationMessage
+	DefaultMaxResultSize                     = 4096
+	DefaultSidecarFailureMessageLines        = 0
+	DefaultEnableStructuredStepLogs          = false
+	DefaultEnableStepProgress                = false
 )
 
 // FeatureFlags holds the features configurations
@@ -63,6 +75,11 @@ type FeatureFlags struct {
 	EnableCustomTasks                bool
 	ScopeWhenExpressionsToTask       bool
 	EnableAPIFields                  string
+	ResultExtractionMethod           string
+	MaxResultSize                    int
+	SidecarFailureMessageLines       int
+	EnableStructuredStepLogs         bool
+	EnableStepProgress               bool
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -114,6 +131,21 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setEnabledAPIFields(cfgMap, DefaultEnableAPIFields, &tc.EnableAPIFields); err != nil {
 		return nil, err
 	}
+	if err := setResultExtractionMethod(cfgMap, DefaultResultExtractionMethod, &tc.ResultExtractionMethod); err != nil {
+		return nil, err
+	}
+	if err := setMaxResultSize(cfgMap, DefaultMaxResultSize, &tc.MaxResultSize); err != nil {
+		return nil, err
+	}
+	if err := setSidecarFailureMessageLines(cfgMap, DefaultSidecarFailureMessageLines, &tc.SidecarFailureMessageLines); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableStructuredStepLogs, DefaultEnableStructuredStepLogs, &tc.EnableStructuredStepLogs); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableStepProgress, DefaultEnableStepProgress, &tc.EnableStepProgress); err != nil {
+		return nil, err
+	}
 
 	// Given that they are alpha features, Tekton Bundles and Custom Tasks should be switched on if
 	// enable-api-fields is "alpha". If enable-api-fields is not "alpha" then fall back to the value of
@@ -151,7 +183,59 @@ func setEnabledAPIFields(cfgMap map[string]string, defaultValue string, feature
 	return nil
 }
 
+// setResultExtractionMethod sets the "results-from" flag based on the content of a given map.
+// If the value is invalid then an error is returned.
+func setResultExtractionMethod(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[resultExtractionMethod]; ok {
+		value = strings.ToLower(cfg)
+	}
+	switch value {
+	case ResultExtractionMethodTerminationMessage, ResultExtractionMethodSidecarLogs:
+		*feature = value
+	default:
+		return fmt.Errorf("invalid value for feature flag %q: %q", resultExtractionMethod, value)
+	}
+	return nil
+}
+
+// setMaxResultSize sets the "max-result-size" flag based on the content of a given map.
+// If the value is not a positive integer then an error is returned.
+func setMaxResultSize(cfgMap map[string]string, defaultValue int, feature *int) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[maxResultSize]; ok {
+		v, err := strconv.Atoi(cfg)
+		if err != nil {
+			return fmt.Errorf("failed parsing feature flags config %q: %v", cfg, err)
+		}
+		value = v
+	}
+	if value <= 0 {
+		return fmt.Errorf("invalid value for feature flag %q: %d", maxResultSize, value)
+	}
+	*feature = value
+	return nil
+}
+
 // NewFeatureFlagsFromConfigMap returns a Config for the given configmap
 func NewFeatureFlagsFromConfigMap(config *corev1.ConfigMap) (*FeatureFlags, error) {
 	return NewFeatureFlagsFromMap(config.Data)
 }
+
+// setSidecarFailureMessageLines sets the "sidecar-failure-message-lines" flag based on the content of a given map.
+// If the value is not a non-negative integer then an error is returned.
+func setSidecarFailureMessageLines(cfgMap map[string]string, defaultValue int, feature *int) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[sidecarFailureMessageLines]; ok {
+		v, err := strconv.Atoi(cfg)
+		if err != nil {
+			return fmt.Errorf("failed parsing feature flags config %q: %v", cfg, err)
+		}
+		value = v
+	}
+	if value < 0 {
+		return fmt.Errorf("invalid value for feature flag %q: %d", sidecarFailureMessageLines, value)
+	}
+	*feature = value
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PrunerKeepKey is the name of the configmap entry that specifies the number of finished
+	// runs of each Task or Pipeline to keep in a namespace
+	PrunerKeepKey = "keep"
+	// PrunerTTLKey is the name of the configmap entry that specifies how long a finished run
+	// is kept after its completion time
+	PrunerTTLKey = "ttl"
+
+	// PrunerKeepAnnotation is the annotation of a namespace that overrides the keep entry
+	// of the pruner configmap for the runs in that namespace
+	PrunerKeepAnnotation = "pruner.tekton.dev/keep"
+	// PrunerTTLAnnotation is the annotation of a namespace that overrides the ttl entry
+	// of the pruner configmap for the runs in that namespace
+	PrunerTTLAnnotation = "pruner.tekton.dev/ttl"
+)
+
+// Pruner holds the configurations for the pruning of finished TaskRuns and PipelineRuns
+// +k8s:deepcopy-gen=true
+type Pruner struct {
+	// Keep is the number of finished runs of each Task or Pipeline kept in a namespace.
+	// Zero keeps all of them.
+	Keep int
+	// TTL is how long a finished run is kept after its completion time.
+	// Zero keeps it until it is pruned by Keep.
+	TTL time.Duration
+}
+
+// GetPrunerConfigName returns the name of the configmap containing all
+// customizations for the pruner.
+func GetPrunerConfigName() string {
+	if e := os.Getenv("CONFIG_PRUNER_NAME"); e != "" {
+		return e
+	}
+	return "config-pruner"
+}
+
+// Enabled returns true if finished runs are pruned.
+func (cfg *Pruner) Enabled() bool {
+	return cfg.Keep > 0 || cfg.TTL > 0
+}
+
+// WithNamespaceOverrides returns a copy of the Pruner with the overrides of the given
+// namespace annotations applied.
+func (cfg *Pruner) WithNamespaceOverrides(annotations map[string]string) (*Pruner, error) {
+	overridden := cfg.DeepCopy()
+	if err := overridden.set(annotations[PrunerKeepAnnotation], annotations[PrunerTTLAnnotation]); err != nil {
+		return nil, fmt.Errorf("failed parsing the pruner annotations of the namespace: %w", err)
+	}
+	return overridden, nil
+}
+
+// set parses the non-empty keep and ttl values into the Pruner.
+func (cfg *Pruner) set(keep, ttl string) error {
+	if keep != "" {
+		k, err := strconv.Atoi(keep)
+		if err != nil {
+			return fmt.Errorf("invalid keep %q: %w", keep, err)
+		}
+		if k < 0 {
+			return fmt.Errorf("invalid keep %q: must not be negative", keep)
+		}
+		cfg.Keep = k
+	}
+	if ttl != "" {
+		t, err := time.ParseDuration(ttl)
+		if err != nil {
+			return fmt.Errorf("invalid ttl %q: %w", ttl, err)
+		}
+		if t < 0 {
+			return fmt.Errorf("invalid ttl %q: must not be negative", ttl)
+		}
+		cfg.TTL = t
+	}
+	return nil
+}
+
+// NewPrunerFromMap returns a Pruner given a map corresponding to a ConfigMap
+func NewPrunerFromMap(cfgMap map[string]string) (*Pruner, error) {
+	tc := Pruner{}
+	if err := tc.set(cfgMap[PrunerKeepKey], cfgMap[PrunerTTLKey]); err != nil {
+		return nil, fmt.Errorf("failed parsing pruner config: %w", err)
+	}
+	return &tc, nil
+}
+
+// NewPrunerFromConfigMap returns a Pruner for the given configmap
+func NewPrunerFromConfigMap(config *corev1.ConfigMap) (*Pruner, error) {
+	return NewPrunerFromMap(config.Data)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewPrunerFromConfigMap(t *testing.T) {
+	expectedConfig := &config.Pruner{
+		Keep: 5,
+		TTL:  24 * time.Hour,
+	}
+	verifyConfigFileWithExpectedPrunerConfig(t, config.GetPrunerConfigName(), expectedConfig)
+}
+
+func TestNewPrunerFromEmptyConfigMap(t *testing.T) {
+	expectedConfig := &config.Pruner{}
+	verifyConfigFileWithExpectedPrunerConfig(t, "config-pruner-empty", expectedConfig)
+	if expectedConfig.Enabled() {
+		t.Error("Expected the pruner to be disabled by default")
+	}
+}
+
+func TestNewPrunerConfigMapErrors(t *testing.T) {
+	for _, tc := range []struct {
+		fileName string
+	}{{
+		fileName: "config-pruner-invalid-keep",
+	}, {
+		fileName: "config-pruner-invalid-ttl",
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			if _, err := config.NewPrunerFromConfigMap(cm); err == nil {
+				t.Error("expected error but received nil")
+			}
+		})
+	}
+}
+
+func TestPrunerWithNamespaceOverrides(t *testing.T) {
+	cfg := &config.Pruner{Keep: 5, TTL: time.Hour}
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		want        *config.Pruner
+		wantErr     bool
+	}{{
+		name: "no overrides",
+		want: &config.Pruner{Keep: 5, TTL: time.Hour},
+	}, {
+		name: "keep override",
+		annotations: map[string]string{
+			config.PrunerKeepAnnotation: "0",
+		},
+		want: &config.Pruner{Keep: 0, TTL: time.Hour},
+	}, {
+		name: "ttl override",
+		annotations: map[string]string{
+			config.PrunerTTLAnnotation: "30m",
+		},
+		want: &config.Pruner{Keep: 5, TTL: 30 * time.Minute},
+	}, {
+		name: "invalid override",
+		annotations: map[string]string{
+			config.PrunerTTLAnnotation: "forever",
+		},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := cfg.WithNamespaceOverrides(tc.annotations)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected error but received nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WithNamespaceOverrides: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+	if cfg.Keep != 5 || cfg.TTL != time.Hour {
+		t.Errorf("Expected WithNamespaceOverrides not to modify the Pruner but got %v", cfg)
+	}
+}
+
+func verifyConfigFileWithExpectedPrunerConfig(t *testing.T, fileName string, expectedConfig *config.Pruner) {
+	cm := test.ConfigMapFromTestFile(t, fileName)
+	if c, err := config.NewPrunerFromConfigMap(cm); err == nil {
+		if d := cmp.Diff(expectedConfig, c); d != "" {
+			t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+		}
+	} else {
+		t.Errorf("NewPrunerFromConfigMap(actual) = %v", err)
+	}
+}
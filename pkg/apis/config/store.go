@@ -31,6 +31,8 @@ type Config struct {
 	FeatureFlags   *FeatureFlags
 	ArtifactBucket *ArtifactBucket
 	ArtifactPVC    *ArtifactPVC
+	Concurrency    *Concurrency
+	Pruner         *Pruner
 }
 
 // FromContext extracts a Config from the provided context.
@@ -52,11 +54,15 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	featureFlags, _ := NewFeatureFlagsFromMap(map[string]string{})
 	artifactBucket, _ := NewArtifactBucketFromMap(map[string]string{})
 	artifactPVC, _ := NewArtifactPVCFromMap(map[string]string{})
+	concurrency, _ := NewConcurrencyFromMap(map[string]string{})
+	pruner, _ := NewPrunerFromMap(map[string]string{})
 	return &Config{
 		Defaults:       defaults,
 		FeatureFlags:   featureFlags,
 		ArtifactBucket: artifactBucket,
 		ArtifactPVC:    artifactPVC,
+		Concurrency:    concurrency,
+		Pruner:         pruner,
 	}
 }
 
@@ -83,6 +89,8 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 				GetFeatureFlagsConfigName():   NewFeatureFlagsFromConfigMap,
 				GetArtifactBucketConfigName(): NewArtifactBucketFromConfigMap,
 				GetArtifactPVCConfigName():    NewArtifactPVCFromConfigMap,
+				GetConcurrencyConfigName():    NewConcurrencyFromConfigMap,
+				GetPrunerConfigName():         NewPrunerFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -114,11 +122,21 @@ func (s *Store) Load() *Config {
 	if artifactPVC == nil {
 		artifactPVC, _ = NewArtifactPVCFromMap(map[string]string{})
 	}
+	concurrency := s.UntypedLoad(GetConcurrencyConfigName())
+	if concurrency == nil {
+		concurrency, _ = NewConcurrencyFromMap(map[string]string{})
+	}
+	pruner := s.UntypedLoad(GetPrunerConfigName())
+	if pruner == nil {
+		pruner, _ = NewPrunerFromMap(map[string]string{})
+	}
 
 	return &Config{
 		Defaults:       defaults.(*Defaults).DeepCopy(),
 		FeatureFlags:   featureFlags.(*FeatureFlags).DeepCopy(),
 		ArtifactBucket: artifactBucket.(*ArtifactBucket).DeepCopy(),
 		ArtifactPVC:    artifactPVC.(*ArtifactPVC).DeepCopy(),
+		Concurrency:    concurrency.(*Concurrency).DeepCopy(),
+		Pruner:         pruner.(*Pruner).DeepCopy(),
 	}
 }
@@ -32,17 +32,23 @@ func TestStoreLoadWithContext(t *testing.T) {
 	featuresConfig := test.ConfigMapFromTestFile(t, "feature-flags-all-flags-set")
 	artifactBucketConfig := test.ConfigMapFromTestFile(t, "config-artifact-bucket")
 	artifactPVCConfig := test.ConfigMapFromTestFile(t, "config-artifact-pvc")
+	concurrencyConfig := test.ConfigMapFromTestFile(t, "config-concurrency")
+	prunerConfig := test.ConfigMapFromTestFile(t, "config-pruner")
 
 	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
 	expectedFeatures, _ := config.NewFeatureFlagsFromConfigMap(featuresConfig)
 	expectedArtifactBucket, _ := config.NewArtifactBucketFromConfigMap(artifactBucketConfig)
 	expectedArtifactPVC, _ := config.NewArtifactPVCFromConfigMap(artifactPVCConfig)
+	expectedConcurrency, _ := config.NewConcurrencyFromConfigMap(concurrencyConfig)
+	expectedPruner, _ := config.NewPrunerFromConfigMap(prunerConfig)
 
 	expected := &config.Config{
 		Defaults:       expectedDefaults,
 		FeatureFlags:   expectedFeatures,
 		ArtifactBucket: expectedArtifactBucket,
 		ArtifactPVC:    expectedArtifactPVC,
+		Concurrency:    expectedConcurrency,
+		Pruner:         expectedPruner,
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -50,6 +56,8 @@ func TestStoreLoadWithContext(t *testing.T) {
 	store.OnConfigChanged(featuresConfig)
 	store.OnConfigChanged(artifactBucketConfig)
 	store.OnConfigChanged(artifactPVCConfig)
+	store.OnConfigChanged(concurrencyConfig)
+	store.OnConfigChanged(prunerConfig)
 
 	cfg := config.FromContext(store.ToContext(context.Background()))
 
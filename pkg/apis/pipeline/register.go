@@ -40,6 +40,10 @@ const (
 	// PipelineTaskLabelKey is used as the label identifier for a PipelineTask
 	PipelineTaskLabelKey = GroupName + "/pipelineTask"
 
+	// ParentPipelineRunLabelKey is used as the label identifier for the PipelineRun
+	// that created a child PipelineRun
+	ParentPipelineRunLabelKey = GroupName + "/parentPipelineRun"
+
 	// ConditionCheckKey is used as the label identifier for a ConditionCheck
 	ConditionCheckKey = GroupName + "/conditionCheck"
 
@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// recordApprovalDecision moves the decision an approver set on the Run of a pending ApprovalTask
+// to the decisions recorded on the Run, along with the user who made it. Only the webhook knows
+// the user updating the Run, so a decision that can't be recorded is left for the validation to
+// reject.
+func (r *Run) recordApprovalDecision(ctx context.Context) {
+	ui := apis.GetUserInfo(ctx)
+	if !apis.IsInUpdate(ctx) || ui == nil || r.IsDone() {
+		return
+	}
+	decision := ApprovalDecisionType(r.Annotations[ApprovalDecisionAnnotation])
+	if decision != ApprovalDecisionApprove && decision != ApprovalDecisionReject {
+		return
+	}
+	decisions, err := r.GetApprovalDecisions()
+	if err != nil {
+		return
+	}
+	decisions = append(decisions, ApprovalDecision{
+		Name:     ui.Username,
+		Groups:   ui.Groups,
+		Decision: decision,
+		Message:  r.Annotations[ApprovalMessageAnnotation],
+		Time:     metav1.Now(),
+	})
+	recorded, err := json.Marshal(decisions)
+	if err != nil {
+		return
+	}
+	r.Annotations[ApprovalDecisionsAnnotation] = string(recorded)
+	delete(r.Annotations, ApprovalDecisionAnnotation)
+	delete(r.Annotations, ApprovalMessageAnnotation)
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func approvalTaskRun(annotations map[string]string, params ...v1alpha1.Param) *v1alpha1.Run {
+	return &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "approval",
+			Annotations: annotations,
+		},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{
+				APIVersion: "tekton.dev/v1alpha1",
+				Kind:       v1alpha1.ApprovalTaskKind,
+			},
+			Params: params,
+		},
+	}
+}
+
+func recordedDecisions(t *testing.T, decisions ...v1alpha1.ApprovalDecision) string {
+	t.Helper()
+	b, err := json.Marshal(decisions)
+	if err != nil {
+		t.Fatalf("failed to marshal decisions: %v", err)
+	}
+	return string(b)
+}
+
+var (
+	alice        = &authenticationv1.UserInfo{Username: "alice", Groups: []string{"release-managers"}}
+	aliceApprove = v1alpha1.ApprovalDecision{
+		Name:     "alice",
+		Groups:   []string{"release-managers"},
+		Decision: v1alpha1.ApprovalDecisionApprove,
+		Time:     metav1.Unix(1000, 0),
+	}
+	bobReject = v1alpha1.ApprovalDecision{
+		Name:     "bob",
+		Decision: v1alpha1.ApprovalDecisionReject,
+		Time:     metav1.Unix(2000, 0),
+	}
+)
+
+func TestRun_IsApprovalTask(t *testing.T) {
+	if !approvalTaskRun(nil).IsApprovalTask() {
+		t.Error("expected the Run referencing ApprovalTask to be an ApprovalTask")
+	}
+	other := approvalTaskRun(nil)
+	other.Spec.Ref.APIVersion = "example.dev/v0"
+	if other.IsApprovalTask() {
+		t.Error("expected the Run referencing another apiVersion not to be an ApprovalTask")
+	}
+}
+
+func TestRun_SetDefaults_RecordsApprovalDecision(t *testing.T) {
+	baseline := approvalTaskRun(map[string]string{
+		v1alpha1.ApprovalDecisionsAnnotation: recordedDecisions(t, bobReject),
+	})
+	run := approvalTaskRun(map[string]string{
+		v1alpha1.ApprovalDecisionsAnnotation: recordedDecisions(t, bobReject),
+		v1alpha1.ApprovalDecisionAnnotation:  "approve",
+		v1alpha1.ApprovalMessageAnnotation:   "lgtm",
+	})
+	ctx := apis.WithUserInfo(apis.WithinUpdate(context.Background(), baseline), alice)
+	run.SetDefaults(ctx)
+
+	if _, ok := run.Annotations[v1alpha1.ApprovalDecisionAnnotation]; ok {
+		t.Errorf("expected the %s annotation to be removed", v1alpha1.ApprovalDecisionAnnotation)
+	}
+	if _, ok := run.Annotations[v1alpha1.ApprovalMessageAnnotation]; ok {
+		t.Errorf("expected the %s annotation to be removed", v1alpha1.ApprovalMessageAnnotation)
+	}
+	decisions, err := run.GetApprovalDecisions()
+	if err != nil {
+		t.Fatalf("GetApprovalDecisions: %v", err)
+	}
+	if len(decisions) != 2 {
+		t.Fatalf("expected 2 decisions but got %d", len(decisions))
+	}
+	got := decisions[1]
+	if got.Time.IsZero() {
+		t.Error("expected the time of the decision to be recorded")
+	}
+	got.Time = aliceApprove.Time
+	want := aliceApprove
+	want.Message = "lgtm"
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+	if err := run.Validate(ctx); err != nil {
+		t.Errorf("expected the recorded decision to be valid but got %v", err)
+	}
+}
+
+func TestRun_SetDefaults_IgnoresApprovalDecision(t *testing.T) {
+	done := approvalTaskRun(map[string]string{v1alpha1.ApprovalDecisionAnnotation: "approve"})
+	done.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}}
+
+	for _, tc := range []struct {
+		name string
+		run  *v1alpha1.Run
+		ctx  context.Context
+	}{{
+		name: "create",
+		run:  approvalTaskRun(map[string]string{v1alpha1.ApprovalDecisionAnnotation: "approve"}),
+		ctx:  apis.WithUserInfo(context.Background(), alice),
+	}, {
+		name: "no user",
+		run:  approvalTaskRun(map[string]string{v1alpha1.ApprovalDecisionAnnotation: "approve"}),
+		ctx:  apis.WithinUpdate(context.Background(), approvalTaskRun(nil)),
+	}, {
+		name: "done",
+		run:  done,
+		ctx:  apis.WithUserInfo(apis.WithinUpdate(context.Background(), approvalTaskRun(nil)), alice),
+	}, {
+		name: "invalid decision",
+		run:  approvalTaskRun(map[string]string{v1alpha1.ApprovalDecisionAnnotation: "maybe"}),
+		ctx:  apis.WithUserInfo(apis.WithinUpdate(context.Background(), approvalTaskRun(nil)), alice),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run.SetDefaults(tc.ctx)
+			if _, ok := tc.run.Annotations[v1alpha1.ApprovalDecisionsAnnotation]; ok {
+				t.Error("expected no decision to be recorded")
+			}
+			if err := tc.run.Validate(tc.ctx); err == nil {
+				t.Error("expected the decision left on the Run to be invalid")
+			}
+		})
+	}
+}
+
+func TestRun_Validate_ApprovalDecisions(t *testing.T) {
+	baseline := approvalTaskRun(map[string]string{
+		v1alpha1.ApprovalDecisionsAnnotation: recordedDecisions(t, aliceApprove),
+	})
+	path := "metadata.annotations[approval.tekton.dev/decisions]"
+	for _, tc := range []struct {
+		name string
+		run  *v1alpha1.Run
+		ctx  context.Context
+		want *apis.FieldError
+	}{{
+		name: "decision forged on create",
+		run: approvalTaskRun(map[string]string{
+			v1alpha1.ApprovalDecisionsAnnotation: recordedDecisions(t, aliceApprove),
+		}),
+		ctx:  context.Background(),
+		want: apis.ErrGeneric("a user can only record decisions of their own", path),
+	}, {
+		name: "decision forged by another user",
+		run: approvalTaskRun(map[string]string{
+			v1alpha1.ApprovalDecisionsAnnotation: recordedDecisions(t, aliceApprove, bobReject),
+		}),
+		ctx:  apis.WithUserInfo(apis.WithinUpdate(context.Background(), baseline), alice),
+		want: apis.ErrGeneric("a user can only record decisions of their own", path),
+	}, {
+		name: "recorded decision removed",
+		run:  approvalTaskRun(map[string]string{}),
+		ctx:  apis.WithUserInfo(apis.WithinUpdate(context.Background(), baseline), alice),
+		want: apis.ErrGeneric("recorded decisions can't be changed or removed", path),
+	}, {
+		name: "invalid recorded decisions",
+		run: approvalTaskRun(map[string]string{
+			v1alpha1.ApprovalDecisionsAnnotation: "not json",
+		}),
+		ctx: apis.WithinUpdate(context.Background(), baseline),
+		want: apis.ErrInvalidValue("invalid approval.tekton.dev/decisions annotation: invalid character 'o' in literal null (expecting 'u')",
+			path),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.run.Validate(tc.ctx)
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	unchanged := approvalTaskRun(map[string]string{
+		v1alpha1.ApprovalDecisionsAnnotation: recordedDecisions(t, aliceApprove),
+	})
+	if err := unchanged.Validate(apis.WithinUpdate(context.Background(), baseline)); err != nil {
+		t.Errorf("expected the unchanged decisions to be valid but got %v", err)
+	}
+}
+
+func TestRun_GetApprovalTaskApprovers(t *testing.T) {
+	approvers := v1alpha1.Param{Name: "approvers", Value: *v1beta1.NewArrayOrString("alice", "group:release-managers")}
+	for _, tc := range []struct {
+		name         string
+		params       []v1alpha1.Param
+		wantRequired int
+		wantErr      bool
+	}{{
+		name:         "default number of approvals",
+		params:       []v1alpha1.Param{approvers},
+		wantRequired: 1,
+	}, {
+		name:         "number of approvals",
+		params:       []v1alpha1.Param{approvers, {Name: "numberOfApprovalsRequired", Value: *v1beta1.NewArrayOrString("2")}},
+		wantRequired: 2,
+	}, {
+		name:    "no approvers",
+		wantErr: true,
+	}, {
+		name:    "approvers not an array",
+		params:  []v1alpha1.Param{{Name: "approvers", Value: *v1beta1.NewArrayOrString("alice")}},
+		wantErr: true,
+	}, {
+		name:    "empty group",
+		params:  []v1alpha1.Param{{Name: "approvers", Value: *v1beta1.NewArrayOrString("alice", "group:")}},
+		wantErr: true,
+	}, {
+		name:    "invalid number of approvals",
+		params:  []v1alpha1.Param{approvers, {Name: "numberOfApprovalsRequired", Value: *v1beta1.NewArrayOrString("0")}},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, required, err := approvalTaskRun(nil, tc.params...).GetApprovalTaskApprovers()
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(approvers.Value.ArrayVal, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+			if required != tc.wantRequired {
+				t.Errorf("expected %d approvals required but got %d", tc.wantRequired, required)
+			}
+		})
+	}
+}
+
+func TestApprovalDecision_IsApprover(t *testing.T) {
+	if !aliceApprove.IsApprover([]string{"alice"}) {
+		t.Error("expected alice to be an approver by name")
+	}
+	if !aliceApprove.IsApprover([]string{"group:release-managers"}) {
+		t.Error("expected alice to be an approver as a member of release-managers")
+	}
+	if bobReject.IsApprover([]string{"alice", "group:release-managers", "release-managers"}) {
+		t.Error("expected bob not to be an approver")
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ApprovalTaskKind is the kind referenced by the Runs of the built-in approval custom task,
+	// which stay pending until enough approvers approve them
+	ApprovalTaskKind = "ApprovalTask"
+
+	// ApprovalTaskApproversParam is the name of the array param listing the approvers of an
+	// ApprovalTask, either the name of a user or group:<name> for the members of a group
+	ApprovalTaskApproversParam = "approvers"
+	// ApprovalTaskApprovalsRequiredParam is the name of the param holding the number of approvals
+	// an ApprovalTask requires, 1 by default
+	ApprovalTaskApprovalsRequiredParam = "numberOfApprovalsRequired"
+	// ApprovalTaskGroupPrefix is the prefix of the approvers which are groups
+	ApprovalTaskGroupPrefix = "group:"
+
+	// ApprovalDecisionAnnotation is the annotation an approver sets on the Run of an ApprovalTask
+	// to approve or reject it
+	ApprovalDecisionAnnotation = "approval.tekton.dev/decision"
+	// ApprovalMessageAnnotation is the optional annotation an approver sets on the Run of an
+	// ApprovalTask along with the decision to explain it
+	ApprovalMessageAnnotation = "approval.tekton.dev/message"
+	// ApprovalDecisionsAnnotation is the annotation in which the webhook records the decisions
+	// made on the Run of an ApprovalTask, along with the user who made them
+	ApprovalDecisionsAnnotation = "approval.tekton.dev/decisions"
+
+	// ApprovalTaskApprovedByResult is the name of the result of an approved ApprovalTask listing
+	// the users who approved it
+	ApprovalTaskApprovedByResult = "approvedBy"
+)
+
+// ApprovalDecisionType is the decision of an approver of an ApprovalTask
+type ApprovalDecisionType string
+
+const (
+	// ApprovalDecisionApprove approves the ApprovalTask
+	ApprovalDecisionApprove ApprovalDecisionType = "approve"
+	// ApprovalDecisionReject rejects the ApprovalTask
+	ApprovalDecisionReject ApprovalDecisionType = "reject"
+)
+
+// ApprovalDecision records the decision of a user on the Run of an ApprovalTask
+type ApprovalDecision struct {
+	// Name is the name of the user who made the decision
+	Name string `json:"name"`
+	// Groups are the groups of the user who made the decision
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// Decision is either approve or reject
+	Decision ApprovalDecisionType `json:"decision"`
+	// Message is the message of the user explaining the decision
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Time is when the decision was made
+	Time metav1.Time `json:"time"`
+}
+
+// ApprovalTaskStatus is the status of an ApprovalTask, reported in the extra fields of the
+// status of its Run
+type ApprovalTaskStatus struct {
+	// Approvers are the users and groups allowed to approve or reject the ApprovalTask
+	Approvers []string `json:"approvers"`
+	// ApprovalsRequired is the number of approvals the ApprovalTask requires
+	ApprovalsRequired int `json:"approvalsRequired"`
+	// Decisions are the decisions of the approvers which were counted, the latest one of each user
+	// +optional
+	Decisions []ApprovalDecision `json:"decisions,omitempty"`
+}
+
+// IsApprovalTask returns true if the Run runs the built-in approval custom task
+func (r *Run) IsApprovalTask() bool {
+	if r.Spec.Ref != nil {
+		return r.Spec.Ref.APIVersion == SchemeGroupVersion.String() && r.Spec.Ref.Kind == ApprovalTaskKind
+	}
+	if r.Spec.Spec != nil {
+		return r.Spec.Spec.APIVersion == SchemeGroupVersion.String() && r.Spec.Spec.Kind == ApprovalTaskKind
+	}
+	return false
+}
+
+// GetApprovalDecisions returns the decisions recorded on the Run of an ApprovalTask
+func (r *Run) GetApprovalDecisions() ([]ApprovalDecision, error) {
+	recorded, ok := r.Annotations[ApprovalDecisionsAnnotation]
+	if !ok || recorded == "" {
+		return nil, nil
+	}
+	var decisions []ApprovalDecision
+	if err := json.Unmarshal([]byte(recorded), &decisions); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", ApprovalDecisionsAnnotation, err)
+	}
+	return decisions, nil
+}
+
+// GetApprovalTaskApprovers returns the approvers of the Run of an ApprovalTask and the number of
+// approvals it requires
+func (r *Run) GetApprovalTaskApprovers() ([]string, int, error) {
+	approversParam := r.Spec.GetParam(ApprovalTaskApproversParam)
+	if approversParam == nil || approversParam.Value.Type != ParamTypeArray || len(approversParam.Value.ArrayVal) == 0 {
+		return nil, 0, fmt.Errorf("%q param must list at least one approver", ApprovalTaskApproversParam)
+	}
+	approvers := approversParam.Value.ArrayVal
+	for _, approver := range approvers {
+		if strings.TrimPrefix(approver, ApprovalTaskGroupPrefix) == "" {
+			return nil, 0, fmt.Errorf("%q param must not list empty approvers", ApprovalTaskApproversParam)
+		}
+	}
+	required := 1
+	if requiredParam := r.Spec.GetParam(ApprovalTaskApprovalsRequiredParam); requiredParam != nil {
+		n, err := strconv.Atoi(requiredParam.Value.StringVal)
+		if err != nil || n < 1 {
+			return nil, 0, fmt.Errorf("%q param must be a positive number but it is %q", ApprovalTaskApprovalsRequiredParam, requiredParam.Value.StringVal)
+		}
+		required = n
+	}
+	return approvers, required, nil
+}
+
+// IsApprover returns true if the user who made the decision is one of the approvers, either by
+// name or as a member of one of the groups
+func (d ApprovalDecision) IsApprover(approvers []string) bool {
+	for _, approver := range approvers {
+		if group := strings.TrimPrefix(approver, ApprovalTaskGroupPrefix); group != approver {
+			for _, g := range d.Groups {
+				if g == group {
+					return true
+				}
+			}
+		} else if approver == d.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"knative.dev/pkg/apis"
+)
+
+// validateApprovalDecisions ensures that the decisions recorded on the Run of an ApprovalTask can't
+// be forged: a user can only add decisions of their own to the ones already recorded.
+func (r *Run) validateApprovalDecisions(ctx context.Context) *apis.FieldError {
+	if decision, ok := r.Annotations[ApprovalDecisionAnnotation]; ok {
+		switch {
+		case decision != string(ApprovalDecisionApprove) && decision != string(ApprovalDecisionReject):
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", decision, ApprovalDecisionApprove, ApprovalDecisionReject),
+				fmt.Sprintf("metadata.annotations[%s]", ApprovalDecisionAnnotation))
+		case r.IsDone():
+			return apis.ErrGeneric("the ApprovalTask is already done", fmt.Sprintf("metadata.annotations[%s]", ApprovalDecisionAnnotation))
+		default:
+			return apis.ErrGeneric("a decision can only be made by updating the Run of a pending ApprovalTask", fmt.Sprintf("metadata.annotations[%s]", ApprovalDecisionAnnotation))
+		}
+	}
+
+	path := fmt.Sprintf("metadata.annotations[%s]", ApprovalDecisionsAnnotation)
+	decisions, err := r.GetApprovalDecisions()
+	if err != nil {
+		return apis.ErrInvalidValue(err.Error(), path)
+	}
+	var recorded []ApprovalDecision
+	if apis.IsInUpdate(ctx) {
+		if baseline, ok := apis.GetBaseline(ctx).(*Run); ok && baseline != nil {
+			if recorded, err = baseline.GetApprovalDecisions(); err != nil {
+				recorded = nil
+			}
+		}
+	}
+	if len(decisions) < len(recorded) || !equality.Semantic.DeepEqual(decisions[:len(recorded)], recorded) {
+		return apis.ErrGeneric("recorded decisions can't be changed or removed", path)
+	}
+	added := decisions[len(recorded):]
+	if len(added) == 0 {
+		return nil
+	}
+	ui := apis.GetUserInfo(ctx)
+	for _, d := range added {
+		if ui == nil || d.Name != ui.Username || !equality.Semantic.DeepEqual(d.Groups, ui.Groups) {
+			return apis.ErrGeneric("a user can only record decisions of their own", path)
+		}
+		if d.Decision != ApprovalDecisionApprove && d.Decision != ApprovalDecisionReject {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", d.Decision, ApprovalDecisionApprove, ApprovalDecisionReject), path)
+		}
+	}
+	return nil
+}
@@ -62,6 +62,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PipelineResourceList{},
 		&Run{},
 		&RunList{},
+		&StepAction{},
+		&StepActionList{},
+		&ResolutionRequest{},
+		&ResolutionRequestList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
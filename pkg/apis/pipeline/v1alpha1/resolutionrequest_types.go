@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	// ResolverTypeLabelKey is the label of a ResolutionRequest naming the resolver
+	// that is responsible for it, such as "git".
+	ResolverTypeLabelKey = pipeline.GroupName + "/resolverType"
+
+	// ResolutionRequestReasonResolving indicates that the resolver is resolving
+	// the requested resource.
+	ResolutionRequestReasonResolving = "Resolving"
+	// ResolutionRequestReasonFailed indicates that the resolver failed to resolve
+	// the requested resource.
+	ResolutionRequestReasonFailed = "ResolutionFailed"
+	// ResolutionRequestReasonInvalidParams indicates that the params of the
+	// ResolutionRequest were rejected by the resolver.
+	ResolutionRequestReasonInvalidParams = "InvalidParams"
+	// ResolutionRequestReasonTimedOut indicates that the resolver didn't resolve
+	// the requested resource in time.
+	ResolutionRequestReasonTimedOut = "ResolutionTimedOut"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResolutionRequest is a request for a Tekton resource, such as a Task or a
+// Pipeline, stored in a remote location. It is created by the controller when
+// a TaskRef or a PipelineRef names a resolver and is fulfilled out of band by
+// that resolver, which writes the resolved resource to the status.
+//
+// +k8s:openapi-gen=true
+type ResolutionRequest struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the information for the request part of the resource request.
+	// +optional
+	Spec ResolutionRequestSpec `json:"spec,omitempty"`
+
+	// Status communicates the state of the request and, ultimately,
+	// the content of the resolved resource.
+	// +optional
+	Status ResolutionRequestStatus `json:"status,omitempty"`
+}
+
+// ResolutionRequestSpec are all the fields in the spec of the
+// ResolutionRequest CRD.
+type ResolutionRequestSpec struct {
+	// Params are the parameters passed to the resolver to identify the
+	// resource, such as the repo and the path of a Task in a git repository.
+	// +optional
+	Params []v1beta1.Param `json:"params,omitempty"`
+}
+
+// ResolutionRequestStatus are all the fields in a ResolutionRequest's
+// status subresource.
+type ResolutionRequestStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// ResolutionRequestStatusFields inlines the status fields.
+	ResolutionRequestStatusFields `json:",inline"`
+}
+
+// ResolutionRequestStatusFields are the ResolutionRequest-specific fields
+// for the status subresource.
+type ResolutionRequestStatusFields struct {
+	// Data is a string representation of the resolved content
+	// of the requested resource in-lined into the ResolutionRequest
+	// object, encoded in base64.
+	// +optional
+	Data string `json:"data,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResolutionRequestList contains a list of ResolutionRequests.
+type ResolutionRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResolutionRequest `json:"items"`
+}
+
+var resolutionRequestCondSet = apis.NewBatchConditionSet()
+
+// GetConditionSet retrieves the condition set for this resource. Implements
+// the KRShaped interface.
+func (*ResolutionRequest) GetConditionSet() apis.ConditionSet { return resolutionRequestCondSet }
+
+// GetStatus retrieves the status of the ResolutionRequest. Implements the
+// KRShaped interface.
+func (rr *ResolutionRequest) GetStatus() *duckv1.Status { return &rr.Status.Status }
+
+// IsDone returns true if the ResolutionRequest has succeeded or failed.
+func (rr *ResolutionRequest) IsDone() bool {
+	return !rr.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+}
+
+// InitializeConditions sets the Succeeded condition to Unknown.
+func (s *ResolutionRequestStatus) InitializeConditions() {
+	resolutionRequestCondSet.Manage(s).InitializeConditions()
+}
+
+// MarkResolving sets the Succeeded condition to Unknown with the Resolving reason.
+// The last transition time of the condition is the time the resolution started.
+func (s *ResolutionRequestStatus) MarkResolving() {
+	resolutionRequestCondSet.Manage(s).MarkUnknown(apis.ConditionSucceeded, ResolutionRequestReasonResolving, "Resolving the requested resource")
+}
+
+// IsResolving returns true if the resolution of the requested resource has
+// started and isn't done yet.
+func (s *ResolutionRequestStatus) IsResolving() bool {
+	c := s.GetCondition(apis.ConditionSucceeded)
+	return c.IsUnknown() && c.GetReason() == ResolutionRequestReasonResolving
+}
+
+// MarkSucceeded sets the Succeeded condition to True.
+func (s *ResolutionRequestStatus) MarkSucceeded() {
+	resolutionRequestCondSet.Manage(s).MarkTrue(apis.ConditionSucceeded)
+}
+
+// MarkFailed sets the Succeeded condition to False with the given reason and message.
+func (s *ResolutionRequestStatus) MarkFailed(reason, messageFormat string, messageA ...interface{}) {
+	resolutionRequestCondSet.Manage(s).MarkFalse(apis.ConditionSucceeded, reason, messageFormat, messageA...)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*ResolutionRequest)(nil)
+
+// Validate checks that the ResolutionRequest names the resolver responsible for it
+// and that its params are unique.
+func (rr *ResolutionRequest) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(rr.GetObjectMeta()).ViaField("metadata"); err != nil {
+		return err
+	}
+	if apis.IsInDelete(ctx) {
+		return nil
+	}
+	var errs *apis.FieldError
+	if rr.GetLabels()[ResolverTypeLabelKey] == "" {
+		errs = errs.Also(apis.ErrMissingField(ResolverTypeLabelKey).ViaField("labels").ViaField("metadata"))
+	}
+	return errs.Also(rr.Spec.Validate(ctx).ViaField("spec"))
+}
+
+// Validate checks that the params of the ResolutionRequest are named and unique.
+func (rs *ResolutionRequestSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for i, p := range rs.Params {
+		if p.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("params", i))
+		} else if seen.Has(p.Name) {
+			errs = errs.Also(apis.ErrMultipleOneOf("name").ViaFieldIndex("params", i))
+		}
+		seen.Insert(p.Name)
+	}
+	return errs
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestResolutionRequest_Valid(t *testing.T) {
+	rr := &v1alpha1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "foo",
+			Labels: map[string]string{v1alpha1.ResolverTypeLabelKey: "git"},
+		},
+		Spec: v1alpha1.ResolutionRequestSpec{
+			Params: []v1beta1.Param{{
+				Name:  "url",
+				Value: *v1beta1.NewArrayOrString("https://github.com/tektoncd/catalog"),
+			}, {
+				Name:  "path",
+				Value: *v1beta1.NewArrayOrString("task/git-clone/0.5/git-clone.yaml"),
+			}},
+		},
+	}
+	if err := rr.Validate(context.Background()); err != nil {
+		t.Errorf("ResolutionRequest.Validate() = %v", err)
+	}
+}
+
+func TestResolutionRequest_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		labels map[string]string
+		params []v1beta1.Param
+		want   *apis.FieldError
+	}{{
+		name: "missing resolver type label",
+		want: apis.ErrMissingField("metadata.labels." + v1alpha1.ResolverTypeLabelKey),
+	}, {
+		name:   "param without a name",
+		labels: map[string]string{v1alpha1.ResolverTypeLabelKey: "git"},
+		params: []v1beta1.Param{{Value: *v1beta1.NewArrayOrString("foo")}},
+		want:   apis.ErrMissingField("spec.params[0].name"),
+	}, {
+		name:   "duplicate params",
+		labels: map[string]string{v1alpha1.ResolverTypeLabelKey: "git"},
+		params: []v1beta1.Param{{
+			Name:  "path",
+			Value: *v1beta1.NewArrayOrString("foo"),
+		}, {
+			Name:  "path",
+			Value: *v1beta1.NewArrayOrString("bar"),
+		}},
+		want: apis.ErrMultipleOneOf("spec.params[1].name"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := &v1alpha1.ResolutionRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: tc.labels},
+				Spec:       v1alpha1.ResolutionRequestSpec{Params: tc.params},
+			}
+			err := rr.Validate(context.Background())
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Errorf("ResolutionRequest.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -28,6 +28,9 @@ var _ apis.Defaultable = (*Run)(nil)
 func (r *Run) SetDefaults(ctx context.Context) {
 	ctx = apis.WithinParent(ctx, r.ObjectMeta)
 	r.Spec.SetDefaults(apis.WithinSpec(ctx))
+	if r.IsApprovalTask() {
+		r.recordApprovalDecision(ctx)
+	}
 }
 
 func (rs *RunSpec) SetDefaults(ctx context.Context) {
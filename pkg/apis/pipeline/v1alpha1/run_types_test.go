@@ -24,6 +24,7 @@ import (
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
@@ -188,6 +189,11 @@ status:
   results:
   - name: foo
     value: bar
+  - name: array
+    value: ['a', 'b']
+  - name: object
+    value:
+      url: abc
   extraFields:
     simple: 'hello'
     complex:
@@ -223,7 +229,13 @@ status:
 				// Results are parsed correctly.
 				Results: []v1alpha1.RunResult{{
 					Name:  "foo",
-					Value: "bar",
+					Value: *runv1alpha1.NewRunResultValue("bar"),
+				}, {
+					Name:  "array",
+					Value: *runv1alpha1.NewRunResultValue("a", "b"),
+				}, {
+					Name:  "object",
+					Value: *runv1alpha1.NewRunResultObject(map[string]string{"url": "abc"}),
 				}},
 				// Any extra fields are simply stored as JSON bytes.
 				ExtraFields: runtime.RawExtension{
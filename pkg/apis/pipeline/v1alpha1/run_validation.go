@@ -34,6 +34,11 @@ func (r *Run) Validate(ctx context.Context) *apis.FieldError {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
+	if r.IsApprovalTask() {
+		if err := r.validateApprovalDecisions(ctx); err != nil {
+			return err
+		}
+	}
 	return r.Spec.Validate(ctx)
 }
 
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StepAction represents a reusable Step that can be referenced from the Steps
+// of a Task.
+//
+// +k8s:openapi-gen=true
+type StepAction struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the StepAction from the client
+	// +optional
+	Spec StepActionSpec `json:"spec"`
+}
+
+// StepActionSpec defines the desired state of StepAction.
+type StepActionSpec struct {
+	// Description is a user-facing description of the StepAction that may be
+	// used to populate a UI.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Image reference name to run for this StepAction.
+	Image string `json:"image,omitempty"`
+
+	// Entrypoint array. Not executed within a shell.
+	// The image's ENTRYPOINT is used if this is not provided.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Arguments to the entrypoint.
+	// The image's CMD is used if this is not provided.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// List of environment variables to set in the container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Script is the contents of an executable file to execute.
+	//
+	// If Script is not empty, the StepAction cannot have a Command and the Args will be passed to the Script.
+	// +optional
+	Script string `json:"script,omitempty"`
+
+	// Step's working directory.
+	// If not specified, the container runtime's default will be used, which
+	// might be configured in the container image.
+	// +optional
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// Params is a list of input parameters required to run the StepAction.
+	// Params must be supplied as inputs in Steps unless they declare a default
+	// value.
+	// +optional
+	Params []v1beta1.ParamSpec `json:"params,omitempty"`
+
+	// Results are values that the StepAction can output. They are added to
+	// the results of the Task that references the StepAction.
+	// +optional
+	Results []v1beta1.TaskResult `json:"results,omitempty"`
+}
+
+// ToStep returns the Step that the StepActionSpec defines.
+func (ss *StepActionSpec) ToStep() v1beta1.Step {
+	return v1beta1.Step{
+		Container: corev1.Container{
+			Image:      ss.Image,
+			Command:    ss.Command,
+			Args:       ss.Args,
+			Env:        ss.Env,
+			WorkingDir: ss.WorkingDir,
+		},
+		Script: ss.Script,
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StepActionList contains a list of StepAction
+type StepActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StepAction `json:"items"`
+}
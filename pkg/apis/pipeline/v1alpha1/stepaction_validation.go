@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*StepAction)(nil)
+
+// Validate implements apis.Validatable
+func (s *StepAction) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(s.GetObjectMeta()).ViaField("metadata"); err != nil {
+		return err
+	}
+	if apis.IsInDelete(ctx) {
+		return nil
+	}
+	return s.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate implements apis.Validatable
+func (ss *StepActionSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if ss.Image == "" {
+		errs = errs.Also(apis.ErrMissingField("image"))
+	}
+	if ss.Script != "" && len(ss.Command) > 0 {
+		errs = errs.Also(apis.ErrMultipleOneOf("script", "command"))
+	}
+	errs = errs.Also(v1beta1.ValidateParameterTypes(ctx, ss.Params).ViaField("params"))
+	// The StepAction is validated as the single Step of a Task to check that it
+	// only references the params it declares.
+	errs = errs.Also(v1beta1.ValidateParameterVariables([]v1beta1.Step{ss.ToStep()}, ss.Params))
+	for i, result := range ss.Results {
+		errs = errs.Also(result.Validate(ctx).ViaFieldIndex("results", i))
+	}
+	return errs
+}
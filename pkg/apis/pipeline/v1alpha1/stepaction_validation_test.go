@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestStepAction_Valid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1alpha1.StepActionSpec
+	}{{
+		name: "image only",
+		spec: v1alpha1.StepActionSpec{Image: "busybox"},
+	}, {
+		name: "script with params and results",
+		spec: v1alpha1.StepActionSpec{
+			Image:  "busybox",
+			Script: "echo $(params.message) > $(results.output.path)",
+			Params: []v1beta1.ParamSpec{{
+				Name: "message",
+				Type: v1beta1.ParamTypeString,
+			}},
+			Results: []v1beta1.TaskResult{{Name: "output"}},
+		},
+	}, {
+		name: "command with array param",
+		spec: v1alpha1.StepActionSpec{
+			Image:   "busybox",
+			Command: []string{"echo"},
+			Args:    []string{"$(params.words[*])"},
+			Env:     []corev1.EnvVar{{Name: "FOO", Value: "$(params.foo)"}},
+			Params: []v1beta1.ParamSpec{{
+				Name: "words",
+				Type: v1beta1.ParamTypeArray,
+			}, {
+				Name: "foo",
+				Type: v1beta1.ParamTypeString,
+			}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sa := &v1alpha1.StepAction{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec:       tc.spec,
+			}
+			if err := sa.Validate(context.Background()); err != nil {
+				t.Errorf("StepAction.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestStepAction_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1alpha1.StepActionSpec
+		want *apis.FieldError
+	}{{
+		name: "missing image",
+		spec: v1alpha1.StepActionSpec{Script: "echo hello"},
+		want: apis.ErrMissingField("spec.image"),
+	}, {
+		name: "script and command",
+		spec: v1alpha1.StepActionSpec{
+			Image:   "busybox",
+			Command: []string{"echo"},
+			Script:  "echo hello",
+		},
+		want: apis.ErrMultipleOneOf("spec.script", "spec.command"),
+	}, {
+		name: "undeclared param",
+		spec: v1alpha1.StepActionSpec{
+			Image: "busybox",
+			Args:  []string{"$(params.foo)"},
+		},
+		want: &apis.FieldError{
+			Message: `non-existent variable in "$(params.foo)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
+		},
+	}, {
+		name: "invalid result name",
+		spec: v1alpha1.StepActionSpec{
+			Image:   "busybox",
+			Results: []v1beta1.TaskResult{{Name: "MY RESULT"}},
+		},
+		want: &apis.FieldError{
+			Message: `invalid key name "MY RESULT"`,
+			Paths:   []string{"spec.results[0].name"},
+			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sa := &v1alpha1.StepAction{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec:       tc.spec,
+			}
+			err := sa.Validate(context.Background())
+			if d := cmp.Diff(tc.want.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("StepAction.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepAction_SetDefaults(t *testing.T) {
+	sa := &v1alpha1.StepAction{
+		Spec: v1alpha1.StepActionSpec{
+			Image: "busybox",
+			Params: []v1beta1.ParamSpec{{
+				Name: "foo",
+			}, {
+				Name:    "bar",
+				Default: v1beta1.NewArrayOrString("a", "b"),
+			}},
+		},
+	}
+	want := []v1beta1.ParamSpec{{
+		Name: "foo",
+		Type: v1beta1.ParamTypeString,
+	}, {
+		Name:    "bar",
+		Type:    v1beta1.ParamTypeArray,
+		Default: v1beta1.NewArrayOrString("a", "b"),
+	}}
+	sa.SetDefaults(context.Background())
+	if d := cmp.Diff(want, sa.Spec.Params); d != "" {
+		t.Errorf("StepAction.SetDefaults() diff %s", diff.PrintWantGot(d))
+	}
+}
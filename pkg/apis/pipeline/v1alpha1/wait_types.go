@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// WaitKind is the kind referenced by the Runs of the built-in wait custom task, which waits
+	// for a duration or until a Kubernetes object matches a condition
+	WaitKind = "Wait"
+
+	// WaitDurationParam is the name of the param holding the duration to wait for
+	WaitDurationParam = "duration"
+	// WaitAPIVersionParam is the name of the param holding the apiVersion of the object to wait for
+	WaitAPIVersionParam = "apiVersion"
+	// WaitObjectKindParam is the name of the param holding the kind of the object to wait for
+	WaitObjectKindParam = "kind"
+	// WaitObjectNameParam is the name of the param holding the name of the object to wait for
+	WaitObjectNameParam = "name"
+	// WaitForParam is the name of the param holding the condition the object must match, either
+	// condition=<type>[=<status>] or jsonpath=<expression>=<value>
+	WaitForParam = "for"
+	// WaitResultsParam is the name of the array param listing the results to emit from the
+	// object once it matches the condition, each as <name>=<jsonpath expression>
+	WaitResultsParam = "results"
+	// WaitPollIntervalParam is the name of the param holding how often the object is checked,
+	// 10s by default
+	WaitPollIntervalParam = "pollInterval"
+
+	// DefaultWaitPollInterval is how often the object waited for is checked by default
+	DefaultWaitPollInterval = 10 * time.Second
+)
+
+// WaitSpec is what the Run of a Wait custom task waits for, parsed from its params
+type WaitSpec struct {
+	// Duration is the duration to wait for, when not waiting for an object
+	Duration time.Duration
+	// Object is the object to wait for, when not waiting for a duration
+	Object *WaitObject
+}
+
+// WaitObject is a Kubernetes object a Wait custom task waits for, in the namespace of the Run
+// unless the object is cluster scoped
+type WaitObject struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	// Condition is the type of the condition of the object to wait for, or empty when waiting
+	// for a JSONPath expression
+	Condition string
+	// JSONPath is the JSONPath expression of the object to wait for, when not waiting for a condition
+	JSONPath string
+	// Value is the status of the condition, or the value of the JSONPath expression, to wait for
+	Value string
+	// Results maps the names of the results to emit to the JSONPath expressions of their value
+	Results []WaitResult
+	// PollInterval is how often the object is checked
+	PollInterval time.Duration
+}
+
+// WaitResult is a result emitted from the object a Wait custom task waited for
+type WaitResult struct {
+	Name     string
+	JSONPath string
+}
+
+// IsWait returns true if the Run runs the built-in wait custom task
+func (r *Run) IsWait() bool {
+	if r.Spec.Ref != nil {
+		return r.Spec.Ref.APIVersion == SchemeGroupVersion.String() && r.Spec.Ref.Kind == WaitKind
+	}
+	if r.Spec.Spec != nil {
+		return r.Spec.Spec.APIVersion == SchemeGroupVersion.String() && r.Spec.Spec.Kind == WaitKind
+	}
+	return false
+}
+
+// GetWaitSpec returns what the Run of a Wait custom task waits for
+func (r *Run) GetWaitSpec() (*WaitSpec, error) {
+	stringParam := func(name string) (string, error) {
+		p := r.Spec.GetParam(name)
+		if p == nil {
+			return "", nil
+		}
+		if p.Value.Type != ParamTypeString {
+			return "", fmt.Errorf("%q param must be a string", name)
+		}
+		return p.Value.StringVal, nil
+	}
+
+	duration, err := stringParam(WaitDurationParam)
+	if err != nil {
+		return nil, err
+	}
+	name, err := stringParam(WaitObjectNameParam)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case duration != "" && name != "":
+		return nil, fmt.Errorf("expected exactly one of the %q and %q params", WaitDurationParam, WaitObjectNameParam)
+	case duration != "":
+		d, err := time.ParseDuration(duration)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("%q param must be a positive duration but it is %q", WaitDurationParam, duration)
+		}
+		return &WaitSpec{Duration: d}, nil
+	case name == "":
+		return nil, fmt.Errorf("expected exactly one of the %q and %q params", WaitDurationParam, WaitObjectNameParam)
+	}
+
+	o := &WaitObject{Name: name, PollInterval: DefaultWaitPollInterval}
+	if o.APIVersion, err = stringParam(WaitAPIVersionParam); err != nil {
+		return nil, err
+	}
+	if o.Kind, err = stringParam(WaitObjectKindParam); err != nil {
+		return nil, err
+	}
+	if o.APIVersion == "" || o.Kind == "" {
+		return nil, fmt.Errorf("the %q and %q params are required to wait for an object", WaitAPIVersionParam, WaitObjectKindParam)
+	}
+	waitFor, err := stringParam(WaitForParam)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.parseFor(waitFor); err != nil {
+		return nil, err
+	}
+	if p := r.Spec.GetParam(WaitResultsParam); p != nil {
+		if p.Value.Type != ParamTypeArray {
+			return nil, fmt.Errorf("%q param must be an array", WaitResultsParam)
+		}
+		for _, result := range p.Value.ArrayVal {
+			parts := strings.SplitN(result, "=", 2)
+			if len(parts) != 2 || parts[0] == "" || !isJSONPathExpression(parts[1]) {
+				return nil, fmt.Errorf("%q param entries must be <name>={<jsonpath>} but one is %q", WaitResultsParam, result)
+			}
+			o.Results = append(o.Results, WaitResult{Name: parts[0], JSONPath: parts[1]})
+		}
+	}
+	interval, err := stringParam(WaitPollIntervalParam)
+	if err != nil {
+		return nil, err
+	}
+	if interval != "" {
+		if o.PollInterval, err = time.ParseDuration(interval); err != nil || o.PollInterval <= 0 {
+			return nil, fmt.Errorf("%q param must be a positive duration but it is %q", WaitPollIntervalParam, interval)
+		}
+	}
+	return &WaitSpec{Object: o}, nil
+}
+
+// parseFor parses the condition the object must match, in the format of kubectl wait --for:
+// condition=<type>, condition=<type>=<status> or jsonpath={<expression>}=<value>
+func (o *WaitObject) parseFor(waitFor string) error {
+	switch {
+	case strings.HasPrefix(waitFor, "condition="):
+		parts := strings.SplitN(strings.TrimPrefix(waitFor, "condition="), "=", 2)
+		o.Condition, o.Value = parts[0], "True"
+		if len(parts) == 2 {
+			o.Value = parts[1]
+		}
+		if o.Condition != "" && o.Value != "" {
+			return nil
+		}
+	case strings.HasPrefix(waitFor, "jsonpath="):
+		expression := strings.TrimPrefix(waitFor, "jsonpath=")
+		if i := strings.LastIndex(expression, "}="); i >= 0 {
+			o.JSONPath, o.Value = expression[:i+1], expression[i+2:]
+			if isJSONPathExpression(o.JSONPath) && o.Value != "" {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%q param must be condition=<type>[=<status>] or jsonpath={<expression>}=<value> but it is %q", WaitForParam, waitFor)
+}
+
+func isJSONPathExpression(expression string) bool {
+	return len(expression) > 2 && strings.HasPrefix(expression, "{") && strings.HasSuffix(expression, "}")
+}
+
+// String returns the condition the object must match, as in the "for" param
+func (o *WaitObject) String() string {
+	if o.JSONPath != "" {
+		return fmt.Sprintf("jsonpath=%s=%s", o.JSONPath, o.Value)
+	}
+	return fmt.Sprintf("condition=%s=%s", o.Condition, o.Value)
+}
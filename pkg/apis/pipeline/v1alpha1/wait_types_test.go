@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func waitRun(params map[string]*v1beta1.ArrayOrString) *v1alpha1.Run {
+	run := &v1alpha1.Run{
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{
+				APIVersion: "tekton.dev/v1alpha1",
+				Kind:       v1alpha1.WaitKind,
+			},
+		},
+	}
+	for name, value := range params {
+		run.Spec.Params = append(run.Spec.Params, v1beta1.Param{Name: name, Value: *value})
+	}
+	return run
+}
+
+func TestRun_IsWait(t *testing.T) {
+	if !waitRun(nil).IsWait() {
+		t.Error("expected the Run referencing Wait to be a Wait")
+	}
+	if waitRun(nil).IsApprovalTask() {
+		t.Error("expected the Run referencing Wait not to be an ApprovalTask")
+	}
+}
+
+func TestRun_GetWaitSpec(t *testing.T) {
+	deployment := func(waitFor string) map[string]*v1beta1.ArrayOrString {
+		return map[string]*v1beta1.ArrayOrString{
+			"apiVersion": v1beta1.NewArrayOrString("apps/v1"),
+			"kind":       v1beta1.NewArrayOrString("Deployment"),
+			"name":       v1beta1.NewArrayOrString("app"),
+			"for":        v1beta1.NewArrayOrString(waitFor),
+		}
+	}
+	withResults := deployment("condition=Available")
+	withResults["results"] = v1beta1.NewArrayOrString("replicas={.status.readyReplicas}", "image={.spec.template.spec.containers[0].image}")
+	withResults["pollInterval"] = v1beta1.NewArrayOrString("1m")
+
+	for _, tc := range []struct {
+		name   string
+		params map[string]*v1beta1.ArrayOrString
+		want   *v1alpha1.WaitSpec
+	}{{
+		name:   "duration",
+		params: map[string]*v1beta1.ArrayOrString{"duration": v1beta1.NewArrayOrString("30s")},
+		want:   &v1alpha1.WaitSpec{Duration: 30 * time.Second},
+	}, {
+		name:   "condition",
+		params: deployment("condition=Available"),
+		want: &v1alpha1.WaitSpec{Object: &v1alpha1.WaitObject{
+			APIVersion: "apps/v1", Kind: "Deployment", Name: "app",
+			Condition: "Available", Value: "True", PollInterval: v1alpha1.DefaultWaitPollInterval,
+		}},
+	}, {
+		name:   "condition status",
+		params: deployment("condition=Progressing=False"),
+		want: &v1alpha1.WaitSpec{Object: &v1alpha1.WaitObject{
+			APIVersion: "apps/v1", Kind: "Deployment", Name: "app",
+			Condition: "Progressing", Value: "False", PollInterval: v1alpha1.DefaultWaitPollInterval,
+		}},
+	}, {
+		name:   "jsonpath",
+		params: deployment("jsonpath={.status.readyReplicas}=3"),
+		want: &v1alpha1.WaitSpec{Object: &v1alpha1.WaitObject{
+			APIVersion: "apps/v1", Kind: "Deployment", Name: "app",
+			JSONPath: "{.status.readyReplicas}", Value: "3", PollInterval: v1alpha1.DefaultWaitPollInterval,
+		}},
+	}, {
+		name:   "results and poll interval",
+		params: withResults,
+		want: &v1alpha1.WaitSpec{Object: &v1alpha1.WaitObject{
+			APIVersion: "apps/v1", Kind: "Deployment", Name: "app",
+			Condition: "Available", Value: "True", PollInterval: time.Minute,
+			Results: []v1alpha1.WaitResult{
+				{Name: "replicas", JSONPath: "{.status.readyReplicas}"},
+				{Name: "image", JSONPath: "{.spec.template.spec.containers[0].image}"},
+			},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := waitRun(tc.params).GetWaitSpec()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestRun_GetWaitSpec_Invalid(t *testing.T) {
+	invalidResults := map[string]*v1beta1.ArrayOrString{
+		"apiVersion": v1beta1.NewArrayOrString("batch/v1"),
+		"kind":       v1beta1.NewArrayOrString("Job"),
+		"name":       v1beta1.NewArrayOrString("job"),
+		"for":        v1beta1.NewArrayOrString("condition=Complete"),
+		"results":    v1beta1.NewArrayOrString(".status.succeeded"),
+	}
+	for _, tc := range []struct {
+		name   string
+		params map[string]*v1beta1.ArrayOrString
+	}{{
+		name: "no params",
+	}, {
+		name: "duration and object",
+		params: map[string]*v1beta1.ArrayOrString{
+			"duration": v1beta1.NewArrayOrString("30s"),
+			"name":     v1beta1.NewArrayOrString("job"),
+		},
+	}, {
+		name:   "invalid duration",
+		params: map[string]*v1beta1.ArrayOrString{"duration": v1beta1.NewArrayOrString("soon")},
+	}, {
+		name: "missing kind",
+		params: map[string]*v1beta1.ArrayOrString{
+			"apiVersion": v1beta1.NewArrayOrString("batch/v1"),
+			"name":       v1beta1.NewArrayOrString("job"),
+			"for":        v1beta1.NewArrayOrString("condition=Complete"),
+		},
+	}, {
+		name: "invalid for",
+		params: map[string]*v1beta1.ArrayOrString{
+			"apiVersion": v1beta1.NewArrayOrString("batch/v1"),
+			"kind":       v1beta1.NewArrayOrString("Job"),
+			"name":       v1beta1.NewArrayOrString("job"),
+			"for":        v1beta1.NewArrayOrString("jsonpath=.status.succeeded=1"),
+		},
+	}, {
+		name:   "invalid results",
+		params: invalidResults,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := waitRun(tc.params).GetWaitSpec(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -31,61 +31,254 @@ func MergeStepsWithStepTemplate(template *v1.Container, steps []Step) ([]Step, e
 		return steps, nil
 	}
 
-	// We need JSON bytes to generate a patch to merge the step containers
-	// onto the template container, so marshal the template.
-	templateAsJSON, err := json.Marshal(template)
-	if err != nil {
-		return nil, err
-	}
-	// We need to do a three-way merge to actually merge the template and
-	// step containers, so we need an empty container as the "original"
-	emptyAsJSON, err := json.Marshal(&v1.Container{})
+	md, err := getMergeData(template)
 	if err != nil {
 		return nil, err
 	}
 
+	mergedSteps := make([]Step, len(steps))
 	for i, s := range steps {
-		// Marshal the step's to JSON
-		stepAsJSON, err := json.Marshal(s.Container)
+		merged, err := mergeWithTemplate(md, &s.Container)
 		if err != nil {
 			return nil, err
 		}
+		// Pass through the fields of the original step, such as its Script for later conversion.
+		s.Container = *merged
+		mergedSteps[i] = s
+	}
+	return mergedSteps, nil
+}
 
-		// Get the patch meta for Container, which is needed for generating and applying the merge patch.
-		patchSchema, err := strategicpatch.NewPatchMetaFromStruct(template)
+// MergeSidecarsWithSidecarTemplate takes a possibly nil container template and a
+// list of sidecars, merging each of the sidecars with the container template, if
+// it's not nil, and returning the resulting list.
+func MergeSidecarsWithSidecarTemplate(template *v1.Container, sidecars []Sidecar) ([]Sidecar, error) {
+	if template == nil {
+		return sidecars, nil
+	}
 
-		if err != nil {
-			return nil, err
-		}
+	md, err := getMergeData(template)
+	if err != nil {
+		return nil, err
+	}
 
-		// Create a merge patch, with the empty JSON as the original, the step JSON as the modified, and the template
-		// JSON as the current - this lets us do a deep merge of the template and step containers, with awareness of
-		// the "patchMerge" tags.
-		patch, err := strategicpatch.CreateThreeWayMergePatch(emptyAsJSON, stepAsJSON, templateAsJSON, patchSchema, true)
+	mergedSidecars := make([]Sidecar, len(sidecars))
+	for i, s := range sidecars {
+		merged, err := mergeWithTemplate(md, &s.Container)
 		if err != nil {
 			return nil, err
 		}
+		s.Container = *merged
+		mergedSidecars[i] = s
+	}
+	return mergedSidecars, nil
+}
 
-		// Actually apply the merge patch to the template JSON.
-		mergedAsJSON, err := strategicpatch.StrategicMergePatchUsingLookupPatchMeta(templateAsJSON, patch, patchSchema)
-		if err != nil {
-			return nil, err
+// MergeStepsWithTaskRunStepTemplate takes a possibly nil StepTemplate of a TaskRun and a
+// list of steps, merging the template into each of the steps, if it's not nil, and returning
+// the resulting list. Unlike the StepTemplate of a Task, the values of the template take
+// precedence over the ones of the steps.
+func MergeStepsWithTaskRunStepTemplate(template *TaskRunStepTemplate, steps []Step) ([]Step, error) {
+	if template == nil {
+		return steps, nil
+	}
+
+	mergedSteps := make([]Step, len(steps))
+	for i, s := range steps {
+		s.Env = mergeEnv(s.Env, template.Env)
+		s.Resources = mergeResources(s.Resources, template.Resources)
+		if template.SecurityContext != nil {
+			// The security context of the step is the template here, so that the fields set
+			// by the template take precedence and the other ones are kept.
+			md, err := getMergeData(&v1.Container{SecurityContext: s.SecurityContext})
+			if err != nil {
+				return nil, err
+			}
+			merged, err := mergeWithTemplate(md, &v1.Container{SecurityContext: template.SecurityContext})
+			if err != nil {
+				return nil, err
+			}
+			s.SecurityContext = merged.SecurityContext
 		}
+		mergedSteps[i] = s
+	}
+	return mergedSteps, nil
+}
 
-		// Unmarshal the merged JSON to a Container pointer, and return it.
-		merged := &v1.Container{}
-		err = json.Unmarshal(mergedAsJSON, merged)
-		if err != nil {
-			return nil, err
+// mergeEnv returns the environment variables with the ones of the override set on top of
+// them, replacing the variables of the same name.
+func mergeEnv(env, override []v1.EnvVar) []v1.EnvVar {
+	if len(override) == 0 {
+		return env
+	}
+	overridden := make(map[string]bool, len(override))
+	for _, e := range override {
+		overridden[e.Name] = true
+	}
+	merged := make([]v1.EnvVar, 0, len(env)+len(override))
+	for _, e := range env {
+		if !overridden[e.Name] {
+			merged = append(merged, e)
 		}
+	}
+	return append(merged, override...)
+}
+
+// MergeStepsWithOverrides takes a list of steps and the overrides of a TaskRun, merging
+// the compute resources of each override into the step of the same name, and returning
+// the resulting list.
+func MergeStepsWithOverrides(steps []Step, overrides []TaskRunStepOverride) []Step {
+	if len(overrides) == 0 {
+		return steps
+	}
+	resources := make(map[string]v1.ResourceRequirements, len(overrides))
+	for _, o := range overrides {
+		resources[o.Name] = o.Resources
+	}
+
+	mergedSteps := make([]Step, len(steps))
+	for i, s := range steps {
+		s.Container = mergeContainerWithOverrides(s.Container, resources)
+		mergedSteps[i] = s
+	}
+	return mergedSteps
+}
+
+// MergeSidecarsWithOverrides takes a list of sidecars and the overrides of a TaskRun,
+// merging the compute resources of each override into the sidecar of the same name, and
+// returning the resulting list.
+func MergeSidecarsWithOverrides(sidecars []Sidecar, overrides []TaskRunSidecarOverride) []Sidecar {
+	if len(overrides) == 0 {
+		return sidecars
+	}
+	resources := make(map[string]v1.ResourceRequirements, len(overrides))
+	for _, o := range overrides {
+		resources[o.Name] = o.Resources
+	}
+
+	mergedSidecars := make([]Sidecar, len(sidecars))
+	for i, s := range sidecars {
+		s.Container = mergeContainerWithOverrides(s.Container, resources)
+		mergedSidecars[i] = s
+	}
+	return mergedSidecars
+}
 
-		// If the container's args is nil, reset it to empty instead
-		if merged.Args == nil && s.Args != nil {
-			merged.Args = []string{}
+// mergeContainerWithOverrides returns the container with the compute resources overridden
+// for its name, if any, merged into its own.
+func mergeContainerWithOverrides(container v1.Container, resources map[string]v1.ResourceRequirements) v1.Container {
+	if r, ok := resources[container.Name]; ok {
+		container.Resources = mergeResources(container.Resources, r)
+	}
+	return container
+}
+
+// mergeResources returns the resources with the limits and requests of the override set
+// on top of them. Since a request can't exceed its limit, a request the override doesn't
+// set is lowered to the limit it sets for the same resource, and a limit the override
+// doesn't set is raised to the request it sets for the same resource.
+func mergeResources(resources, override v1.ResourceRequirements) v1.ResourceRequirements {
+	merged := resources.DeepCopy()
+	merged.Limits = mergeResourceList(merged.Limits, override.Limits)
+	merged.Requests = mergeResourceList(merged.Requests, override.Requests)
+	for name, limit := range override.Limits {
+		if _, ok := override.Requests[name]; ok {
+			continue
+		}
+		if request, ok := merged.Requests[name]; ok && request.Cmp(limit) > 0 {
+			merged.Requests[name] = limit.DeepCopy()
+		}
+	}
+	for name, request := range override.Requests {
+		if _, ok := override.Limits[name]; ok {
+			continue
 		}
+		if limit, ok := merged.Limits[name]; ok && limit.Cmp(request) < 0 {
+			merged.Limits[name] = request.DeepCopy()
+		}
+	}
+	return *merged
+}
+
+func mergeResourceList(list, override v1.ResourceList) v1.ResourceList {
+	if len(override) == 0 {
+		return list
+	}
+	if list == nil {
+		list = v1.ResourceList{}
+	}
+	for name, quantity := range override {
+		list[name] = quantity.DeepCopy()
+	}
+	return list
+}
+
+// mergeData holds the JSON of a container template and the patch meta needed to merge
+// containers with it.
+type mergeData struct {
+	emptyJSON    []byte
+	templateJSON []byte
+	patchSchema  strategicpatch.PatchMetaFromStruct
+}
+
+func getMergeData(template *v1.Container) (*mergeData, error) {
+	// We need JSON bytes to generate a patch to merge the containers
+	// onto the template container, so marshal the template.
+	templateAsJSON, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	// We need to do a three-way merge to actually merge the template and
+	// containers, so we need an empty container as the "original"
+	emptyAsJSON, err := json.Marshal(&v1.Container{})
+	if err != nil {
+		return nil, err
+	}
+	// Get the patch meta for Container, which is needed for generating and applying the merge patch.
+	patchSchema, err := strategicpatch.NewPatchMetaFromStruct(template)
+	if err != nil {
+		return nil, err
+	}
+	return &mergeData{
+		emptyJSON:    emptyAsJSON,
+		templateJSON: templateAsJSON,
+		patchSchema:  patchSchema,
+	}, nil
+}
+
+// mergeWithTemplate merges the container with the template, the values of the container
+// taking precedence.
+func mergeWithTemplate(md *mergeData, container *v1.Container) (*v1.Container, error) {
+	// Marshal the container to JSON
+	containerAsJSON, err := json.Marshal(container)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a merge patch, with the empty JSON as the original, the container JSON as the modified, and the template
+	// JSON as the current - this lets us do a deep merge of the template and containers, with awareness of
+	// the "patchMerge" tags.
+	patch, err := strategicpatch.CreateThreeWayMergePatch(md.emptyJSON, containerAsJSON, md.templateJSON, md.patchSchema, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Actually apply the merge patch to the template JSON.
+	mergedAsJSON, err := strategicpatch.StrategicMergePatchUsingLookupPatchMeta(md.templateJSON, patch, md.patchSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the merged JSON to a Container pointer, and return it.
+	merged := &v1.Container{}
+	err = json.Unmarshal(mergedAsJSON, merged)
+	if err != nil {
+		return nil, err
+	}
 
-		// Pass through original step Script, for later conversion.
-		steps[i] = Step{Container: *merged, Script: s.Script}
+	// If the container's args is nil, reset it to empty instead
+	if merged.Args == nil && container.Args != nil {
+		merged.Args = []string{}
 	}
-	return steps, nil
+	return merged, nil
 }
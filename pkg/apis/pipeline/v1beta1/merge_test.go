@@ -18,11 +18,13 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestMergeStepsWithStepTemplate(t *testing.T) {
@@ -101,6 +103,28 @@ func TestMergeStepsWithStepTemplate(t *testing.T) {
 				Value: "NEW_VALUE",
 			}},
 		}}},
+	}, {
+		name: "keep-step-fields",
+		template: &corev1.Container{
+			Command: []string{"/somecmd"},
+		},
+		steps: []Step{{
+			Container: corev1.Container{Image: "some-image"},
+			Script:    "echo hello",
+			Timeout:   &metav1.Duration{Duration: time.Minute},
+			OnError:   "continue",
+			Retries:   2,
+		}},
+		expected: []Step{{
+			Container: corev1.Container{
+				Command: []string{"/somecmd"},
+				Image:   "some-image",
+			},
+			Script:  "echo hello",
+			Timeout: &metav1.Duration{Duration: time.Minute},
+			OnError: "continue",
+			Retries: 2,
+		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			result, err := MergeStepsWithStepTemplate(tc.template, tc.steps)
@@ -114,3 +138,340 @@ func TestMergeStepsWithStepTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeSidecarsWithSidecarTemplate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *corev1.Container
+		sidecars []Sidecar
+		expected []Sidecar
+	}{{
+		name:     "nil-template",
+		template: nil,
+		sidecars: []Sidecar{{Container: corev1.Container{
+			Image: "some-image",
+		}}},
+		expected: []Sidecar{{Container: corev1.Container{
+			Image: "some-image",
+		}}},
+	}, {
+		name: "merge-and-overwrite-slice",
+		template: &corev1.Container{
+			Env: []corev1.EnvVar{{
+				Name:  "KEEP_THIS",
+				Value: "A_VALUE",
+			}, {
+				Name:  "SOME_KEY",
+				Value: "ORIGINAL_VALUE",
+			}},
+		},
+		sidecars: []Sidecar{{
+			Container: corev1.Container{
+				Image: "some-image",
+				Env: []corev1.EnvVar{{
+					Name:  "SOME_KEY",
+					Value: "NEW_VALUE",
+				}},
+			},
+			Script: "echo hello",
+		}},
+		expected: []Sidecar{{
+			Container: corev1.Container{
+				Image: "some-image",
+				Env: []corev1.EnvVar{{
+					Name:  "KEEP_THIS",
+					Value: "A_VALUE",
+				}, {
+					Name:  "SOME_KEY",
+					Value: "NEW_VALUE",
+				}},
+			},
+			Script: "echo hello",
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := MergeSidecarsWithSidecarTemplate(tc.template, tc.sidecars)
+			if err != nil {
+				t.Errorf("expected no error. Got error %v", err)
+			}
+
+			if d := cmp.Diff(tc.expected, result); d != "" {
+				t.Errorf("merged sidecars don't match, diff: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMergeStepsWithTaskRunStepTemplate(t *testing.T) {
+	runAsNonRoot := true
+	runAsUser := int64(1000)
+	for _, tc := range []struct {
+		name     string
+		template *TaskRunStepTemplate
+		steps    []Step
+		expected []Step
+	}{{
+		name:     "nil-template",
+		template: nil,
+		steps: []Step{{Container: corev1.Container{
+			Image: "some-image",
+		}}},
+		expected: []Step{{Container: corev1.Container{
+			Image: "some-image",
+		}}},
+	}, {
+		name: "template-overrides-env",
+		template: &TaskRunStepTemplate{
+			Env: []corev1.EnvVar{{
+				Name:  "HTTP_PROXY",
+				Value: "http://proxy.internal:3128",
+			}, {
+				Name:  "SOME_KEY",
+				Value: "TEMPLATE_VALUE",
+			}},
+		},
+		steps: []Step{{
+			Container: corev1.Container{
+				Image: "some-image",
+				Env: []corev1.EnvVar{{
+					Name:  "KEEP_THIS",
+					Value: "A_VALUE",
+				}, {
+					Name:  "SOME_KEY",
+					Value: "STEP_VALUE",
+				}},
+			},
+			Script: "echo hello",
+		}},
+		expected: []Step{{
+			Container: corev1.Container{
+				Image: "some-image",
+				Env: []corev1.EnvVar{{
+					Name:  "KEEP_THIS",
+					Value: "A_VALUE",
+				}, {
+					Name:  "HTTP_PROXY",
+					Value: "http://proxy.internal:3128",
+				}, {
+					Name:  "SOME_KEY",
+					Value: "TEMPLATE_VALUE",
+				}},
+			},
+			Script: "echo hello",
+		}},
+	}, {
+		name: "template-overrides-resources",
+		template: &TaskRunStepTemplate{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+		steps: []Step{{Container: corev1.Container{
+			Image: "some-image",
+			Resources: corev1.ResourceRequirements{
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi"), corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		}}},
+		expected: []Step{{Container: corev1.Container{
+			Image: "some-image",
+			Resources: corev1.ResourceRequirements{
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi"), corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		}}},
+	}, {
+		name: "template-overrides-security-context-fields",
+		template: &TaskRunStepTemplate{
+			SecurityContext: &corev1.SecurityContext{RunAsNonRoot: &runAsNonRoot},
+		},
+		steps: []Step{{Container: corev1.Container{
+			Image: "some-image",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:    &runAsUser,
+				RunAsNonRoot: &[]bool{false}[0],
+			},
+		}}},
+		expected: []Step{{Container: corev1.Container{
+			Image: "some-image",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:    &runAsUser,
+				RunAsNonRoot: &runAsNonRoot,
+			},
+		}}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := MergeStepsWithTaskRunStepTemplate(tc.template, tc.steps)
+			if err != nil {
+				t.Errorf("expected no error. Got error %v", err)
+			}
+
+			if d := cmp.Diff(tc.expected, result); d != "" {
+				t.Errorf("merged steps don't match, diff: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMergeStepsWithOverrides(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		steps     []Step
+		overrides []TaskRunStepOverride
+		expected  []Step
+	}{{
+		name: "no-overrides",
+		steps: []Step{{Container: corev1.Container{
+			Name: "build",
+		}}},
+		expected: []Step{{Container: corev1.Container{
+			Name: "build",
+		}}},
+	}, {
+		name: "merge-resources",
+		steps: []Step{{
+			Container: corev1.Container{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+			OnError: "continue",
+		}, {
+			Container: corev1.Container{
+				Name: "push",
+			},
+		}},
+		overrides: []TaskRunStepOverride{{
+			Name: "build",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+			},
+		}},
+		expected: []Step{{
+			Container: corev1.Container{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("8"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+				},
+			},
+			OnError: "continue",
+		}, {
+			Container: corev1.Container{
+				Name: "push",
+			},
+		}},
+	}, {
+		name: "limits-below-requests",
+		steps: []Step{{
+			Container: corev1.Container{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		}},
+		overrides: []TaskRunStepOverride{{
+			Name: "build",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		}},
+		expected: []Step{{
+			Container: corev1.Container{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+		}},
+	}, {
+		name: "requests-above-limits",
+		steps: []Step{{
+			Container: corev1.Container{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+		}},
+		overrides: []TaskRunStepOverride{{
+			Name: "build",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("1500Mi"),
+				},
+			},
+		}},
+		expected: []Step{{
+			Container: corev1.Container{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("1500Mi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			result := MergeStepsWithOverrides(tc.steps, tc.overrides)
+			if d := cmp.Diff(tc.expected, result); d != "" {
+				t.Errorf("merged steps don't match, diff: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMergeSidecarsWithOverrides(t *testing.T) {
+	sidecars := []Sidecar{{Container: corev1.Container{
+		Name: "cache",
+	}}}
+	overrides := []TaskRunSidecarOverride{{
+		Name: "cache",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+		},
+	}}
+	expected := []Sidecar{{Container: corev1.Container{
+		Name: "cache",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+		},
+	}}}
+	result := MergeSidecarsWithOverrides(sidecars, overrides)
+	if d := cmp.Diff(expected, result); d != "" {
+		t.Errorf("merged sidecars don't match, diff: %s", diff.PrintWantGot(d))
+	}
+}
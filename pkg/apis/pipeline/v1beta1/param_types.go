@@ -37,7 +37,7 @@ type ParamSpec struct {
 	// Name declares the name by which a parameter is referenced.
 	Name string `json:"name"`
 	// Type is the user-specified type of the parameter. The possible types
-	// are currently "string" and "array", and "string" is the default.
+	// are currently "string", "array" and "object", and "string" is the default.
 	// +optional
 	Type ParamType `json:"type,omitempty"`
 	// Description is a user-facing description of the parameter that may be
@@ -49,19 +49,45 @@ type ParamSpec struct {
 	// parameter.
 	// +optional
 	Default *ArrayOrString `json:"default,omitempty"`
+	// Properties is the JSON Schema properties to support key-value pairs parameter.
+	// It declares the keys of an "object" parameter.
+	// +optional
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
+}
+
+// PropertySpec defines the struct for object keys
+type PropertySpec struct {
+	// Type is the type of the value of the key. Only "string" is currently supported.
+	// +optional
+	Type ParamType `json:"type,omitempty"`
 }
 
 // SetDefaults set the default type
 func (pp *ParamSpec) SetDefaults(ctx context.Context) {
-	if pp != nil && pp.Type == "" {
-		if pp.Default != nil {
+	if pp == nil {
+		return
+	}
+	if pp.Type == "" {
+		switch {
+		case pp.Default != nil:
 			// propagate the parsed ArrayOrString's type to the parent ParamSpec's type
 			pp.Type = pp.Default.Type
-		} else {
+		case pp.Properties != nil:
+			// a param declaring properties is an object
+			pp.Type = ParamTypeObject
+		default:
 			// ParamTypeString is the default value (when no type can be inferred from the default value)
 			pp.Type = ParamTypeString
 		}
 	}
+	if pp.Type == ParamTypeObject {
+		// the keys of an object are strings unless specified otherwise
+		for key, propertySpec := range pp.Properties {
+			if propertySpec.Type == "" {
+				pp.Properties[key] = PropertySpec{Type: ParamTypeString}
+			}
+		}
+	}
 }
 
 // ResourceParam declares a string value to use for the parameter called Name, and is used in
@@ -82,27 +108,33 @@ type ParamType string
 const (
 	ParamTypeString ParamType = "string"
 	ParamTypeArray  ParamType = "array"
+	ParamTypeObject ParamType = "object"
 )
 
 // AllParamTypes can be used for ParamType validation.
-var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray}
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject}
 
 // ArrayOrString is modeled after IntOrString in kubernetes/apimachinery:
 
-// ArrayOrString is a type that can hold a single string or string array.
-// Used in JSON unmarshalling so that a single JSON field can accept
-// either an individual string or an array of strings.
+// ArrayOrString is a type that can hold a single string, a string array or a
+// string map. Used in JSON unmarshalling so that a single JSON field can accept
+// either an individual string, an array of strings or an object of strings.
 type ArrayOrString struct {
-	Type      ParamType `json:"type"` // Represents the stored type of ArrayOrString.
-	StringVal string    `json:"stringVal"`
-	ArrayVal  []string  `json:"arrayVal"`
+	Type      ParamType         `json:"type"` // Represents the stored type of ArrayOrString.
+	StringVal string            `json:"stringVal"`
+	ArrayVal  []string          `json:"arrayVal"`
+	ObjectVal map[string]string `json:"objectVal"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaller interface.
 func (arrayOrString *ArrayOrString) UnmarshalJSON(value []byte) error {
-	if value[0] == '"' {
+	switch value[0] {
+	case '"':
 		arrayOrString.Type = ParamTypeString
 		return json.Unmarshal(value, &arrayOrString.StringVal)
+	case '{':
+		arrayOrString.Type = ParamTypeObject
+		return json.Unmarshal(value, &arrayOrString.ObjectVal)
 	}
 	arrayOrString.Type = ParamTypeArray
 	return json.Unmarshal(value, &arrayOrString.ArrayVal)
@@ -115,16 +147,32 @@ func (arrayOrString ArrayOrString) MarshalJSON() ([]byte, error) {
 		return json.Marshal(arrayOrString.StringVal)
 	case ParamTypeArray:
 		return json.Marshal(arrayOrString.ArrayVal)
+	case ParamTypeObject:
+		return json.Marshal(arrayOrString.ObjectVal)
 	default:
 		return []byte{}, fmt.Errorf("impossible ArrayOrString.Type: %q", arrayOrString.Type)
 	}
 }
 
-// ApplyReplacements applyes replacements for ArrayOrString type
-func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string) {
-	if arrayOrString.Type == ParamTypeString {
+// ApplyReplacements applyes replacements for ArrayOrString type. A string that is a single reference to
+// one of the objectReplacements, e.g. "$(params.myObject[*])", is replaced by the whole object.
+func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) {
+	switch arrayOrString.Type {
+	case ParamTypeString:
+		for k, v := range objectReplacements {
+			if arrayOrString.StringVal == fmt.Sprintf("$(%s[*])", k) {
+				*arrayOrString = *NewObject(v).DeepCopy()
+				return
+			}
+		}
 		arrayOrString.StringVal = substitution.ApplyReplacements(arrayOrString.StringVal, stringReplacements)
-	} else {
+	case ParamTypeObject:
+		newObjectVal := make(map[string]string, len(arrayOrString.ObjectVal))
+		for k, v := range arrayOrString.ObjectVal {
+			newObjectVal[k] = substitution.ApplyReplacements(v, stringReplacements)
+		}
+		arrayOrString.ObjectVal = newObjectVal
+	default:
 		var newArrayVal []string
 		for _, v := range arrayOrString.ArrayVal {
 			newArrayVal = append(newArrayVal, substitution.ApplyArrayReplacements(v, stringReplacements, arrayReplacements)...)
@@ -148,17 +196,94 @@ func NewArrayOrString(value string, values ...string) *ArrayOrString {
 	}
 }
 
+// NewObject creates an ArrayOrString of type ParamTypeObject holding the given keys and values.
+func NewObject(values map[string]string) *ArrayOrString {
+	return &ArrayOrString{
+		Type:      ParamTypeObject,
+		ObjectVal: values,
+	}
+}
+
 // ArrayReference returns the name of the parameter from array parameter reference
 // returns arrayParam from $(params.arrayParam[*])
 func ArrayReference(a string) string {
 	return strings.TrimSuffix(strings.TrimPrefix(a, "$("+ParamsPrefix+"."), "[*])")
 }
 
+// ObjectReference returns the name of the parameter from a whole object parameter reference
+// returns objectParam from $(params.objectParam[*])
+func ObjectReference(o string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(o, "$("+ParamsPrefix+"."), "[*])")
+}
+
+// propertyKeys returns the keys declared in the properties of the ParamSpec.
+func (pp ParamSpec) propertyKeys() sets.String {
+	keys := sets.NewString()
+	for key := range pp.Properties {
+		keys.Insert(key)
+	}
+	return keys
+}
+
+// MissingObjectKeys returns the sorted keys declared in the properties of the ParamSpec which are
+// neither in the provided value nor in the default value of the param.
+func (pp ParamSpec) MissingObjectKeys(value map[string]string) []string {
+	var keys []string
+	for _, key := range pp.propertyKeys().List() {
+		if _, ok := value[key]; ok {
+			continue
+		}
+		if pp.Default != nil {
+			if _, ok := pp.Default.ObjectVal[key]; ok {
+				continue
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// validateProperties validates that properties are only declared for object params, that an object param
+// declares at least one string key, and that its default value, if any, provides all the declared keys.
+func (pp ParamSpec) validateProperties() (errs *apis.FieldError) {
+	if pp.Type != ParamTypeObject {
+		if len(pp.Properties) != 0 {
+			return apis.ErrGeneric(fmt.Sprintf("properties can only be declared for %q params", ParamTypeObject), "properties")
+		}
+		return nil
+	}
+	if len(pp.Properties) == 0 {
+		return apis.ErrMissingField("properties")
+	}
+	for _, key := range pp.propertyKeys().List() {
+		if propertyType := pp.Properties[key].Type; propertyType != ParamTypeString {
+			errs = errs.Also(apis.ErrInvalidValue(propertyType, "type").ViaFieldKey("properties", key))
+		}
+	}
+	if pp.Default != nil && pp.Default.Type == ParamTypeObject {
+		var missingKeys []string
+		for _, key := range pp.propertyKeys().List() {
+			if _, ok := pp.Default.ObjectVal[key]; !ok {
+				missingKeys = append(missingKeys, key)
+			}
+		}
+		if len(missingKeys) != 0 {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("default value is missing the keys declared in properties: %v", missingKeys), "default"))
+		}
+	}
+	return errs
+}
+
 func validatePipelineParametersVariablesInTaskParameters(params []Param, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for _, param := range params {
-		if param.Value.Type == ParamTypeString {
+		switch param.Value.Type {
+		case ParamTypeString:
 			errs = errs.Also(validateStringVariable(param.Value.StringVal, prefix, paramNames, arrayParamNames).ViaFieldKey("params", param.Name))
-		} else {
+		case ParamTypeObject:
+			for key, value := range param.Value.ObjectVal {
+				errs = errs.Also(validateStringVariable(value, prefix, paramNames, arrayParamNames).ViaFieldKey("value", key).ViaFieldKey("params", param.Name))
+			}
+		default:
 			for idx, arrayElement := range param.Value.ArrayVal {
 				errs = errs.Also(validateArrayVariable(arrayElement, prefix, paramNames, arrayParamNames).ViaFieldIndex("value", idx).ViaFieldKey("params", param.Name))
 			}
@@ -167,6 +292,55 @@ func validatePipelineParametersVariablesInTaskParameters(params []Param, prefix
 	return errs
 }
 
+// validatePipelineParametersObjectUsage validates that the object params are only referenced through their declared
+// keys, e.g. $(params.myObject.key), except for a PipelineTask param whose whole value is $(params.myObject[*]).
+func validatePipelineParametersObjectUsage(tasks []PipelineTask, prefix string, objectKeys map[string]sets.String) (errs *apis.FieldError) {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	for idx, task := range tasks {
+		for _, param := range task.Params {
+			switch param.Value.Type {
+			case ParamTypeString:
+				if objectKeys[ObjectReference(param.Value.StringVal)] != nil {
+					continue
+				}
+				errs = errs.Also(substitution.ValidateObjectKeysP(param.Value.StringVal, prefix, objectKeys).ViaFieldKey("params", param.Name).ViaIndex(idx))
+			case ParamTypeObject:
+				for key, value := range param.Value.ObjectVal {
+					errs = errs.Also(substitution.ValidateObjectKeysP(value, prefix, objectKeys).ViaFieldKey("value", key).ViaFieldKey("params", param.Name).ViaIndex(idx))
+				}
+			default:
+				for i, arrayElement := range param.Value.ArrayVal {
+					errs = errs.Also(substitution.ValidateObjectKeysP(arrayElement, prefix, objectKeys).ViaFieldIndex("value", i).ViaFieldKey("params", param.Name).ViaIndex(idx))
+				}
+			}
+		}
+		for _, param := range task.Matrix {
+			for i, arrayElement := range param.Value.ArrayVal {
+				errs = errs.Also(substitution.ValidateObjectKeysP(arrayElement, prefix, objectKeys).ViaFieldIndex("value", i).ViaFieldKey("matrix", param.Name).ViaIndex(idx))
+			}
+		}
+		for i, we := range task.WhenExpressions {
+			errs = errs.Also(substitution.ValidateObjectKeysP(we.Input, prefix, objectKeys).ViaField("input").ViaFieldIndex("when", i).ViaIndex(idx))
+			errs = errs.Also(substitution.ValidateObjectKeysP(we.CEL, prefix, objectKeys).ViaField("cel").ViaFieldIndex("when", i).ViaIndex(idx))
+			for _, val := range we.Values {
+				errs = errs.Also(substitution.ValidateObjectKeysP(val, prefix, objectKeys).ViaField("values").ViaFieldIndex("when", i).ViaIndex(idx))
+			}
+		}
+	}
+	return errs
+}
+
+func validatePipelineParametersVariablesInMatrixParameters(matrix []Param, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
+	for _, param := range matrix {
+		for idx, arrayElement := range param.Value.ArrayVal {
+			errs = errs.Also(validateArrayVariable(arrayElement, prefix, paramNames, arrayParamNames).ViaFieldIndex("value", idx).ViaFieldKey("matrix", param.Name))
+		}
+	}
+	return errs
+}
+
 func validateStringVariable(value, prefix string, stringVars sets.String, arrayVars sets.String) *apis.FieldError {
 	errs := substitution.ValidateVariableP(value, prefix, stringVars)
 	return errs.Also(substitution.ValidateVariableProhibitedP(value, prefix, arrayVars))
@@ -52,6 +52,36 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 			Type:    v1beta1.ParamTypeArray,
 			Default: v1beta1.NewArrayOrString("an", "array"),
 		},
+	}, {
+		name: "inferred object type from properties",
+		before: &v1beta1.ParamSpec{
+			Name: "parametername",
+			Properties: map[string]v1beta1.PropertySpec{
+				"url":    {},
+				"commit": {Type: v1beta1.ParamTypeString},
+			},
+		},
+		defaultsApplied: &v1beta1.ParamSpec{
+			Name: "parametername",
+			Type: v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{
+				"url":    {Type: v1beta1.ParamTypeString},
+				"commit": {Type: v1beta1.ParamTypeString},
+			},
+		},
+	}, {
+		name: "inferred object type from default value",
+		before: &v1beta1.ParamSpec{
+			Name:       "parametername",
+			Properties: map[string]v1beta1.PropertySpec{"url": {}},
+			Default:    v1beta1.NewObject(map[string]string{"url": "a"}),
+		},
+		defaultsApplied: &v1beta1.ParamSpec{
+			Name:       "parametername",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}},
+			Default:    v1beta1.NewObject(map[string]string{"url": "a"}),
+		},
 	}, {
 		name: "fully defined ParamSpec",
 		before: &v1beta1.ParamSpec{
@@ -83,6 +113,7 @@ func TestArrayOrString_ApplyReplacements(t *testing.T) {
 		input              *v1beta1.ArrayOrString
 		stringReplacements map[string]string
 		arrayReplacements  map[string][]string
+		objectReplacements map[string]map[string]string
 	}
 	tests := []struct {
 		name           string
@@ -128,10 +159,34 @@ func TestArrayOrString_ApplyReplacements(t *testing.T) {
 			arrayReplacements:  map[string][]string{"arraykey": {}},
 		},
 		expectedOutput: v1beta1.NewArrayOrString("firstvalue", "lastvalue"),
+	}, {
+		name: "string replacements on object",
+		args: args{
+			input:              v1beta1.NewObject(map[string]string{"url": "$(some)", "commit": "sha-$(anotherkey)"}),
+			stringReplacements: map[string]string{"some": "value", "anotherkey": "value"},
+			arrayReplacements:  map[string][]string{"arraykey": {"array", "value"}},
+		},
+		expectedOutput: v1beta1.NewObject(map[string]string{"url": "value", "commit": "sha-value"}),
+	}, {
+		name: "object replacement on string",
+		args: args{
+			input:              v1beta1.NewArrayOrString("$(objectkey[*])"),
+			stringReplacements: map[string]string{"objectkey.url": "value"},
+			objectReplacements: map[string]map[string]string{"objectkey": {"url": "value"}},
+		},
+		expectedOutput: v1beta1.NewObject(map[string]string{"url": "value"}),
+	}, {
+		name: "object key replacement on string",
+		args: args{
+			input:              v1beta1.NewArrayOrString("url is $(objectkey.url)"),
+			stringReplacements: map[string]string{"objectkey.url": "value"},
+			objectReplacements: map[string]map[string]string{"objectkey": {"url": "value"}},
+		},
+		expectedOutput: v1beta1.NewArrayOrString("url is value"),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.args.input.ApplyReplacements(tt.args.stringReplacements, tt.args.arrayReplacements)
+			tt.args.input.ApplyReplacements(tt.args.stringReplacements, tt.args.arrayReplacements, tt.args.objectReplacements)
 			if d := cmp.Diff(tt.expectedOutput, tt.args.input); d != "" {
 				t.Errorf("ApplyReplacements() output did not match expected value %s", diff.PrintWantGot(d))
 			}
@@ -153,6 +208,7 @@ func TestArrayOrString_UnmarshalJSON(t *testing.T) {
 		{"{\"val\":[]}", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}}},
 		{"{\"val\":[\"oneelement\"]}", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"oneelement"}}},
 		{"{\"val\":[\"multiple\", \"elements\"]}", *v1beta1.NewArrayOrString("multiple", "elements")},
+		{"{\"val\":{\"key1\": \"value1\", \"key2\": \"value2\"}}", *v1beta1.NewObject(map[string]string{"key1": "value1", "key2": "value2"})},
 	}
 
 	for _, c := range cases {
@@ -174,6 +230,7 @@ func TestArrayOrString_MarshalJSON(t *testing.T) {
 		{*v1beta1.NewArrayOrString("123"), "{\"val\":\"123\"}"},
 		{*v1beta1.NewArrayOrString("123", "1234"), "{\"val\":[\"123\",\"1234\"]}"},
 		{*v1beta1.NewArrayOrString("a", "a", "a"), "{\"val\":[\"a\",\"a\",\"a\"]}"},
+		{*v1beta1.NewObject(map[string]string{"key1": "value1", "key2": "value2"}), "{\"val\":{\"key1\":\"value1\",\"key2\":\"value2\"}}"},
 	}
 
 	for _, c := range cases {
@@ -206,3 +263,62 @@ func TestArrayReference(t *testing.T) {
 		}
 	}
 }
+
+func TestObjectReference(t *testing.T) {
+	tests := []struct {
+		name, p, expectedResult string
+	}{{
+		name:           "valid object parameter expression with star notation returns param name",
+		p:              "$(params.objectParam[*])",
+		expectedResult: "objectParam",
+	}, {
+		name:           "invalid object parameter without dollar notation returns the input as is",
+		p:              "params.objectParam[*]",
+		expectedResult: "params.objectParam[*]",
+	}}
+	for _, tt := range tests {
+		if d := cmp.Diff(tt.expectedResult, v1beta1.ObjectReference(tt.p)); d != "" {
+			t.Errorf(diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestParamSpec_MissingObjectKeys(t *testing.T) {
+	properties := map[string]v1beta1.PropertySpec{
+		"url":      {Type: v1beta1.ParamTypeString},
+		"revision": {Type: v1beta1.ParamTypeString},
+		"depth":    {Type: v1beta1.ParamTypeString},
+	}
+	tests := []struct {
+		name         string
+		paramSpec    v1beta1.ParamSpec
+		value        map[string]string
+		expectedKeys []string
+	}{{
+		name:      "all keys provided",
+		paramSpec: v1beta1.ParamSpec{Name: "git", Type: v1beta1.ParamTypeObject, Properties: properties},
+		value:     map[string]string{"url": "u", "revision": "r", "depth": "1"},
+	}, {
+		name:         "missing keys are sorted",
+		paramSpec:    v1beta1.ParamSpec{Name: "git", Type: v1beta1.ParamTypeObject, Properties: properties},
+		value:        map[string]string{"url": "u"},
+		expectedKeys: []string{"depth", "revision"},
+	}, {
+		name: "missing keys provided by the default value",
+		paramSpec: v1beta1.ParamSpec{
+			Name:       "git",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: properties,
+			Default:    v1beta1.NewObject(map[string]string{"depth": "1"}),
+		},
+		value:        map[string]string{"url": "u"},
+		expectedKeys: []string{"revision"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := cmp.Diff(tt.expectedKeys, tt.paramSpec.MissingObjectKeys(tt.value)); d != "" {
+				t.Errorf(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -38,6 +38,9 @@ func (ps *PipelineSpec) SetDefaults(ctx context.Context) {
 		if pt.TaskSpec != nil {
 			pt.TaskSpec.SetDefaults(ctx)
 		}
+		if pt.PipelineSpec != nil {
+			pt.PipelineSpec.SetDefaults(ctx)
+		}
 	}
 	for i := range ps.Params {
 		ps.Params[i].SetDefaults(ctx)
@@ -51,5 +54,8 @@ func (ps *PipelineSpec) SetDefaults(ctx context.Context) {
 		if ft.TaskSpec != nil {
 			ft.TaskSpec.SetDefaults(ctx)
 		}
+		if ft.PipelineSpec != nil {
+			ft.PipelineSpec.SetDefaults(ctx)
+		}
 	}
 }
@@ -131,6 +131,26 @@ func TestPipelineSpec_SetDefaults(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		desc: "pipeline task with pipelineSpec - default task kind must be " + string(v1beta1.NamespacedTaskKind),
+		ps: &v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name: "foo", PipelineSpec: &v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name: "bar", TaskRef: &v1beta1.TaskRef{Name: "bar-task"},
+					}},
+				},
+			}},
+		},
+		want: &v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name: "foo", PipelineSpec: &v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name: "bar", TaskRef: &v1beta1.TaskRef{Name: "bar-task", Kind: v1beta1.NamespacedTaskKind},
+					}},
+				},
+			}},
+		},
 	}}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -19,7 +19,9 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -102,12 +104,17 @@ type PipelineResult struct {
 	// Name the given name
 	Name string `json:"name"`
 
+	// Type is the user-specified type of the result. The possible types
+	// are "string", "array" and "object", and "string" is the default.
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description"`
 
 	// Value the expression used to retrieve the value
-	Value string `json:"value"`
+	Value ArrayOrString `json:"value"`
 }
 
 type PipelineTaskMetadata struct {
@@ -150,6 +157,14 @@ type PipelineTask struct {
 	// +optional
 	TaskSpec *EmbeddedTask `json:"taskSpec,omitempty"`
 
+	// PipelineRef is a reference to a pipeline definition, run in a child PipelineRun.
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+
+	// PipelineSpec is a specification of a pipeline, run in a child PipelineRun.
+	// +optional
+	PipelineSpec *PipelineSpec `json:"pipelineSpec,omitempty"`
+
 	// Conditions is a list of conditions that need to be true for the task to run
 	// Conditions are deprecated, use WhenExpressions instead
 	// +optional
@@ -163,6 +178,16 @@ type PipelineTask struct {
 	// +optional
 	Retries int `json:"retries,omitempty"`
 
+	// RetryStrategy configures when and how soon a failed TaskRun is retried
+	// +optional
+	RetryStrategy *RetryStrategy `json:"retryStrategy,omitempty"`
+
+	// OnError defines the exiting behavior of the PipelineRun when the PipelineTask fails,
+	// either "stopAndFail", the default, or "continue", which tolerates the failure: the
+	// tasks depending on it still run and the PipelineRun doesn't fail.
+	// +optional
+	OnError PipelineTaskOnErrorType `json:"onError,omitempty"`
+
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
@@ -176,6 +201,11 @@ type PipelineTask struct {
 	// +optional
 	Params []Param `json:"params,omitempty"`
 
+	// Matrix declares array parameters used to fan out this task: a TaskRun
+	// (or Run) is created for each combination of their values.
+	// +optional
+	Matrix []Param `json:"matrix,omitempty"`
+
 	// Workspaces maps workspaces from the pipeline spec to the workspaces
 	// declared in the Task.
 	// +optional
@@ -188,8 +218,148 @@ type PipelineTask struct {
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
+// PipelineTaskOnErrorType defines the exiting behavior of a PipelineRun when one of its PipelineTasks fails
+type PipelineTaskOnErrorType string
+
+const (
+	// PipelineTaskStopAndFail fails the PipelineRun when the PipelineTask fails
+	PipelineTaskStopAndFail PipelineTaskOnErrorType = "stopAndFail"
+	// PipelineTaskContinue tolerates the failure of the PipelineTask: the PipelineRun continues
+	// executing the tasks depending on it and doesn't fail
+	PipelineTaskContinue PipelineTaskOnErrorType = "continue"
+)
+
+// RetryStrategy configures the retries of a PipelineTask
+type RetryStrategy struct {
+	// Backoff is the delay between the failure of a TaskRun and its retry.
+	// The TaskRun is retried at once if it isn't set.
+	// +optional
+	Backoff *Backoff `json:"backoff,omitempty"`
+
+	// OnReasons is the list of the reasons of the failures that are retried,
+	// e.g. "PodEvicted", "TaskRunImagePullFailed", "ExceededNodeResources" or
+	// "TaskRunTimeout". A TaskRun failing for another reason isn't retried.
+	// All failures are retried if it is empty.
+	// +optional
+	OnReasons []string `json:"onReasons,omitempty"`
+}
+
+// Backoff is an exponential delay with jitter between the retries of a PipelineTask
+type Backoff struct {
+	// Duration is the delay before the first retry
+	Duration *metav1.Duration `json:"duration"`
+
+	// Factor multiplies the delay after each retry. Defaults to 2.
+	// +optional
+	Factor int `json:"factor,omitempty"`
+
+	// MaxDuration caps the delay between two retries
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+}
+
+// DefaultBackoffFactor is the factor multiplying the delay after each retry
+// when the Backoff doesn't set one
+const DefaultBackoffFactor = 2
+
+// Delay returns the delay before the retry following the given number of
+// retries already done, without jitter. The delay is clamped to MaxDuration,
+// or to the longest Duration if it isn't set, instead of overflowing.
+func (b *Backoff) Delay(retriesDone int) time.Duration {
+	if b == nil || b.Duration == nil {
+		return 0
+	}
+	factor := time.Duration(b.Factor)
+	if factor == 0 {
+		factor = DefaultBackoffFactor
+	}
+	maxDelay := time.Duration(math.MaxInt64)
+	if b.MaxDuration != nil {
+		maxDelay = b.MaxDuration.Duration
+	}
+	delay := b.Duration.Duration
+	for i := 0; i < retriesDone && factor > 1; i++ {
+		if delay > maxDelay/factor {
+			delay = maxDelay
+			break
+		}
+		delay *= factor
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// RetriesOn returns true if a TaskRun failing with the given reason is retried
+func (rs *RetryStrategy) RetriesOn(reason string) bool {
+	if rs == nil || len(rs.OnReasons) == 0 {
+		return true
+	}
+	for _, r := range rs.OnReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRetryStrategy validates the backoff and reasons of the RetryStrategy
+func (pt PipelineTask) validateRetryStrategy(ctx context.Context) (errs *apis.FieldError) {
+	if pt.RetryStrategy == nil {
+		return nil
+	}
+	// This is an alpha feature and will fail validation if it's used in a pipeline spec
+	// when the enable-api-fields feature gate is anything but "alpha".
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "retryStrategy", config.AlphaAPIFields))
+	if pt.Retries == 0 {
+		errs = errs.Also(apis.ErrGeneric("retryStrategy requires retries to be set", "retries", "retryStrategy"))
+	}
+	if b := pt.RetryStrategy.Backoff; b != nil {
+		if b.Duration == nil {
+			errs = errs.Also(apis.ErrMissingField("retryStrategy.backoff.duration"))
+		} else if b.Duration.Duration <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", b.Duration.Duration), "retryStrategy.backoff.duration"))
+		}
+		if b.Factor < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 1", b.Factor), "retryStrategy.backoff.factor"))
+		}
+		if b.MaxDuration != nil && b.Duration != nil && b.MaxDuration.Duration < b.Duration.Duration {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= duration %s", b.MaxDuration.Duration, b.Duration.Duration), "retryStrategy.backoff.maxDuration"))
+		}
+	}
+	for i, reason := range pt.RetryStrategy.OnReasons {
+		if reason == "" {
+			errs = errs.Also(apis.ErrInvalidValue("reason must not be empty", "").ViaFieldIndex("onReasons", i).ViaField("retryStrategy"))
+		}
+	}
+	return errs
+}
+
+// validateOnError validates the OnError of the PipelineTask
+func (pt PipelineTask) validateOnError(ctx context.Context) (errs *apis.FieldError) {
+	if pt.OnError == "" {
+		return nil
+	}
+	// This is an alpha feature and will fail validation if it's used in a pipeline spec
+	// when the enable-api-fields feature gate is anything but "alpha".
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "pipeline task onError", config.AlphaAPIFields))
+	if pt.OnError != PipelineTaskStopAndFail && pt.OnError != PipelineTaskContinue {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", pt.OnError, PipelineTaskStopAndFail, PipelineTaskContinue), "onError"))
+	}
+	return errs
+}
+
+// ToleratesFailure returns true if the failure of the PipelineTask doesn't stop or fail the PipelineRun
+func (pt PipelineTask) ToleratesFailure() bool {
+	return pt.OnError == PipelineTaskContinue
+}
+
 // validateRefOrSpec validates at least one of taskRef or taskSpec is specified
 func (pt PipelineTask) validateRefOrSpec() (errs *apis.FieldError) {
+	if pt.IsChildPipeline() {
+		return pt.validatePipelineRefOrSpec()
+	}
 	// can't have both taskRef and taskSpec at the same time
 	if pt.TaskRef != nil && pt.TaskSpec != nil {
 		errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec"))
@@ -201,6 +371,18 @@ func (pt PipelineTask) validateRefOrSpec() (errs *apis.FieldError) {
 	return errs
 }
 
+// validatePipelineRefOrSpec validates exactly one of pipelineRef or pipelineSpec is specified,
+// and none of taskRef or taskSpec
+func (pt PipelineTask) validatePipelineRefOrSpec() (errs *apis.FieldError) {
+	if pt.TaskRef != nil || pt.TaskSpec != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec", "pipelineRef", "pipelineSpec"))
+	}
+	if pt.PipelineRef != nil && pt.PipelineSpec != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("pipelineRef", "pipelineSpec"))
+	}
+	return errs
+}
+
 // validateCustomTask validates custom task specifications - checking kind and fail if not yet supported features specified
 func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
 	if pt.TaskRef != nil && pt.TaskRef.Kind == "" {
@@ -225,6 +407,9 @@ func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
 	if pt.Retries > 0 {
 		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support retries", "retries"))
 	}
+	if pt.RetryStrategy != nil {
+		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support retries", "retryStrategy"))
+	}
 	if pt.Resources != nil {
 		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support PipelineResources", "resources"))
 	}
@@ -258,9 +443,10 @@ func (pt PipelineTask) validateTask(ctx context.Context) (errs *apis.FieldError)
 			if errSlice := validation.IsQualifiedName(pt.TaskRef.Name); len(errSlice) != 0 {
 				errs = errs.Also(apis.ErrInvalidValue(strings.Join(errSlice, ","), "name"))
 			}
-		} else {
+		} else if pt.TaskRef.Resolver == "" {
 			errs = errs.Also(apis.ErrInvalidValue("taskRef must specify name", "taskRef.name"))
 		}
+		errs = errs.Also(validateResolverRef(ctx, pt.TaskRef.ResolverRef, pt.TaskRef.Name, pt.TaskRef.Bundle).ViaField("taskRef"))
 		// fail if bundle is present when EnableTektonOCIBundles feature flag is off (as it won't be allowed nor used)
 		if pt.TaskRef.Bundle != "" {
 			errs = errs.Also(apis.ErrDisallowedFields("taskref.bundle"))
@@ -297,6 +483,8 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// If EnableCustomTasks feature flag is on, validate custom task specifications
 	// pipeline task having taskRef with APIVersion is classified as custom task
 	switch {
+	case pt.IsChildPipeline():
+		errs = errs.Also(pt.validateChildPipeline(ctx))
 	case cfg.FeatureFlags.EnableCustomTasks && pt.TaskRef != nil && pt.TaskRef.APIVersion != "":
 		errs = errs.Also(pt.validateCustomTask())
 	case cfg.FeatureFlags.EnableCustomTasks && pt.TaskSpec != nil && pt.TaskSpec.APIVersion != "":
@@ -307,9 +495,104 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 	default:
 		errs = errs.Also(pt.validateTask(ctx))
 	}
+	errs = errs.Also(pt.validateMatrix(ctx))
+	errs = errs.Also(pt.validateRetryStrategy(ctx))
+	errs = errs.Also(pt.validateOnError(ctx))
 	return
 }
 
+// IsChildPipeline returns true if the PipelineTask runs a Pipeline in a child PipelineRun
+// instead of running a Task
+func (pt PipelineTask) IsChildPipeline() bool {
+	return pt.PipelineRef != nil || pt.PipelineSpec != nil
+}
+
+// validateChildPipeline validates a pipeline task running a Pipeline - checking the pipelineRef or
+// pipelineSpec and failing if features which only apply to Tasks are specified
+func (pt PipelineTask) validateChildPipeline(ctx context.Context) (errs *apis.FieldError) {
+	// This is an alpha feature and will fail validation if it's used in a pipeline spec
+	// when the enable-api-fields feature gate is anything but "alpha".
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "pipelineRef or pipelineSpec", config.AlphaAPIFields))
+	if pt.PipelineSpec != nil {
+		errs = errs.Also(pt.PipelineSpec.Validate(ctx).ViaField("pipelineSpec"))
+	}
+	if pt.PipelineRef != nil {
+		if pt.PipelineRef.Name != "" {
+			if errSlice := validation.IsQualifiedName(pt.PipelineRef.Name); len(errSlice) != 0 {
+				errs = errs.Also(apis.ErrInvalidValue(strings.Join(errSlice, ","), "pipelineRef.name"))
+			}
+		} else if pt.PipelineRef.Resolver == "" {
+			errs = errs.Also(apis.ErrMissingField("pipelineRef.name"))
+		}
+		if pt.PipelineRef.Bundle != "" {
+			if _, err := name.ParseReference(pt.PipelineRef.Bundle); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid bundle reference (%s)", err.Error()), "pipelineRef.bundle"))
+			}
+		}
+		errs = errs.Also(validateResolverRef(ctx, pt.PipelineRef.ResolverRef, pt.PipelineRef.Name, pt.PipelineRef.Bundle).ViaField("pipelineRef"))
+	}
+	// Conditions are deprecated so the effort to support them with child pipelines is not justified.
+	// When expressions should be used instead.
+	if len(pt.Conditions) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support conditions - use when expressions instead", "conditions"))
+	}
+	if pt.Retries > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support retries", "retries"))
+	}
+	if pt.RetryStrategy != nil {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support retries", "retryStrategy"))
+	}
+	if pt.Resources != nil {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support PipelineResources", "resources"))
+	}
+	if pt.IsMatrixed() {
+		errs = errs.Also(apis.ErrInvalidValue("pipelines in pipelines do not support matrix", "matrix"))
+	}
+	return errs
+}
+
+// IsMatrixed returns true if the PipelineTask fans out over a Matrix of parameters
+func (pt PipelineTask) IsMatrixed() bool {
+	return len(pt.Matrix) > 0
+}
+
+// validateMatrix validates the matrix parameters: they must be non-empty arrays, must not be
+// declared more than once and must not be passed as regular parameters too
+func (pt PipelineTask) validateMatrix(ctx context.Context) (errs *apis.FieldError) {
+	if !pt.IsMatrixed() {
+		return nil
+	}
+	// This is an alpha feature and will fail validation if it's used in a pipeline spec
+	// when the enable-api-fields feature gate is anything but "alpha".
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "matrix", config.AlphaAPIFields))
+	paramNames := sets.NewString()
+	for _, param := range pt.Params {
+		paramNames.Insert(param.Name)
+	}
+	matrixNames := sets.NewString()
+	for _, param := range pt.Matrix {
+		switch {
+		case param.Value.Type != ParamTypeArray:
+			errs = errs.Also(apis.ErrInvalidValue("matrix parameters must be of type array", "value").ViaFieldKey("matrix", param.Name))
+		case len(param.Value.ArrayVal) == 0:
+			errs = errs.Also(apis.ErrInvalidValue("matrix parameters must not be empty arrays", "value").ViaFieldKey("matrix", param.Name))
+		}
+		if matrixNames.Has(param.Name) {
+			errs = errs.Also(apis.ErrGeneric("parameter appears more than once", "").ViaFieldKey("matrix", param.Name))
+		}
+		if paramNames.Has(param.Name) {
+			errs = errs.Also(apis.ErrMultipleOneOf(fmt.Sprintf("matrix[%s]", param.Name), fmt.Sprintf("params[%s]", param.Name)))
+		}
+		matrixNames.Insert(param.Name)
+	}
+	// Conditions are deprecated so the effort to support them with matrix is not justified.
+	// When expressions should be used instead.
+	if len(pt.Conditions) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("matrixed tasks do not support conditions - use when expressions instead", "conditions"))
+	}
+	return errs
+}
+
 func (pt PipelineTask) Deps() []string {
 	deps := []string{}
 
@@ -18,11 +18,14 @@ package v1beta1
 
 import (
 	"context"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
@@ -255,6 +258,7 @@ func TestPipelineTask_ValidateRegularTask_Success(t *testing.T) {
 	tests := []struct {
 		name  string
 		tasks PipelineTask
+		wc    func(context.Context) context.Context
 	}{{
 		name: "pipeline task - valid taskRef name",
 		tasks: PipelineTask{
@@ -267,10 +271,24 @@ func TestPipelineTask_ValidateRegularTask_Success(t *testing.T) {
 			Name:     "foo",
 			TaskSpec: &EmbeddedTask{TaskSpec: getTaskSpec()},
 		},
+	}, {
+		name: "pipeline task - taskRef with a resolver",
+		tasks: PipelineTask{
+			Name: "foo",
+			TaskRef: &TaskRef{ResolverRef: ResolverRef{
+				Resolver: "git",
+				Params:   []Param{{Name: "path", Value: *NewArrayOrString("task.yaml")}},
+			}},
+		},
+		wc: enableAlphaAPIFields,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.tasks.validateTask(context.Background())
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			err := tt.tasks.validateTask(ctx)
 			if err != nil {
 				t.Errorf("PipelineTask.validateTask() returned error for valid pipeline task: %v", err)
 			}
@@ -320,6 +338,13 @@ func TestPipelineTask_ValidateRegularTask_Failure(t *testing.T) {
 			TaskRef: &TaskRef{Name: "bar", Bundle: "docker.io/foo"},
 		},
 		expectedError: *apis.ErrDisallowedFields("taskref.bundle"),
+	}, {
+		name: "pipeline task - use of resolver without the feature flag set",
+		task: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{ResolverRef: ResolverRef{Resolver: "git"}},
+		},
+		expectedError: *apis.ErrGeneric(`resolver requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("taskRef.resolver"),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -380,6 +405,368 @@ func TestPipelineTask_Validate_Failure(t *testing.T) {
 	}
 }
 
+func TestPipelineTask_validateMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "parameter in matrix",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+			}, {
+				Name: "browser", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"chrome", "safari"}},
+			}},
+			Params: []Param{{
+				Name: "version", Value: ArrayOrString{Type: ParamTypeString, StringVal: "v1"},
+			}},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "matrix requires alpha api fields",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+			}},
+		},
+		wantErrs: apis.ErrGeneric(`matrix requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "parameters in matrix must be arrays",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeString, StringVal: "linux"},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("matrix parameters must be of type array", "matrix[platform].value"),
+	}, {
+		name: "parameters in matrix must not be empty arrays",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{}},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("matrix parameters must not be empty arrays", "matrix[platform].value"),
+	}, {
+		name: "duplicate parameters in matrix",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+			}, {
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"windows"}},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrGeneric("parameter appears more than once", "matrix[platform]"),
+	}, {
+		name: "parameters in both matrix and params",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+			}},
+			Params: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeString, StringVal: "linux"},
+			}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("matrix[platform]", "params[platform]"),
+	}, {
+		name: "matrix with conditions",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+			}},
+			Conditions: []PipelineTaskCondition{{ConditionRef: "condition"}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("matrixed tasks do not support conditions - use when expressions instead", "conditions"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.validateMatrix(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.validateMatrix() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_validateRetryStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "backoff and reasons",
+		pt: &PipelineTask{
+			Name:    "task",
+			Retries: 3,
+			RetryStrategy: &RetryStrategy{
+				Backoff: &Backoff{
+					Duration:    &metav1.Duration{Duration: 10 * time.Second},
+					Factor:      3,
+					MaxDuration: &metav1.Duration{Duration: time.Minute},
+				},
+				OnReasons: []string{"PodEvicted"},
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "retryStrategy without alpha feature gate",
+		pt: &PipelineTask{
+			Name:          "task",
+			Retries:       1,
+			RetryStrategy: &RetryStrategy{OnReasons: []string{"PodEvicted"}},
+		},
+		wantErrs: apis.ErrGeneric(`retryStrategy requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "retryStrategy without retries",
+		pt: &PipelineTask{
+			Name:          "task",
+			RetryStrategy: &RetryStrategy{OnReasons: []string{"PodEvicted"}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrGeneric("retryStrategy requires retries to be set", "retries", "retryStrategy"),
+	}, {
+		name: "backoff without duration",
+		pt: &PipelineTask{
+			Name:          "task",
+			Retries:       1,
+			RetryStrategy: &RetryStrategy{Backoff: &Backoff{Factor: 2}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMissingField("retryStrategy.backoff.duration"),
+	}, {
+		name: "invalid backoff",
+		pt: &PipelineTask{
+			Name:    "task",
+			Retries: 1,
+			RetryStrategy: &RetryStrategy{
+				Backoff: &Backoff{
+					Duration:    &metav1.Duration{Duration: time.Minute},
+					Factor:      -1,
+					MaxDuration: &metav1.Duration{Duration: time.Second},
+				},
+			},
+		},
+		wc: enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("-1 should be >= 1", "retryStrategy.backoff.factor").Also(
+			apis.ErrInvalidValue("1s should be >= duration 1m0s", "retryStrategy.backoff.maxDuration")),
+	}, {
+		name: "empty reason",
+		pt: &PipelineTask{
+			Name:          "task",
+			Retries:       1,
+			RetryStrategy: &RetryStrategy{OnReasons: []string{"PodEvicted", ""}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("reason must not be empty", "retryStrategy.onReasons[1]"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.validateRetryStrategy(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.validateRetryStrategy() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_validateOnError(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "onError continue",
+		pt: &PipelineTask{
+			Name:    "task",
+			OnError: PipelineTaskContinue,
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "onError stopAndFail",
+		pt: &PipelineTask{
+			Name:    "task",
+			OnError: PipelineTaskStopAndFail,
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "onError without alpha feature gate",
+		pt: &PipelineTask{
+			Name:    "task",
+			OnError: PipelineTaskContinue,
+		},
+		wantErrs: apis.ErrGeneric(`pipeline task onError requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "invalid onError",
+		pt: &PipelineTask{
+			Name:    "task",
+			OnError: "ignore",
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("ignore should be stopAndFail or continue", "onError"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.validateOnError(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.validateOnError() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	backoff := &Backoff{
+		Duration:    &metav1.Duration{Duration: 10 * time.Second},
+		MaxDuration: &metav1.Duration{Duration: 30 * time.Second},
+	}
+	for retriesDone, want := range []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second} {
+		if got := backoff.Delay(retriesDone); got != want {
+			t.Errorf("Delay(%d) = %s, want %s", retriesDone, got, want)
+		}
+	}
+	unbounded := &Backoff{Duration: &metav1.Duration{Duration: time.Hour}, Factor: 10}
+	if got := unbounded.Delay(100); got != time.Duration(math.MaxInt64) {
+		t.Errorf("Delay(100) without a MaxDuration = %s, want it clamped to %s", got, time.Duration(math.MaxInt64))
+	}
+	var noBackoff *Backoff
+	if got := noBackoff.Delay(1); got != 0 {
+		t.Errorf("Delay() of a nil Backoff = %s, want 0", got)
+	}
+}
+
+func TestPipelineTask_ValidateChildPipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       *PipelineTask
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "pipelineRef",
+		pt: &PipelineTask{
+			Name:        "pipeline",
+			PipelineRef: &PipelineRef{Name: "child"},
+			Params: []Param{{
+				Name: "version", Value: ArrayOrString{Type: ParamTypeString, StringVal: "v1"},
+			}},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "pipelineSpec",
+		pt: &PipelineTask{
+			Name: "pipeline",
+			PipelineSpec: &PipelineSpec{
+				Tasks: []PipelineTask{{Name: "task", TaskRef: &TaskRef{Name: "task"}}},
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "pipelines in pipelines require alpha api fields",
+		pt: &PipelineTask{
+			Name:        "pipeline",
+			PipelineRef: &PipelineRef{Name: "child"},
+		},
+		wantErrs: apis.ErrGeneric(`pipelineRef or pipelineSpec requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "both pipelineRef and pipelineSpec",
+		pt: &PipelineTask{
+			Name:        "pipeline",
+			PipelineRef: &PipelineRef{Name: "child"},
+			PipelineSpec: &PipelineSpec{
+				Tasks: []PipelineTask{{Name: "task", TaskRef: &TaskRef{Name: "task"}}},
+			},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("pipelineRef", "pipelineSpec"),
+	}, {
+		name: "both pipelineRef and taskRef",
+		pt: &PipelineTask{
+			Name:        "pipeline",
+			PipelineRef: &PipelineRef{Name: "child"},
+			TaskRef:     &TaskRef{Name: "task"},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("taskRef", "taskSpec", "pipelineRef", "pipelineSpec"),
+	}, {
+		name: "pipelineRef without name",
+		pt: &PipelineTask{
+			Name:        "pipeline",
+			PipelineRef: &PipelineRef{},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMissingField("pipelineRef.name"),
+	}, {
+		name: "invalid pipelineSpec",
+		pt: &PipelineTask{
+			Name:         "pipeline",
+			PipelineSpec: &PipelineSpec{},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrGeneric("expected at least one, got none", "pipelineSpec.description", "pipelineSpec.params", "pipelineSpec.resources", "pipelineSpec.tasks", "pipelineSpec.workspaces"),
+	}, {
+		name: "pipelines in pipelines don't support retries",
+		pt: &PipelineTask{
+			Name:        "pipeline",
+			PipelineRef: &PipelineRef{Name: "child"},
+			Retries:     2,
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("pipelines in pipelines do not support retries", "retries"),
+	}, {
+		name: "pipelines in pipelines don't support conditions",
+		pt: &PipelineTask{
+			Name:        "pipeline",
+			PipelineRef: &PipelineRef{Name: "child"},
+			Conditions:  []PipelineTaskCondition{{ConditionRef: "condition"}},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("pipelines in pipelines do not support conditions - use when expressions instead", "conditions"),
+	}, {
+		name: "pipelines in pipelines don't support PipelineResources",
+		pt: &PipelineTask{
+			Name:        "pipeline",
+			PipelineRef: &PipelineRef{Name: "child"},
+			Resources:   &PipelineTaskResources{},
+		},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue("pipelines in pipelines do not support PipelineResources", "resources"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			if d := cmp.Diff(tt.wantErrs.Error(), tt.pt.Validate(ctx).Error()); d != "" {
+				t.Errorf("PipelineTask.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineTaskList_Names(t *testing.T) {
 	tasks := []PipelineTask{
 		{Name: "task-1"},
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
@@ -59,18 +60,20 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(validateGraph(ps.Tasks))
 	errs = errs.Also(validateParamResults(ps.Tasks))
 	// The parameter variables should be valid
-	errs = errs.Also(validatePipelineParameterVariables(ps.Tasks, ps.Params).ViaField("tasks"))
-	errs = errs.Also(validatePipelineParameterVariables(ps.Finally, ps.Params).ViaField("finally"))
+	errs = errs.Also(validatePipelineParameterVariables(ctx, ps.Tasks, ps.Params).ViaField("tasks"))
+	errs = errs.Also(validatePipelineParameterVariables(ctx, ps.Finally, ps.Params).ViaField("finally"))
 	errs = errs.Also(validatePipelineContextVariables(ps.Tasks).ViaField("tasks"))
 	errs = errs.Also(validatePipelineContextVariables(ps.Finally).ViaField("finally"))
 	errs = errs.Also(validateExecutionStatusVariables(ps.Tasks, ps.Finally))
 	// Validate the pipeline's workspaces.
 	errs = errs.Also(validatePipelineWorkspaces(ps.Workspaces, ps.Tasks, ps.Finally))
 	// Validate the pipeline's results
-	errs = errs.Also(validatePipelineResults(ps.Results))
+	errs = errs.Also(validatePipelineResults(ctx, ps.Results))
+	errs = errs.Also(validateFinallyResultRefsInPipelineResults(ps.Results, ps.Finally))
 	errs = errs.Also(validateTasksAndFinallySection(ps))
 	errs = errs.Also(validateFinalTasks(ps.Tasks, ps.Finally))
-	errs = errs.Also(validateWhenExpressions(ps.Tasks, ps.Finally))
+	errs = errs.Also(validateWhenExpressions(ctx, ps.Tasks, ps.Finally))
+	errs = errs.Also(validateMatrixedTaskResultsNotConsumed(ps))
 	return errs
 }
 
@@ -129,9 +132,10 @@ func validatePipelineWorkspaces(wss []PipelineWorkspaceDeclaration, pts []Pipeli
 // validatePipelineParameterVariables validates parameters with those specified by each pipeline task,
 // (1) it validates the type of parameter is either string or array (2) parameter default value matches
 // with the type of that param (3) ensures that the referenced param variable is defined is part of the param declarations
-func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec) (errs *apis.FieldError) {
+func validatePipelineParameterVariables(ctx context.Context, tasks []PipelineTask, params []ParamSpec) (errs *apis.FieldError) {
 	parameterNames := sets.NewString()
 	arrayParameterNames := sets.NewString()
+	objectParameterKeys := map[string]sets.String{}
 
 	for _, p := range params {
 		// Verify that p is a valid type.
@@ -151,22 +155,33 @@ func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec
 				"type", "default.type").ViaFieldKey("params", p.Name))
 		}
 
+		if p.Type == ParamTypeObject {
+			errs = errs.Also(validateEnabledAPIFields(ctx, "object type parameter", config.AlphaAPIFields, "type").ViaFieldKey("params", p.Name))
+		}
+		errs = errs.Also(p.validateProperties().ViaFieldKey("params", p.Name))
+
 		if parameterNames.Has(p.Name) {
 			errs = errs.Also(apis.ErrGeneric("parameter appears more than once", "").ViaFieldKey("params", p.Name))
 		}
-		// Add parameter name to parameterNames, and to arrayParameterNames if type is array.
+		// Add parameter name to parameterNames, to arrayParameterNames if type is array, and its keys to
+		// objectParameterKeys if type is object.
 		parameterNames.Insert(p.Name)
-		if p.Type == ParamTypeArray {
+		switch p.Type {
+		case ParamTypeArray:
 			arrayParameterNames.Insert(p.Name)
+		case ParamTypeObject:
+			objectParameterKeys[p.Name] = p.propertyKeys()
 		}
 	}
 
-	return errs.Also(validatePipelineParametersVariables(tasks, "params", parameterNames, arrayParameterNames))
+	errs = errs.Also(validatePipelineParametersVariables(tasks, "params", parameterNames, arrayParameterNames))
+	return errs.Also(validatePipelineParametersObjectUsage(tasks, "params", objectParameterKeys))
 }
 
 func validatePipelineParametersVariables(tasks []PipelineTask, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for idx, task := range tasks {
 		errs = errs.Also(validatePipelineParametersVariablesInTaskParameters(task.Params, prefix, paramNames, arrayParamNames).ViaIndex(idx))
+		errs = errs.Also(validatePipelineParametersVariablesInMatrixParameters(task.Matrix, prefix, paramNames, arrayParamNames).ViaIndex(idx))
 		errs = errs.Also(task.WhenExpressions.validatePipelineParametersVariables(prefix, paramNames, arrayParamNames).ViaIndex(idx))
 	}
 	return errs
@@ -186,6 +201,12 @@ func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
 		for _, param := range task.Params {
 			paramValues = append(paramValues, param.Value.StringVal)
 			paramValues = append(paramValues, param.Value.ArrayVal...)
+			for _, value := range param.Value.ObjectVal {
+				paramValues = append(paramValues, value)
+			}
+		}
+		for _, param := range task.Matrix {
+			paramValues = append(paramValues, param.Value.ArrayVal...)
 		}
 	}
 	errs := validatePipelineContextVariablesInParamValues(paramValues, "context\\.pipelineRun", pipelineRunContextNames)
@@ -193,7 +214,7 @@ func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
 }
 
 func containsExecutionStatusRef(p string) bool {
-	if strings.HasPrefix(p, "tasks.") && strings.HasSuffix(p, ".status") {
+	if strings.HasPrefix(p, "tasks.") && (strings.HasSuffix(p, ".status") || strings.HasSuffix(p, ".reason")) {
 		return true
 	}
 	return false
@@ -270,9 +291,11 @@ func validateExecutionStatusVariablesExpressions(expressions []string, ptNames s
 				continue
 			}
 			// check if it contains context variable accessing execution status - $(tasks.taskname.status)
+			// or the reason of the execution status - $(tasks.taskname.reason)
 			if containsExecutionStatusRef(expression) {
-				// strip tasks. and .status from tasks.taskname.status to further verify task name
-				pt := strings.TrimSuffix(strings.TrimPrefix(expression, "tasks."), ".status")
+				// strip tasks. and .status or .reason from tasks.taskname.status to further verify task name
+				pt := strings.TrimPrefix(expression, "tasks.")
+				pt = strings.TrimSuffix(strings.TrimSuffix(pt, ".status"), ".reason")
 				// report an error if the task name does not exist in the list of dag tasks
 				if !ptNames.Has(pt) {
 					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline task %s is not defined in the pipeline", pt), fieldPath))
@@ -300,16 +323,24 @@ func validatePipelineContextVariablesInParamValues(paramValues []string, prefix
 func validateParamResults(tasks []PipelineTask) (errs *apis.FieldError) {
 	for idx, task := range tasks {
 		for _, param := range task.Params {
-			expressions, ok := GetVarSubstitutionExpressionsForParam(param)
-			if ok {
-				if LooksLikeContainsResultRefs(expressions) {
-					expressions = filter(expressions, looksLikeResultRef)
-					resultRefs := NewResultRefs(expressions)
-					if len(expressions) != len(resultRefs) {
-						errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("expected all of the expressions %v to be result expressions but only %v were", expressions, resultRefs),
-							"value").ViaFieldKey("params", param.Name).ViaFieldIndex("tasks", idx))
-					}
-				}
+			errs = errs.Also(validateParamResultExpressions(param).ViaFieldKey("params", param.Name).ViaFieldIndex("tasks", idx))
+		}
+		for _, param := range task.Matrix {
+			errs = errs.Also(validateParamResultExpressions(param).ViaFieldKey("matrix", param.Name).ViaFieldIndex("tasks", idx))
+		}
+	}
+	return errs
+}
+
+func validateParamResultExpressions(param Param) (errs *apis.FieldError) {
+	expressions, ok := GetVarSubstitutionExpressionsForParam(param)
+	if ok {
+		if LooksLikeContainsResultRefs(expressions) {
+			expressions = filter(expressions, looksLikeResultRef)
+			resultRefs := NewResultRefs(expressions)
+			if len(expressions) != len(resultRefs) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("expected all of the expressions %v to be result expressions but only %v were", expressions, resultRefs),
+					"value"))
 			}
 		}
 	}
@@ -327,13 +358,15 @@ func filter(arr []string, cond func(string) bool) []string {
 }
 
 // validatePipelineResults ensure that pipeline result variables are properly configured
-func validatePipelineResults(results []PipelineResult) (errs *apis.FieldError) {
+func validatePipelineResults(ctx context.Context, results []PipelineResult) (errs *apis.FieldError) {
 	for idx, result := range results {
+		errs = errs.Also(validateResultType(ctx, result.Type).ViaFieldIndex("results", idx))
+		errs = errs.Also(validatePipelineResultValueType(result).ViaFieldIndex("results", idx))
 		expressions, ok := GetVarSubstitutionExpressionsForPipelineResult(result)
 		if ok {
-			if LooksLikeContainsResultRefs(expressions) {
-				expressions = filter(expressions, looksLikeResultRef)
-				resultRefs := NewResultRefs(expressions)
+			expressions = filter(expressions, looksLikePipelineResultRef)
+			if len(expressions) > 0 {
+				resultRefs := NewPipelineResultRefs(expressions)
 				if len(expressions) != len(resultRefs) {
 					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("expected all of the expressions %v to be result expressions but only %v were", expressions, resultRefs),
 						"value").ViaFieldIndex("results", idx))
@@ -345,6 +378,90 @@ func validatePipelineResults(results []PipelineResult) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateFinallyResultRefsInPipelineResults ensures that the pipeline results referencing the
+// results of finally tasks, $(finally.<finallyTaskName>.results.<resultName>), reference finally tasks
+func validateFinallyResultRefsInPipelineResults(results []PipelineResult, finally []PipelineTask) (errs *apis.FieldError) {
+	finallyNames := PipelineTaskList(finally).Names()
+	for idx, result := range results {
+		expressions, _ := GetVarSubstitutionExpressionsForPipelineResult(result)
+		for _, expression := range expressions {
+			if !IsFinallyResultRef(expression) {
+				continue
+			}
+			for _, ref := range NewPipelineResultRefs([]string{expression}) {
+				if !finallyNames.Has(ref.PipelineTask) {
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("finally task %s is not defined in the pipeline", ref.PipelineTask), "value").ViaFieldIndex("results", idx))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// validatePipelineResultValueType ensures that the value of a pipeline result matches its type: the value
+// of an array or object result is either a single reference to an array or object task result, or an array
+// or object, while the value of a string result is a string
+func validatePipelineResultValueType(result PipelineResult) *apis.FieldError {
+	switch result.Type {
+	case ResultsTypeArray, ResultsTypeObject:
+		if result.Value.Type == ParamTypeString {
+			if !isWholeResultRef(result.Value.StringVal) {
+				return apis.ErrInvalidValue(fmt.Sprintf("the value of the %s result %s must be a single reference to a task result, e.g. $(tasks.task1.results.result1[*])", result.Type, result.Name), "value")
+			}
+			return nil
+		}
+		if string(result.Value.Type) != string(result.Type) {
+			return apis.ErrInvalidValue(fmt.Sprintf("the value of the %s result %s must be an %s", result.Type, result.Name, result.Type), "value")
+		}
+	default:
+		if result.Value.Type != ParamTypeString {
+			return apis.ErrInvalidValue(fmt.Sprintf("the value of the string result %s must be a string", result.Name), "value")
+		}
+	}
+	return nil
+}
+
+// isWholeResultRef returns true if the given value is a single result reference, such as $(tasks.task1.results.result1[*])
+func isWholeResultRef(value string) bool {
+	expressions := validateString(value)
+	return len(expressions) == 1 && value == fmt.Sprintf("$(%s)", expressions[0]) && len(NewPipelineResultRefs(expressions)) == 1
+}
+
+// validateMatrixedTaskResultsNotConsumed ensures that no pipeline task or pipeline result references the results
+// of a matrixed pipeline task, since a matrixed pipeline task produces one set of results per combination
+func validateMatrixedTaskResultsNotConsumed(ps *PipelineSpec) (errs *apis.FieldError) {
+	matrixedTasks := sets.NewString()
+	for _, pt := range append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...) {
+		if pt.IsMatrixed() {
+			matrixedTasks.Insert(pt.Name)
+		}
+	}
+	if matrixedTasks.Len() == 0 {
+		return nil
+	}
+	for idx, t := range ps.Tasks {
+		errs = errs.Also(validateResultRefsNotFromMatrixedTasks(PipelineTaskResultRefs(&t), matrixedTasks).ViaFieldIndex("tasks", idx))
+	}
+	for idx, t := range ps.Finally {
+		errs = errs.Also(validateResultRefsNotFromMatrixedTasks(PipelineTaskResultRefs(&t), matrixedTasks).ViaFieldIndex("finally", idx))
+	}
+	for idx, result := range ps.Results {
+		expressions, _ := GetVarSubstitutionExpressionsForPipelineResult(result)
+		errs = errs.Also(validateResultRefsNotFromMatrixedTasks(NewPipelineResultRefs(expressions), matrixedTasks).ViaFieldIndex("results", idx))
+	}
+	return errs
+}
+
+func validateResultRefsNotFromMatrixedTasks(refs []*ResultRef, matrixedTasks sets.String) (errs *apis.FieldError) {
+	for _, ref := range refs {
+		if matrixedTasks.Has(ref.PipelineTask) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid task result reference, "+
+				"results of the matrixed task %s cannot be consumed", ref.PipelineTask), ""))
+		}
+	}
+	return errs
+}
+
 func validateTasksAndFinallySection(ps *PipelineSpec) *apis.FieldError {
 	if len(ps.Finally) != 0 && len(ps.Tasks) == 0 {
 		return apis.ErrInvalidValue(fmt.Sprintf("spec.tasks is empty but spec.finally has %d tasks", len(ps.Finally)), "finally")
@@ -422,13 +539,13 @@ func validateTasksInputFrom(tasks []PipelineTask) (errs *apis.FieldError) {
 	return errs
 }
 
-func validateWhenExpressions(tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
+func validateWhenExpressions(ctx context.Context, tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
 	for i, t := range tasks {
 		errs = errs.Also(validateOneOfWhenExpressionsOrConditions(t).ViaFieldIndex("tasks", i))
-		errs = errs.Also(t.WhenExpressions.validate().ViaFieldIndex("tasks", i))
+		errs = errs.Also(t.WhenExpressions.validate(ctx).ViaFieldIndex("tasks", i))
 	}
 	for i, t := range finalTasks {
-		errs = errs.Also(t.WhenExpressions.validate().ViaFieldIndex("finally", i))
+		errs = errs.Also(t.WhenExpressions.validate(ctx).ViaFieldIndex("finally", i))
 	}
 	return errs
 }
@@ -128,7 +128,7 @@ func TestPipeline_Validate_Success(t *testing.T) {
 				Results: []PipelineResult{{
 					Name:        "pipeline-result",
 					Description: "this is my pipeline result",
-					Value:       "pipeline-result-default",
+					Value:       *NewArrayOrString("pipeline-result-default"),
 				}},
 			},
 		},
@@ -1195,9 +1195,13 @@ func TestValidatePipelineResults_Success(t *testing.T) {
 	results := []PipelineResult{{
 		Name:        "my-pipeline-result",
 		Description: "this is my pipeline result",
-		Value:       "$(tasks.a-task.results.output)",
+		Value:       *NewArrayOrString("$(tasks.a-task.results.output)"),
+	}, {
+		Name:        "my-finally-result",
+		Description: "this is my pipeline result produced by a finally task",
+		Value:       *NewArrayOrString("$(finally.a-final-task.results.output)"),
 	}}
-	if err := validatePipelineResults(results); err != nil {
+	if err := validatePipelineResults(context.Background(), results); err != nil {
 		t.Errorf("Pipeline.validatePipelineResults() returned error for valid pipeline: %s: %v", desc, err)
 	}
 }
@@ -1207,13 +1211,13 @@ func TestValidatePipelineResults_Failure(t *testing.T) {
 	results := []PipelineResult{{
 		Name:        "my-pipeline-result",
 		Description: "this is my pipeline result",
-		Value:       "$(tasks.a-task.results.output.output)",
+		Value:       *NewArrayOrString("$(tasks.a-task.results.output.key.key)"),
 	}}
 	expectedError := apis.FieldError{
-		Message: `invalid value: expected all of the expressions [tasks.a-task.results.output.output] to be result expressions but only [] were`,
+		Message: `invalid value: expected all of the expressions [tasks.a-task.results.output.key.key] to be result expressions but only [] were`,
 		Paths:   []string{"results[0].value"},
 	}
-	err := validatePipelineResults(results)
+	err := validatePipelineResults(context.Background(), results)
 	if err == nil {
 		t.Errorf("Pipeline.validatePipelineResults() did not return for invalid pipeline: %s", desc)
 	}
@@ -1222,6 +1226,193 @@ func TestValidatePipelineResults_Failure(t *testing.T) {
 	}
 }
 
+func TestValidateFinallyResultRefsInPipelineResults(t *testing.T) {
+	finally := []PipelineTask{{
+		Name:    "final-task",
+		TaskRef: &TaskRef{Name: "final-task"},
+	}}
+	tests := []struct {
+		name          string
+		results       []PipelineResult
+		expectedError *apis.FieldError
+	}{{
+		name: "valid reference to a finally task result",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Value: *NewArrayOrString("$(finally.final-task.results.output)"),
+		}},
+	}, {
+		name: "task results are not checked against the finally tasks",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Value: *NewArrayOrString("$(tasks.a-task.results.output)"),
+		}},
+	}, {
+		name: "invalid reference to a missing finally task",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Value: *NewArrayOrString("$(tasks.a-task.results.output)"),
+		}, {
+			Name:  "my-finally-result",
+			Value: *NewArrayOrString("$(finally.no-task.results.output)"),
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: finally task no-task is not defined in the pipeline`,
+			Paths:   []string{"results[1].value"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFinallyResultRefsInPipelineResults(tt.results, finally)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("validateFinallyResultRefsInPipelineResults() returned error for valid pipeline results: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("validateFinallyResultRefsInPipelineResults() did not return error for invalid pipeline results")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("validateFinallyResultRefsInPipelineResults() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidatePipelineResults_Types(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []PipelineResult
+		wc      func(context.Context) context.Context
+		wantErr *apis.FieldError
+	}{{
+		name: "array result from a whole array task result",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Type:  ResultsTypeArray,
+			Value: *NewArrayOrString("$(tasks.a-task.results.output[*])"),
+		}},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "array result from task results",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Type:  ResultsTypeArray,
+			Value: *NewArrayOrString("$(tasks.a-task.results.output)", "$(tasks.b-task.results.output)"),
+		}},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "object result from object task result keys",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Type:  ResultsTypeObject,
+			Value: *NewObject(map[string]string{"url": "$(tasks.a-task.results.output.url)"}),
+		}},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "array result requires alpha",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Type:  ResultsTypeArray,
+			Value: *NewArrayOrString("$(tasks.a-task.results.output[*])"),
+		}},
+		wantErr: apis.ErrGeneric(`array results requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaFieldIndex("results", 0),
+	}, {
+		name: "array result with a string value",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Type:  ResultsTypeArray,
+			Value: *NewArrayOrString("prefix-$(tasks.a-task.results.output[*])"),
+		}},
+		wc:      enableAlphaAPIFields,
+		wantErr: apis.ErrInvalidValue("the value of the array result my-pipeline-result must be a single reference to a task result, e.g. $(tasks.task1.results.result1[*])", "results[0].value"),
+	}, {
+		name: "string result with an array value",
+		results: []PipelineResult{{
+			Name:  "my-pipeline-result",
+			Value: *NewArrayOrString("$(tasks.a-task.results.output)", "$(tasks.b-task.results.output)"),
+		}},
+		wantErr: apis.ErrInvalidValue("the value of the string result my-pipeline-result must be a string", "results[0].value"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			err := validatePipelineResults(ctx, tt.results)
+			if d := cmp.Diff(tt.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("validatePipelineResults() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateMatrixedTaskResultsNotConsumed(t *testing.T) {
+	matrixedTask := PipelineTask{
+		Name:    "matrixed",
+		TaskRef: &TaskRef{Name: "foo-task"},
+		Matrix: []Param{{
+			Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+		}},
+	}
+	tests := []struct {
+		name          string
+		ps            *PipelineSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "results of regular tasks consumed alongside a matrixed task",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{matrixedTask, {
+				Name:    "regular",
+				TaskRef: &TaskRef{Name: "foo-task"},
+			}, {
+				Name:    "consumer",
+				TaskRef: &TaskRef{Name: "foo-task"},
+				Params: []Param{{
+					Name: "a-param", Value: *NewArrayOrString("$(tasks.regular.results.output)"),
+				}},
+			}},
+		},
+	}, {
+		name: "results of a matrixed task consumed by a task, a final task and a pipeline result",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{matrixedTask, {
+				Name:    "consumer",
+				TaskRef: &TaskRef{Name: "foo-task"},
+				Params: []Param{{
+					Name: "a-param", Value: *NewArrayOrString("$(tasks.matrixed.results.output)"),
+				}},
+			}},
+			Finally: []PipelineTask{{
+				Name:    "final",
+				TaskRef: &TaskRef{Name: "foo-task"},
+				WhenExpressions: WhenExpressions{{
+					Input:    "$(tasks.matrixed.results.output)",
+					Operator: selection.In,
+					Values:   []string{"foo"},
+				}},
+			}},
+			Results: []PipelineResult{{
+				Name:  "result",
+				Value: *NewArrayOrString("$(tasks.matrixed.results.output)"),
+			}},
+		},
+		expectedError: apis.ErrInvalidValue("invalid task result reference, results of the matrixed task matrixed cannot be consumed", "").ViaFieldIndex("tasks", 1).Also(
+			apis.ErrInvalidValue("invalid task result reference, results of the matrixed task matrixed cannot be consumed", "").ViaFieldIndex("finally", 0)).Also(
+			apis.ErrInvalidValue("invalid task result reference, results of the matrixed task matrixed cannot be consumed", "").ViaFieldIndex("results", 0)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMatrixedTaskResultsNotConsumed(tt.ps)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("validateMatrixedTaskResultsNotConsumed() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineParameterVariables_Success(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -1317,10 +1508,26 @@ func TestValidatePipelineParameterVariables_Success(t *testing.T) {
 				Name: "a-param", Value: ArrayOrString{StringVal: "$(input.workspace.$(baz))"},
 			}},
 		}},
+	}, {
+		name: "valid array parameter variables in matrix",
+		params: []ParamSpec{{
+			Name: "platforms", Type: ParamTypeArray,
+		}, {
+			Name: "browser", Type: ParamTypeString,
+		}},
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Matrix: []Param{{
+				Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"$(params.platforms[*])"}},
+			}, {
+				Name: "browser", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"$(params.browser)", "chrome"}},
+			}},
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validatePipelineParameterVariables(tt.tasks, tt.params)
+			err := validatePipelineParameterVariables(context.Background(), tt.tasks, tt.params)
 			if err != nil {
 				t.Errorf("Pipeline.validatePipelineParameterVariables() returned error for valid pipeline parameters: %v", err)
 			}
@@ -1347,6 +1554,19 @@ func TestValidatePipelineParameterVariables_Failure(t *testing.T) {
 			Message: `non-existent variable in "$(params.does-not-exist)"`,
 			Paths:   []string{"[0].params[a-param]"},
 		},
+	}, {
+		name: "invalid pipeline task with a matrix parameter which is missing from the param declarations",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Matrix: []Param{{
+				Name: "a-param", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"$(params.does-not-exist)"}},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.does-not-exist)"`,
+			Paths:   []string{"[0].matrix[a-param].value[0]"},
+		},
 	}, {
 		name: "invalid string parameter variables in when expression, missing input param from the param declarations",
 		tasks: []PipelineTask{{
@@ -1598,7 +1818,7 @@ func TestValidatePipelineParameterVariables_Failure(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validatePipelineParameterVariables(tt.tasks, tt.params)
+			err := validatePipelineParameterVariables(context.Background(), tt.tasks, tt.params)
 			if err == nil {
 				t.Errorf("Pipeline.validatePipelineParameterVariables() did not return error for invalid pipeline parameters")
 			}
@@ -1609,6 +1829,136 @@ func TestValidatePipelineParameterVariables_Failure(t *testing.T) {
 	}
 }
 
+func TestValidatePipelineParameterVariables_ObjectParams(t *testing.T) {
+	gitParam := ParamSpec{
+		Name: "git",
+		Type: ParamTypeObject,
+		Properties: map[string]PropertySpec{
+			"url":      {Type: ParamTypeString},
+			"revision": {Type: ParamTypeString},
+		},
+	}
+	tests := []struct {
+		name          string
+		params        []ParamSpec
+		tasks         []PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name:   "object keys and whole object in task parameters",
+		params: []ParamSpec{gitParam},
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "url", Value: *NewArrayOrString("$(params.git.url)"),
+			}, {
+				Name: "git", Value: *NewArrayOrString("$(params.git[*])"),
+			}, {
+				Name: "repo", Value: *NewObject(map[string]string{"url": "$(params.git.url)", "commit": "$(params.git.revision)"}),
+			}},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(params.git.revision)",
+				Operator: selection.In,
+				Values:   []string{"main"},
+			}},
+		}},
+	}, {
+		name:   "undeclared object key",
+		params: []ParamSpec{gitParam},
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "depth", Value: *NewArrayOrString("$(params.git.depth)"),
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(params.git.depth)"`,
+			Paths:   []string{"[0].params[depth]"},
+		},
+	}, {
+		name:   "whole object embedded in a string",
+		params: []ParamSpec{gitParam},
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "git", Value: *NewArrayOrString("repo: $(params.git[*])"),
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "repo: $(params.git[*])"`,
+			Paths:   []string{"[0].params[git]"},
+		},
+	}, {
+		name:   "whole object in when expression",
+		params: []ParamSpec{gitParam},
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(params.git)",
+				Operator: selection.In,
+				Values:   []string{"main"},
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.git)"`,
+			Paths:   []string{"[0].when[0].input"},
+		},
+	}, {
+		name: "object param without properties",
+		params: []ParamSpec{{
+			Name: "git", Type: ParamTypeObject,
+		}},
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"params[git].properties"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePipelineParameterVariables(enableAlphaAPIFields(context.Background()), tt.tasks, tt.params)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("Pipeline.validatePipelineParameterVariables() returned error for valid pipeline parameters: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Pipeline.validatePipelineParameterVariables() did not return error for invalid pipeline parameters")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Pipeline.validatePipelineParameterVariables() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidatePipelineParameterVariables_ObjectParamsRequireAlpha(t *testing.T) {
+	params := []ParamSpec{{
+		Name:       "git",
+		Type:       ParamTypeObject,
+		Properties: map[string]PropertySpec{"url": {Type: ParamTypeString}},
+	}}
+	tasks := []PipelineTask{{
+		Name:    "bar",
+		TaskRef: &TaskRef{Name: "bar-task"},
+	}}
+	want := &apis.FieldError{
+		Message: `object type parameter requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		Paths:   []string{"params[git].type"},
+	}
+	err := validatePipelineParameterVariables(context.Background(), tasks, params)
+	if d := cmp.Diff(want.Error(), err.Error()); d != "" {
+		t.Errorf("Pipeline.validatePipelineParameterVariables() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestValidatePipelineWorkspaces_Success(t *testing.T) {
 	desc := "unused pipeline spec workspaces do not cause an error"
 	workspaces := []PipelineWorkspaceDeclaration{{
@@ -2365,6 +2715,23 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 				Values:   []string{"Success"},
 			}},
 		}},
+	}, {
+		name: "valid string variable in finally accessing pipelineTask reason",
+		tasks: []PipelineTask{{
+			Name: "foo",
+		}},
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "foo-reason", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.foo.reason)"},
+			}},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.foo.reason)",
+				Operator: selection.In,
+				Values:   []string{"TaskRunTimeout"},
+			}},
+		}},
 	}, {
 		name: "valid task result reference with status as a variable must not cause validation failure",
 		tasks: []PipelineTask{{
@@ -2476,6 +2843,32 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks`,
 			Paths:   []string{"tasks[0].params[tasks-status].value"},
 		},
+	}, {
+		name: "invalid string variable in dag task accessing pipelineTask reason",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params: []Param{{
+				Name: "bar-reason", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.bar.reason)"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks`,
+			Paths:   []string{"tasks[0].params[bar-reason].value"},
+		},
+	}, {
+		name: "invalid string variable in finally accessing missing pipelineTask reason",
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "notask-reason", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.notask.reason)"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline task notask is not defined in the pipeline`,
+			Paths:   []string{"finally[0].params[notask-reason].value"},
+		},
 	}, {
 		name: "invalid string variable in finally accessing missing pipelineTask status",
 		finalTasks: []PipelineTask{{
@@ -2593,3 +2986,16 @@ func enableFeatures(t *testing.T, features []string) func(context.Context) conte
 		return s.ToContext(ctx)
 	}
 }
+
+func enableAlphaAPIFields(ctx context.Context) context.Context {
+	featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+		"enable-api-fields": "alpha",
+	})
+	cfg := &config.Config{
+		Defaults: &config.Defaults{
+			DefaultTimeoutMinutes: 60,
+		},
+		FeatureFlags: featureFlags,
+	}
+	return config.ToContext(ctx, cfg)
+}
@@ -205,6 +205,13 @@ type PipelineRunSpec struct {
 	// TaskRunSpecs holds a set of runtime specs
 	// +optional
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// StepTemplate is merged into every Step of the TaskRuns created for the PipelineRun,
+	// unless the TaskRunSpecs specify another one for the PipelineTask.
+	// +optional
+	StepTemplate *TaskRunStepTemplate `json:"stepTemplate,omitempty"`
 }
 
 type TimeoutFields struct {
@@ -253,6 +260,12 @@ type PipelineRef struct {
 	// Bundle url reference to a Tekton Bundle.
 	// +optional
 	Bundle string `json:"bundle,omitempty"`
+
+	// ResolverRef allows referencing a Pipeline in a remote location
+	// like a git repo. This field is only supported when the alpha
+	// feature gate is enabled.
+	// +optional
+	ResolverRef `json:",omitempty"`
 }
 
 // PipelineRunStatus defines the observed state of PipelineRun
@@ -390,6 +403,10 @@ type PipelineRunStatusFields struct {
 	// +optional
 	Runs map[string]*PipelineRunRunStatus `json:"runs,omitempty"`
 
+	// map of PipelineRunChildPipelineRunStatus with the child PipelineRun name as the key
+	// +optional
+	ChildPipelineRuns map[string]*PipelineRunChildPipelineRunStatus `json:"childPipelineRuns,omitempty"`
+
 	// PipelineResults are the list of results written out by the pipeline task's containers
 	// +optional
 	PipelineResults []PipelineRunResult `json:"pipelineResults,omitempty"`
@@ -400,6 +417,20 @@ type PipelineRunStatusFields struct {
 	// list of tasks that were skipped due to when expressions evaluating to false
 	// +optional
 	SkippedTasks []SkippedTask `json:"skippedTasks,omitempty"`
+
+	// list of tasks that failed without failing the PipelineRun because their onError is continue
+	// +optional
+	ToleratedFailures []ToleratedFailure `json:"toleratedFailures,omitempty"`
+}
+
+// ToleratedFailure is used to describe the Tasks that failed without failing the PipelineRun
+// because their OnError is "continue".
+type ToleratedFailure struct {
+	// Name is the Pipeline Task name
+	Name string `json:"name"`
+	// Reason is the reason of the failure of the Pipeline Task
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -419,7 +450,7 @@ type PipelineRunResult struct {
 	Name string `json:"name"`
 
 	// Value is the result returned from the execution of this PipelineRun
-	Value string `json:"value"`
+	Value ArrayOrString `json:"value"`
 }
 
 // PipelineRunTaskRunStatus contains the name of the PipelineTask for this TaskRun and the TaskRun's Status
@@ -449,6 +480,18 @@ type PipelineRunRunStatus struct {
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
 }
 
+// PipelineRunChildPipelineRunStatus contains the name of the PipelineTask for this child PipelineRun and the child PipelineRun's Status
+type PipelineRunChildPipelineRunStatus struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Status is the PipelineRunStatus for the corresponding child PipelineRun
+	// +optional
+	Status *PipelineRunStatus `json:"status,omitempty"`
+	// WhenExpressions is the list of checks guarding the execution of the PipelineTask
+	// +optional
+	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+}
+
 // PipelineRunConditionCheckStatus returns the condition check status
 type PipelineRunConditionCheckStatus struct {
 	// ConditionName is the name of the Condition
@@ -488,6 +531,28 @@ type PipelineTaskRunSpec struct {
 	PipelineTaskName       string       `json:"pipelineTaskName,omitempty"`
 	TaskServiceAccountName string       `json:"taskServiceAccountName,omitempty"`
 	TaskPodTemplate        *PodTemplate `json:"taskPodTemplate,omitempty"`
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// StepTemplate is merged into every Step of the TaskRun of the PipelineTask.
+	// +optional
+	StepTemplate *TaskRunStepTemplate `json:"stepTemplate,omitempty"`
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// StepOverrides overrides the compute resources of the Steps of the TaskRun of the
+	// PipelineTask, by Step name.
+	// +optional
+	// +listType=atomic
+	StepOverrides []TaskRunStepOverride `json:"stepOverrides,omitempty"`
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// SidecarOverrides overrides the compute resources of the Sidecars of the TaskRun of the
+	// PipelineTask, by Sidecar name.
+	// +optional
+	// +listType=atomic
+	SidecarOverrides []TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
 }
 
 // GetTaskRunSpec returns the task specific spec for a given
@@ -497,12 +562,18 @@ func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSp
 		PipelineTaskName:       pipelineTaskName,
 		TaskServiceAccountName: pr.GetServiceAccountName(pipelineTaskName),
 		TaskPodTemplate:        pr.Spec.PodTemplate,
+		StepTemplate:           pr.Spec.StepTemplate,
 	}
 	for _, task := range pr.Spec.TaskRunSpecs {
 		if task.PipelineTaskName == pipelineTaskName {
 			if task.TaskPodTemplate != nil {
 				s.TaskPodTemplate = task.TaskPodTemplate
 			}
+			if task.StepTemplate != nil {
+				s.StepTemplate = task.StepTemplate
+			}
+			s.StepOverrides = task.StepOverrides
+			s.SidecarOverrides = task.SidecarOverrides
 			if task.TaskServiceAccountName != "" {
 				s.TaskServiceAccountName = task.TaskServiceAccountName
 			}
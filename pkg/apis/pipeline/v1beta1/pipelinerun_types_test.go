@@ -25,6 +25,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -450,3 +451,76 @@ func TestPipelineRunGetPodSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestPipelineRunGetTaskRunSpecStepTemplate(t *testing.T) {
+	defaultTemplate := &v1beta1.TaskRunStepTemplate{
+		Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "proxy"}},
+	}
+	taskTemplate := &v1beta1.TaskRunStepTemplate{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+		},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:  &v1beta1.PipelineRef{Name: "prs"},
+			StepTemplate: defaultTemplate,
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "compile",
+				StepTemplate:     taskTemplate,
+			}, {
+				PipelineTaskName:       "test",
+				TaskServiceAccountName: "test-sa",
+			}},
+		},
+	}
+	for taskName, want := range map[string]*v1beta1.TaskRunStepTemplate{
+		"compile": taskTemplate,
+		"test":    defaultTemplate,
+		"unknown": defaultTemplate,
+	} {
+		t.Run(taskName, func(t *testing.T) {
+			if d := cmp.Diff(want, pr.GetTaskRunSpec(taskName).StepTemplate); d != "" {
+				t.Errorf("wrong step template %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunGetTaskRunSpecOverrides(t *testing.T) {
+	stepOverrides := []v1beta1.TaskRunStepOverride{{
+		Name: "build",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+		},
+	}}
+	sidecarOverrides := []v1beta1.TaskRunSidecarOverride{{
+		Name: "cache",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "prs"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "compile",
+				StepOverrides:    stepOverrides,
+				SidecarOverrides: sidecarOverrides,
+			}},
+		},
+	}
+	compile := pr.GetTaskRunSpec("compile")
+	if d := cmp.Diff(stepOverrides, compile.StepOverrides); d != "" {
+		t.Errorf("wrong step overrides %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(sidecarOverrides, compile.SidecarOverrides); d != "" {
+		t.Errorf("wrong sidecar overrides %s", diff.PrintWantGot(d))
+	}
+	other := pr.GetTaskRunSpec("test")
+	if other.StepOverrides != nil || other.SidecarOverrides != nil {
+		t.Errorf("expected no overrides for test, got %v and %v", other.StepOverrides, other.SidecarOverrides)
+	}
+}
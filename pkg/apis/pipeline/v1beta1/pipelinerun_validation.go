@@ -49,15 +49,20 @@ func (pr *PipelineRun) Validate(ctx context.Context) *apis.FieldError {
 func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	cfg := config.FromContextOrDefaults(ctx)
 	// can't have both pipelineRef and pipelineSpec at the same time
-	if (ps.PipelineRef != nil && ps.PipelineRef.Name != "") && ps.PipelineSpec != nil {
+	if (ps.PipelineRef != nil && (ps.PipelineRef.Name != "" || ps.PipelineRef.Resolver != "")) && ps.PipelineSpec != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("pipelineref", "pipelinespec"))
 	}
 
 	// Check that one of PipelineRef and PipelineSpec is present
-	if (ps.PipelineRef == nil || (ps.PipelineRef != nil && ps.PipelineRef.Name == "")) && ps.PipelineSpec == nil {
+	if (ps.PipelineRef == nil || (ps.PipelineRef != nil && ps.PipelineRef.Name == "" && ps.PipelineRef.Resolver == "")) && ps.PipelineSpec == nil {
 		errs = errs.Also(apis.ErrMissingField("pipelineref.name", "pipelinespec"))
 	}
 
+	// Validate the resolver of the PipelineRef if it's present
+	if ps.PipelineRef != nil {
+		errs = errs.Also(validateResolverRef(ctx, ps.PipelineRef.ResolverRef, ps.PipelineRef.Name, ps.PipelineRef.Bundle).ViaField("pipelineref"))
+	}
+
 	// If EnableTektonOCIBundles feature flag is on validate it.
 	// Otherwise, fail if it is present (as it won't be allowed nor used)
 	if cfg.FeatureFlags.EnableTektonOCIBundles {
@@ -128,6 +133,16 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
+	if ps.StepTemplate != nil {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "stepTemplate", config.AlphaAPIFields))
+	}
+	for idx, trs := range ps.TaskRunSpecs {
+		if trs.StepTemplate != nil {
+			errs = errs.Also(ValidateEnabledAPIFields(ctx, "taskRunSpecs stepTemplate", config.AlphaAPIFields).ViaFieldIndex("taskRunSpecs", idx))
+		}
+		errs = errs.Also(validateOverrides(ctx, trs.StepOverrides, trs.SidecarOverrides).ViaFieldIndex("taskRunSpecs", idx))
+	}
+
 	return errs
 }
 
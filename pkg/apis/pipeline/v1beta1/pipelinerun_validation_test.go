@@ -600,6 +600,104 @@ func TestPipelineRunWithAlphaFields_Invalid(t *testing.T) {
 			},
 		},
 		want: apis.ErrGeneric(fmt.Sprintf(`timeouts requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`)),
+	}, {
+		name: "pipelineref resolver when apifields stable",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					ResolverRef: v1beta1.ResolverRef{Resolver: "git"},
+				},
+			},
+		},
+		want: apis.ErrGeneric(`resolver requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("spec.pipelineref.resolver"),
+	}, {
+		name: "pipelineref resolver with a bundle",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Bundle:      "docker.io/foo/bar",
+					ResolverRef: v1beta1.ResolverRef{Resolver: "git"},
+				},
+			},
+		},
+		want: apis.ErrMultipleOneOf("spec.pipelineref.bundle", "spec.pipelineref.resolver").Also(apis.ErrMissingField("spec.pipelineref.name")),
+		wc:   enableAlphaAPIFields,
+	}, {
+		name: "stepTemplate when apifields stable",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				StepTemplate: &v1beta1.TaskRunStepTemplate{
+					Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+				},
+			},
+		},
+		want: apis.ErrGeneric(`stepTemplate requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "taskRunSpecs stepTemplate when apifields stable",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+					PipelineTaskName: "bar",
+					StepTemplate: &v1beta1.TaskRunStepTemplate{
+						Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+					},
+				}},
+			},
+		},
+		want: apis.ErrGeneric(`taskRunSpecs stepTemplate requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "taskRunSpecs stepOverrides without a name",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+					PipelineTaskName: "bar",
+					StepOverrides:    []v1beta1.TaskRunStepOverride{{}},
+				}},
+			},
+		},
+		want: apis.ErrMissingField("spec.taskRunSpecs[0].stepOverrides[0].name"),
+		wc:   enableAlphaAPIFields,
+	}, {
+		name: "taskRunSpecs sidecarOverrides when apifields stable",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+					PipelineTaskName: "bar",
+					SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{Name: "cache"}},
+				}},
+			},
+		},
+		want: apis.ErrGeneric(`sidecarOverrides requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
 	}}
 
 	for _, tc := range tests {
@@ -639,6 +737,47 @@ func TestPipelineRunWithAlphaFields_Validate(t *testing.T) {
 			},
 		},
 		wc: enableAlphaAPIFields,
+	}, {
+		name: "pipelineref with a resolver",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					ResolverRef: v1beta1.ResolverRef{
+						Resolver: "git",
+						Params: []v1beta1.Param{{
+							Name:  "path",
+							Value: *v1beta1.NewArrayOrString("pipeline.yaml"),
+						}},
+					},
+				},
+			},
+		},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "stepTemplate and taskRunSpecs stepTemplate",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				StepTemplate: &v1beta1.TaskRunStepTemplate{
+					Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+				},
+				TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+					PipelineTaskName: "bar",
+					StepTemplate: &v1beta1.TaskRunStepTemplate{
+						Env: []corev1.EnvVar{{Name: "NO_PROXY", Value: "localhost"}},
+					},
+				}},
+			},
+		},
+		wc: enableAlphaAPIFields,
 	}}
 
 	for _, ts := range tests {
@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"knative.dev/pkg/apis"
+)
+
+// validateResolverRef validates the resolver and params of a reference to a Task or a Pipeline.
+// A reference that names a resolver can't also set the name or the bundle of the resource,
+// since the resource is identified by the params passed to the resolver.
+func validateResolverRef(ctx context.Context, ref ResolverRef, name, bundle string) (errs *apis.FieldError) {
+	if ref.Resolver == "" {
+		if len(ref.Params) > 0 {
+			errs = errs.Also(apis.ErrMissingField("resolver"))
+		}
+		return errs
+	}
+	// This is an alpha feature and will fail validation if it's used
+	// when the enable-api-fields feature gate is anything but "alpha".
+	errs = errs.Also(ValidateEnabledAPIFields(ctx, "resolver", config.AlphaAPIFields).ViaField("resolver"))
+	if name != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("name", "resolver"))
+	}
+	if bundle != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("bundle", "resolver"))
+	}
+	return errs.Also(validateParameters(ref.Params).ViaField("params"))
+}
@@ -26,16 +26,23 @@ import (
 type ResultRef struct {
 	PipelineTask string
 	Result       string
+	// Property is the key of an object result referenced as tasks.<taskName>.results.<resultName>.<key>
+	Property string
 }
 
 const (
 	resultExpressionFormat = "tasks.<taskName>.results.<resultName>"
+	// objectResultExpressionFormat is the format of a reference to a key of an object result
+	objectResultExpressionFormat = "tasks.<taskName>.results.<objectResultName>.<key>"
 	// ResultTaskPart Constant used to define the "tasks" part of a pipeline result reference
 	ResultTaskPart = "tasks"
 	// ResultResultPart Constant used to define the "results" part of a pipeline result reference
 	ResultResultPart = "results"
+	// ResultFinallyPart Constant used to define the "finally" part of a pipeline result reference
+	// to the result of a finally task
+	ResultFinallyPart = "finally"
 	// TODO(#2462) use one regex across all substitutions
-	variableSubstitutionFormat = `\$\([_a-zA-Z0-9.-]+(\.[_a-zA-Z0-9.-]+)*\)`
+	variableSubstitutionFormat = `\$\([_a-zA-Z0-9.-]+(\.[_a-zA-Z0-9.-]+)*(\[\*\])?\)`
 	// ResultNameFormat Constant used to define the the regex Result.Name should follow
 	ResultNameFormat = `^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`
 )
@@ -49,7 +56,7 @@ var resultNameFormatRegex = regexp.MustCompile(ResultNameFormat)
 func NewResultRefs(expressions []string) []*ResultRef {
 	var resultRefs []*ResultRef
 	for _, expression := range expressions {
-		pipelineTask, result, err := parseExpression(expression)
+		pipelineTask, result, property, err := parseExpression(expression)
 		// If the expression isn't a result but is some other expression,
 		// parseExpression will return an error, in which case we just skip that expression,
 		// since although it's not a result ref, it might be some other kind of reference
@@ -57,12 +64,44 @@ func NewResultRefs(expressions []string) []*ResultRef {
 			resultRefs = append(resultRefs, &ResultRef{
 				PipelineTask: pipelineTask,
 				Result:       result,
+				Property:     property,
 			})
 		}
 	}
 	return resultRefs
 }
 
+// NewPipelineResultRefs extracts all ResultReferences from a pipeline result, which can reference
+// the results of finally tasks, as finally.<finallyTaskName>.results.<resultName>, in addition to
+// the results of tasks.
+func NewPipelineResultRefs(expressions []string) []*ResultRef {
+	var taskExpressions []string
+	for _, expression := range expressions {
+		taskExpressions = append(taskExpressions, toTaskResultExpression(expression))
+	}
+	return NewResultRefs(taskExpressions)
+}
+
+// IsFinallyResultRef returns true if the expression is a reference to the result of a finally task.
+func IsFinallyResultRef(expression string) bool {
+	return strings.HasPrefix(expression, ResultFinallyPart+".") && len(NewPipelineResultRefs([]string{expression})) == 1
+}
+
+// toTaskResultExpression returns the expression referencing the result of a finally task in the
+// same form as a reference to the result of a task.
+func toTaskResultExpression(expression string) string {
+	if strings.HasPrefix(expression, ResultFinallyPart+".") {
+		return ResultTaskPart + strings.TrimPrefix(expression, ResultFinallyPart)
+	}
+	return expression
+}
+
+// looksLikePipelineResultRef attempts to check if the given string looks like it contains any
+// result references to the results of tasks or finally tasks.
+func looksLikePipelineResultRef(expression string) bool {
+	return looksLikeResultRef(toTaskResultExpression(expression))
+}
+
 // LooksLikeContainsResultRefs attempts to check if param or a pipeline result looks like it contains any
 // result references.
 // This is useful if we want to make sure the param looks like a ResultReference before
@@ -94,6 +133,11 @@ func GetVarSubstitutionExpressionsForParam(param Param) ([]string, bool) {
 	case ParamTypeString:
 		// string type
 		allExpressions = append(allExpressions, validateString(param.Value.StringVal)...)
+	case ParamTypeObject:
+		// object type
+		for _, value := range param.Value.ObjectVal {
+			allExpressions = append(allExpressions, validateString(value)...)
+		}
 	default:
 		return nil, false
 	}
@@ -102,7 +146,19 @@ func GetVarSubstitutionExpressionsForParam(param Param) ([]string, bool) {
 
 // GetVarSubstitutionExpressionsForPipelineResult extracts all the value between "$(" and ")"" for a pipeline result
 func GetVarSubstitutionExpressionsForPipelineResult(result PipelineResult) ([]string, bool) {
-	allExpressions := validateString(result.Value)
+	var allExpressions []string
+	switch result.Value.Type {
+	case ParamTypeArray:
+		for _, value := range result.Value.ArrayVal {
+			allExpressions = append(allExpressions, validateString(value)...)
+		}
+	case ParamTypeObject:
+		for _, value := range result.Value.ObjectVal {
+			allExpressions = append(allExpressions, validateString(value)...)
+		}
+	default:
+		allExpressions = validateString(result.Value.StringVal)
+	}
 	return allExpressions, len(allExpressions) != 0
 }
 
@@ -122,12 +178,21 @@ func stripVarSubExpression(expression string) string {
 	return strings.TrimSuffix(strings.TrimPrefix(expression, "$("), ")")
 }
 
-func parseExpression(substitutionExpression string) (string, string, error) {
-	subExpressions := strings.Split(substitutionExpression, ".")
-	if len(subExpressions) != 4 || subExpressions[0] != ResultTaskPart || subExpressions[2] != ResultResultPart {
-		return "", "", fmt.Errorf("Must be of the form %q", resultExpressionFormat)
+func parseExpression(substitutionExpression string) (string, string, string, error) {
+	// A reference to a whole array or object result, tasks.<taskName>.results.<resultName>[*],
+	// refers to the same result as tasks.<taskName>.results.<resultName>
+	wholeResult := strings.HasSuffix(substitutionExpression, "[*]")
+	subExpressions := strings.Split(strings.TrimSuffix(substitutionExpression, "[*]"), ".")
+	if len(subExpressions) < 4 || len(subExpressions) > 5 || subExpressions[0] != ResultTaskPart || subExpressions[2] != ResultResultPart {
+		return "", "", "", fmt.Errorf("Must be of the form %q or %q", resultExpressionFormat, objectResultExpressionFormat)
 	}
-	return subExpressions[1], subExpressions[3], nil
+	if len(subExpressions) == 5 {
+		if wholeResult {
+			return "", "", "", fmt.Errorf("Must be of the form %q", objectResultExpressionFormat)
+		}
+		return subExpressions[1], subExpressions[3], subExpressions[4], nil
+	}
+	return subExpressions[1], subExpressions[3], "", nil
 }
 
 // PipelineTaskResultRefs walks all the places a result reference can be used
@@ -146,6 +211,11 @@ func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 		refs = append(refs, NewResultRefs(expressions)...)
 	}
 
+	for _, p := range pt.Matrix {
+		expressions, _ := GetVarSubstitutionExpressionsForParam(p)
+		refs = append(refs, NewResultRefs(expressions)...)
+	}
+
 	for _, whenExpression := range pt.WhenExpressions {
 		expressions, _ := whenExpression.GetVarSubstitutionExpressions()
 		refs = append(refs, NewResultRefs(expressions)...)
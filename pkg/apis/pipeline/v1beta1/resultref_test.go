@@ -41,6 +41,27 @@ func TestNewResultReference(t *testing.T) {
 			PipelineTask: "sumTask",
 			Result:       "sumResult",
 		}},
+	}, {
+		name: "Test valid expression referencing a whole array or object result",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewArrayOrString("$(tasks.sumTask.results.sumResult[*])"),
+		},
+		want: []*v1beta1.ResultRef{{
+			PipelineTask: "sumTask",
+			Result:       "sumResult",
+		}},
+	}, {
+		name: "Test valid expression referencing a key of an object result",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewArrayOrString("$(tasks.sumTask.results.sumResult.key)"),
+		},
+		want: []*v1beta1.ResultRef{{
+			PipelineTask: "sumTask",
+			Result:       "sumResult",
+			Property:     "key",
+		}},
 	}, {
 		name: "substitution within string",
 		param: v1beta1.Param{
@@ -594,3 +615,44 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 		t.Errorf("%v", d)
 	}
 }
+
+func TestNewPipelineResultRefs(t *testing.T) {
+	expressions := []string{
+		"tasks.pt1.results.r1",
+		"finally.ft1.results.r2",
+		"finally.ft2.results.r3[*]",
+		"params.foo",
+	}
+	refs := v1beta1.NewPipelineResultRefs(expressions)
+	expectedRefs := []*v1beta1.ResultRef{{
+		PipelineTask: "pt1",
+		Result:       "r1",
+	}, {
+		PipelineTask: "ft1",
+		Result:       "r2",
+	}, {
+		PipelineTask: "ft2",
+		Result:       "r3",
+	}}
+	if d := cmp.Diff(expectedRefs, refs); d != "" {
+		t.Errorf("%v", d)
+	}
+}
+
+func TestIsFinallyResultRef(t *testing.T) {
+	for _, tt := range []struct {
+		expression string
+		want       bool
+	}{
+		{expression: "finally.ft1.results.r1", want: true},
+		{expression: "tasks.pt1.results.r1", want: false},
+		{expression: "finally.ft1.status", want: false},
+		{expression: "params.finally", want: false},
+	} {
+		t.Run(tt.expression, func(t *testing.T) {
+			if got := v1beta1.IsFinallyResultRef(tt.expression); got != tt.want {
+				t.Errorf("IsFinallyResultRef(%q) = %t, want %t", tt.expression, got, tt.want)
+			}
+		})
+	}
+}
@@ -23,5 +23,8 @@ import (
 // ApplyStepReplacements applies variable interpolation on a Step.
 func ApplyStepReplacements(step *Step, stringReplacements map[string]string, arrayReplacements map[string][]string) {
 	step.Script = substitution.ApplyReplacements(step.Script, stringReplacements)
+	if len(step.When) > 0 {
+		step.When = step.When.ReplaceWhenExpressionsVariables(stringReplacements, arrayReplacements)
+	}
 	applyContainerReplacements(&step.Container, stringReplacements, arrayReplacements)
 }
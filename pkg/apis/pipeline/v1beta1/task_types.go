@@ -99,6 +99,22 @@ type TaskSpec struct {
 	// the steps start and end after the steps complete.
 	Sidecars []Sidecar `json:"sidecars,omitempty"`
 
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// SidecarTemplate can be used as the basis for all sidecar containers within the
+	// Task, so that the sidecars inherit settings on the base container.
+	// +optional
+	SidecarTemplate *corev1.Container `json:"sidecarTemplate,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// ComputeResources is the compute resource budget of the pod of the Task. It replaces
+	// the compute resources of the steps, which are assigned a share of the budget.
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+
 	// Workspaces are the volumes that this Task requires.
 	Workspaces []WorkspaceDeclaration `json:"workspaces,omitempty"`
 
@@ -111,11 +127,30 @@ type TaskResult struct {
 	// Name the given name
 	Name string `json:"name"`
 
+	// Type is the user-specified type of the result. The possible types
+	// are "string", "array" and "object", and "string" is the default.
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description"`
 }
 
+// ResultsType indicates the type of a result;
+// Used to distinguish between a single string, an array of strings and an object of strings.
+type ResultsType string
+
+// Valid ResultsTypes:
+const (
+	ResultsTypeString ResultsType = "string"
+	ResultsTypeArray  ResultsType = "array"
+	ResultsTypeObject ResultsType = "object"
+)
+
+// AllResultsTypes can be used for ResultsTypes validation.
+var AllResultsTypes = []ResultsType{ResultsTypeString, ResultsTypeArray, ResultsTypeObject}
+
 // Step embeds the Container type, which allows it to include fields not
 // provided by Container.
 type Step struct {
@@ -147,6 +182,54 @@ type Step struct {
 	// stopAndFail indicates exit the taskRun if the container exits with non-zero exit code
 	// continue indicates continue executing the rest of the steps irrespective of the container exit code
 	OnError string `json:"onError,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Retries is the number of times the Step is run again in place when it exits with a
+	// non-zero exit code, before the next Step starts. The Timeout applies to all the attempts.
+	// +optional
+	Retries int `json:"retries,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// When is a list of when expressions that must all evaluate to true for the Step to run.
+	// They are evaluated by the entrypoint when the Step starts, and can reference the
+	// results written by the previous Steps with $(results.<name>).
+	// +optional
+	When WhenExpressions `json:"when,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Ref references a StepAction that defines the image, command, args, env and script
+	// of this Step. It is resolved when the TaskRun is reconciled.
+	// +optional
+	Ref *Ref `json:"ref,omitempty"`
+
+	// Params are the parameters passed to the StepAction referenced by Ref.
+	// +optional
+	Params []Param `json:"params,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// WaitForSidecars are the names of the Sidecars of the Task this Step needs. The Step
+	// waits for these Sidecars to be ready before running, and only for them: the first
+	// Step otherwise waits for all the Sidecars of the Task.
+	// +optional
+	// +listType=atomic
+	WaitForSidecars []string `json:"waitForSidecars,omitempty"`
+}
+
+// Ref can be used to refer to a specific instance of a StepAction.
+type Ref struct {
+	// Name of the referenced StepAction.
+	Name string `json:"name,omitempty"`
+	// Bundle url reference to a Tekton Bundle.
+	// +optional
+	Bundle string `json:"bundle,omitempty"`
 }
 
 // Sidecar has nearly the same data structure as Step, consisting of a Container and an optional Script, but does not have the ability to timeout.
@@ -168,6 +251,27 @@ type Sidecar struct {
 	// not have access to it.
 	// +optional
 	Workspaces []WorkspaceUsage `json:"workspaces,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// StartOrder is the order in which the Sidecar is started: a Sidecar with a StartOrder
+	// isn't started until all the Sidecars with a lower StartOrder are ready, or terminated.
+	// Sidecars with the same StartOrder are started together. Defaults to 0.
+	// +optional
+	StartOrder int `json:"startOrder,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// TerminationGracePeriod is how long the Sidecar is given to finish its work and exit
+	// on its own once it is signaled with SIGTERM when the Steps are done, before it is
+	// killed. The termination grace period of the Pod is raised to cover it, so it applies
+	// to all of its containers: the Steps still running when the TaskRun is cancelled or
+	// times out are given as long before they are killed. Defaults to the termination grace
+	// period of the Pod.
+	// +optional
+	TerminationGracePeriod *metav1.Duration `json:"terminationGracePeriod,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -192,6 +296,12 @@ type TaskRef struct {
 	// Bundle url reference to a Tekton Bundle.
 	// +optional
 	Bundle string `json:"bundle,omitempty"`
+
+	// ResolverRef allows referencing a Task in a remote location
+	// like a git repo. This field is only supported when the alpha
+	// feature gate is enabled.
+	// +optional
+	ResolverRef `json:",omitempty"`
 }
 
 // Check that Pipeline may be validated and defaulted.
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/substitution"
@@ -49,6 +50,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
 	errs = errs.Also(validateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
 	errs = errs.Also(validateWorkspaceUsages(ctx, ts))
+	errs = errs.Also(validateSidecarLifecycle(ctx, ts))
 	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
 	if err != nil {
 		errs = errs.Also(&apis.FieldError{
@@ -59,12 +61,50 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(validateSteps(ctx, mergedSteps).ViaField("steps"))
+	if ts.SidecarTemplate != nil {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "sidecarTemplate", config.AlphaAPIFields))
+		if _, err := MergeSidecarsWithSidecarTemplate(ts.SidecarTemplate, ts.Sidecars); err != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("error merging sidecar template and sidecars: %s", err),
+				Paths:   []string{"sidecarTemplate"},
+				Details: err.Error(),
+			})
+		}
+	}
+	if ts.ComputeResources != nil {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "computeResources", config.AlphaAPIFields))
+	}
+	errs = errs.Also(validateStepRefs(ctx, ts.Steps).ViaField("steps"))
 	errs = errs.Also(ts.Resources.Validate(ctx).ViaField("resources"))
-	errs = errs.Also(ValidateParameterTypes(ts.Params).ViaField("params"))
+	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
 	errs = errs.Also(ValidateParameterVariables(ts.Steps, ts.Params))
 	errs = errs.Also(ValidateResourcesVariables(ts.Steps, ts.Resources))
 	errs = errs.Also(validateTaskContextVariables(ts.Steps))
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
+	errs = errs.Also(validateStepWhenResults(ts.Steps, ts.Results).ViaField("steps"))
+	return errs
+}
+
+// validateStepWhenResults validates that the results referenced by the when expressions
+// of the Steps are declared by the Task.
+func validateStepWhenResults(steps []Step, results []TaskResult) (errs *apis.FieldError) {
+	resultNames := sets.NewString()
+	for _, r := range results {
+		resultNames.Insert(r.Name)
+	}
+	for idx, s := range steps {
+		for i, we := range s.When {
+			expressions, _ := we.GetVarSubstitutionExpressions()
+			for _, expression := range expressions {
+				if !strings.HasPrefix(expression, "results.") {
+					continue
+				}
+				if name := strings.TrimPrefix(expression, "results."); !resultNames.Has(name) {
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q is not a result declared by the Task", name), "").ViaFieldIndex("when", i).ViaIndex(idx))
+				}
+			}
+		}
+	}
 	return errs
 }
 
@@ -75,11 +115,23 @@ func validateResults(ctx context.Context, results []TaskResult) (errs *apis.Fiel
 	return errs
 }
 
-func (tr TaskResult) Validate(_ context.Context) *apis.FieldError {
+func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if !resultNameFormatRegex.MatchString(tr.Name) {
 		return apis.ErrInvalidKeyName(tr.Name, "name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat))
 	}
-	return nil
+	return validateResultType(ctx, tr.Type)
+}
+
+// validateResultType validates the type of a Task or Pipeline result: array and object results are alpha features.
+func validateResultType(ctx context.Context, resultType ResultsType) *apis.FieldError {
+	switch resultType {
+	case "", ResultsTypeString:
+		return nil
+	case ResultsTypeArray, ResultsTypeObject:
+		return ValidateEnabledAPIFields(ctx, fmt.Sprintf("%s results", resultType), config.AlphaAPIFields)
+	default:
+		return apis.ErrInvalidValue(resultType, "type")
+	}
 }
 
 // a mount path which conflicts with any other declared workspaces, with the explicitly
@@ -155,6 +207,46 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 	return errs
 }
 
+// validateSidecarLifecycle validates that the Sidecars the Steps wait for are Sidecars of the
+// Task, and the start order and termination grace period of the Sidecars.
+//
+// This is an alpha feature and will fail validation if it's used by a step
+// or sidecar when the enable-api-fields feature gate is anything but "alpha".
+func validateSidecarLifecycle(ctx context.Context, ts *TaskSpec) (errs *apis.FieldError) {
+	sidecarNames := sets.NewString()
+	for _, s := range ts.Sidecars {
+		sidecarNames.Insert(s.Name)
+	}
+
+	for stepIdx, step := range ts.Steps {
+		if len(step.WaitForSidecars) != 0 {
+			errs = errs.Also(ValidateEnabledAPIFields(ctx, "step waitForSidecars", config.AlphaAPIFields).ViaIndex(stepIdx).ViaField("steps"))
+		}
+		for i, name := range step.WaitForSidecars {
+			if name == "" || !sidecarNames.Has(name) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q is not a sidecar of the Task", name), "").ViaFieldIndex("waitForSidecars", i).ViaIndex(stepIdx).ViaField("steps"))
+			}
+		}
+	}
+
+	for sidecarIdx, sidecar := range ts.Sidecars {
+		if sidecar.StartOrder != 0 {
+			errs = errs.Also(ValidateEnabledAPIFields(ctx, "sidecar startOrder", config.AlphaAPIFields).ViaIndex(sidecarIdx).ViaField("sidecars"))
+			if sidecar.StartOrder < 0 {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", sidecar.StartOrder), "startOrder").ViaIndex(sidecarIdx).ViaField("sidecars"))
+			}
+		}
+		if sidecar.TerminationGracePeriod != nil {
+			errs = errs.Also(ValidateEnabledAPIFields(ctx, "sidecar terminationGracePeriod", config.AlphaAPIFields).ViaIndex(sidecarIdx).ViaField("sidecars"))
+			if sidecar.TerminationGracePeriod.Duration < 0 {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", sidecar.TerminationGracePeriod.Duration), "terminationGracePeriod").ViaIndex(sidecarIdx).ViaField("sidecars"))
+			}
+		}
+	}
+
+	return errs
+}
+
 func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	// Task must not have duplicate volume names.
 	vols := sets.NewString()
@@ -178,7 +270,8 @@ func validateSteps(ctx context.Context, steps []Step) (errs *apis.FieldError) {
 }
 
 func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.FieldError) {
-	if s.Image == "" {
+	// The image of a Step that references a StepAction comes from the StepAction.
+	if s.Image == "" && s.Ref == nil {
 		errs = errs.Also(apis.ErrMissingField("Image"))
 	}
 
@@ -232,6 +325,18 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 		}
 	}
 
+	if s.Retries != 0 {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "step retries", config.AlphaAPIFields).ViaField("steps"))
+		if s.Retries < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", s.Retries), "retries"))
+		}
+	}
+
+	if len(s.When) > 0 {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "step when expressions", config.AlphaAPIFields).ViaField("steps"))
+		errs = errs.Also(s.When.validateWhenExpressionsFields(ctx).ViaField("when"))
+	}
+
 	if s.Script != "" {
 		cleaned := strings.TrimSpace(s.Script)
 		if strings.HasPrefix(cleaned, "#!win") {
@@ -241,8 +346,59 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 	return errs
 }
 
-func ValidateParameterTypes(params []ParamSpec) (errs *apis.FieldError) {
+// validateStepRefs validates the Steps that reference a StepAction. They are validated before
+// being merged with the StepTemplate, which may set fields that a Step referencing a
+// StepAction can't set itself.
+func validateStepRefs(ctx context.Context, steps []Step) (errs *apis.FieldError) {
+	for i, s := range steps {
+		if s.Ref == nil {
+			if len(s.Params) > 0 {
+				errs = errs.Also(apis.ErrGeneric("params can only be set on a Step that references a StepAction", "params").ViaIndex(i))
+			}
+			continue
+		}
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "step ref", config.AlphaAPIFields).ViaIndex(i))
+		errs = errs.Also(s.Ref.validate().ViaField("ref").ViaIndex(i))
+		if s.Image != "" {
+			errs = errs.Also(apis.ErrMultipleOneOf("ref", "image").ViaIndex(i))
+		}
+		if len(s.Command) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("ref", "command").ViaIndex(i))
+		}
+		if len(s.Args) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("ref", "args").ViaIndex(i))
+		}
+		if len(s.Env) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("ref", "env").ViaIndex(i))
+		}
+		if s.Script != "" {
+			errs = errs.Also(apis.ErrMultipleOneOf("ref", "script").ViaIndex(i))
+		}
+		if s.WorkingDir != "" {
+			errs = errs.Also(apis.ErrMultipleOneOf("ref", "workingDir").ViaIndex(i))
+		}
+		errs = errs.Also(validateParameters(s.Params).ViaField("params").ViaIndex(i))
+	}
+	return errs
+}
+
+func (r *Ref) validate() (errs *apis.FieldError) {
+	if r.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	if r.Bundle != "" {
+		if _, err := name.ParseReference(r.Bundle); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid bundle reference (%s)", err.Error()), "bundle"))
+		}
+	}
+	return errs
+}
+
+func ValidateParameterTypes(ctx context.Context, params []ParamSpec) (errs *apis.FieldError) {
 	for _, p := range params {
+		if p.Type == ParamTypeObject {
+			errs = errs.Also(validateEnabledAPIFields(ctx, "object type parameter", config.AlphaAPIFields, "type").ViaField(p.Name))
+		}
 		errs = errs.Also(p.ValidateType())
 	}
 	return errs
@@ -271,22 +427,27 @@ func (p ParamSpec) ValidateType() *apis.FieldError {
 			},
 		}
 	}
-	return nil
+	return p.validateProperties().ViaField(p.Name)
 }
 
 func ValidateParameterVariables(steps []Step, params []ParamSpec) *apis.FieldError {
 	parameterNames := sets.NewString()
 	arrayParameterNames := sets.NewString()
+	objectParameterKeys := map[string]sets.String{}
 
 	for _, p := range params {
 		parameterNames.Insert(p.Name)
-		if p.Type == ParamTypeArray {
+		switch p.Type {
+		case ParamTypeArray:
 			arrayParameterNames.Insert(p.Name)
+		case ParamTypeObject:
+			objectParameterKeys[p.Name] = p.propertyKeys()
 		}
 	}
 
 	errs := validateVariables(steps, "params", parameterNames)
-	return errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	errs = errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	return errs.Also(validateObjectUsage(steps, "params", objectParameterKeys))
 }
 
 func validateTaskContextVariables(steps []Step) *apis.FieldError {
@@ -351,6 +512,38 @@ func validateStepArrayUsage(step Step, prefix string, vars sets.String) *apis.Fi
 	return errs
 }
 
+func validateObjectUsage(steps []Step, prefix string, objectKeys map[string]sets.String) (errs *apis.FieldError) {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	for idx, step := range steps {
+		errs = errs.Also(validateStepObjectUsage(step, prefix, objectKeys).ViaFieldIndex("steps", idx))
+	}
+	return errs
+}
+
+func validateStepObjectUsage(step Step, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	errs := validateTaskObjectKeys(step.Name, prefix, objectKeys).ViaField("name")
+	errs = errs.Also(validateTaskObjectKeys(step.Image, prefix, objectKeys).ViaField("image"))
+	errs = errs.Also(validateTaskObjectKeys(step.WorkingDir, prefix, objectKeys).ViaField("workingDir"))
+	errs = errs.Also(validateTaskObjectKeys(step.Script, prefix, objectKeys).ViaField("script"))
+	for i, cmd := range step.Command {
+		errs = errs.Also(validateTaskObjectKeys(cmd, prefix, objectKeys).ViaFieldIndex("command", i))
+	}
+	for i, arg := range step.Args {
+		errs = errs.Also(validateTaskObjectKeys(arg, prefix, objectKeys).ViaFieldIndex("args", i))
+	}
+	for _, env := range step.Env {
+		errs = errs.Also(validateTaskObjectKeys(env.Value, prefix, objectKeys).ViaFieldKey("env", env.Name))
+	}
+	for i, v := range step.VolumeMounts {
+		errs = errs.Also(validateTaskObjectKeys(v.Name, prefix, objectKeys).ViaField("name").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(validateTaskObjectKeys(v.MountPath, prefix, objectKeys).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(validateTaskObjectKeys(v.SubPath, prefix, objectKeys).ViaField("subPath").ViaFieldIndex("volumeMount", i))
+	}
+	return errs
+}
+
 func validateVariables(steps []Step, prefix string, vars sets.String) (errs *apis.FieldError) {
 	for idx, step := range steps {
 		errs = errs.Also(validateStepVariables(step, prefix, vars).ViaFieldIndex("steps", idx))
@@ -377,6 +570,13 @@ func validateStepVariables(step Step, prefix string, vars sets.String) *apis.Fie
 		errs = errs.Also(validateTaskVariable(v.MountPath, prefix, vars).ViaField("MountPath").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(validateTaskVariable(v.SubPath, prefix, vars).ViaField("SubPath").ViaFieldIndex("volumeMount", i))
 	}
+	for i, we := range step.When {
+		errs = errs.Also(validateTaskVariable(we.Input, prefix, vars).ViaField("input").ViaFieldIndex("when", i))
+		for _, val := range we.Values {
+			errs = errs.Also(validateTaskVariable(val, prefix, vars).ViaField("values").ViaFieldIndex("when", i))
+		}
+		errs = errs.Also(validateTaskVariable(we.CEL, prefix, vars).ViaField("cel").ViaFieldIndex("when", i))
+	}
 	return errs
 }
 
@@ -391,3 +591,7 @@ func validateTaskNoArrayReferenced(value, prefix string, arrayNames sets.String)
 func validateTaskArraysIsolated(value, prefix string, arrayNames sets.String) *apis.FieldError {
 	return substitution.ValidateVariableIsolatedP(value, prefix, arrayNames)
 }
+
+func validateTaskObjectKeys(value, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	return substitution.ValidateObjectKeysP(value, prefix, objectKeys)
+}
@@ -28,7 +28,9 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
 )
 
@@ -956,6 +958,19 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Paths:   []string{"results[0].name"},
 			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
 		},
+	}, {
+		name: "result type not validate",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1beta1.TaskResult{{
+				Name: "my-result",
+				Type: "map",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: map`,
+			Paths:   []string{"results[0].type"},
+		},
 	}, {
 		name: "context not validate",
 		fields: fields{
@@ -1197,8 +1212,640 @@ func TestStepOnError(t *testing.T) {
 
 }
 
+func TestStepRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1beta1.Step
+		enableAlpha   bool
+		expectedError *apis.FieldError
+	}{{
+		name: "valid step retries",
+		steps: []v1beta1.Step{{
+			Retries: 2,
+			Container: corev1.Container{
+				Image: "image",
+				Args:  []string{"arg"},
+			},
+		}},
+		enableAlpha: true,
+	}, {
+		name: "negative step retries",
+		steps: []v1beta1.Step{{
+			Retries: -1,
+			Container: corev1.Container{
+				Image: "image",
+				Args:  []string{"arg"},
+			},
+		}},
+		enableAlpha:   true,
+		expectedError: apis.ErrInvalidValue("-1 should be >= 0", "steps[0].retries"),
+	}, {
+		name: "step retries without alpha feature gate",
+		steps: []v1beta1.Step{{
+			Retries: 2,
+			Container: corev1.Container{
+				Image: "image",
+				Args:  []string{"arg"},
+			},
+		}},
+		expectedError: apis.ErrGeneric(`step retries requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps: tt.steps,
+			}
+			ctx := context.Background()
+			if tt.enableAlpha {
+				featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+					"enable-api-fields": "alpha",
+				})
+				ctx = config.ToContext(ctx, &config.Config{FeatureFlags: featureFlags})
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSidecarTemplate(t *testing.T) {
+	tests := []struct {
+		name            string
+		sidecarTemplate *corev1.Container
+		enableAlpha     bool
+		expectedError   *apis.FieldError
+	}{{
+		name: "valid sidecar template",
+		sidecarTemplate: &corev1.Container{
+			Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+		},
+		enableAlpha: true,
+	}, {
+		name: "sidecar template without alpha feature gate",
+		sidecarTemplate: &corev1.Container{
+			Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+		},
+		expectedError: apis.ErrGeneric(`sidecarTemplate requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Image: "image",
+				}}},
+				Sidecars: []v1beta1.Sidecar{{Container: corev1.Container{
+					Name:  "proxy",
+					Image: "proxy-image",
+				}}},
+				SidecarTemplate: tt.sidecarTemplate,
+			}
+			ctx := context.Background()
+			if tt.enableAlpha {
+				featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+					"enable-api-fields": "alpha",
+				})
+				ctx = config.ToContext(ctx, &config.Config{FeatureFlags: featureFlags})
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSidecarLifecycle(t *testing.T) {
+	gracePeriod := &metav1.Duration{Duration: 10 * time.Second}
+	tests := []struct {
+		name          string
+		steps         []v1beta1.Step
+		sidecars      []v1beta1.Sidecar
+		enableAlpha   bool
+		expectedError *apis.FieldError
+	}{{
+		name: "valid sidecar lifecycle",
+		steps: []v1beta1.Step{{
+			Container:       corev1.Container{Image: "image"},
+			WaitForSidecars: []string{"db"},
+		}},
+		sidecars: []v1beta1.Sidecar{{
+			Container:              corev1.Container{Name: "db", Image: "db-image"},
+			StartOrder:             1,
+			TerminationGracePeriod: gracePeriod,
+		}},
+		enableAlpha: true,
+	}, {
+		name: "step waits for an unknown sidecar",
+		steps: []v1beta1.Step{{
+			Container:       corev1.Container{Image: "image"},
+			WaitForSidecars: []string{"cache"},
+		}},
+		sidecars: []v1beta1.Sidecar{{
+			Container: corev1.Container{Name: "db", Image: "db-image"},
+		}},
+		enableAlpha:   true,
+		expectedError: apis.ErrInvalidValue(`"cache" is not a sidecar of the Task`, "steps[0].waitForSidecars[0]"),
+	}, {
+		name: "negative sidecar start order",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "image"},
+		}},
+		sidecars: []v1beta1.Sidecar{{
+			Container:  corev1.Container{Name: "db", Image: "db-image"},
+			StartOrder: -1,
+		}},
+		enableAlpha:   true,
+		expectedError: apis.ErrInvalidValue("-1 should be >= 0", "sidecars[0].startOrder"),
+	}, {
+		name: "negative sidecar termination grace period",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "image"},
+		}},
+		sidecars: []v1beta1.Sidecar{{
+			Container:              corev1.Container{Name: "db", Image: "db-image"},
+			TerminationGracePeriod: &metav1.Duration{Duration: -time.Second},
+		}},
+		enableAlpha:   true,
+		expectedError: apis.ErrInvalidValue("-1s should be >= 0", "sidecars[0].terminationGracePeriod"),
+	}, {
+		name: "sidecar lifecycle without alpha feature gate",
+		steps: []v1beta1.Step{{
+			Container:       corev1.Container{Image: "image"},
+			WaitForSidecars: []string{"db"},
+		}},
+		sidecars: []v1beta1.Sidecar{{
+			Container:              corev1.Container{Name: "db", Image: "db-image"},
+			StartOrder:             1,
+			TerminationGracePeriod: gracePeriod,
+		}},
+		expectedError: apis.ErrGeneric(`step waitForSidecars requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).Also(
+			apis.ErrGeneric(`sidecar startOrder requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`)).Also(
+			apis.ErrGeneric(`sidecar terminationGracePeriod requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps:    tt.steps,
+				Sidecars: tt.sidecars,
+			}
+			ctx := context.Background()
+			if tt.enableAlpha {
+				featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+					"enable-api-fields": "alpha",
+				})
+				ctx = config.ToContext(ctx, &config.Config{FeatureFlags: featureFlags})
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestComputeResources(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{Container: corev1.Container{
+			Image: "image",
+		}}},
+		ComputeResources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		},
+	}
+	ctx := context.Background()
+	want := apis.ErrGeneric(`computeResources requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`)
+	if d := cmp.Diff(want.Error(), ts.Validate(ctx).Error()); d != "" {
+		t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+	}
+
+	featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+		"enable-api-fields": "alpha",
+	})
+	ctx = config.ToContext(ctx, &config.Config{FeatureFlags: featureFlags})
+	if err := ts.Validate(ctx); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v", err)
+	}
+}
+
+func TestStepWhenExpressions(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1beta1.Step
+		params        []v1beta1.ParamSpec
+		results       []v1beta1.TaskResult
+		enableAlpha   bool
+		expectedError *apis.FieldError
+	}{{
+		name: "valid step when expressions",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "image"},
+			When: v1beta1.WhenExpressions{{
+				Input:    "$(params.branch)",
+				Operator: selection.In,
+				Values:   []string{"main"},
+			}, {
+				Input:    "$(results.changed)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+		}},
+		params:      []v1beta1.ParamSpec{{Name: "branch"}},
+		results:     []v1beta1.TaskResult{{Name: "changed"}},
+		enableAlpha: true,
+	}, {
+		name: "invalid operator",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "image"},
+			When: v1beta1.WhenExpressions{{
+				Input:    "foo",
+				Operator: selection.Exists,
+				Values:   []string{"foo"},
+			}},
+		}},
+		enableAlpha:   true,
+		expectedError: apis.ErrInvalidValue(`operator "exists" is not recognized. valid operators: in,notin`, "steps[0].when[0]"),
+	}, {
+		name: "undeclared result",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "image"},
+			When: v1beta1.WhenExpressions{{
+				Input:    "$(results.changed)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+		}},
+		enableAlpha:   true,
+		expectedError: apis.ErrInvalidValue(`"changed" is not a result declared by the Task`, "steps[0].when[0]"),
+	}, {
+		name: "undeclared param",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "image"},
+			When: v1beta1.WhenExpressions{{
+				Input:    "$(params.branch)",
+				Operator: selection.In,
+				Values:   []string{"main"},
+			}},
+		}},
+		enableAlpha:   true,
+		expectedError: apis.ErrGeneric(`non-existent variable in "$(params.branch)"`, "steps[0].when[0].input"),
+	}, {
+		name: "step when expressions without alpha feature gate",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "image"},
+			When: v1beta1.WhenExpressions{{
+				Input:    "foo",
+				Operator: selection.In,
+				Values:   []string{"foo"},
+			}},
+		}},
+		expectedError: apis.ErrGeneric(`step when expressions requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps:   tt.steps,
+				Params:  tt.params,
+				Results: tt.results,
+			}
+			ctx := context.Background()
+			if tt.enableAlpha {
+				featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+					"enable-api-fields": "alpha",
+				})
+				ctx = config.ToContext(ctx, &config.Config{FeatureFlags: featureFlags})
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepRef(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1beta1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "valid step ref",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "clone"},
+			Ref:       &v1beta1.Ref{Name: "git-clone"},
+			Params: []v1beta1.Param{{
+				Name:  "url",
+				Value: *v1beta1.NewArrayOrString("https://github.com/tektoncd/pipeline"),
+			}},
+		}},
+	}, {
+		name: "valid step ref with bundle",
+		steps: []v1beta1.Step{{
+			Ref: &v1beta1.Ref{Name: "git-clone", Bundle: "gcr.io/my-bundle:latest"},
+		}},
+	}, {
+		name: "step ref without name",
+		steps: []v1beta1.Step{{
+			Ref: &v1beta1.Ref{},
+		}},
+		expectedError: apis.ErrMissingField("steps[0].ref.name"),
+	}, {
+		name: "step ref with invalid bundle",
+		steps: []v1beta1.Step{{
+			Ref: &v1beta1.Ref{Name: "git-clone", Bundle: "invalid reference"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: "invalid value: invalid bundle reference (could not parse reference: invalid reference)",
+			Paths:   []string{"steps[0].ref.bundle"},
+		},
+	}, {
+		name: "step ref with image and script",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "busybox"},
+			Ref:       &v1beta1.Ref{Name: "git-clone"},
+			Script:    "echo hello",
+		}},
+		expectedError: apis.ErrMultipleOneOf("steps[0].ref", "steps[0].image").Also(
+			apis.ErrMultipleOneOf("steps[0].ref", "steps[0].script")),
+	}, {
+		name: "step ref with duplicate params",
+		steps: []v1beta1.Step{{
+			Ref: &v1beta1.Ref{Name: "git-clone"},
+			Params: []v1beta1.Param{{
+				Name:  "url",
+				Value: *v1beta1.NewArrayOrString("a"),
+			}, {
+				Name:  "url",
+				Value: *v1beta1.NewArrayOrString("b"),
+			}},
+		}},
+		expectedError: apis.ErrMultipleOneOf("steps[0].params[url].name"),
+	}, {
+		name: "params without step ref",
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "busybox"},
+			Params: []v1beta1.Param{{
+				Name:  "url",
+				Value: *v1beta1.NewArrayOrString("a"),
+			}},
+		}},
+		expectedError: apis.ErrGeneric("params can only be set on a Step that references a StepAction", "steps[0].params"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps: tt.steps,
+			}
+			featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+				"enable-api-fields": "alpha",
+			})
+			cfg := &config.Config{
+				FeatureFlags: featureFlags,
+			}
+			ctx := config.ToContext(context.Background(), cfg)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 // TestIncompatibleAPIVersions exercises validation of fields that
 // require a specific feature gate version in order to work.
+func TestObjectParamsValidate(t *testing.T) {
+	gitParam := v1beta1.ParamSpec{
+		Name: "git",
+		Type: v1beta1.ParamTypeObject,
+		Properties: map[string]v1beta1.PropertySpec{
+			"url":      {Type: v1beta1.ParamTypeString},
+			"revision": {Type: v1beta1.ParamTypeString},
+		},
+	}
+	tests := []struct {
+		name   string
+		params []v1beta1.ParamSpec
+		steps  []v1beta1.Step
+	}{{
+		name:   "object param keys used in steps",
+		params: []v1beta1.ParamSpec{gitParam},
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:       "clone",
+				Image:      "git",
+				Args:       []string{"clone", "$(params.git.url)"},
+				WorkingDir: "/workspace/$(params.git.revision)",
+			},
+			Script: "git checkout $(params.git.revision)",
+		}},
+	}, {
+		name: "object param with default value",
+		params: []v1beta1.ParamSpec{{
+			Name:       "git",
+			Properties: map[string]v1beta1.PropertySpec{"url": {}},
+			Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+		}},
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Image: "git",
+				Env:   []corev1.EnvVar{{Name: "URL", Value: "$(params.git.url)"}},
+			},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Params: tt.params,
+				Steps:  tt.steps,
+			}
+			featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+				"enable-api-fields": "alpha",
+			})
+			cfg := &config.Config{
+				FeatureFlags: featureFlags,
+			}
+			ctx := config.ToContext(context.Background(), cfg)
+			ts.SetDefaults(ctx)
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestObjectParamsValidateErrors(t *testing.T) {
+	gitParam := v1beta1.ParamSpec{
+		Name: "git",
+		Type: v1beta1.ParamTypeObject,
+		Properties: map[string]v1beta1.PropertySpec{
+			"url":      {Type: v1beta1.ParamTypeString},
+			"revision": {Type: v1beta1.ParamTypeString},
+		},
+	}
+	tests := []struct {
+		name          string
+		params        []v1beta1.ParamSpec
+		steps         []v1beta1.Step
+		expectedError apis.FieldError
+	}{{
+		name:   "undeclared object key",
+		params: []v1beta1.ParamSpec{gitParam},
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Image: "git",
+				Args:  []string{"clone", "--depth=$(params.git.depth)"},
+			},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "--depth=$(params.git.depth)"`,
+			Paths:   []string{"steps[0].args[1]"},
+		},
+	}, {
+		name:   "undeclared object keys in several steps",
+		params: []v1beta1.ParamSpec{gitParam},
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "clone",
+				Image: "git",
+				Args:  []string{"clone", "--depth=$(params.git.depth)"},
+			},
+		}, {
+			Container: corev1.Container{
+				Name:  "fetch",
+				Image: "git",
+				Args:  []string{"fetch", "--depth=$(params.git.depth)"},
+			},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "--depth=$(params.git.depth)"`,
+			Paths:   []string{"steps[0].args[1]", "steps[1].args[1]"},
+		},
+	}, {
+		name:   "whole object used in a step",
+		params: []v1beta1.ParamSpec{gitParam},
+		steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Image: "git",
+			},
+			Script: "echo $(params.git)",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "echo $(params.git)"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "object param without properties",
+		params: []v1beta1.ParamSpec{{
+			Name: "git",
+			Type: v1beta1.ParamTypeObject,
+		}},
+		steps: validSteps,
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"params.git.properties"},
+		},
+	}, {
+		name: "properties on a string param",
+		params: []v1beta1.ParamSpec{{
+			Name:       "git",
+			Type:       v1beta1.ParamTypeString,
+			Properties: map[string]v1beta1.PropertySpec{"url": {}},
+		}},
+		steps: validSteps,
+		expectedError: apis.FieldError{
+			Message: `properties can only be declared for "object" params`,
+			Paths:   []string{"params.git.properties"},
+		},
+	}, {
+		name: "non-string property",
+		params: []v1beta1.ParamSpec{{
+			Name:       "git",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeArray}},
+		}},
+		steps: validSteps,
+		expectedError: apis.FieldError{
+			Message: `invalid value: array`,
+			Paths:   []string{"params.git.properties[url].type"},
+		},
+	}, {
+		name: "default value missing keys",
+		params: []v1beta1.ParamSpec{{
+			Name: "git",
+			Type: v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{
+				"url":      {Type: v1beta1.ParamTypeString},
+				"revision": {Type: v1beta1.ParamTypeString},
+			},
+			Default: v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+		}},
+		steps: validSteps,
+		expectedError: apis.FieldError{
+			Message: `default value is missing the keys declared in properties: [revision]`,
+			Paths:   []string{"params.git.default"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Params: tt.params,
+				Steps:  tt.steps,
+			}
+			featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+				"enable-api-fields": "alpha",
+			})
+			cfg := &config.Config{
+				FeatureFlags: featureFlags,
+			}
+			ctx := config.ToContext(context.Background(), cfg)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestObjectParamsRequireAlpha(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name:       "git",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}},
+		}},
+		Steps: validSteps,
+	}
+	ctx := context.Background()
+	ts.SetDefaults(ctx)
+	want := &apis.FieldError{
+		Message: `object type parameter requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		Paths:   []string{"params.git.type"},
+	}
+	err := ts.Validate(ctx)
+	if d := cmp.Diff(want.Error(), err.Error()); d != "" {
+		t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestIncompatibleAPIVersions(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -1266,6 +1913,58 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 				script-1`,
 			}},
 		},
+	}, {
+		name:            "array results require alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "my-image",
+				},
+			}},
+			Results: []v1beta1.TaskResult{{
+				Name: "array-result",
+				Type: v1beta1.ResultsTypeArray,
+			}},
+		},
+	}, {
+		name:            "object results require alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "my-image",
+				},
+			}},
+			Results: []v1beta1.TaskResult{{
+				Name: "object-result",
+				Type: v1beta1.ResultsTypeObject,
+			}},
+		},
+	}, {
+		name:            "object params require alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "git",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}},
+			}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "my-image",
+					Args:  []string{"$(params.git.url)"},
+				},
+			}},
+		},
+	}, {
+		name:            "step ref requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Ref: &v1beta1.Ref{Name: "git-clone"},
+			}},
+		},
 	}}
 	versions := []string{"alpha", "stable"}
 	for _, tt := range tests {
@@ -60,6 +60,67 @@ type TaskRunSpec struct {
 	// Workspaces is a list of WorkspaceBindings from volumes to workspaces.
 	// +optional
 	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// StepTemplate is merged into every Step of the Task, after the StepTemplate of the Task,
+	// overriding the values of both.
+	// +optional
+	StepTemplate *TaskRunStepTemplate `json:"stepTemplate,omitempty"`
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// StepOverrides overrides the compute resources of the Steps of the Task, by Step name.
+	// +optional
+	// +listType=atomic
+	StepOverrides []TaskRunStepOverride `json:"stepOverrides,omitempty"`
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// SidecarOverrides overrides the compute resources of the Sidecars of the Task, by Sidecar name.
+	// +optional
+	// +listType=atomic
+	SidecarOverrides []TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// ComputeResources is the compute resource budget of the pod of the TaskRun. It replaces
+	// the one of the Task.
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+}
+
+// TaskRunStepOverride is used to override the compute resources of a Step of the Task.
+type TaskRunStepOverride struct {
+	// Name is the name of the Step to override.
+	Name string `json:"name"`
+	// Resources are the compute resources merged into the ones of the Step.
+	Resources corev1.ResourceRequirements `json:"resources"`
+}
+
+// TaskRunSidecarOverride is used to override the compute resources of a Sidecar of the Task.
+type TaskRunSidecarOverride struct {
+	// Name is the name of the Sidecar to override.
+	Name string `json:"name"`
+	// Resources are the compute resources merged into the ones of the Sidecar.
+	Resources corev1.ResourceRequirements `json:"resources"`
+}
+
+// TaskRunStepTemplate holds the environment variables, compute resources and security
+// context merged into every Step of a TaskRun. Its values take precedence over the ones
+// set by a Step or by the StepTemplate of its Task.
+type TaskRunStepTemplate struct {
+	// Env is a list of environment variables to set in the Steps.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	Env []corev1.EnvVar `json:"env,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	// Resources are the compute resources required by the Steps.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// SecurityContext is the security context the Steps run with.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -75,6 +136,19 @@ const (
 type TaskRunDebug struct {
 	// +optional
 	Breakpoint []string `json:"breakpoint,omitempty"`
+	// BeforeSteps are the names of the steps the TaskRun pauses before running
+	// +optional
+	// +listType=atomic
+	BeforeSteps []string `json:"beforeSteps,omitempty"`
+	// AfterSteps are the names of the steps the TaskRun pauses after running
+	// +optional
+	// +listType=atomic
+	AfterSteps []string `json:"afterSteps,omitempty"`
+}
+
+// HasBreakpoints returns true if the TaskRun pauses at any breakpoint
+func (d *TaskRunDebug) HasBreakpoints() bool {
+	return d != nil && (len(d.Breakpoint) > 0 || len(d.BeforeSteps) > 0 || len(d.AfterSteps) > 0)
 }
 
 // TaskRunInputs holds the input values that this task was invoked with.
@@ -115,10 +189,24 @@ const (
 	TaskRunReasonSuccessful TaskRunReason = "Succeeded"
 	// TaskRunReasonFailed is the reason set when the TaskRun completed with a failure
 	TaskRunReasonFailed TaskRunReason = "Failed"
+	// TaskRunReasonPaused is the reason set when a step of the TaskRun is paused at a breakpoint
+	TaskRunReasonPaused TaskRunReason = "Paused"
+	// TaskRunReasonSidecarFailed is the reason set when a sidecar of the TaskRun terminated
+	// abnormally while the steps were still running
+	TaskRunReasonSidecarFailed TaskRunReason = "SidecarFailed"
 	// TaskRunReasonCancelled is the reason set when the Taskrun is cancelled by the user
 	TaskRunReasonCancelled TaskRunReason = "TaskRunCancelled"
 	// TaskRunReasonTimedOut is the reason set when the Taskrun has timed out
 	TaskRunReasonTimedOut TaskRunReason = "TaskRunTimeout"
+	// TaskRunReasonResultLargerThanAllowedLimit is the reason set when one of the results
+	// read from the sidecar logs is larger than the "max-result-size" feature flag
+	TaskRunReasonResultLargerThanAllowedLimit TaskRunReason = "TaskRunResultLargerThanAllowedLimit"
+	// TaskRunReasonImagePullFailed is the reason set when the image of a step or sidecar
+	// couldn't be pulled for longer than the "default-pending-timeout-minutes" default
+	TaskRunReasonImagePullFailed TaskRunReason = "TaskRunImagePullFailed"
+	// TaskRunReasonExceededNodeResources is the reason set when the Pod of the TaskRun didn't
+	// fit on any node for longer than the "default-pending-timeout-minutes" default
+	TaskRunReasonExceededNodeResources TaskRunReason = "ExceededNodeResources"
 )
 
 func (t TaskRunReason) String() string {
@@ -225,8 +313,13 @@ type TaskRunResult struct {
 	// Name the given name
 	Name string `json:"name"`
 
+	// Type is the user-specified type of the result. The possible types
+	// are "string", "array" and "object", and "string" is the default.
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
 	// Value the given value of the result
-	Value string `json:"value"`
+	Value ArrayOrString `json:"value"`
 }
 
 // GetGroupVersionKind implements kmeta.OwnerRefable.
@@ -270,12 +363,24 @@ func (trs *TaskRunStatus) SetCondition(newCond *apis.Condition) {
 	}
 }
 
+// StepSkippedReason is the reason of the terminated state of a Step which wasn't run
+// because its when expressions evaluated to false.
+const StepSkippedReason = "Skipped"
+
 // StepState reports the results of running a step in a Task.
 type StepState struct {
 	corev1.ContainerState `json:",inline"`
 	Name                  string `json:"name,omitempty"`
 	ContainerName         string `json:"container,omitempty"`
 	ImageID               string `json:"imageID,omitempty"`
+	// AttemptExitCodes are the exit codes of the attempts of a Step with retries
+	// which was run more than once, in order
+	// +optional
+	AttemptExitCodes []int32 `json:"attemptExitCodes,omitempty"`
+	// Progress is the latest progress message the Step printed while it was running,
+	// when the structured step logs are enabled
+	// +optional
+	Progress string `json:"progress,omitempty"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -43,15 +43,20 @@ func (tr *TaskRun) Validate(ctx context.Context) *apis.FieldError {
 func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	cfg := config.FromContextOrDefaults(ctx)
 	// can't have both taskRef and taskSpec at the same time
-	if (ts.TaskRef != nil && ts.TaskRef.Name != "") && ts.TaskSpec != nil {
+	if (ts.TaskRef != nil && (ts.TaskRef.Name != "" || ts.TaskRef.Resolver != "")) && ts.TaskSpec != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("taskref", "taskspec"))
 	}
 
 	// Check that one of TaskRef and TaskSpec is present
-	if (ts.TaskRef == nil || (ts.TaskRef != nil && ts.TaskRef.Name == "")) && ts.TaskSpec == nil {
+	if (ts.TaskRef == nil || (ts.TaskRef != nil && ts.TaskRef.Name == "" && ts.TaskRef.Resolver == "")) && ts.TaskSpec == nil {
 		errs = errs.Also(apis.ErrMissingField("taskref.name", "taskspec"))
 	}
 
+	// Validate the resolver of the TaskRef if it's present
+	if ts.TaskRef != nil {
+		errs = errs.Also(validateResolverRef(ctx, ts.TaskRef.ResolverRef, ts.TaskRef.Name, ts.TaskRef.Bundle).ViaField("taskref"))
+	}
+
 	// If EnableTektonOCIBundles feature flag is on validate it.
 	// Otherwise, fail if it is present (as it won't be allowed nor used)
 	if cfg.FeatureFlags.EnableTektonOCIBundles {
@@ -97,7 +102,53 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", ts.Timeout.Duration.String()), "timeout"))
 		}
 	}
+	if ts.StepTemplate != nil {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "stepTemplate", config.AlphaAPIFields))
+	}
+	errs = errs.Also(validateOverrides(ctx, ts.StepOverrides, ts.SidecarOverrides))
+	if ts.ComputeResources != nil {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "computeResources", config.AlphaAPIFields))
+		if len(ts.StepOverrides) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("stepOverrides", "computeResources"))
+		}
+	}
+
+	return errs
+}
 
+// validateOverrides makes sure the step and sidecar overrides name distinct Steps and Sidecars.
+func validateOverrides(ctx context.Context, stepOverrides []TaskRunStepOverride, sidecarOverrides []TaskRunSidecarOverride) (errs *apis.FieldError) {
+	if len(stepOverrides) > 0 {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "stepOverrides", config.AlphaAPIFields))
+		var names []string
+		for _, o := range stepOverrides {
+			names = append(names, o.Name)
+		}
+		errs = errs.Also(validateOverrideNames(names).ViaField("stepOverrides"))
+	}
+	if len(sidecarOverrides) > 0 {
+		errs = errs.Also(ValidateEnabledAPIFields(ctx, "sidecarOverrides", config.AlphaAPIFields))
+		var names []string
+		for _, o := range sidecarOverrides {
+			names = append(names, o.Name)
+		}
+		errs = errs.Also(validateOverrideNames(names).ViaField("sidecarOverrides"))
+	}
+	return errs
+}
+
+func validateOverrideNames(names []string) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for idx, name := range names {
+		if name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(idx))
+			continue
+		}
+		if seen.Has(name) {
+			errs = errs.Also(apis.ErrMultipleOneOf("name").ViaIndex(idx))
+		}
+		seen.Insert(name)
+	}
 	return errs
 }
 
@@ -112,6 +163,24 @@ func validateDebug(db *TaskRunDebug) (errs *apis.FieldError) {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s is not a valid breakpoint. Available valid breakpoints include %s", b, validBreakpoints.List()), "breakpoint"))
 		}
 	}
+	errs = errs.Also(validateBreakpointSteps(db.BeforeSteps).ViaField("beforeSteps"))
+	errs = errs.Also(validateBreakpointSteps(db.AfterSteps).ViaField("afterSteps"))
+	return errs
+}
+
+// validateBreakpointSteps ensures the names of the steps to pause at are set and unique.
+// The Task isn't known yet, so the steps are looked up once the TaskRun is reconciled.
+func validateBreakpointSteps(steps []string) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for i, s := range steps {
+		if s == "" {
+			errs = errs.Also(apis.ErrInvalidValue("the name of a step can't be empty", "").ViaIndex(i))
+		}
+		if seen.Has(s) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("step %s is listed more than once", s), "").ViaIndex(i))
+		}
+		seen.Insert(s)
+	}
 	return errs
 }
 
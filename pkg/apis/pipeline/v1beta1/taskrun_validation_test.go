@@ -26,6 +26,7 @@ import (
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -262,6 +263,115 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wantErr: apis.ErrInvalidValue("breakito is not a valid breakpoint. Available valid breakpoints include [onFailure]", "debug.breakpoint"),
 		wc:      enableAlphaAPIFields,
+	}, {
+		name: "invalid breakpoint steps",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+			},
+			Debug: &v1beta1.TaskRunDebug{
+				BeforeSteps: []string{"build", ""},
+				AfterSteps:  []string{"test", "test"},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("the name of a step can't be empty", "debug.beforeSteps[1]").Also(
+			apis.ErrInvalidValue("step test is listed more than once", "debug.afterSteps[1]")),
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "taskref resolver when apifields stable",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				ResolverRef: v1beta1.ResolverRef{Resolver: "git"},
+			},
+		},
+		wantErr: apis.ErrGeneric("resolver requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaField("taskref.resolver"),
+	}, {
+		name: "taskref resolver with a name",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name:        "my-task",
+				ResolverRef: v1beta1.ResolverRef{Resolver: "git"},
+			},
+		},
+		wantErr: apis.ErrMultipleOneOf("taskref.name", "taskref.resolver"),
+		wc:      enableAlphaAPIFields,
+	}, {
+		name: "taskref params without a resolver",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+				ResolverRef: v1beta1.ResolverRef{
+					Params: []v1beta1.Param{{Name: "path", Value: *v1beta1.NewArrayOrString("task.yaml")}},
+				},
+			},
+		},
+		wantErr: apis.ErrMissingField("taskref.resolver"),
+		wc:      enableAlphaAPIFields,
+	}, {
+		name: "taskref resolver with duplicate params",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				ResolverRef: v1beta1.ResolverRef{
+					Resolver: "git",
+					Params: []v1beta1.Param{
+						{Name: "path", Value: *v1beta1.NewArrayOrString("task.yaml")},
+						{Name: "path", Value: *v1beta1.NewArrayOrString("other.yaml")},
+					},
+				},
+			},
+		},
+		wantErr: apis.ErrMultipleOneOf("taskref.params[path].name"),
+		wc:      enableAlphaAPIFields,
+	}, {
+		name: "stepOverrides when apifields stable",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:       &v1beta1.TaskRef{Name: "task"},
+			StepOverrides: []v1beta1.TaskRunStepOverride{{Name: "build"}},
+		},
+		wantErr: apis.ErrGeneric(`stepOverrides requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "sidecarOverrides when apifields stable",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:          &v1beta1.TaskRef{Name: "task"},
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{Name: "cache"}},
+		},
+		wantErr: apis.ErrGeneric(`sidecarOverrides requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "stepOverrides without a name",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:       &v1beta1.TaskRef{Name: "task"},
+			StepOverrides: []v1beta1.TaskRunStepOverride{{}},
+		},
+		wantErr: apis.ErrMissingField("stepOverrides[0].name"),
+		wc:      enableAlphaAPIFields,
+	}, {
+		name: "duplicate sidecarOverrides",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:          &v1beta1.TaskRef{Name: "task"},
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{Name: "cache"}, {Name: "cache"}},
+		},
+		wantErr: apis.ErrMultipleOneOf("sidecarOverrides[1].name"),
+		wc:      enableAlphaAPIFields,
+	}, {
+		name: "computeResources when apifields stable",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("2")},
+			},
+		},
+		wantErr: apis.ErrGeneric(`computeResources requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "computeResources with stepOverrides",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:       &v1beta1.TaskRef{Name: "task"},
+			StepOverrides: []v1beta1.TaskRunStepOverride{{Name: "build"}},
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("2")},
+			},
+		},
+		wantErr: apis.ErrMultipleOneOf("stepOverrides", "computeResources"),
+		wc:      enableAlphaAPIFields,
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -28,11 +28,17 @@ import (
 // to the wantVersion value and, if not, returns an error stating which feature
 // is dependent on the version and what the current version actually is.
 func ValidateEnabledAPIFields(ctx context.Context, featureName, wantVersion string) *apis.FieldError {
+	return validateEnabledAPIFields(ctx, featureName, wantVersion)
+}
+
+// validateEnabledAPIFields is ValidateEnabledAPIFields with the paths of the returned error, which
+// ViaField and the like need to locate it in the resource.
+func validateEnabledAPIFields(ctx context.Context, featureName, wantVersion string, paths ...string) *apis.FieldError {
 	currentVersion := config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields
 	if currentVersion != wantVersion {
 		var errs *apis.FieldError
 		message := fmt.Sprintf(`%s requires "enable-api-fields" feature gate to be %q but it is %q`, featureName, wantVersion, currentVersion)
-		return errs.Also(apis.ErrGeneric(message))
+		return errs.Also(apis.ErrGeneric(message, paths...))
 	}
 	return nil
 }
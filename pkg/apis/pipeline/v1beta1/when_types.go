@@ -18,7 +18,12 @@ package v1beta1
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"k8s.io/apimachinery/pkg/selection"
 )
@@ -27,16 +32,46 @@ import (
 // to determine whether the Task should be executed or skipped
 type WhenExpression struct {
 	// Input is the string for guard checking which can be a static input or an output from a parent Task
-	Input string `json:"input"`
+	// +optional
+	Input string `json:"input,omitempty"`
 
 	// Operator that represents an Input's relationship to the values
-	Operator selection.Operator `json:"operator"`
+	// +optional
+	Operator selection.Operator `json:"operator,omitempty"`
 
 	// Values is an array of strings, which is compared against the input, for guard checking
 	// It must be non-empty
-	Values []string `json:"values"`
+	// +optional
+	Values []string `json:"values,omitempty"`
+
+	// CEL is a Common Expression Language expression which must evaluate to true for the guarded
+	// Task to run. The variables it references, such as parameters and results, are string variables
+	// of the expression. It can't be used along with Input, Operator and Values.
+	// +optional
+	CEL string `json:"cel,omitempty"`
+
+	// CELVariables holds the values of the variables referenced by the CEL expression once they are
+	// substituted, keyed by their name, e.g. "params.branch". It is set by Tekton and never
+	// serialized, so that the values can't be set by authors.
+	CELVariables map[string]string `json:"-"`
 }
 
+// celVariablesName is the name of the variable of the CEL environment holding the values of
+// the variables referenced by the CEL expressions of when expressions.
+const celVariablesName = "tektonVariables"
+
+// celProgramCacheSize is the number of compiled CEL expressions kept in celPrograms.
+const celProgramCacheSize = 1024
+
+// celEnv is the CEL environment in which the CEL expressions of when expressions are compiled.
+var celEnv, celEnvErr = cel.NewEnv(cel.Declarations(
+	decls.NewVar(celVariablesName, decls.NewMapType(decls.String, decls.String)),
+))
+
+// celPrograms caches the programs compiled from the CEL expressions, keyed by expression, since
+// the same expressions are evaluated again on every reconcile.
+var celPrograms, _ = lru.New(celProgramCacheSize)
+
 func (we *WhenExpression) isInputInValues() bool {
 	for i := range we.Values {
 		if we.Values[i] == we.Input {
@@ -47,6 +82,12 @@ func (we *WhenExpression) isInputInValues() bool {
 }
 
 func (we *WhenExpression) isTrue() bool {
+	if we.CEL != "" {
+		// An expression that can't be evaluated, e.g. because a variable it converts to a number
+		// isn't one, doesn't allow the execution of the guarded Task.
+		allowed, err := evaluateCEL(we.CEL, we.CELVariables)
+		return err == nil && allowed
+	}
 	if we.Operator == selection.In {
 		return we.isInputInValues()
 	}
@@ -59,17 +100,79 @@ func (we *WhenExpression) applyReplacements(replacements map[string]string, arra
 
 	var replacedValues []string
 	for _, val := range we.Values {
-		// arrayReplacements holds a list of array parameters with a pattern - params.arrayParam1
-		// array params are referenced using $(params.arrayParam1[*])
-		// check if the param exist in the arrayReplacements to replace it with a list of values
-		if _, ok := arrayReplacements[fmt.Sprintf("%s.%s", ParamsPrefix, ArrayReference(val))]; ok {
+		// arrayReplacements holds a list of array parameters and results with a pattern - params.arrayParam1
+		// array params and results are referenced using $(params.arrayParam1[*]) or $(tasks.task1.results.arrayResult1[*])
+		// check if the param or result exist in the arrayReplacements to replace it with a list of values
+		if _, ok := arrayReplacements[strings.TrimSuffix(stripVarSubExpression(val), "[*]")]; ok {
 			replacedValues = append(replacedValues, substitution.ApplyArrayReplacements(val, replacements, arrayReplacements)...)
 		} else {
 			replacedValues = append(replacedValues, substitution.ApplyReplacements(val, replacements))
 		}
 	}
 
-	return WhenExpression{Input: replacedInput, Operator: we.Operator, Values: replacedValues}
+	return WhenExpression{Input: replacedInput, Operator: we.Operator, Values: replacedValues, CEL: we.CEL, CELVariables: we.replaceCELVariables(replacements)}
+}
+
+// replaceCELVariables returns the values of the variables of the CEL expression, along with the
+// ones found in the replacements. The values aren't pasted in the expression, so that they can't
+// change it.
+func (we *WhenExpression) replaceCELVariables(replacements map[string]string) map[string]string {
+	variables := we.CELVariables
+	copied := false
+	for _, name := range validateString(we.CEL) {
+		value, ok := replacements[name]
+		if !ok {
+			continue
+		}
+		if !copied {
+			variables = make(map[string]string, len(we.CELVariables)+1)
+			for k, v := range we.CELVariables {
+				variables[k] = v
+			}
+			copied = true
+		}
+		variables[name] = value
+	}
+	return variables
+}
+
+// compileCEL compiles the CEL expression, in which each variable is looked up in the values
+// of the variables of the CEL environment.
+func compileCEL(expression string) (cel.Program, error) {
+	if celEnvErr != nil {
+		return nil, celEnvErr
+	}
+	ast, issues := celEnv.Compile(variableSubstitutionRegex.ReplaceAllStringFunc(expression, func(variable string) string {
+		return fmt.Sprintf("%s[%s]", celVariablesName, strconv.Quote(stripVarSubExpression(variable)))
+	}))
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	return celEnv.Program(ast)
+}
+
+// evaluateCEL evaluates the CEL expression, which must be a boolean expression, with the given
+// values of its variables. The expression is only compiled the first time it is evaluated.
+func evaluateCEL(expression string, variables map[string]string) (bool, error) {
+	var prg cel.Program
+	if cached, ok := celPrograms.Get(expression); ok {
+		prg = cached.(cel.Program)
+	} else {
+		var err error
+		if prg, err = compileCEL(expression); err != nil {
+			return false, err
+		}
+		celPrograms.Add(expression, prg)
+	}
+	out, _, err := prg.Eval(map[string]interface{}{celVariablesName: variables})
+	if err != nil {
+		return false, err
+	}
+	allowed, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q evaluated to %v instead of a bool", expression, out.Value())
+	}
+	return allowed, nil
 }
 
 // GetVarSubstitutionExpressions extracts all the values between "$(" and ")" in a When Expression
@@ -79,6 +182,7 @@ func (we *WhenExpression) GetVarSubstitutionExpressions() ([]string, bool) {
 	for _, value := range we.Values {
 		allExpressions = append(allExpressions, validateString(value)...)
 	}
+	allExpressions = append(allExpressions, validateString(we.CEL)...)
 	return allExpressions, len(allExpressions) != 0
 }
 
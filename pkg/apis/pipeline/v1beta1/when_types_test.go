@@ -77,6 +77,65 @@ func TestAllowsExecution(t *testing.T) {
 			},
 		},
 		expected: true,
+	}, {
+		name: "cel expression - true",
+		whenExpressions: WhenExpressions{
+			{CEL: "85 >= 80 && 'release-v1'.matches('release-.*')"},
+		},
+		expected: true,
+	}, {
+		name: "cel expression - false",
+		whenExpressions: WhenExpressions{
+			{CEL: "75.5 >= 80.0"},
+		},
+		expected: false,
+	}, {
+		name: "cel expression along with in expression - false",
+		whenExpressions: WhenExpressions{
+			{
+				Input:    "foo",
+				Operator: selection.In,
+				Values:   []string{"foo"},
+			}, {
+				CEL: "'main' != 'main'",
+			},
+		},
+		expected: false,
+	}, {
+		name: "cel expression not evaluating to a bool",
+		whenExpressions: WhenExpressions{
+			{CEL: "'foo' + 'bar'"},
+		},
+		expected: false,
+	}, {
+		name: "cel expression failing to evaluate",
+		whenExpressions: WhenExpressions{
+			{CEL: "'85' >= 80"},
+		},
+		expected: false,
+	}, {
+		name: "cel expression with variables - true",
+		whenExpressions: WhenExpressions{{
+			CEL: "int($(tasks.test.results.coverage)) >= 80 && $(params.branch).matches('release-.*')",
+			CELVariables: map[string]string{
+				"tasks.test.results.coverage": "85",
+				"params.branch":               "release-v1",
+			},
+		}},
+		expected: true,
+	}, {
+		name: "cel expression with a variable holding an expression - false",
+		whenExpressions: WhenExpressions{{
+			CEL:          "$(params.branch) == 'main'",
+			CELVariables: map[string]string{"params.branch": "dev' || 'a' == 'a"},
+		}},
+		expected: false,
+	}, {
+		name: "cel expression with a variable without value - false",
+		whenExpressions: WhenExpressions{{
+			CEL: "$(params.branch) != 'main'",
+		}},
+		expected: false,
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -90,10 +149,11 @@ func TestAllowsExecution(t *testing.T) {
 
 func TestReplaceWhenExpressionsVariables(t *testing.T) {
 	tests := []struct {
-		name            string
-		whenExpressions WhenExpressions
-		replacements    map[string]string
-		expected        WhenExpressions
+		name              string
+		whenExpressions   WhenExpressions
+		replacements      map[string]string
+		arrayReplacements map[string][]string
+		expected          WhenExpressions
 	}{{
 		name: "params replacement in input",
 		whenExpressions: WhenExpressions{
@@ -170,6 +230,44 @@ func TestReplaceWhenExpressionsVariables(t *testing.T) {
 				Values:   []string{"bar"},
 			},
 		},
+	}, {
+		name: "array results replacement in values",
+		whenExpressions: WhenExpressions{
+			{
+				Input:    "bar",
+				Operator: selection.In,
+				Values:   []string{"$(tasks.aTask.results.foo[*])"},
+			},
+		},
+		arrayReplacements: map[string][]string{
+			"tasks.aTask.results.foo": {"foo", "bar"},
+		},
+		expected: WhenExpressions{
+			{
+				Input:    "bar",
+				Operator: selection.In,
+				Values:   []string{"foo", "bar"},
+			},
+		},
+	}, {
+		name: "object result key replacement in input",
+		whenExpressions: WhenExpressions{
+			{
+				Input:    "$(tasks.aTask.results.foo.key)",
+				Operator: selection.In,
+				Values:   []string{"bar"},
+			},
+		},
+		replacements: map[string]string{
+			"tasks.aTask.results.foo.key": "bar",
+		},
+		expected: WhenExpressions{
+			{
+				Input:    "bar",
+				Operator: selection.In,
+				Values:   []string{"bar"},
+			},
+		},
 	}, {
 		name: "replacements in multiple when expressions",
 		whenExpressions: WhenExpressions{
@@ -201,7 +299,7 @@ func TestReplaceWhenExpressionsVariables(t *testing.T) {
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := tc.whenExpressions.ReplaceWhenExpressionsVariables(tc.replacements, nil)
+			got := tc.whenExpressions.ReplaceWhenExpressionsVariables(tc.replacements, tc.arrayReplacements)
 			if d := cmp.Diff(tc.expected, got); d != "" {
 				t.Errorf("Error evaluating When Expressions in test case %s", diff.PrintWantGot(d))
 			}
@@ -286,6 +384,40 @@ func TestApplyReplacements(t *testing.T) {
 			Operator: selection.In,
 			Values:   []string{"dev", "stage", "foo.txt", "readme.md", "test.go"},
 		},
+	}, {
+		name: "replace variables in cel",
+		original: &WhenExpression{
+			CEL: "int($(tasks.test.results.coverage)) >= int($(params.coverage)) && $(params.branch).matches('release-.*')",
+		},
+		replacements: map[string]string{
+			"tasks.test.results.coverage": "85",
+			"params.coverage":             "80",
+			"params.branch":               "release-v1",
+		},
+		expected: &WhenExpression{
+			CEL: "int($(tasks.test.results.coverage)) >= int($(params.coverage)) && $(params.branch).matches('release-.*')",
+			CELVariables: map[string]string{
+				"tasks.test.results.coverage": "85",
+				"params.coverage":             "80",
+				"params.branch":               "release-v1",
+			},
+		},
+	}, {
+		name: "replace variables in cel keeping the substituted ones",
+		original: &WhenExpression{
+			CEL:          "int($(tasks.test.results.coverage)) >= 80 && $(params.branch).matches('release-.*')",
+			CELVariables: map[string]string{"params.branch": "release-v1"},
+		},
+		replacements: map[string]string{
+			"tasks.test.results.coverage": "85",
+		},
+		expected: &WhenExpression{
+			CEL: "int($(tasks.test.results.coverage)) >= 80 && $(params.branch).matches('release-.*')",
+			CELVariables: map[string]string{
+				"tasks.test.results.coverage": "85",
+				"params.branch":               "release-v1",
+			},
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -296,3 +428,14 @@ func TestApplyReplacements(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVarSubstitutionExpressions_CEL(t *testing.T) {
+	we := WhenExpression{CEL: "$(tasks.test.results.coverage) >= $(params.coverage)"}
+	got, ok := we.GetVarSubstitutionExpressions()
+	if !ok {
+		t.Fatalf("Expected the cel expression to contain variables")
+	}
+	if d := cmp.Diff([]string{"tasks.test.results.coverage", "params.coverage"}, got); d != "" {
+		t.Errorf("Unexpected variables of the cel expression %s", diff.PrintWantGot(d))
+	}
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -31,22 +33,31 @@ var validWhenOperators = []string{
 	string(selection.NotIn),
 }
 
-func (wes WhenExpressions) validate() *apis.FieldError {
-	errs := wes.validateWhenExpressionsFields().ViaField("when")
+func (wes WhenExpressions) validate(ctx context.Context) *apis.FieldError {
+	errs := wes.validateWhenExpressionsFields(ctx).ViaField("when")
 	return errs.Also(wes.validateTaskResultsVariables().ViaField("when"))
 }
 
-func (wes WhenExpressions) validateWhenExpressionsFields() (errs *apis.FieldError) {
+func (wes WhenExpressions) validateWhenExpressionsFields(ctx context.Context) (errs *apis.FieldError) {
 	for idx, we := range wes {
-		errs = errs.Also(we.validateWhenExpressionFields().ViaIndex(idx))
+		errs = errs.Also(we.validateWhenExpressionFields(ctx).ViaIndex(idx))
 	}
 	return errs
 }
 
-func (we *WhenExpression) validateWhenExpressionFields() *apis.FieldError {
+func (we *WhenExpression) validateWhenExpressionFields(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(we, &WhenExpression{}) || we == nil {
 		return apis.ErrMissingField(apis.CurrentField)
 	}
+	if we.CEL != "" {
+		if err := ValidateEnabledAPIFields(ctx, "when expressions using cel", config.AlphaAPIFields); err != nil {
+			return err
+		}
+		if we.Input != "" || we.Operator != "" || len(we.Values) != 0 {
+			return apis.ErrMultipleOneOf("cel", "input", "operator", "values")
+		}
+		return validateCEL(we.CEL).ViaField("cel")
+	}
 	if !sets.NewString(validWhenOperators...).Has(string(we.Operator)) {
 		message := fmt.Sprintf("operator %q is not recognized. valid operators: %s", we.Operator, strings.Join(validWhenOperators, ","))
 		return apis.ErrInvalidValue(message, apis.CurrentField)
@@ -57,6 +68,41 @@ func (we *WhenExpression) validateWhenExpressionFields() *apis.FieldError {
 	return nil
 }
 
+// validateCEL compiles the CEL expression, whose variables are string variables of the
+// expression, and can't be quoted in its string literals.
+func validateCEL(expression string) *apis.FieldError {
+	if quotedCELVariable(expression) {
+		return apis.ErrInvalidValue(fmt.Sprintf("%q quotes a variable, which is a string variable of the expression and can't be part of a string literal", expression), apis.CurrentField)
+	}
+	if _, err := compileCEL(expression); err != nil {
+		return apis.ErrInvalidValue(fmt.Sprintf("%q is not a valid CEL expression: %v", expression, err), apis.CurrentField)
+	}
+	return nil
+}
+
+// quotedCELVariable returns true if a variable is referenced in a string literal of the
+// CEL expression.
+func quotedCELVariable(expression string) bool {
+	var quote byte
+	for i := 0; i < len(expression); i++ {
+		switch c := expression[i]; {
+		case quote == 0:
+			if c == '\'' || c == '"' {
+				quote = c
+			}
+		case c == '\\':
+			i++
+		case c == quote:
+			quote = 0
+		default:
+			if loc := variableSubstitutionRegex.FindStringIndex(expression[i:]); loc != nil && loc[0] == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (wes WhenExpressions) validateTaskResultsVariables() *apis.FieldError {
 	for idx, we := range wes {
 		expressions, ok := we.GetVarSubstitutionExpressions()
@@ -77,6 +123,7 @@ func (wes WhenExpressions) validateTaskResultsVariables() *apis.FieldError {
 func (wes WhenExpressions) validatePipelineParametersVariables(prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for idx, we := range wes {
 		errs = errs.Also(validateStringVariable(we.Input, prefix, paramNames, arrayParamNames).ViaField("input").ViaFieldIndex("when", idx))
+		errs = errs.Also(validateStringVariable(we.CEL, prefix, paramNames, arrayParamNames).ViaField("cel").ViaFieldIndex("when", idx))
 		for _, val := range we.Values {
 			// one of the values could be a reference to an array param, such as, $(params.foo[*])
 			// extract the variable name from the pattern $(params.foo[*]), if the variable name matches with one of the array params
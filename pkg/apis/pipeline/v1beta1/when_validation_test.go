@@ -17,9 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
 	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
 )
 
 func TestWhenExpressions_Valid(t *testing.T) {
@@ -54,7 +58,7 @@ func TestWhenExpressions_Valid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.wes.validate(); err != nil {
+			if err := tt.wes.validate(context.Background()); err != nil {
 				t.Errorf("WhenExpressions.validate() returned an error for valid when expressions: %s", tt.wes)
 			}
 		})
@@ -104,9 +108,91 @@ func TestWhenExpressions_Invalid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.wes.validate(); err == nil {
+			if err := tt.wes.validate(context.Background()); err == nil {
 				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %s, %s", tt.wes, err)
 			}
 		})
 	}
 }
+
+func TestWhenExpressions_CEL(t *testing.T) {
+	tests := []struct {
+		name     string
+		wes      WhenExpressions
+		wc       func(context.Context) context.Context
+		wantErrs *apis.FieldError
+	}{{
+		name: "valid cel expression",
+		wes: []WhenExpression{{
+			CEL: "int($(tasks.test.results.coverage)) >= 80 && $(params.branch).matches('release-.*')",
+		}},
+		wc: enableAlphaAPIFields,
+	}, {
+		name: "quoted variable in cel",
+		wes: []WhenExpression{{
+			CEL: "'$(params.branch)'.matches('release-.*')",
+		}},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrInvalidValue(`"'$(params.branch)'.matches('release-.*')" quotes a variable, which is a string variable of the expression and can't be part of a string literal`, "when[0].cel"),
+	}, {
+		name: "variable compared to a number in cel",
+		wes: []WhenExpression{{
+			CEL: "$(params.coverage) >= 80",
+		}},
+		wc: enableAlphaAPIFields,
+		wantErrs: &apis.FieldError{
+			Message: `invalid value: "$(params.coverage) >= 80" is not a valid CEL expression: ERROR: <input>:1:36: found no matching overload for '_>=_' applied to '(string, int)'
+ | tektonVariables["params.coverage"] >= 80
+ | ...................................^`,
+			Paths: []string{"when[0].cel"},
+		},
+	}, {
+		name: "cel expression without alpha feature gate",
+		wes: []WhenExpression{{
+			CEL: "$(params.coverage) >= 80",
+		}},
+		wantErrs: apis.ErrGeneric(`when expressions using cel requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "cel expression along with input",
+		wes: []WhenExpression{{
+			Input: "foo",
+			CEL:   "'foo' == 'foo'",
+		}},
+		wc:       enableAlphaAPIFields,
+		wantErrs: apis.ErrMultipleOneOf("when[0].cel", "when[0].input", "when[0].operator", "when[0].values"),
+	}, {
+		name: "invalid cel syntax",
+		wes: []WhenExpression{{
+			CEL: "$(params.coverage) >=",
+		}},
+		wc: enableAlphaAPIFields,
+		wantErrs: &apis.FieldError{
+			Message: `invalid value: "$(params.coverage) >=" is not a valid CEL expression: ERROR: <input>:1:38: Syntax error: mismatched input '<EOF>' expecting {'[', '{', '(', '.', '-', '!', 'true', 'false', 'null', NUM_FLOAT, NUM_INT, NUM_UINT, STRING, BYTES, IDENTIFIER}
+ | tektonVariables["params.coverage"] >=
+ | .....................................^`,
+			Paths: []string{"when[0].cel"},
+		},
+	}, {
+		name: "invalid result reference in cel",
+		wes: []WhenExpression{{
+			CEL: "$(tasks.a-task.resultsTypo.output) == 'foo'",
+		}},
+		wc: enableAlphaAPIFields,
+		wantErrs: &apis.FieldError{
+			Message: `invalid value: expected all of the expressions [tasks.a-task.resultsTypo.output] to be result expressions but only [] were`,
+			Paths:   []string{"when[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			err := tt.wes.validate(ctx)
+			if d := cmp.Diff(tt.wantErrs.Error(), err.Error()); d != "" {
+				t.Errorf("WhenExpressions.validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
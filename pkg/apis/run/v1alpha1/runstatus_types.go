@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -67,8 +68,85 @@ type RunStatusFields struct {
 type RunResult struct {
 	// Name the given name
 	Name string `json:"name"`
-	// Value the given value of the result
-	Value string `json:"value"`
+	// Value the given value of the result, a string, an array of strings or
+	// an object of strings
+	Value RunResultValue `json:"value"`
+}
+
+// RunResultType indicates the type of the value of a Run result.
+type RunResultType string
+
+// Valid RunResultTypes:
+const (
+	RunResultTypeString RunResultType = "string"
+	RunResultTypeArray  RunResultType = "array"
+	RunResultTypeObject RunResultType = "object"
+)
+
+// RunResultValue is modeled after ArrayOrString in v1beta1, which can't be
+// used here without an import cycle.
+
+// RunResultValue is a type that can hold a single string, a string array or a
+// string map. Used in JSON unmarshalling so that the value of a Run result can
+// be either an individual string, an array of strings or an object of strings.
+type RunResultValue struct {
+	Type      RunResultType     `json:"type"` // Represents the stored type of RunResultValue.
+	StringVal string            `json:"stringVal"`
+	ArrayVal  []string          `json:"arrayVal"`
+	ObjectVal map[string]string `json:"objectVal"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (value *RunResultValue) UnmarshalJSON(data []byte) error {
+	switch data[0] {
+	case '"':
+		value.Type = RunResultTypeString
+		return json.Unmarshal(data, &value.StringVal)
+	case '{':
+		value.Type = RunResultTypeObject
+		return json.Unmarshal(data, &value.ObjectVal)
+	}
+	value.Type = RunResultTypeArray
+	return json.Unmarshal(data, &value.ArrayVal)
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (value RunResultValue) MarshalJSON() ([]byte, error) {
+	switch value.Type {
+	case RunResultTypeString:
+		return json.Marshal(value.StringVal)
+	case RunResultTypeArray:
+		return json.Marshal(value.ArrayVal)
+	case RunResultTypeObject:
+		return json.Marshal(value.ObjectVal)
+	default:
+		return []byte{}, fmt.Errorf("impossible RunResultValue.Type: %q", value.Type)
+	}
+}
+
+// NewRunResultValue creates a RunResultValue of type RunResultTypeString or
+// RunResultTypeArray, based on how many inputs are given (>1 input will create
+// an array, not string).
+func NewRunResultValue(value string, values ...string) *RunResultValue {
+	if len(values) > 0 {
+		return &RunResultValue{
+			Type:     RunResultTypeArray,
+			ArrayVal: append([]string{value}, values...),
+		}
+	}
+	return &RunResultValue{
+		Type:      RunResultTypeString,
+		StringVal: value,
+	}
+}
+
+// NewRunResultObject creates a RunResultValue of type RunResultTypeObject
+// holding the given keys and values.
+func NewRunResultObject(values map[string]string) *RunResultValue {
+	return &RunResultValue{
+		Type:      RunResultTypeObject,
+		ObjectVal: values,
+	}
 }
 
 var runCondSet = apis.NewBatchConditionSet()
@@ -52,7 +52,9 @@ func (in *RunStatusFields) DeepCopyInto(out *RunStatusFields) {
 	if in.Results != nil {
 		in, out := &in.Results, &out.Results
 		*out = make([]RunResult, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.ExtraFields.DeepCopyInto(&out.ExtraFields)
 	return
@@ -67,3 +69,48 @@ func (in *RunStatusFields) DeepCopy() *RunStatusFields {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunResult) DeepCopyInto(out *RunResult) {
+	*out = *in
+	in.Value.DeepCopyInto(&out.Value)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunResult.
+func (in *RunResult) DeepCopy() *RunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunResultValue) DeepCopyInto(out *RunResultValue) {
+	*out = *in
+	if in.ArrayVal != nil {
+		in, out := &in.ArrayVal, &out.ArrayVal
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ObjectVal != nil {
+		in, out := &in.ObjectVal, &out.ObjectVal
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunResultValue.
+func (in *RunResultValue) DeepCopy() *RunResultValue {
+	if in == nil {
+		return nil
+	}
+	out := new(RunResultValue)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -26,8 +26,12 @@ type PipelineExpansion interface{}
 
 type PipelineRunExpansion interface{}
 
+type ResolutionRequestExpansion interface{}
+
 type RunExpansion interface{}
 
+type StepActionExpansion interface{}
+
 type TaskExpansion interface{}
 
 type TaskRunExpansion interface{}
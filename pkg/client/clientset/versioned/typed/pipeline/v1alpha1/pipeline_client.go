@@ -30,7 +30,9 @@ type TektonV1alpha1Interface interface {
 	ConditionsGetter
 	PipelinesGetter
 	PipelineRunsGetter
+	ResolutionRequestsGetter
 	RunsGetter
+	StepActionsGetter
 	TasksGetter
 	TaskRunsGetter
 }
@@ -56,10 +58,18 @@ func (c *TektonV1alpha1Client) PipelineRuns(namespace string) PipelineRunInterfa
 	return newPipelineRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) ResolutionRequests(namespace string) ResolutionRequestInterface {
+	return newResolutionRequests(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Runs(namespace string) RunInterface {
 	return newRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) StepActions(namespace string) StepActionInterface {
+	return newStepActions(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Tasks(namespace string) TaskInterface {
 	return newTasks(c, namespace)
 }
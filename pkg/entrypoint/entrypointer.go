@@ -18,6 +18,7 @@ package entrypoint
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -42,6 +43,32 @@ const (
 	FailOnError     = "stopAndFail"
 )
 
+const (
+	// BreakpointExitSuffix is the suffix of the post file of a step paused at a breakpoint, to which
+	// the debug-continue and debug-fail-continue scripts write the exit code of the step
+	BreakpointExitSuffix = ".breakpointexit"
+	// BreakpointPausedSuffix is the suffix of the post file of a step which exists while the step is
+	// paused at a breakpoint, and holds the name of the breakpoint
+	BreakpointPausedSuffix = ".paused"
+	// PausedCommand is the entrypoint subcommand probing the readiness of the steps with breakpoints,
+	// which succeeds only while the step is paused at a breakpoint
+	PausedCommand = "paused"
+
+	breakpointOnFailure = "onFailure"
+	breakpointBefore    = "before"
+	breakpointAfter     = "after"
+)
+
+// BreakpointExitError is returned when a step paused at a breakpoint is resumed with the
+// debug-fail-continue script, and holds the exit code written by the script.
+type BreakpointExitError struct {
+	ExitCode int
+}
+
+func (e BreakpointExitError) Error() string {
+	return fmt.Sprintf("step failed at breakpoint with exit code %d", e.ExitCode)
+}
+
 // Entrypointer holds fields for running commands with redirected
 // entrypoints.
 type Entrypointer struct {
@@ -55,11 +82,16 @@ type Entrypointer struct {
 	// WaitFileContent indicates the WaitFile should have non-zero size
 	// before continuing with execution.
 	WaitFileContent bool
+	// SidecarReadyFiles is the set of files projecting the readiness of the
+	// sidecars the step needs, which are waited for to have content once
+	// the WaitFiles exist.
+	SidecarReadyFiles []string
 	// PostFile is the file to write when complete. If not specified, no
 	// file is written.
 	PostFile string
 
-	// Termination path is the path of a file to write the starting time of this endpopint
+	// Termination path is the path of a file to write the starting time of this endpopint.
+	// If not specified, no termination message is written.
 	TerminationPath string
 
 	// Waiter encapsulates waiting for files to exist.
@@ -71,14 +103,27 @@ type Entrypointer struct {
 
 	// Results is the set of files that might contain task results
 	Results []string
+	// ResultsDir is the directory the task results are written to. Defaults to /tekton/results.
+	ResultsDir string
 	// Timeout is an optional user-specified duration within which the Step must complete
 	Timeout *time.Duration
 	// BreakpointOnFailure helps determine if entrypoint execution needs to adapt debugging requirements
 	BreakpointOnFailure bool
+	// BreakpointBeforeStep pauses the step before running the command, until the breakpoint is exited
+	BreakpointBeforeStep bool
+	// BreakpointAfterStep pauses the step after running the command, until the breakpoint is exited
+	BreakpointAfterStep bool
+	// Retries is the number of times the command is run again when it exits with a non-zero exit code,
+	// before writing the post file. The Timeout applies to all the attempts together.
+	Retries int
 	// OnError defines exiting behavior of the entrypoint
 	// set it to "stopAndFail" to indicate the entrypoint to exit the taskRun if the container exits with non zero exit code
 	// set it to "continue" to indicate the entrypoint to continue executing the rest of the steps irrespective of the container exit code
 	OnError string
+	// When are the when expressions that must all evaluate to true for the command to run.
+	// The results referenced with $(results.<name>) are read from the ResultsDir.
+	// The post file is written when the command is skipped, so that the next steps run.
+	When v1beta1.WhenExpressions
 	// StepMetadataDir is the directory for a step where the step related metadata can be stored
 	StepMetadataDir string
 	// StepMetadataDirLink is the directory which needs to be linked to the StepMetadataDir
@@ -114,8 +159,10 @@ func (e Entrypointer) Go() error {
 
 	output := []v1beta1.PipelineResourceResult{}
 	defer func() {
-		if wErr := termination.WriteMessage(e.TerminationPath, output); wErr != nil {
-			logger.Fatalf("Error while writing message: %s", wErr)
+		if e.TerminationPath != "" {
+			if wErr := termination.WriteMessage(e.TerminationPath, output); wErr != nil {
+				logger.Fatalf("Error while writing message: %s", wErr)
+			}
 		}
 		_ = logger.Sync()
 	}()
@@ -126,12 +173,19 @@ func (e Entrypointer) Go() error {
 
 	for _, f := range e.WaitFiles {
 		if err := e.Waiter.Wait(f, e.WaitFileContent, e.BreakpointOnFailure); err != nil {
-			// An error happened while waiting, so we bail
-			// *but* we write postfile to make next steps bail too.
-			// In case of breakpoint on failure do not write post file.
-			if !e.BreakpointOnFailure {
-				e.WritePostFile(e.PostFile, err)
-			}
+			return e.abortWaiting(err, &output)
+		}
+	}
+	for _, f := range e.SidecarReadyFiles {
+		if err := e.Waiter.Wait(f, true, false); err != nil {
+			return e.abortWaiting(err, &output)
+		}
+	}
+
+	if e.BreakpointBeforeStep {
+		if err := e.breakpoint(breakpointBefore); err != nil {
+			// The step was failed at the breakpoint, so it doesn't run.
+			e.WritePostFile(e.PostFile, err)
 			output = append(output, v1beta1.PipelineResourceResult{
 				Key:        "StartedAt",
 				Value:      time.Now().Format(timeFormat),
@@ -163,7 +217,19 @@ func (e Entrypointer) Go() error {
 			ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
 			defer cancel()
 		}
-		err = e.Runner.Run(ctx, e.Args...)
+		if e.allowsExecution() {
+			err = e.Runner.Run(ctx, e.Args...)
+			if e.Retries > 0 {
+				err = e.retry(ctx, logger, err, &output)
+			}
+		} else {
+			logger.Info("Skipping step because its when expressions evaluated to false")
+			output = append(output, v1beta1.PipelineResourceResult{
+				Key:        "Reason",
+				Value:      v1beta1.StepSkippedReason,
+				ResultType: v1beta1.InternalTektonResultType,
+			})
+		}
 		if err == context.DeadlineExceeded {
 			output = append(output, v1beta1.PipelineResourceResult{
 				Key:        "Reason",
@@ -173,10 +239,17 @@ func (e Entrypointer) Go() error {
 		}
 	}
 
-	var ee *exec.ExitError
+	// The step is paused after running, or on failure, and its outcome is then the one the
+	// breakpoint was exited with.
 	switch {
+	case e.BreakpointAfterStep:
+		err = e.breakpoint(breakpointAfter)
 	case err != nil && e.BreakpointOnFailure:
-		logger.Info("Skipping writing to PostFile")
+		err = e.breakpoint(breakpointOnFailure)
+	}
+
+	var ee *exec.ExitError
+	switch {
 	case e.OnError == ContinueOnError && errors.As(err, &ee):
 		// with continue on error and an ExitError, write non-zero exit code and a post file
 		exitCode := strconv.Itoa(ee.ExitCode())
@@ -207,13 +280,101 @@ func (e Entrypointer) Go() error {
 	return err
 }
 
+// retry runs the command again while it exits with a non-zero exit code and retries remain,
+// and records the exit code of each attempt in the output. It returns the error of the last attempt.
+func (e Entrypointer) retry(ctx context.Context, logger *zap.SugaredLogger, err error, output *[]v1beta1.PipelineResourceResult) error {
+	var exitCodes []string
+	var ee *exec.ExitError
+	for retry := 1; retry <= e.Retries && errors.As(err, &ee) && ctx.Err() == nil; retry++ {
+		exitCodes = append(exitCodes, strconv.Itoa(ee.ExitCode()))
+		logger.Infof("Step exited with code %d, retrying (%d/%d)", ee.ExitCode(), retry, e.Retries)
+		err = e.Runner.Run(ctx, e.Args...)
+	}
+	if len(exitCodes) == 0 {
+		return err
+	}
+	switch {
+	case err == nil:
+		exitCodes = append(exitCodes, "0")
+	case errors.As(err, &ee):
+		exitCodes = append(exitCodes, strconv.Itoa(ee.ExitCode()))
+	}
+	*output = append(*output, v1beta1.PipelineResourceResult{
+		Key:        "AttemptExitCodes",
+		Value:      strings.Join(exitCodes, ","),
+		ResultType: v1beta1.InternalTektonResultType,
+	})
+	return err
+}
+
+// stepWhenExpression is how a when expression of a step is passed to the entrypoint, along
+// with the values of the variables of its CEL expression, which aren't serialized with it.
+type stepWhenExpression struct {
+	v1beta1.WhenExpression
+	CELVariables map[string]string `json:"celVariables,omitempty"`
+}
+
+// MarshalWhen returns the JSON form of the when expressions of a step passed to the entrypoint.
+func MarshalWhen(wes v1beta1.WhenExpressions) ([]byte, error) {
+	expressions := make([]stepWhenExpression, 0, len(wes))
+	for _, we := range wes {
+		expressions = append(expressions, stepWhenExpression{WhenExpression: we, CELVariables: we.CELVariables})
+	}
+	return json.Marshal(expressions)
+}
+
+// UnmarshalWhen parses the when expressions of a step passed to the entrypoint.
+func UnmarshalWhen(data []byte) (v1beta1.WhenExpressions, error) {
+	var expressions []stepWhenExpression
+	if err := json.Unmarshal(data, &expressions); err != nil {
+		return nil, err
+	}
+	wes := make(v1beta1.WhenExpressions, 0, len(expressions))
+	for _, e := range expressions {
+		we := e.WhenExpression
+		we.CELVariables = e.CELVariables
+		wes = append(wes, we)
+	}
+	return wes, nil
+}
+
+// allowsExecution evaluates the when expressions once the results they reference are
+// replaced with the contents of the result files. A result that wasn't written is empty.
+func (e Entrypointer) allowsExecution() bool {
+	if len(e.When) == 0 {
+		return true
+	}
+	replacements := map[string]string{}
+	for _, we := range e.When {
+		expressions, _ := we.GetVarSubstitutionExpressions()
+		for _, expression := range expressions {
+			if !strings.HasPrefix(expression, "results.") {
+				continue
+			}
+			content, err := ioutil.ReadFile(filepath.Join(e.resultsDir(), strings.TrimPrefix(expression, "results.")))
+			if err != nil && !os.IsNotExist(err) {
+				log.Printf("Failed to read result %q: %v", expression, err)
+			}
+			replacements[expression] = string(content)
+		}
+	}
+	return e.When.ReplaceWhenExpressionsVariables(replacements, nil).AllowsExecution()
+}
+
+func (e Entrypointer) resultsDir() string {
+	if e.ResultsDir != "" {
+		return e.ResultsDir
+	}
+	return pipeline.DefaultResultPath
+}
+
 func (e Entrypointer) readResultsFromDisk() error {
 	output := []v1beta1.PipelineResourceResult{}
 	for _, resultFile := range e.Results {
 		if resultFile == "" {
 			continue
 		}
-		fileContents, err := ioutil.ReadFile(filepath.Join(pipeline.DefaultResultPath, resultFile))
+		fileContents, err := ioutil.ReadFile(filepath.Join(e.resultsDir(), resultFile))
 		if os.IsNotExist(err) {
 			continue
 		} else if err != nil {
@@ -235,6 +396,52 @@ func (e Entrypointer) readResultsFromDisk() error {
 	return nil
 }
 
+// abortWaiting handles an error that happened while waiting, so we bail
+// *but* we write postfile to make next steps bail too.
+// In case of breakpoint on failure the step is paused first.
+func (e Entrypointer) abortWaiting(err error, output *[]v1beta1.PipelineResourceResult) error {
+	if e.BreakpointOnFailure {
+		err = e.breakpoint(breakpointOnFailure)
+	}
+	e.WritePostFile(e.PostFile, err)
+	*output = append(*output, v1beta1.PipelineResourceResult{
+		Key:        "StartedAt",
+		Value:      time.Now().Format(timeFormat),
+		ResultType: v1beta1.InternalTektonResultType,
+	})
+	return err
+}
+
+// breakpoint pauses the step at the breakpoint until the debug-continue or debug-fail-continue
+// script writes the exit code of the step. It returns nil when the step was resumed with
+// debug-continue, and a BreakpointExitError with the exit code otherwise.
+func (e Entrypointer) breakpoint(name string) error {
+	pausedFile := e.PostFile + BreakpointPausedSuffix
+	exitFile := e.PostFile + BreakpointExitSuffix
+	e.PostWriter.Write(pausedFile, name)
+	defer func() {
+		// Remove the files so that the step can be paused at another breakpoint
+		_ = os.Remove(pausedFile)
+		_ = os.Remove(exitFile)
+	}()
+
+	log.Printf("Step paused at breakpoint %s, run /tekton/debug/scripts/debug-continue or /tekton/debug/scripts/debug-fail-continue to resume", name)
+	if err := e.Waiter.Wait(exitFile, true, false); err != nil {
+		log.Printf("Error waiting for %s: %v", exitFile, err)
+	}
+	// If the exit code can't be read, it defaults to 0 as we would like
+	// to encourage to continue running the next steps in the taskRun
+	exitCode, err := e.BreakpointExitCode(exitFile)
+	if err != nil {
+		log.Println("error occurred while reading breakpoint exit code : " + err.Error())
+	}
+	if exitCode != 0 {
+		return BreakpointExitError{ExitCode: exitCode}
+	}
+	return nil
+}
+
+// BreakpointExitCode reads the exit code written to the file by the debug scripts
 func (e Entrypointer) BreakpointExitCode(breakpointExitPostFile string) (int, error) {
 	exitCode, err := ioutil.ReadFile(breakpointExitPostFile)
 	if os.IsNotExist(err) {
@@ -24,13 +24,16 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/selection"
 )
 
 func TestEntrypointerFailures(t *testing.T) {
@@ -265,6 +268,72 @@ func TestEntrypointer(t *testing.T) {
 	}
 }
 
+func TestEntrypointer_SidecarReadyFiles(t *testing.T) {
+	fw, fr, fpw := &fakeContentWaiter{}, &fakeRunner{}, &fakePostWriter{}
+	terminationFile, err := ioutil.TempFile("", "termination")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary termination file: %v", err)
+	}
+	defer os.Remove(terminationFile.Name())
+
+	if err := (Entrypointer{
+		Entrypoint:        "echo",
+		WaitFiles:         []string{"/tekton/tools/0"},
+		SidecarReadyFiles: []string{"/tekton/downward/sidecars/db", "/tekton/downward/sidecars/cache"},
+		PostFile:          "/tekton/tools/1",
+		Waiter:            fw,
+		Runner:            fr,
+		PostWriter:        fpw,
+		TerminationPath:   terminationFile.Name(),
+	}).Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	// The previous step is waited for first, then the sidecars, which must be ready.
+	want := []waitedFile{{
+		file: "/tekton/tools/0",
+	}, {
+		file:          "/tekton/downward/sidecars/db",
+		expectContent: true,
+	}, {
+		file:          "/tekton/downward/sidecars/cache",
+		expectContent: true,
+	}}
+	if d := cmp.Diff(want, fw.waited, cmp.AllowUnexported(waitedFile{})); d != "" {
+		t.Errorf("Waited for files %s", diff.PrintWantGot(d))
+	}
+	if fr.args == nil {
+		t.Error("Wanted command to be run, got nil")
+	}
+}
+
+func TestEntrypointer_NoTerminationPath(t *testing.T) {
+	fw, fr, fpw := &fakeContentWaiter{}, &fakeRunner{}, &fakePostWriter{}
+
+	// A sidecar started after others waits for them to be ready, and has no
+	// termination message to write.
+	if err := (Entrypointer{
+		Entrypoint:        "echo",
+		SidecarReadyFiles: []string{"/tekton/downward/sidecars/proxy"},
+		Waiter:            fw,
+		Runner:            fr,
+		PostWriter:        fpw,
+	}).Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	want := []waitedFile{{
+		file:          "/tekton/downward/sidecars/proxy",
+		expectContent: true,
+	}}
+	if d := cmp.Diff(want, fw.waited, cmp.AllowUnexported(waitedFile{})); d != "" {
+		t.Errorf("Waited for files %s", diff.PrintWantGot(d))
+	}
+	if fr.args == nil {
+		t.Error("Wanted command to be run, got nil")
+	}
+}
+
 func TestEntrypointer_ReadBreakpointExitCodeFromDisk(t *testing.T) {
 	expectedExitCode := 1
 	// setup test
@@ -284,6 +353,99 @@ func TestEntrypointer_ReadBreakpointExitCodeFromDisk(t *testing.T) {
 	}
 }
 
+func TestEntrypointer_Breakpoints(t *testing.T) {
+	for _, c := range []struct {
+		desc                   string
+		beforeStep, afterStep  bool
+		runner                 Runner
+		breakpointExitCode     int
+		wantRun, wantErr       bool
+		wantPausedAtBreakpoint string
+	}{{
+		desc:                   "the step is continued at the breakpoint before it",
+		beforeStep:             true,
+		runner:                 &fakeRunner{},
+		wantRun:                true,
+		wantPausedAtBreakpoint: "before",
+	}, {
+		desc:                   "the step is failed at the breakpoint before it",
+		beforeStep:             true,
+		runner:                 &fakeRunner{},
+		breakpointExitCode:     1,
+		wantErr:                true,
+		wantPausedAtBreakpoint: "before",
+	}, {
+		desc:                   "the step is continued at the breakpoint after it",
+		afterStep:              true,
+		runner:                 &fakeRunner{},
+		wantRun:                true,
+		wantPausedAtBreakpoint: "after",
+	}, {
+		desc:                   "the step is failed at the breakpoint after it",
+		afterStep:              true,
+		runner:                 &fakeRunner{},
+		breakpointExitCode:     1,
+		wantRun:                true,
+		wantErr:                true,
+		wantPausedAtBreakpoint: "after",
+	}, {
+		desc:                   "the failed step is continued at the breakpoint after it",
+		afterStep:              true,
+		runner:                 &fakeErrorRunner{},
+		wantRun:                true,
+		wantPausedAtBreakpoint: "after",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "breakpoints")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary directory: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			postFile := filepath.Join(dir, "0")
+			// The debug scripts write the exit code of the breakpoint while the step is paused.
+			if err := ioutil.WriteFile(postFile+BreakpointExitSuffix, []byte(fmt.Sprintf("%d", c.breakpointExitCode)), 0700); err != nil {
+				t.Fatalf("unexpected error writing the breakpoint exit file: %v", err)
+			}
+
+			fpw := &fakePostWriter{}
+			err = Entrypointer{
+				Entrypoint:           "echo",
+				PostFile:             postFile,
+				Waiter:               &fakeWaiter{},
+				Runner:               c.runner,
+				PostWriter:           fpw,
+				TerminationPath:      filepath.Join(dir, "termination"),
+				BreakpointBeforeStep: c.beforeStep,
+				BreakpointAfterStep:  c.afterStep,
+			}.Go()
+
+			if c.wantErr && err == nil {
+				t.Error("Entrypointer didn't fail")
+			} else if !c.wantErr && err != nil {
+				t.Errorf("Entrypointer failed: %v", err)
+			}
+			var ran bool
+			switch r := c.runner.(type) {
+			case *fakeRunner:
+				ran = r.args != nil
+			case *fakeErrorRunner:
+				ran = r.args != nil
+			}
+			if ran != c.wantRun {
+				t.Errorf("Ran the step: %t, want %t", ran, c.wantRun)
+			}
+			if paused, ok := fpw.contents[postFile+BreakpointPausedSuffix]; !ok {
+				t.Errorf("Wanted paused file %s written", postFile+BreakpointPausedSuffix)
+			} else if paused != c.wantPausedAtBreakpoint {
+				t.Errorf("Paused at breakpoint %q, want %q", paused, c.wantPausedAtBreakpoint)
+			}
+			if _, err := os.Stat(postFile + BreakpointExitSuffix); !os.IsNotExist(err) {
+				t.Errorf("Wanted breakpoint exit file removed once the step is resumed")
+			}
+		})
+	}
+}
+
 func TestEntrypointer_OnError(t *testing.T) {
 	for _, c := range []struct {
 		desc, postFile, onError string
@@ -366,6 +528,224 @@ func TestEntrypointer_OnError(t *testing.T) {
 	}
 }
 
+func TestEntrypointer_Retries(t *testing.T) {
+	for _, c := range []struct {
+		desc             string
+		retries          int
+		failures         int
+		wantRuns         int
+		wantErr          bool
+		wantAttemptCodes string
+	}{{
+		desc:     "no retries",
+		failures: 1,
+		wantRuns: 1,
+		wantErr:  true,
+	}, {
+		desc:     "succeeds without retrying",
+		retries:  2,
+		wantRuns: 1,
+	}, {
+		desc:             "succeeds after retrying",
+		retries:          2,
+		failures:         2,
+		wantRuns:         3,
+		wantAttemptCodes: "3,3,0",
+	}, {
+		desc:             "fails after exhausting the retries",
+		retries:          2,
+		failures:         5,
+		wantRuns:         3,
+		wantErr:          true,
+		wantAttemptCodes: "3,3,3",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fr, fpw := &fakeFlakyRunner{failures: c.failures}, &fakePostWriter{}
+			terminationFile, err := ioutil.TempFile("", "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			}
+			defer os.Remove(terminationFile.Name())
+			err = Entrypointer{
+				Entrypoint:      "echo",
+				PostFile:        "step-one",
+				Waiter:          &fakeWaiter{},
+				Runner:          fr,
+				PostWriter:      fpw,
+				TerminationPath: terminationFile.Name(),
+				Retries:         c.retries,
+			}.Go()
+			if c.wantErr != (err != nil) {
+				t.Fatalf("Entrypointer returned %v, want an error: %t", err, c.wantErr)
+			}
+			if fr.runs != c.wantRuns {
+				t.Errorf("Ran the command %d times, want %d", fr.runs, c.wantRuns)
+			}
+			wantPostFile := "step-one"
+			if c.wantErr {
+				wantPostFile += ".err"
+			}
+			if fpw.wrote == nil || *fpw.wrote != wantPostFile {
+				t.Errorf("Wrote post file %v, want %q", fpw.wrote, wantPostFile)
+			}
+
+			fileContents, err := ioutil.ReadFile(terminationFile.Name())
+			if err != nil {
+				t.Fatalf("Failed to read the termination file: %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("Failed to parse the termination message: %v", err)
+			}
+			gotAttemptCodes := ""
+			for _, result := range entries {
+				if result.Key == "AttemptExitCodes" {
+					gotAttemptCodes = result.Value
+				}
+			}
+			if gotAttemptCodes != c.wantAttemptCodes {
+				t.Errorf("Recorded the attempt exit codes %q, want %q", gotAttemptCodes, c.wantAttemptCodes)
+			}
+		})
+	}
+}
+
+func TestEntrypointer_When(t *testing.T) {
+	for _, c := range []struct {
+		desc        string
+		when        v1beta1.WhenExpressions
+		results     map[string]string
+		wantRun     bool
+		wantSkipped bool
+	}{{
+		desc:    "no when expressions",
+		wantRun: true,
+	}, {
+		desc: "when expressions evaluating to true",
+		when: v1beta1.WhenExpressions{{
+			Input:    "main",
+			Operator: selection.In,
+			Values:   []string{"main", "release"},
+		}},
+		wantRun: true,
+	}, {
+		desc: "when expressions evaluating to false",
+		when: v1beta1.WhenExpressions{{
+			Input:    "feature",
+			Operator: selection.In,
+			Values:   []string{"main", "release"},
+		}},
+		wantSkipped: true,
+	}, {
+		desc: "when expressions using the result of a previous step",
+		when: v1beta1.WhenExpressions{{
+			Input:    "$(results.changed)",
+			Operator: selection.In,
+			Values:   []string{"true"},
+		}},
+		results: map[string]string{"changed": "true"},
+		wantRun: true,
+	}, {
+		desc: "when expressions using a result that wasn't written",
+		when: v1beta1.WhenExpressions{{
+			Input:    "$(results.changed)",
+			Operator: selection.In,
+			Values:   []string{"true"},
+		}},
+		wantSkipped: true,
+	}, {
+		desc: "cel expression using a param and the result of a previous step",
+		when: v1beta1.WhenExpressions{{
+			CEL:          "$(params.branch) == 'main' && int($(results.changes)) > 0",
+			CELVariables: map[string]string{"params.branch": "main"},
+		}},
+		results: map[string]string{"changes": "3"},
+		wantRun: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			resultsDir, err := ioutil.TempDir("", "results")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary results dir: %v", err)
+			}
+			defer os.RemoveAll(resultsDir)
+			for name, value := range c.results {
+				if err := ioutil.WriteFile(filepath.Join(resultsDir, name), []byte(value), 0644); err != nil {
+					t.Fatalf("unexpected error writing result %q: %v", name, err)
+				}
+			}
+			terminationFile, err := ioutil.TempFile("", "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			}
+			defer os.Remove(terminationFile.Name())
+
+			fr, fpw := &fakeRunner{}, &fakePostWriter{}
+			if err := (Entrypointer{
+				Entrypoint:      "echo",
+				PostFile:        "step-one",
+				Waiter:          &fakeWaiter{},
+				Runner:          fr,
+				PostWriter:      fpw,
+				TerminationPath: terminationFile.Name(),
+				ResultsDir:      resultsDir,
+				When:            c.when,
+			}).Go(); err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			if gotRun := fr.args != nil; gotRun != c.wantRun {
+				t.Errorf("Ran the command: %t, want %t", gotRun, c.wantRun)
+			}
+			if fpw.wrote == nil || *fpw.wrote != "step-one" {
+				t.Errorf("Wrote post file %v, want %q", fpw.wrote, "step-one")
+			}
+
+			fileContents, err := ioutil.ReadFile(terminationFile.Name())
+			if err != nil {
+				t.Fatalf("Failed to read the termination file: %v", err)
+			}
+			var entries []v1beta1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("Failed to parse the termination message: %v", err)
+			}
+			gotSkipped := false
+			for _, result := range entries {
+				if result.Key == "Reason" && result.Value == v1beta1.StepSkippedReason {
+					gotSkipped = true
+				}
+			}
+			if gotSkipped != c.wantSkipped {
+				t.Errorf("Recorded the step as skipped: %t, want %t", gotSkipped, c.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestMarshalWhen(t *testing.T) {
+	when := v1beta1.WhenExpressions{{
+		Input:    "$(results.changed)",
+		Operator: selection.In,
+		Values:   []string{"true"},
+	}, {
+		CEL:          "$(params.branch) == 'main'",
+		CELVariables: map[string]string{"params.branch": "main"},
+	}}
+	b, err := MarshalWhen(when)
+	if err != nil {
+		t.Fatalf("MarshalWhen() error = %v", err)
+	}
+	want := `[{"input":"$(results.changed)","operator":"in","values":["true"]},{"cel":"$(params.branch) == 'main'","celVariables":{"params.branch":"main"}}]`
+	if d := cmp.Diff(want, string(b)); d != "" {
+		t.Errorf("MarshalWhen() diff %s", diff.PrintWantGot(d))
+	}
+	got, err := UnmarshalWhen(b)
+	if err != nil {
+		t.Fatalf("UnmarshalWhen() error = %v", err)
+	}
+	if d := cmp.Diff(when, got); d != "" {
+		t.Errorf("UnmarshalWhen() diff %s", diff.PrintWantGot(d))
+	}
+}
+
 type fakeWaiter struct{ waited []string }
 
 func (f *fakeWaiter) Wait(file string, _ bool, _ bool) error {
@@ -373,6 +753,18 @@ func (f *fakeWaiter) Wait(file string, _ bool, _ bool) error {
 	return nil
 }
 
+type waitedFile struct {
+	file          string
+	expectContent bool
+}
+
+type fakeContentWaiter struct{ waited []waitedFile }
+
+func (f *fakeContentWaiter) Wait(file string, expectContent bool, _ bool) error {
+	f.waited = append(f.waited, waitedFile{file: file, expectContent: expectContent})
+	return nil
+}
+
 type fakeRunner struct{ args *[]string }
 
 func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
@@ -386,9 +778,14 @@ type fakePostWriter struct {
 	exitCode     *string
 	source       *string
 	link         *string
+	contents     map[string]string
 }
 
 func (f *fakePostWriter) Write(file, content string) {
+	if f.contents == nil {
+		f.contents = map[string]string{}
+	}
+	f.contents[file] = content
 	if content == "" {
 		f.wrote = &file
 	} else {
@@ -442,3 +839,16 @@ func (f *fakeExitErrorRunner) Run(ctx context.Context, args ...string) error {
 	f.args = &args
 	return exec.Command("ls", "/bogus/path").Run()
 }
+
+type fakeFlakyRunner struct {
+	failures int
+	runs     int
+}
+
+func (f *fakeFlakyRunner) Run(ctx context.Context, args ...string) error {
+	f.runs++
+	if f.runs <= f.failures {
+		return exec.Command("sh", "-c", "exit 3").Run()
+	}
+	return nil
+}
@@ -38,6 +38,8 @@ const (
 var (
 	// sshURLRegexFormat matches the url of SSH git repository
 	sshURLRegexFormat = regexp.MustCompile(`(ssh://[\w\d\.]+|.+@?.+\..+:)(:[\d]+){0,1}/*(.*)`)
+	// commitRegexFormat matches a full commit SHA
+	commitRegexFormat = regexp.MustCompile(`^[0-9a-f]{40}$`)
 )
 
 func run(logger *zap.SugaredLogger, dir string, args ...string) (string, error) {
@@ -74,13 +76,16 @@ type FetchSpec struct {
 
 // Fetch fetches the specified git repository at the revision into path, using the refspec to fetch if provided.
 func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
+	if strings.HasPrefix(spec.Revision, "-") {
+		return fmt.Errorf("invalid revision %q: it must not start with \"-\"", spec.Revision)
+	}
 	if err := ensureHomeEnv(logger); err != nil {
 		return err
 	}
 	validateGitAuth(logger, pipeline.CredsDir, spec.URL)
 
 	if spec.Path != "" {
-		if _, err := run(logger, "", "init", spec.Path); err != nil {
+		if _, err := run(logger, "", "init", "--", spec.Path); err != nil {
 			return err
 		}
 		if err := os.Chdir(spec.Path); err != nil {
@@ -93,7 +98,7 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 		return err
 	}
 	trimmedURL := strings.TrimSpace(spec.URL)
-	if _, err := run(logger, "", "remote", "add", "origin", trimmedURL); err != nil {
+	if _, err := run(logger, "", "remote", "add", "--", "origin", trimmedURL); err != nil {
 		return err
 	}
 
@@ -144,7 +149,8 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	// The --force parameter tells git-fetch that its ok to update an existing HEAD in a
 	// non-fast-forward manner (though this cannot be possible on initial fetch, it can help
 	// when the refspec specifies the same destination twice)
-	fetchArgs = append(fetchArgs, "origin", "--update-head-ok", "--force")
+	// The options come before "--", so that a revision or refspec is never taken for one.
+	fetchArgs = append(fetchArgs, "--update-head-ok", "--force", "--", "origin")
 	fetchArgs = append(fetchArgs, fetchParam...)
 	if _, err := run(logger, spec.Path, fetchArgs...); err != nil {
 		return fmt.Errorf("failed to fetch %v: %v", fetchParam, err)
@@ -154,7 +160,8 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 		return fmt.Errorf("error parsing %s after fetching refspec %s", checkoutParam, spec.Refspec)
 	}
 
-	if _, err := run(logger, "", "checkout", "-f", checkoutParam); err != nil {
+	// checkout doesn't honor --end-of-options, so the revision is checked not to be an option when fetching.
+	if _, err := run(logger, "", "checkout", "-f", checkoutParam, "--"); err != nil {
 		return err
 	}
 
@@ -175,8 +182,49 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	return nil
 }
 
+// LsRemote returns the commit that the revision, a branch, a tag or a commit SHA, points to
+// in the git repository at url, without fetching it. Like git, a tag is preferred to a branch
+// of the same name, and an annotated tag resolves to the commit it tags.
+func LsRemote(logger *zap.SugaredLogger, url, revision string) (string, error) {
+	// ls-remote matches its patterns against the end of the refs, so the refs it lists are
+	// matched again against the full names the revision can stand for. The commits that
+	// annotated tags point to are only listed when asked for with "^{}".
+	candidates := []string{"refs/tags/" + revision, "refs/heads/" + revision}
+	if revision == "HEAD" || strings.HasPrefix(revision, "refs/") {
+		candidates = append([]string{revision}, candidates...)
+	}
+	// The url comes after "--", so that it is never taken for an option such as --upload-pack.
+	args := []string{"ls-remote", "--", strings.TrimSpace(url)}
+	for _, ref := range candidates {
+		args = append(args, ref, ref+"^{}")
+	}
+	output, err := run(logger, "", args...)
+	if err != nil {
+		return "", err
+	}
+	refs := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			refs[fields[1]] = fields[0]
+		}
+	}
+	for _, ref := range candidates {
+		if commit, ok := refs[ref+"^{}"]; ok {
+			return commit, nil
+		}
+		if commit, ok := refs[ref]; ok {
+			return commit, nil
+		}
+	}
+	// A commit SHA isn't advertised as a ref by the remote.
+	if commitRegexFormat.MatchString(revision) {
+		return revision, nil
+	}
+	return "", fmt.Errorf("revision %q not found in %s", revision, url)
+}
+
 func ShowCommit(logger *zap.SugaredLogger, revision, path string) (string, error) {
-	output, err := run(logger, path, "show", "-q", "--pretty=format:%H", revision)
+	output, err := run(logger, path, "show", "-q", "--pretty=format:%H", "--end-of-options", revision, "--")
 	if err != nil {
 		return "", err
 	}
@@ -184,7 +232,7 @@ func ShowCommit(logger *zap.SugaredLogger, revision, path string) (string, error
 }
 
 func showRef(logger *zap.SugaredLogger, revision, path string) (string, error) {
-	output, err := run(logger, path, "show", "-q", "--pretty=format:%D", revision)
+	output, err := run(logger, path, "show", "-q", "--pretty=format:%D", "--end-of-options", revision, "--")
 	if err != nil {
 		return "", err
 	}
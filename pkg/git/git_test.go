@@ -266,6 +266,55 @@ func TestFetch(t *testing.T) {
 	}
 }
 
+func TestLsRemote(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	gitDir, cleanup := createTempDir(t)
+	defer cleanup()
+	createTempGit(t, logger, gitDir)
+	commit, err := ShowCommit(logger, "HEAD", gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A branch whose name ends with the name of another branch, and an annotated tag.
+	if _, err := run(logger, gitDir, "checkout", "-b", "feature/main"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(logger, gitDir, "commit", "--allow-empty", "-m", "Feature"); err != nil {
+		t.Fatal(err)
+	}
+	featureCommit, err := ShowCommit(logger, "HEAD", gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(logger, gitDir, "tag", "-a", "v1", "-m", "Version 1", commit); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(logger, gitDir, "checkout", "main"); err != nil {
+		t.Fatal(err)
+	}
+
+	for revision, want := range map[string]string{
+		"main":            commit,
+		"refs/heads/main": commit,
+		"HEAD":            commit,
+		commit:            commit,
+		"feature/main":    featureCommit,
+		"v1":              commit,
+		"refs/tags/v1":    commit,
+	} {
+		got, err := LsRemote(logger, gitDir, revision)
+		if err != nil {
+			t.Errorf("LsRemote(%q) error = %v", revision, err)
+		} else if got != want {
+			t.Errorf("LsRemote(%q) = %q, want %q", revision, got, want)
+		}
+	}
+	if _, err := LsRemote(logger, gitDir, "missing"); err == nil {
+		t.Error("expected an error for a missing revision but got none")
+	}
+}
+
 func createTempDir(t *testing.T) (string, func()) {
 	dir, err := ioutil.TempDir("", "git-init-")
 	if err != nil {
@@ -49,6 +49,10 @@ var (
 	runningPRsCount = stats.Float64("running_pipelineruns_count",
 		"Number of pipelineruns executing currently",
 		stats.UnitDimensionless)
+
+	prPrunedCount = stats.Float64("pipelinerun_pruned_count",
+		"number of pipelineruns deleted by the pruner",
+		stats.UnitDimensionless)
 )
 
 const (
@@ -66,6 +70,7 @@ type Recorder struct {
 	pipelineRun tag.Key
 	namespace   tag.Key
 	status      tag.Key
+	reason      tag.Key
 
 	ReportingPeriod time.Duration
 }
@@ -114,6 +119,12 @@ func NewRecorder() (*Recorder, error) {
 		}
 		r.status = status
 
+		reason, recorderErr := tag.NewKey("reason")
+		if recorderErr != nil {
+			return
+		}
+		r.reason = reason
+
 		recorderErr = view.Register(
 			&view.View{
 				Description: prDuration.Description(),
@@ -132,6 +143,12 @@ func NewRecorder() (*Recorder, error) {
 				Measure:     runningPRsCount,
 				Aggregation: view.LastValue(),
 			},
+			&view.View{
+				Description: prPrunedCount.Description(),
+				Measure:     prPrunedCount,
+				Aggregation: view.Count(),
+				TagKeys:     []tag.Key{r.namespace, r.reason},
+			},
 		)
 
 		if recorderErr != nil {
@@ -186,6 +203,26 @@ func (r *Recorder) DurationAndCount(pr *v1beta1.PipelineRun) error {
 	return nil
 }
 
+// Pruned logs the deletion of a finished PipelineRun by the pruner for the given reason
+// returns an error if its failed to log the metrics
+func (r *Recorder) Pruned(pr *v1beta1.PipelineRun, reason string) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", pr.Name)
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.namespace, pr.Namespace),
+		tag.Insert(r.reason, reason),
+	)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, prPrunedCount.M(1))
+	return nil
+}
+
 // RunningPipelineRuns logs the number of PipelineRuns running right now
 // returns an error if its failed to log the metrics
 func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
@@ -47,6 +47,9 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.RunningPipelineRuns(nil); err == nil {
 		t.Error("Current PR count recording expected to return error but got nil")
 	}
+	if err := metrics.Pruned(&v1beta1.PipelineRun{}, "ttl"); err == nil {
+		t.Error("Pruned recording expected to return error but got nil")
+	}
 }
 
 func TestRecordPipelineRunDurationCount(t *testing.T) {
@@ -170,6 +173,25 @@ func TestRecordPipelineRunDurationCount(t *testing.T) {
 	}
 }
 
+func TestRecordPruned(t *testing.T) {
+	for _, reason := range []string{"ttl", "keep"} {
+		t.Run(reason, func(t *testing.T) {
+			unregisterMetrics()
+
+			metrics, err := NewRecorder()
+			if err != nil {
+				t.Fatalf("NewRecorder: %v", err)
+			}
+
+			pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1", Namespace: "ns"}}
+			if err := metrics.Pruned(pr, reason); err != nil {
+				t.Errorf("Pruned: %v", err)
+			}
+			metricstest.CheckCountData(t, "pipelinerun_pruned_count", map[string]string{"namespace": "ns", "reason": reason}, 1)
+		})
+	}
+}
+
 func TestRecordRunningPipelineRunsCount(t *testing.T) {
 	unregisterMetrics()
 
@@ -213,7 +235,7 @@ func TestRecordRunningPipelineRunsCount(t *testing.T) {
 }
 
 func unregisterMetrics() {
-	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count")
+	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count", "pipelinerun_pruned_count")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
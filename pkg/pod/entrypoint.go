@@ -23,10 +23,13 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/pkg/names"
 	"gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -47,6 +50,13 @@ const (
 	readyAnnotation        = "tekton.dev/ready"
 	readyAnnotationValue   = "READY"
 
+	// downwardMountSidecarsDir is the directory of the Downward volume projecting the
+	// readiness of the sidecars the steps wait for
+	downwardMountSidecarsDir = "sidecars"
+	// sidecarReadyAnnotationPrefix is the prefix of the annotations signaling the steps
+	// waiting for a sidecar that it is ready
+	sidecarReadyAnnotationPrefix = "sidecar-ready.tekton.dev/"
+
 	stepPrefix    = "step-"
 	sidecarPrefix = "sidecar-"
 
@@ -94,7 +104,9 @@ var (
 // command, we must have fetched the image's ENTRYPOINT before calling this
 // method, using entrypoint_lookup.go.
 // Additionally, Step timeouts are added as entrypoint flag.
-func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1beta1.TaskSpec, breakpointConfig *v1beta1.TaskRunDebug) (corev1.Container, []corev1.Container, error) {
+// If resultsFromSidecarLogs is true, the results are read by a sidecar instead
+// of being written to the termination message by the entrypoint.
+func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1beta1.TaskSpec, breakpointConfig *v1beta1.TaskRunDebug, resultsFromSidecarLogs bool) (corev1.Container, []corev1.Container, error) {
 	initContainer := corev1.Container{
 		Name:  "place-tools",
 		Image: entrypointImage,
@@ -115,39 +127,59 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 	for i, s := range steps {
 		var argsForEntrypoint []string
 		name := StepName(steps[i].Name, i)
-		switch i {
-		case 0:
+		var sidecarReadyFiles []string
+		if taskSpec != nil && len(taskSpec.Steps) >= i+1 {
+			for _, sidecar := range taskSpec.Steps[i].WaitForSidecars {
+				sidecarReadyFiles = append(sidecarReadyFiles, sidecarReadyFile(sidecarContainerName(sidecar)))
+			}
+		}
+		switch {
+		case i == 0 && len(sidecarReadyFiles) == 0:
 			argsForEntrypoint = []string{
 				// First step waits for the Downward volume file.
 				"-wait_file", filepath.Join(downwardMountPoint, downwardMountReadyFile),
 				"-wait_file_content", // Wait for file contents, not just an empty file.
-				// Start next step.
-				"-post_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i)),
-				"-termination_path", terminationPath,
-				"-step_metadata_dir", filepath.Join(pipeline.StepsDir, name),
-				"-step_metadata_dir_link", filepath.Join(pipeline.StepsDir, fmt.Sprintf("%d", i)),
 			}
-		default:
-			// All other steps wait for previous file, write next file.
+		case i > 0:
+			// All other steps wait for previous file.
 			argsForEntrypoint = []string{
 				"-wait_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i-1)),
-				"-post_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i)),
-				"-termination_path", terminationPath,
-				"-step_metadata_dir", filepath.Join(pipeline.StepsDir, name),
-				"-step_metadata_dir_link", filepath.Join(pipeline.StepsDir, fmt.Sprintf("%d", i)),
 			}
 		}
+		if len(sidecarReadyFiles) > 0 {
+			// The step only waits for the sidecars it needs to be ready.
+			argsForEntrypoint = append(argsForEntrypoint, "-sidecar_ready_file", strings.Join(sidecarReadyFiles, ","))
+		}
+		argsForEntrypoint = append(argsForEntrypoint,
+			// Start next step.
+			"-post_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i)),
+			"-termination_path", terminationPath,
+			"-step_metadata_dir", filepath.Join(pipeline.StepsDir, name),
+			"-step_metadata_dir_link", filepath.Join(pipeline.StepsDir, fmt.Sprintf("%d", i)),
+		)
 		argsForEntrypoint = append(argsForEntrypoint, commonExtraEntrypointArgs...)
 		if taskSpec != nil {
 			if taskSpec.Steps != nil && len(taskSpec.Steps) >= i+1 {
 				if taskSpec.Steps[i].Timeout != nil {
 					argsForEntrypoint = append(argsForEntrypoint, "-timeout", taskSpec.Steps[i].Timeout.Duration.String())
 				}
+				if taskSpec.Steps[i].Retries > 0 {
+					argsForEntrypoint = append(argsForEntrypoint, "-retries", strconv.Itoa(taskSpec.Steps[i].Retries))
+				}
 				if taskSpec.Steps[i].OnError != "" {
 					argsForEntrypoint = append(argsForEntrypoint, "-on_error", taskSpec.Steps[i].OnError)
 				}
+				if len(taskSpec.Steps[i].When) > 0 {
+					when, err := entrypoint.MarshalWhen(taskSpec.Steps[i].When)
+					if err != nil {
+						return corev1.Container{}, nil, fmt.Errorf("failed to marshal the when expressions of step %d: %w", i, err)
+					}
+					argsForEntrypoint = append(argsForEntrypoint, "-when", string(when))
+				}
+			}
+			if !resultsFromSidecarLogs {
+				argsForEntrypoint = append(argsForEntrypoint, resultArgument(steps, taskSpec.Results)...)
 			}
-			argsForEntrypoint = append(argsForEntrypoint, resultArgument(steps, taskSpec.Results)...)
 		}
 
 		cmd, args := s.Command, s.Args
@@ -159,15 +191,8 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 			cmd = []string{cmd[0]}
 		}
 
-		if breakpointConfig != nil && len(breakpointConfig.Breakpoint) > 0 {
-			breakpoints := breakpointConfig.Breakpoint
-			for _, b := range breakpoints {
-				// TODO(TEP #0042): Add other breakpoints
-				if b == BreakpointOnFailure {
-					argsForEntrypoint = append(argsForEntrypoint, "-breakpoint_on_failure")
-				}
-			}
-		}
+		breakpointArgs := stepBreakpointArgs(breakpointConfig, s.Name)
+		argsForEntrypoint = append(argsForEntrypoint, breakpointArgs...)
 
 		argsForEntrypoint = append(argsForEntrypoint, "-entrypoint", cmd[0], "--")
 		argsForEntrypoint = append(argsForEntrypoint, args...)
@@ -175,7 +200,22 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 		steps[i].Command = []string{entrypointBinary}
 		steps[i].Args = argsForEntrypoint
 		steps[i].VolumeMounts = append(steps[i].VolumeMounts, toolsMount)
+		if i > 0 && len(sidecarReadyFiles) > 0 {
+			steps[i].VolumeMounts = append(steps[i].VolumeMounts, downwardMount)
+		}
 		steps[i].TerminationMessagePath = terminationPath
+		if len(breakpointArgs) > 0 {
+			// The step is only ready while it's paused at a breakpoint, which reports the paused
+			// step in the status of the TaskRun. A step which hasn't been probed yet isn't ready,
+			// so it can't be mistaken for a paused one.
+			steps[i].ReadinessProbe = &corev1.Probe{
+				Handler: corev1.Handler{Exec: &corev1.ExecAction{
+					Command: []string{entrypointBinary, entrypoint.PausedCommand, filepath.Join(mountPoint, fmt.Sprintf("%d", i)) + entrypoint.BreakpointPausedSuffix},
+				}},
+				PeriodSeconds:    1,
+				FailureThreshold: 1,
+			}
+		}
 	}
 	// Mount the Downward volume into the first step container.
 	steps[0].VolumeMounts = append(steps[0].VolumeMounts, downwardMount)
@@ -183,6 +223,30 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 	return initContainer, steps, nil
 }
 
+// stepBreakpointArgs returns the entrypoint args of the breakpoints of the step.
+func stepBreakpointArgs(breakpointConfig *v1beta1.TaskRunDebug, stepName string) []string {
+	if !breakpointConfig.HasBreakpoints() {
+		return nil
+	}
+	var args []string
+	for _, b := range breakpointConfig.Breakpoint {
+		if b == BreakpointOnFailure {
+			args = append(args, "-breakpoint_on_failure")
+		}
+	}
+	for _, name := range breakpointConfig.BeforeSteps {
+		if name == stepName {
+			args = append(args, "-breakpoint_before_step")
+		}
+	}
+	for _, name := range breakpointConfig.AfterSteps {
+		if name == stepName {
+			args = append(args, "-breakpoint_after_step")
+		}
+	}
+	return args
+}
+
 func resultArgument(steps []corev1.Container, results []v1beta1.TaskResult) []string {
 	if len(results) == 0 {
 		return nil
@@ -223,8 +287,54 @@ func UpdateReady(ctx context.Context, kubeclient kubernetes.Interface, pod corev
 	return err
 }
 
+// UpdateSidecarsReady updates the Pod's annotations to signal the steps waiting
+// for the sidecars which are ready to start, by projecting the readiness of
+// each sidecar via the Downward API.
+func UpdateSidecarsReady(ctx context.Context, kubeclient kubernetes.Interface, pod corev1.Pod) error {
+	waitedFor := map[string]bool{}
+	for _, v := range pod.Spec.Volumes {
+		if v.Name != downwardVolumeName || v.DownwardAPI == nil {
+			continue
+		}
+		for _, item := range v.DownwardAPI.Items {
+			if strings.HasPrefix(item.Path, downwardMountSidecarsDir+"/") {
+				waitedFor[sidecarReadyAnnotationPrefix+strings.TrimPrefix(item.Path, downwardMountSidecarsDir+"/")] = true
+			}
+		}
+	}
+
+	var patch []jsonpatch.JsonPatchOperation
+	for _, s := range pod.Status.ContainerStatuses {
+		// A terminated sidecar won't become ready, so the steps waiting for it
+		// aren't kept waiting until the TaskRun times out.
+		if !isContainerSidecar(s.Name) || !((s.State.Running != nil && s.Ready) || s.State.Terminated != nil) {
+			continue
+		}
+		annotation := sidecarReadyAnnotation(s.Name)
+		if !waitedFor[annotation] || pod.Annotations[annotation] == readyAnnotationValue {
+			continue
+		}
+		patch = append(patch, jsonpatch.JsonPatchOperation{
+			Operation: "add",
+			Path:      "/metadata/annotations/" + strings.Replace(annotation, "/", "~1", 1),
+			Value:     readyAnnotationValue,
+		})
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	_, err = kubeclient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
+
 // StopSidecars updates sidecar containers in the Pod to a nop image, which
-// exits successfully immediately.
+// exits successfully immediately. Replacing the image of a running sidecar
+// signals it with SIGTERM, and the kubelet gives it the termination grace
+// period of the Pod to exit on its own before it is killed.
 func StopSidecars(ctx context.Context, nopImage string, kubeclient kubernetes.Interface, namespace, name string) (*corev1.Pod, error) {
 	newPod, err := kubeclient.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 	if k8serrors.IsNotFound(err) {
@@ -282,6 +392,101 @@ func isContainerSidecar(name string) bool { return strings.HasPrefix(name, sidec
 // trimStepPrefix returns the container name, stripped of its step prefix.
 func trimStepPrefix(name string) string { return strings.TrimPrefix(name, stepPrefix) }
 
+// sidecarContainerName returns the name of the container of the sidecar.
+func sidecarContainerName(name string) string {
+	return names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, name))
+}
+
+// sidecarReadyAnnotation returns the annotation signaling the steps waiting for
+// the sidecar with the given container name that it is ready.
+func sidecarReadyAnnotation(containerName string) string {
+	return sidecarReadyAnnotationPrefix + TrimSidecarPrefix(containerName)
+}
+
+// sidecarReadyFile returns the file projecting the readiness of the sidecar
+// with the given container name.
+func sidecarReadyFile(containerName string) string {
+	return filepath.Join(downwardMountPoint, downwardMountSidecarsDir, TrimSidecarPrefix(containerName))
+}
+
+// downwardVolumeForSidecars returns the Downward volume projecting the ready
+// annotation, along with the readiness of the sidecars the steps wait for, and of
+// the sidecars started before others.
+func downwardVolumeForSidecars(steps []v1beta1.Step, sidecars []v1beta1.Sidecar) corev1.Volume {
+	volume := *downwardVolume.DeepCopy()
+	var waitedFor []string
+	for _, s := range steps {
+		waitedFor = append(waitedFor, s.WaitForSidecars...)
+	}
+	for i := range sidecars {
+		waitedFor = append(waitedFor, sidecarsStartedBefore(sidecars, i)...)
+	}
+	projected := map[string]bool{}
+	for _, sidecar := range waitedFor {
+		containerName := sidecarContainerName(sidecar)
+		if projected[containerName] {
+			continue
+		}
+		projected[containerName] = true
+		volume.DownwardAPI.Items = append(volume.DownwardAPI.Items, corev1.DownwardAPIVolumeFile{
+			Path: filepath.Join(downwardMountSidecarsDir, TrimSidecarPrefix(containerName)),
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fmt.Sprintf("metadata.annotations['%s']", sidecarReadyAnnotation(containerName)),
+			},
+		})
+	}
+	return volume
+}
+
+// sidecarsStartedBefore returns the names of the sidecars with a lower start order
+// than the sidecar at index i, which it waits for to be ready before starting.
+func sidecarsStartedBefore(sidecars []v1beta1.Sidecar, i int) []string {
+	var names []string
+	for _, s := range sidecars {
+		if s.StartOrder < sidecars[i].StartOrder {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// orderSidecars rewrites the sidecars started after others to run their command
+// with the entrypoint binary, which waits for the sidecars with a lower start order
+// to be ready before running it, the same way steps wait for the sidecars they
+// need. The sidecar containers are given in the order of the sidecars. As for the
+// steps, their command must have been resolved beforehand, using
+// entrypoint_lookup.go.
+func orderSidecars(sidecarContainers []corev1.Container, sidecars []v1beta1.Sidecar) ([]corev1.Container, error) {
+	for i := range sidecars {
+		var sidecarReadyFiles []string
+		for _, name := range sidecarsStartedBefore(sidecars, i) {
+			sidecarReadyFiles = append(sidecarReadyFiles, sidecarReadyFile(sidecarContainerName(name)))
+		}
+		if len(sidecarReadyFiles) == 0 {
+			continue
+		}
+
+		cmd, args := sidecarContainers[i].Command, sidecarContainers[i].Args
+		if len(cmd) == 0 {
+			return nil, fmt.Errorf("Sidecar %d did not specify command", i)
+		}
+		if len(cmd) > 1 {
+			args = append(cmd[1:], args...)
+			cmd = []string{cmd[0]}
+		}
+		argsForEntrypoint := []string{
+			"-sidecar_ready_file", strings.Join(sidecarReadyFiles, ","),
+			// Unlike a step, a sidecar has no start time or results to report.
+			"-termination_path", "",
+			"-entrypoint", cmd[0], "--",
+		}
+		sidecarContainers[i].Command = []string{entrypointBinary}
+		sidecarContainers[i].Args = append(argsForEntrypoint, args...)
+		sidecarContainers[i].VolumeMounts = append(sidecarContainers[i].VolumeMounts, toolsMount, downwardMount)
+	}
+	return sidecarContainers, nil
+}
+
 // TrimSidecarPrefix returns the container name, stripped of its sidecar
 // prefix.
 func TrimSidecarPrefix(name string) string { return strings.TrimPrefix(name, sidecarPrefix) }
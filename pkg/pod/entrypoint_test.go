@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -95,7 +96,7 @@ func TestOrderContainers(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{toolsMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	gotInit, got, err := orderContainers(images.EntrypointImage, []string{}, steps, nil, nil)
+	gotInit, got, err := orderContainers(images.EntrypointImage, []string{}, steps, nil, nil, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -115,6 +116,214 @@ func TestOrderContainers(t *testing.T) {
 	}
 }
 
+func TestOrderContainersWithWaitForSidecars(t *testing.T) {
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-3",
+		Command: []string{"cmd"},
+	}}
+	taskSpec := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			WaitForSidecars: []string{"db"},
+		}, {}, {
+			WaitForSidecars: []string{"db", "cache"},
+		}},
+	}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-sidecar_ready_file", "/tekton/downward/sidecars/db",
+			"-post_file", "/tekton/tools/0",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-unnamed-0",
+			"-step_metadata_dir_link", "/tekton/steps/0",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/0",
+			"-post_file", "/tekton/tools/1",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-unnamed-1",
+			"-step_metadata_dir_link", "/tekton/steps/1",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-3",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/1",
+			"-sidecar_ready_file", "/tekton/downward/sidecars/db,/tekton/downward/sidecars/cache",
+			"-post_file", "/tekton/tools/2",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-unnamed-2",
+			"-step_metadata_dir_link", "/tekton/steps/2",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, taskSpec, nil, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+
+	wantVolume := corev1.Volume{
+		Name: downwardVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path:     "ready",
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['tekton.dev/ready']"},
+				}, {
+					Path:     "sidecars/db",
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['sidecar-ready.tekton.dev/db']"},
+				}, {
+					Path:     "sidecars/cache",
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['sidecar-ready.tekton.dev/cache']"},
+				}},
+			},
+		},
+	}
+	if d := cmp.Diff(wantVolume, downwardVolumeForSidecars(taskSpec.Steps, taskSpec.Sidecars)); d != "" {
+		t.Errorf("Downward volume Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestOrderSidecars(t *testing.T) {
+	sidecars := []v1beta1.Sidecar{{
+		Container: corev1.Container{Name: "proxy"},
+	}, {
+		Container:  corev1.Container{Name: "db"},
+		StartOrder: 1,
+	}, {
+		Container:  corev1.Container{Name: "cache"},
+		StartOrder: 1,
+	}, {
+		Container:  corev1.Container{Name: "app"},
+		StartOrder: 2,
+	}}
+	sidecarContainers := []corev1.Container{{
+		Name:    "proxy",
+		Image:   "proxy-image",
+		Command: []string{"envoy"},
+	}, {
+		Name:    "db",
+		Image:   "db-image",
+		Command: []string{"postgres", "-D", "/data"},
+	}, {
+		Name:    "cache",
+		Image:   "cache-image",
+		Command: []string{"redis-server"},
+		Args:    []string{"--port", "6379"},
+	}, {
+		Name:    "app",
+		Image:   "app-image",
+		Command: []string{"app"},
+	}}
+	want := []corev1.Container{{
+		Name:    "proxy",
+		Image:   "proxy-image",
+		Command: []string{"envoy"},
+	}, {
+		Name:    "db",
+		Image:   "db-image",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-sidecar_ready_file", "/tekton/downward/sidecars/proxy",
+			"-termination_path", "",
+			"-entrypoint", "postgres", "--",
+			"-D", "/data",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount, downwardMount},
+	}, {
+		Name:    "cache",
+		Image:   "cache-image",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-sidecar_ready_file", "/tekton/downward/sidecars/proxy",
+			"-termination_path", "",
+			"-entrypoint", "redis-server", "--",
+			"--port", "6379",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount, downwardMount},
+	}, {
+		Name:    "app",
+		Image:   "app-image",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-sidecar_ready_file", "/tekton/downward/sidecars/proxy,/tekton/downward/sidecars/db,/tekton/downward/sidecars/cache",
+			"-termination_path", "",
+			"-entrypoint", "app", "--",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount, downwardMount},
+	}}
+	got, err := orderSidecars(sidecarContainers, sidecars)
+	if err != nil {
+		t.Fatalf("orderSidecars: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+
+	wantVolume := corev1.Volume{
+		Name: downwardVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path:     "ready",
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['tekton.dev/ready']"},
+				}, {
+					Path:     "sidecars/proxy",
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['sidecar-ready.tekton.dev/proxy']"},
+				}, {
+					Path:     "sidecars/db",
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['sidecar-ready.tekton.dev/db']"},
+				}, {
+					Path:     "sidecars/cache",
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['sidecar-ready.tekton.dev/cache']"},
+				}},
+			},
+		},
+	}
+	if d := cmp.Diff(wantVolume, downwardVolumeForSidecars(nil, sidecars)); d != "" {
+		t.Errorf("Downward volume Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestOrderSidecarsNoCommand(t *testing.T) {
+	sidecars := []v1beta1.Sidecar{{
+		Container: corev1.Container{Name: "proxy"},
+	}, {
+		Container:  corev1.Container{Name: "db"},
+		StartOrder: 1,
+	}}
+	sidecarContainers := []corev1.Container{{
+		Name:    "proxy",
+		Command: []string{"envoy"},
+	}, {
+		Name: "db",
+	}}
+	if _, err := orderSidecars(sidecarContainers, sidecars); err == nil {
+		t.Error("orderSidecars: expected an error for a sidecar without command")
+	}
+}
+
 func TestOrderContainersWithDebugOnFailure(t *testing.T) {
 	steps := []corev1.Container{{
 		Image:   "step-1",
@@ -137,11 +346,88 @@ func TestOrderContainersWithDebugOnFailure(t *testing.T) {
 		},
 		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
 		TerminationMessagePath: "/tekton/termination",
+		ReadinessProbe:         pausedProbe("/tekton/tools/0.paused"),
 	}}
 	taskRunDebugConfig := &v1beta1.TaskRunDebug{
 		Breakpoint: []string{"onFailure"},
 	}
-	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, nil, taskRunDebugConfig)
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, nil, taskRunDebugConfig, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestOrderContainersWithDebugBeforeAndAfterSteps(t *testing.T) {
+	steps := []corev1.Container{{
+		Name:    "build",
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Name:    "test",
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}, {
+		Name:    "push",
+		Image:   "step-3",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Name:    "build",
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-build",
+			"-step_metadata_dir_link", "/tekton/steps/0",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Name:    "test",
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/0",
+			"-post_file", "/tekton/tools/1",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-test",
+			"-step_metadata_dir_link", "/tekton/steps/1",
+			"-breakpoint_before_step",
+			"-breakpoint_after_step",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount},
+		TerminationMessagePath: "/tekton/termination",
+		ReadinessProbe:         pausedProbe("/tekton/tools/1.paused"),
+	}, {
+		Name:    "push",
+		Image:   "step-3",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/1",
+			"-post_file", "/tekton/tools/2",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-push",
+			"-step_metadata_dir_link", "/tekton/steps/2",
+			"-breakpoint_after_step",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount},
+		TerminationMessagePath: "/tekton/termination",
+		ReadinessProbe:         pausedProbe("/tekton/tools/2.paused"),
+	}}
+	taskRunDebugConfig := &v1beta1.TaskRunDebug{
+		BeforeSteps: []string{"test"},
+		AfterSteps:  []string{"test", "push"},
+	}
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, nil, taskRunDebugConfig, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -150,6 +436,16 @@ func TestOrderContainersWithDebugOnFailure(t *testing.T) {
 	}
 }
 
+func pausedProbe(pausedFile string) *corev1.Probe {
+	return &corev1.Probe{
+		Handler: corev1.Handler{Exec: &corev1.ExecAction{
+			Command: []string{entrypointBinary, "paused", pausedFile},
+		}},
+		PeriodSeconds:    1,
+		FailureThreshold: 1,
+	}
+}
+
 func TestEntryPointResults(t *testing.T) {
 	taskSpec := v1beta1.TaskSpec{
 		Results: []v1beta1.TaskResult{{
@@ -223,7 +519,7 @@ func TestEntryPointResults(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{toolsMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil)
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -265,7 +561,7 @@ func TestEntryPointResultsSingleStep(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil)
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -303,7 +599,7 @@ func TestEntryPointSingleResultsSingleStep(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil)
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -363,7 +659,89 @@ func TestEntryPointOnError(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{toolsMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil)
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEntryPointRetries(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Retries: 3,
+		}},
+	}
+
+	steps := []corev1.Container{{
+		Name:    "flaky-step",
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}}
+
+	want := []corev1.Container{{
+		Name:    "flaky-step",
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-flaky-step",
+			"-step_metadata_dir_link", "/tekton/steps/0",
+			"-retries", "3",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEntryPointStepWhen(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			When: v1beta1.WhenExpressions{{
+				Input:    "$(results.changed)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+		}},
+	}
+
+	steps := []corev1.Container{{
+		Name:    "optional-step",
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}}
+
+	want := []corev1.Container{{
+		Name:    "optional-step",
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/steps/step-optional-step",
+			"-step_metadata_dir_link", "/tekton/steps/0",
+			"-when", `[{"input":"$(results.changed)","operator":"in","values":["true"]}]`,
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, nil, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -563,3 +941,54 @@ func TestStopSidecars(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateSidecarsReady(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Annotations: map[string]string{sidecarReadyAnnotationPrefix + "ready-before": readyAnnotationValue},
+		},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{downwardVolumeForSidecars([]v1beta1.Step{{
+				WaitForSidecars: []string{"db", "cache", "ready-before"},
+			}}, nil)},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "sidecar-db",
+				Ready: true,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}, {
+				// Not ready yet.
+				Name:  "sidecar-cache",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}, {
+				// Already signaled as ready.
+				Name:  "sidecar-ready-before",
+				Ready: true,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}, {
+				// No step waits for it.
+				Name:  "sidecar-unused",
+				Ready: true,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+	kubeclient := fakek8s.NewSimpleClientset(&pod)
+	if err := UpdateSidecarsReady(context.Background(), kubeclient, pod); err != nil {
+		t.Fatalf("UpdateSidecarsReady: %v", err)
+	}
+	got, err := kubeclient.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting the Pod: %v", err)
+	}
+	want := map[string]string{
+		sidecarReadyAnnotationPrefix + "db":           readyAnnotationValue,
+		sidecarReadyAnnotationPrefix + "ready-before": readyAnnotationValue,
+	}
+	if d := cmp.Diff(want, got.Annotations); d != "" {
+		t.Errorf("Annotations Diff %s", diff.PrintWantGot(d))
+	}
+}
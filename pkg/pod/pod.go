@@ -19,6 +19,7 @@ package pod
 import (
 	"context"
 	"fmt"
+	"math"
 	"path/filepath"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -132,13 +133,27 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	if err != nil {
 		return nil, err
 	}
+	// The StepTemplate of the TaskRun is merged afterwards, so that its values take precedence
+	// over the ones of the Steps and of the StepTemplate of the Task.
+	steps, err = v1beta1.MergeStepsWithTaskRunStepTemplate(taskRun.Spec.StepTemplate, steps)
+	if err != nil {
+		return nil, err
+	}
+	sidecars, err := v1beta1.MergeSidecarsWithSidecarTemplate(taskSpec.SidecarTemplate, taskSpec.Sidecars)
+	if err != nil {
+		return nil, err
+	}
+	// The overrides of the TaskRun are merged last, so that they replace the compute
+	// resources of the Steps and Sidecars they name.
+	steps = v1beta1.MergeStepsWithOverrides(steps, taskRun.Spec.StepOverrides)
+	sidecars = v1beta1.MergeSidecarsWithOverrides(sidecars, taskRun.Spec.SidecarOverrides)
 
 	// Convert any steps with Script to command+args.
 	// If any are found, append an init container to initialize scripts.
 	if alphaAPIEnabled {
-		scriptsInit, stepContainers, sidecarContainers = convertScripts(b.Images.ShellImage, b.Images.ShellImageWin, steps, taskSpec.Sidecars, taskRun.Spec.Debug)
+		scriptsInit, stepContainers, sidecarContainers = convertScripts(b.Images.ShellImage, b.Images.ShellImageWin, steps, sidecars, taskRun.Spec.Debug)
 	} else {
-		scriptsInit, stepContainers, sidecarContainers = convertScripts(b.Images.ShellImage, "", steps, taskSpec.Sidecars, nil)
+		scriptsInit, stepContainers, sidecarContainers = convertScripts(b.Images.ShellImage, "", steps, sidecars, nil)
 	}
 	if scriptsInit != nil {
 		initContainers = append(initContainers, *scriptsInit)
@@ -160,29 +175,69 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		return nil, err
 	}
 
+	// Sidecars started after others are run by the entrypoint binary too, which waits
+	// for the sidecars started before them to be ready.
+	for i := range sidecars {
+		if len(sidecarsStartedBefore(sidecars, i)) == 0 {
+			continue
+		}
+		if _, err := resolveEntrypoints(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, sidecarContainers[i:i+1]); err != nil {
+			return nil, err
+		}
+	}
+	sidecarContainers, err = orderSidecars(sidecarContainers, sidecars)
+	if err != nil {
+		return nil, err
+	}
+
 	// Rewrite steps with entrypoint binary. Append the entrypoint init
 	// container to place the entrypoint binary. Also add timeout flags
 	// to entrypoint binary.
+	// Results that are read from the logs of a sidecar are not written to
+	// the termination message, so they are not bound by its size limit.
+	resultsFromSidecarLogs := config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs && len(taskSpec.Results) > 0
+	commonExtraEntrypointArgs := credEntrypointArgs
+	if featureFlags := config.FromContextOrDefaults(ctx).FeatureFlags; featureFlags.EnableStructuredStepLogs || featureFlags.EnableStepProgress {
+		// Steps prefix their output with their name and a timestamp, which
+		// their progress is read from.
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-structured_logs")
+	}
 	if alphaAPIEnabled {
-		entrypointInit, stepContainers, err = orderContainers(b.Images.EntrypointImage, credEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug)
+		entrypointInit, stepContainers, err = orderContainers(b.Images.EntrypointImage, commonExtraEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug, resultsFromSidecarLogs)
 	} else {
-		entrypointInit, stepContainers, err = orderContainers(b.Images.EntrypointImage, credEntrypointArgs, stepContainers, &taskSpec, nil)
+		entrypointInit, stepContainers, err = orderContainers(b.Images.EntrypointImage, commonExtraEntrypointArgs, stepContainers, &taskSpec, nil, resultsFromSidecarLogs)
 	}
 	if err != nil {
 		return nil, err
 	}
+	if resultsFromSidecarLogs {
+		sidecarContainers = append(sidecarContainers, sidecarLogResultsContainer(b.Images.EntrypointImage, len(stepContainers), taskSpec.Results, taskRun.GetTimeout(ctx)))
+	}
 	// place the entrypoint first in case other init containers rely on its
 	// features (e.g. decode-script).
 	initContainers = append([]corev1.Container{entrypointInit}, initContainers...)
-	volumes = append(volumes, toolsVolume, downwardVolume)
+	volumes = append(volumes, toolsVolume, downwardVolumeForSidecars(taskSpec.Steps, sidecars))
 
 	limitRangeMin, err := getLimitRangeMinimum(ctx, taskRun.Namespace, b.KubeClient)
 	if err != nil {
 		return nil, err
 	}
 
-	// Zero out non-max resource requests.
-	stepContainers = resolveResourceRequests(stepContainers, limitRangeMin)
+	// The compute resource budget of the TaskRun replaces the one of the Task.
+	budget := taskSpec.ComputeResources
+	if taskRun.Spec.ComputeResources != nil {
+		budget = taskRun.Spec.ComputeResources
+	}
+	if budget != nil {
+		// Spread the budget over the steps.
+		stepContainers, err = resolveComputeResourceBudget(stepContainers, sidecarContainers, initContainers, *budget, limitRangeMin)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Zero out non-max resource requests.
+		stepContainers = resolveResourceRequests(stepContainers, limitRangeMin)
+	}
 
 	// Add implicit env vars.
 	// They're prepended to the list, so that if the user specified any
@@ -274,7 +329,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 
 	// Merge sidecar containers with step containers.
 	for _, sc := range sidecarContainers {
-		sc.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sc.Name))
+		sc.Name = sidecarContainerName(sc.Name)
 		mergedPodContainers = append(mergedPodContainers, sc)
 	}
 
@@ -317,29 +372,48 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 			Labels:      makeLabels(taskRun),
 		},
 		Spec: corev1.PodSpec{
-			RestartPolicy:                corev1.RestartPolicyNever,
-			InitContainers:               initContainers,
-			Containers:                   mergedPodContainers,
-			ServiceAccountName:           taskRun.Spec.ServiceAccountName,
-			Volumes:                      volumes,
-			NodeSelector:                 podTemplate.NodeSelector,
-			Tolerations:                  podTemplate.Tolerations,
-			Affinity:                     affinity,
-			SecurityContext:              podTemplate.SecurityContext,
-			RuntimeClassName:             podTemplate.RuntimeClassName,
-			AutomountServiceAccountToken: podTemplate.AutomountServiceAccountToken,
-			SchedulerName:                podTemplate.SchedulerName,
-			HostNetwork:                  podTemplate.HostNetwork,
-			DNSPolicy:                    dnsPolicy,
-			DNSConfig:                    podTemplate.DNSConfig,
-			EnableServiceLinks:           podTemplate.EnableServiceLinks,
-			PriorityClassName:            priorityClassName,
-			ImagePullSecrets:             podTemplate.ImagePullSecrets,
-			HostAliases:                  podTemplate.HostAliases,
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			InitContainers:                initContainers,
+			Containers:                    mergedPodContainers,
+			ServiceAccountName:            taskRun.Spec.ServiceAccountName,
+			Volumes:                       volumes,
+			NodeSelector:                  podTemplate.NodeSelector,
+			Tolerations:                   podTemplate.Tolerations,
+			Affinity:                      affinity,
+			SecurityContext:               podTemplate.SecurityContext,
+			RuntimeClassName:              podTemplate.RuntimeClassName,
+			AutomountServiceAccountToken:  podTemplate.AutomountServiceAccountToken,
+			SchedulerName:                 podTemplate.SchedulerName,
+			HostNetwork:                   podTemplate.HostNetwork,
+			DNSPolicy:                     dnsPolicy,
+			DNSConfig:                     podTemplate.DNSConfig,
+			EnableServiceLinks:            podTemplate.EnableServiceLinks,
+			PriorityClassName:             priorityClassName,
+			ImagePullSecrets:              podTemplate.ImagePullSecrets,
+			HostAliases:                   podTemplate.HostAliases,
+			TerminationGracePeriodSeconds: sidecarsTerminationGracePeriodSeconds(sidecars),
 		},
 	}, nil
 }
 
+// sidecarsTerminationGracePeriodSeconds returns the termination grace period of the
+// Pod covering the termination grace periods of the sidecars, if any is longer than
+// the default one. The kubelet gives the sidecars it to exit once they're stopped.
+func sidecarsTerminationGracePeriodSeconds(sidecars []v1beta1.Sidecar) *int64 {
+	var seconds int64 = corev1.DefaultTerminationGracePeriodSeconds
+	for _, s := range sidecars {
+		if s.TerminationGracePeriod != nil {
+			if d := int64(math.Ceil(s.TerminationGracePeriod.Seconds())); d > seconds {
+				seconds = d
+			}
+		}
+	}
+	if seconds == corev1.DefaultTerminationGracePeriodSeconds {
+		return nil
+	}
+	return &seconds
+}
+
 // makeLabels constructs the labels we will propagate from TaskRuns to Pods.
 func makeLabels(s *v1beta1.TaskRun) map[string]string {
 	labels := make(map[string]string, len(s.ObjectMeta.Labels)+1)
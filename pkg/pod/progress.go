@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// stepProgressMarker starts the lines a step prints to report its
+	// progress, once the entrypoint has prefixed them.
+	stepProgressMarker = "::progress::"
+	// stepProgressLogTailLines is the number of the last lines of the logs of
+	// a running step in which its latest progress is looked for.
+	stepProgressLogTailLines = 100
+)
+
+// SetStepProgressFromLogs reads the latest progress reported by the running
+// steps of the TaskRun from the tail of their logs, and sets it in their
+// StepState. A step that didn't report its progress in the tail of its logs
+// keeps the progress it reported earlier.
+func SetStepProgressFromLogs(ctx context.Context, kubeclient kubernetes.Interface, tr *v1beta1.TaskRun, pod *corev1.Pod) error {
+	var merr *multierror.Error
+	for i, step := range tr.Status.Steps {
+		if step.Running == nil {
+			continue
+		}
+		tailLines := int64(stepProgressLogTailLines)
+		logs, err := kubeclient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: step.ContainerName, TailLines: &tailLines}).Stream(ctx)
+		if err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("error reading the logs of step %q of Pod %q: %w", step.Name, pod.Name, err))
+			continue
+		}
+		progress, err := parseStepProgress(logs)
+		logs.Close()
+		if err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("error reading the logs of step %q of Pod %q: %w", step.Name, pod.Name, err))
+			continue
+		}
+		if progress != "" {
+			tr.Status.Steps[i].Progress = progress
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// parseStepProgress returns the last progress message in the structured logs
+// of a step, where each line is prefixed with the name of the step and a
+// timestamp, or an empty string if there is none.
+func parseStepProgress(r io.Reader) (string, error) {
+	var progress string
+	reader := bufio.NewReader(r)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return "", readErr
+		}
+		fields := strings.SplitN(strings.TrimRight(line, "\r\n"), " ", 3)
+		if len(fields) == 3 && strings.HasPrefix(fields[0], "[") && strings.HasSuffix(fields[0], "]") && strings.HasPrefix(fields[2], stepProgressMarker) {
+			progress = strings.TrimSpace(strings.TrimPrefix(fields[2], stepProgressMarker))
+		}
+		if readErr == io.EOF {
+			return progress, nil
+		}
+	}
+}
+
+// keepStepProgress copies the progress of the previous states of the steps
+// to their new states, since it's only read from their logs while they run.
+func keepStepProgress(previous, steps []v1beta1.StepState) {
+	for _, p := range previous {
+		if p.Progress == "" {
+			continue
+		}
+		for i := range steps {
+			if steps[i].ContainerName == p.ContainerName && steps[i].Progress == "" {
+				steps[i].Progress = p.Progress
+			}
+		}
+	}
+}
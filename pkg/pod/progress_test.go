@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/logging"
+)
+
+func TestParseStepProgress(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		logs string
+		want string
+	}{{
+		desc: "no progress",
+		logs: "[step-foo] 2022-03-04T05:06:07.890Z hello\n",
+		want: "",
+	}, {
+		desc: "last progress",
+		logs: "[step-foo] 2022-03-04T05:06:07.890Z ::progress::10%\n" +
+			"[step-foo] 2022-03-04T05:06:08.890Z downloading\n" +
+			"[step-foo] 2022-03-04T05:06:09.890Z ::progress:: 50% \n" +
+			"[step-foo] 2022-03-04T05:06:10.890Z still downloading\n",
+		want: "50%",
+	}, {
+		desc: "progress on the last line without newline",
+		logs: "[step-foo] 2022-03-04T05:06:07.890Z ::progress::10%\n[step-foo] 2022-03-04T05:06:09.890Z ::progress::done",
+		want: "done",
+	}, {
+		desc: "progress marker not at the start of the output",
+		logs: "[step-foo] 2022-03-04T05:06:07.890Z echo ::progress::10%\n",
+		want: "",
+	}, {
+		desc: "unprefixed output",
+		logs: "::progress::10%\n",
+		want: "",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseStepProgress(strings.NewReader(tc.logs))
+			if err != nil {
+				t.Fatalf("parseStepProgress: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unexpected progress %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSetStepProgressFromLogs(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"}}
+	kubeclient := fakek8s.NewSimpleClientset(pod)
+	tr := &v1beta1.TaskRun{
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					Name:           "foo",
+					ContainerName:  "step-foo",
+					Progress:       "10%",
+				}},
+			},
+		},
+	}
+	// The fake client returns logs without progress, so the earlier progress is kept.
+	if err := SetStepProgressFromLogs(context.Background(), kubeclient, tr, pod); err != nil {
+		t.Fatalf("SetStepProgressFromLogs: %v", err)
+	}
+	if tr.Status.Steps[0].Progress != "10%" {
+		t.Errorf("Expected the progress of the step to be kept but got %q", tr.Status.Steps[0].Progress)
+	}
+}
+
+func TestMakeTaskRunStatusKeepsStepProgress(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-foo",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}, {
+				Name:  "step-bar",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+	tr := v1beta1.TaskRun{
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					Name:           "foo",
+					ContainerName:  "step-foo",
+					Progress:       "done",
+				}, {
+					ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}},
+					Name:           "bar",
+					ContainerName:  "step-bar",
+				}},
+			},
+		},
+	}
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(context.Background(), logger, tr, pod)
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus: %v", err)
+	}
+	var progress []string
+	for _, step := range got.Steps {
+		progress = append(progress, step.Progress)
+	}
+	if d := cmp.Diff([]string{"done", ""}, progress); d != "" {
+		t.Errorf("Unexpected progress of the steps %s", diff.PrintWantGot(d))
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package pod
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -78,3 +80,85 @@ func resolveResourceRequests(containers []corev1.Container, limitRangeMin corev1
 
 	return containers
 }
+
+// resolveComputeResourceBudget spreads the compute resource budget of a Task over the
+// containers of its steps, replacing their compute resources. The steps run one after
+// another, so the requests of the budget left by the sidecars and by the LimitRange
+// minimums of the other steps are set on the first step, and the scheduler sees the
+// budget as the peak of the pod. The init containers run before the steps, so their
+// requests only need to fit in the budget. The limits of the budget left by the
+// sidecars are set on every step, and a limit without a request is only requested by
+// the first step.
+func resolveComputeResourceBudget(steps, sidecars, inits []corev1.Container, budget corev1.ResourceRequirements, limitRangeMin corev1.ResourceList) ([]corev1.Container, error) {
+	if len(steps) == 0 {
+		return steps, nil
+	}
+
+	requests := corev1.ResourceList{}
+	otherRequests := corev1.ResourceList{}
+	for name, total := range budget.Requests {
+		minimum := limitRangeMin[name]
+		if minimum == emptyResourceQuantity {
+			minimum = zeroQty
+		}
+		for _, c := range inits {
+			if q, ok := c.Resources.Requests[name]; ok && q.Cmp(total) > 0 {
+				return nil, fmt.Errorf("the %s request %s of the compute resources is lower than the request %s of init container %s", name, total.String(), q.String(), c.Name)
+			}
+		}
+		available := total.DeepCopy()
+		for _, c := range sidecars {
+			if q, ok := c.Resources.Requests[name]; ok {
+				available.Sub(q)
+			}
+		}
+		for range steps[1:] {
+			available.Sub(minimum)
+		}
+		if available.Cmp(minimum) < 0 {
+			return nil, fmt.Errorf("the %s request %s of the compute resources doesn't cover the requests of the sidecars and the LimitRange minimum of the steps", name, total.String())
+		}
+		requests[name] = available
+		otherRequests[name] = minimum
+	}
+
+	limits := corev1.ResourceList{}
+	for name, total := range budget.Limits {
+		available := total.DeepCopy()
+		for _, c := range sidecars {
+			if q, ok := c.Resources.Limits[name]; ok {
+				available.Sub(q)
+			}
+		}
+		if available.Sign() <= 0 {
+			return nil, fmt.Errorf("the %s limit %s of the compute resources doesn't cover the limits of the sidecars", name, total.String())
+		}
+		if q, ok := requests[name]; ok && q.Cmp(available) > 0 {
+			return nil, fmt.Errorf("the %s request of the steps is higher than their limit %s", name, available.String())
+		}
+		limits[name] = available
+		if _, ok := budget.Requests[name]; !ok {
+			// Kubernetes defaults the request of the first step to its limit. The other
+			// steps need an explicit request, or theirs would default to the limit too.
+			minimum := limitRangeMin[name]
+			if minimum == emptyResourceQuantity {
+				minimum = zeroQty
+			}
+			otherRequests[name] = minimum
+		}
+	}
+
+	for i := range steps {
+		steps[i].Resources = corev1.ResourceRequirements{}
+		if len(limits) > 0 {
+			steps[i].Resources.Limits = limits.DeepCopy()
+		}
+		switch {
+		case i == 0 && len(requests) > 0:
+			steps[i].Resources.Requests = requests
+		case i > 0 && len(otherRequests) > 0:
+			steps[i].Resources.Requests = otherRequests.DeepCopy()
+		}
+	}
+	return steps, nil
+}
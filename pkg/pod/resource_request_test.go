@@ -344,3 +344,162 @@ func TestResolveResourceRequests_LimitRange(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveComputeResourceBudget(t *testing.T) {
+	limitRangeMin := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("64Mi"),
+	}
+	for _, c := range []struct {
+		desc     string
+		budget   corev1.ResourceRequirements
+		steps    []corev1.Container
+		sidecars []corev1.Container
+		inits    []corev1.Container
+		want     []corev1.Container
+	}{{
+		desc: "requests on the first step, minimum on the others",
+		budget: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+		steps: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")},
+			},
+		}, {}, {}},
+		want: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1800m"),
+					corev1.ResourceMemory: resource.MustParse("896Mi"),
+				},
+			},
+		}, {
+			Resources: corev1.ResourceRequirements{Requests: limitRangeMin},
+		}, {
+			Resources: corev1.ResourceRequirements{Requests: limitRangeMin},
+		}},
+	}, {
+		desc: "sidecars are taken out of the budget",
+		budget: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		},
+		steps: []corev1.Container{{}, {}},
+		sidecars: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		}},
+		inits: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		}},
+		want: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1400m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+			},
+		}, {
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+			},
+		}},
+	}, {
+		desc: "limits without requests are only requested by the first step",
+		budget: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory:           resource.MustParse("1Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+			},
+		},
+		steps: []corev1.Container{{}, {}},
+		want: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1900m")},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory:           resource.MustParse("1Gi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+				},
+			},
+		}, {
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("100m"),
+					corev1.ResourceMemory:           resource.MustParse("64Mi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("0"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory:           resource.MustParse("1Gi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+				},
+			},
+		}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := resolveComputeResourceBudget(c.steps, c.sidecars, c.inits, c.budget, limitRangeMin)
+			if err != nil {
+				t.Fatalf("resolveComputeResourceBudget() = %v", err)
+			}
+			if d := cmp.Diff(c.want, got, resourceQuantityCmp); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolveComputeResourceBudget_Error(t *testing.T) {
+	for _, c := range []struct {
+		desc     string
+		budget   corev1.ResourceRequirements
+		sidecars []corev1.Container
+		inits    []corev1.Container
+	}{{
+		desc: "requests don't cover the minimum of the steps",
+		budget: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("150m")},
+		},
+	}, {
+		desc: "requests don't cover an init container",
+		budget: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+		inits: []corev1.Container{{
+			Name: "init",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		}},
+	}, {
+		desc: "limits don't cover the sidecars",
+		budget: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+		sidecars: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}},
+	}, {
+		desc: "requests of the steps are higher than their limits",
+		budget: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			steps := []corev1.Container{{}, {}}
+			limitRangeMin := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}
+			if _, err := resolveComputeResourceBudget(steps, c.sidecars, c.inits, c.budget, limitRangeMin); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
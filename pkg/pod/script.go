@@ -95,7 +95,6 @@ func convertScripts(shellImageLinux string, shellImageWin string, steps []v1beta
 		VolumeMounts: []corev1.VolumeMount{writeScriptsVolumeMount, toolsMount},
 	}
 
-	breakpoints := []string{}
 	sideCarSteps := []v1beta1.Step{}
 	for _, step := range sidecars {
 		sidecarStep := v1beta1.Step{
@@ -106,16 +105,16 @@ func convertScripts(shellImageLinux string, shellImageWin string, steps []v1beta
 		sideCarSteps = append(sideCarSteps, sidecarStep)
 	}
 
-	// Add mounts for debug
-	if debugConfig != nil && len(debugConfig.Breakpoint) > 0 {
-		breakpoints = debugConfig.Breakpoint
-		placeScriptsInit.VolumeMounts = append(placeScriptsInit.VolumeMounts, debugScriptsVolumeMount)
-	}
+	convertedStepContainers := convertListOfSteps(steps, &placeScriptsInit, &placeScripts, "script")
 
-	convertedStepContainers := convertListOfSteps(steps, &placeScriptsInit, &placeScripts, breakpoints, "script")
+	// Add mounts and scripts for debug to all the steps, whether they have a script or not,
+	// so that any step paused at a breakpoint can be resumed.
+	if debugConfig.HasBreakpoints() {
+		placeScripts = true
+		placeDebugScripts(&placeScriptsInit, convertedStepContainers)
+	}
 
-	// Pass empty breakpoint list in "sidecar step to container" converter to not rewrite the scripts and add breakpoints to sidecar
-	sidecarContainers := convertListOfSteps(sideCarSteps, &placeScriptsInit, &placeScripts, []string{}, "sidecar-script")
+	sidecarContainers := convertListOfSteps(sideCarSteps, &placeScriptsInit, &placeScripts, "sidecar-script")
 	if placeScripts {
 		return &placeScriptsInit, convertedStepContainers, sidecarContainers
 	}
@@ -126,7 +125,7 @@ func convertScripts(shellImageLinux string, shellImageWin string, steps []v1beta
 //
 // It iterates through the list of steps (or sidecars), generates the script file name and heredoc termination string,
 // adds an entry to the init container args, sets up the step container to run the script, and sets the volume mounts.
-func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, placeScripts *bool, breakpoints []string, namePrefix string) []corev1.Container {
+func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, placeScripts *bool, namePrefix string) []corev1.Container {
 	containers := []corev1.Container{}
 	for i, s := range steps {
 		if s.Script == "" {
@@ -185,44 +184,44 @@ cat > ${scriptfile} << '%s'
 			steps[i].Command = []string{scriptFile}
 		}
 		steps[i].VolumeMounts = append(steps[i].VolumeMounts, scriptsVolumeMount)
-
-		// Add debug mounts if breakpoints are present
-		if len(breakpoints) > 0 {
-			debugInfoVolumeMount := corev1.VolumeMount{
-				Name:      debugInfoVolumeName,
-				MountPath: filepath.Join(debugInfoDir, fmt.Sprintf("%d", i)),
-			}
-			steps[i].VolumeMounts = append(steps[i].VolumeMounts, debugScriptsVolumeMount, debugInfoVolumeMount)
-		}
 		containers = append(containers, steps[i].Container)
 	}
 
-	// Place debug scripts if breakpoints are enabled
-	if len(breakpoints) > 0 {
-		type script struct {
-			name    string
-			content string
-		}
-		debugScripts := []script{{
-			name:    "continue",
-			content: defaultScriptPreamble + fmt.Sprintf(debugContinueScriptTemplate, len(steps), debugInfoDir, mountPoint),
-		}, {
-			name:    "fail-continue",
-			content: defaultScriptPreamble + fmt.Sprintf(debugFailScriptTemplate, len(steps), debugInfoDir, mountPoint),
-		}}
-
-		// Add debug or breakpoint related scripts to /tekton/debug/scripts
-		// Iterate through the debugScripts and add routine for each of them in the initContainer for their creation
-		for _, debugScript := range debugScripts {
-			tmpFile := filepath.Join(debugScriptsDir, fmt.Sprintf("%s-%s", "debug", debugScript.name))
-			heredoc := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s-heredoc-randomly-generated", "debug", debugScript.name))
+	return containers
+}
 
-			initContainer.Args[1] += fmt.Sprintf(initScriptDirective, tmpFile, heredoc, debugScript.content, heredoc)
+// placeDebugScripts adds the debug scripts resuming the steps paused at a breakpoint to the
+// init container, and mounts them along with the debug info of each step in the step containers.
+func placeDebugScripts(initContainer *corev1.Container, steps []corev1.Container) {
+	initContainer.VolumeMounts = append(initContainer.VolumeMounts, debugScriptsVolumeMount)
+	for i := range steps {
+		debugInfoVolumeMount := corev1.VolumeMount{
+			Name:      debugInfoVolumeName,
+			MountPath: filepath.Join(debugInfoDir, fmt.Sprintf("%d", i)),
 		}
-
+		steps[i].VolumeMounts = append(steps[i].VolumeMounts, debugScriptsVolumeMount, debugInfoVolumeMount)
 	}
 
-	return containers
+	type script struct {
+		name    string
+		content string
+	}
+	debugScripts := []script{{
+		name:    "continue",
+		content: defaultScriptPreamble + fmt.Sprintf(debugContinueScriptTemplate, debugInfoDir, mountPoint),
+	}, {
+		name:    "fail-continue",
+		content: defaultScriptPreamble + fmt.Sprintf(debugFailScriptTemplate, debugInfoDir, mountPoint),
+	}}
+
+	// Add debug or breakpoint related scripts to /tekton/debug/scripts
+	// Iterate through the debugScripts and add routine for each of them in the initContainer for their creation
+	for _, debugScript := range debugScripts {
+		tmpFile := filepath.Join(debugScriptsDir, fmt.Sprintf("%s-%s", "debug", debugScript.name))
+		heredoc := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s-heredoc-randomly-generated", "debug", debugScript.name))
+
+		initContainer.Args[1] += fmt.Sprintf(initScriptDirective, tmpFile, heredoc, debugScript.content, heredoc)
+	}
 }
 
 // encodeScript encodes a script field into a format that avoids kubernetes' built-in processing of container args,
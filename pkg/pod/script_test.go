@@ -17,6 +17,12 @@ limitations under the License.
 package pod
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -277,21 +283,18 @@ cat > ${tmpfile} << 'debug-continue-heredoc-randomly-generated-78c5n'
 #!/bin/sh
 set -xe
 
-numberOfSteps=4
 debugInfo=/tekton/debug/info
 tektonTools=/tekton/tools
 
-postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
+postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | sort -n | tail -1)"
 stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
 
-if [ $stepNumber -lt $numberOfSteps ]; then
-	touch ${tektonTools}/${stepNumber} # Mark step as success
-	echo "0" > ${tektonTools}/${stepNumber}.breakpointexit
-	echo "Executing step $stepNumber..."
-else
-	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
-	exit 0
+if [ ! -f ${tektonTools}/${stepNumber}.paused ]; then
+	echo "Step $stepNumber isn't paused at a breakpoint"
+	exit 1
 fi
+echo "0" > ${tektonTools}/${stepNumber}.breakpointexit # Mark step as success
+echo "Resuming step $stepNumber..."
 debug-continue-heredoc-randomly-generated-78c5n
 tmpfile="/tekton/debug/scripts/debug-fail-continue"
 touch ${tmpfile} && chmod +x ${tmpfile}
@@ -299,21 +302,18 @@ cat > ${tmpfile} << 'debug-fail-continue-heredoc-randomly-generated-6nl7g'
 #!/bin/sh
 set -xe
 
-numberOfSteps=4
 debugInfo=/tekton/debug/info
 tektonTools=/tekton/tools
 
-postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
+postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | sort -n | tail -1)"
 stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
 
-if [ $stepNumber -lt $numberOfSteps ]; then
-	touch ${tektonTools}/${stepNumber}.err # Mark step as a failure
-	echo "1" > ${tektonTools}/${stepNumber}.breakpointexit
-	echo "Executing step $stepNumber..."
-else
-	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
-	exit 0
+if [ ! -f ${tektonTools}/${stepNumber}.paused ]; then
+	echo "Step $stepNumber isn't paused at a breakpoint"
+	exit 1
 fi
+echo "1" > ${tektonTools}/${stepNumber}.breakpointexit # Mark step as a failure
+echo "Resuming step $stepNumber..."
 debug-fail-continue-heredoc-randomly-generated-6nl7g
 `},
 		VolumeMounts: []corev1.VolumeMount{writeScriptsVolumeMount, toolsMount, debugScriptsVolumeMount},
@@ -325,6 +325,8 @@ debug-fail-continue-heredoc-randomly-generated-6nl7g
 			{Name: debugInfoVolumeName, MountPath: "/tekton/debug/info/0"}},
 	}, {
 		Image: "step-2",
+		VolumeMounts: []corev1.VolumeMount{debugScriptsVolumeMount,
+			{Name: debugInfoVolumeName, MountPath: "/tekton/debug/info/1"}},
 	}, {
 		Image:   "step-3",
 		Command: []string{"/tekton/scripts/script-2-mz4c7"},
@@ -354,6 +356,57 @@ debug-fail-continue-heredoc-randomly-generated-6nl7g
 	}
 }
 
+func TestDebugScripts_StepNumber(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template string
+		wantExit string
+	}{{
+		name:     "continue",
+		template: debugContinueScriptTemplate,
+		wantExit: "0",
+	}, {
+		name:     "fail-continue",
+		template: debugFailScriptTemplate,
+		wantExit: "1",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			debugInfo, err := ioutil.TempDir("", "debug-info")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(debugInfo)
+			tektonTools, err := ioutil.TempDir("", "tools")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(tektonTools)
+			// The step numbers are compared as numbers, so that step 10 is picked
+			// over steps 2 and 9.
+			for _, step := range []string{"2", "9", "10"} {
+				if err := os.Mkdir(filepath.Join(debugInfo, step), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := ioutil.WriteFile(filepath.Join(tektonTools, "10.paused"), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			script := defaultScriptPreamble + fmt.Sprintf(tc.template, debugInfo, tektonTools)
+			if out, err := exec.Command("sh", "-c", script).CombinedOutput(); err != nil {
+				t.Fatalf("debug-%s failed: %v\n%s", tc.name, err, out)
+			}
+			got, err := ioutil.ReadFile(filepath.Join(tektonTools, "10.breakpointexit"))
+			if err != nil {
+				t.Fatalf("expected the exit code of step 10 to be written: %v", err)
+			}
+			if strings.TrimSpace(string(got)) != tc.wantExit {
+				t.Errorf("expected exit code %q but got %q", tc.wantExit, got)
+			}
+		})
+	}
+}
+
 func TestConvertScripts_WithSidecar(t *testing.T) {
 	names.TestingSeed()
 
@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// sidecarLogResultsName is the name of the sidecar that prints the results
+	// of the TaskRun to its logs.
+	sidecarLogResultsName = "tekton-log-results"
+	// sidecarLogResultsContainerName is the name of the results sidecar
+	// container, once the sidecar prefix has been added.
+	sidecarLogResultsContainerName = sidecarPrefix + sidecarLogResultsName
+)
+
+// ErrResultLargerThanAllowedLimit is returned when a result read from the
+// sidecar logs is larger than the configured "max-result-size".
+var ErrResultLargerThanAllowedLimit = errors.New("result larger than allowed limit")
+
+// sidecarLogResultsContainer returns the sidecar that waits for the last of
+// stepCount Steps to be done and then prints the results to its logs. It gives
+// up waiting once the timeout of the TaskRun has passed.
+func sidecarLogResultsContainer(entrypointImage string, stepCount int, results []v1beta1.TaskResult, timeout time.Duration) corev1.Container {
+	return corev1.Container{
+		Name:  sidecarLogResultsName,
+		Image: entrypointImage,
+		Command: []string{
+			"/ko-app/entrypoint", "sidecar-log-results",
+			pipeline.DefaultResultPath,
+			filepath.Join(mountPoint, fmt.Sprintf("%d", stepCount-1)),
+			collectResultsName(results),
+			timeout.String(),
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount, {
+			Name:      "tekton-internal-results",
+			MountPath: pipeline.DefaultResultPath,
+		}},
+	}
+}
+
+// hasSidecarLogResults returns true if the Pod reads its results from the
+// logs of the results sidecar.
+func hasSidecarLogResults(pod *corev1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == sidecarLogResultsContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// isSidecarLogResultsRunning returns true if the Pod reads its results from
+// the logs of the results sidecar and the sidecar hasn't terminated yet.
+func isSidecarLogResultsRunning(pod *corev1.Pod) bool {
+	if !hasSidecarLogResults(pod) {
+		return false
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name == sidecarLogResultsContainerName {
+			return s.State.Terminated == nil
+		}
+	}
+	return true
+}
+
+// SetTaskRunResultsFromSidecarLogs reads the results printed by the results
+// sidecar of the Pod, if any, and adds them to the status of the TaskRun.
+// ErrResultLargerThanAllowedLimit is returned if a result is larger than
+// maxResultSize bytes.
+func SetTaskRunResultsFromSidecarLogs(ctx context.Context, logger *zap.SugaredLogger, kubeclient kubernetes.Interface, tr *v1beta1.TaskRun, pod *corev1.Pod, maxResultSize int) error {
+	if !hasSidecarLogResults(pod) {
+		return nil
+	}
+	logs, err := kubeclient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: sidecarLogResultsContainerName}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading the logs of the results sidecar of Pod %q: %w", pod.Name, err)
+	}
+	defer logs.Close()
+
+	results, err := parseSidecarLogResults(logs, maxResultSize)
+	if err != nil {
+		return err
+	}
+	var specResults []v1beta1.TaskResult
+	if tr.Status.TaskSpec != nil {
+		specResults = tr.Status.TaskSpec.Results
+	}
+	taskResults, _, _ := filterResultsAndResources(logger, results, specResults)
+	tr.Status.TaskRunResults = removeDuplicateResults(append(tr.Status.TaskRunResults, taskResults...))
+	return nil
+}
+
+// parseSidecarLogResults reads the results printed as JSON lines by the
+// results sidecar. Lines that are not results are ignored.
+func parseSidecarLogResults(r io.Reader, maxResultSize int) ([]v1beta1.PipelineResourceResult, error) {
+	var results []v1beta1.PipelineResourceResult
+	reader := bufio.NewReader(r)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, fmt.Errorf("error reading the logs of the results sidecar: %w", readErr)
+		}
+		var result v1beta1.PipelineResourceResult
+		if err := json.Unmarshal(line, &result); err == nil && result.ResultType == v1beta1.TaskRunResultType {
+			if len(result.Value) > maxResultSize {
+				return nil, fmt.Errorf("%w: result %q is %d bytes, the maximum is %d bytes", ErrResultLargerThanAllowedLimit, result.Key, len(result.Value), maxResultSize)
+			}
+			results = append(results, result)
+		}
+		if readErr == io.EOF {
+			return results, nil
+		}
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+func TestParseSidecarLogResults(t *testing.T) {
+	large := strings.Repeat("a", 5000)
+	for _, tc := range []struct {
+		desc string
+		logs string
+		want []v1beta1.PipelineResourceResult
+	}{{
+		desc: "no results",
+		logs: "",
+	}, {
+		desc: "results larger than the termination message",
+		logs: `{"key":"foo","value":"` + large + `","type":"TaskRunResult"}
+{"key":"bar","value":"hello","type":"TaskRunResult"}
+`,
+		want: []v1beta1.PipelineResourceResult{{
+			Key:        "foo",
+			Value:      large,
+			ResultType: v1beta1.TaskRunResultType,
+		}, {
+			Key:        "bar",
+			Value:      "hello",
+			ResultType: v1beta1.TaskRunResultType,
+		}},
+	}, {
+		desc: "lines that are not results are ignored",
+		logs: `Wrote results from /tekton/results
+{"key":"StartedAt","value":"2022-01-01T00:00:00Z","type":"InternalTektonResult"}
+{"key":"foo","value":"bar","type":"TaskRunResult"}`,
+		want: []v1beta1.PipelineResourceResult{{
+			Key:        "foo",
+			Value:      "bar",
+			ResultType: v1beta1.TaskRunResultType,
+		}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseSidecarLogResults(strings.NewReader(tc.logs), 8192)
+			if err != nil {
+				t.Fatalf("parseSidecarLogResults: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParseSidecarLogResultsLargerThanAllowedLimit(t *testing.T) {
+	logs := `{"key":"foo","value":"` + strings.Repeat("a", 4097) + `","type":"TaskRunResult"}`
+	_, err := parseSidecarLogResults(strings.NewReader(logs), 4096)
+	if !errors.Is(err, ErrResultLargerThanAllowedLimit) {
+		t.Errorf("expected ErrResultLargerThanAllowedLimit but got %v", err)
+	}
+}
+
+func TestSetTaskRunResultsFromSidecarLogs(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		containers []corev1.Container
+	}{{
+		desc:       "no results sidecar",
+		containers: []corev1.Container{{Name: "step-foo"}},
+	}, {
+		desc:       "results sidecar",
+		containers: []corev1.Container{{Name: "step-foo"}, {Name: sidecarLogResultsContainerName}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Spec:       corev1.PodSpec{Containers: tc.containers},
+			}
+			kubeclient := fakek8s.NewSimpleClientset(pod)
+			tr := &v1beta1.TaskRun{}
+			logger, _ := logging.NewLogger("", "status")
+			// The fake client returns logs that are not results, which are ignored.
+			if err := SetTaskRunResultsFromSidecarLogs(context.Background(), logger, kubeclient, tr, pod, 4096); err != nil {
+				t.Fatalf("SetTaskRunResultsFromSidecarLogs: %v", err)
+			}
+			if len(tr.Status.TaskRunResults) != 0 {
+				t.Errorf("expected no results but got %v", tr.Status.TaskRunResults)
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatusWaitsForSidecarLogResults(t *testing.T) {
+	for _, tc := range []struct {
+		desc          string
+		sidecarState  corev1.ContainerState
+		wantCondition corev1.ConditionStatus
+	}{{
+		desc:          "results sidecar running",
+		sidecarState:  corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		wantCondition: corev1.ConditionUnknown,
+	}, {
+		desc:          "results sidecar terminated",
+		sidecarState:  corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+		wantCondition: corev1.ConditionTrue,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "step-foo"}, {Name: sidecarLogResultsContainerName}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "step-foo",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+					}, {
+						Name:  sidecarLogResultsContainerName,
+						State: tc.sidecarState,
+					}},
+				},
+			}
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(context.Background(), logger, v1beta1.TaskRun{}, pod)
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus: %v", err)
+			}
+			if c := got.GetCondition(apis.ConditionSucceeded); c == nil || c.Status != tc.wantCondition {
+				t.Errorf("expected condition status %s but got %v", tc.wantCondition, c)
+			}
+		})
+	}
+}
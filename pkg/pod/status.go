@@ -17,6 +17,7 @@ limitations under the License.
 package pod
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -24,6 +25,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/termination"
 	"go.uber.org/zap"
@@ -41,6 +43,10 @@ const (
 	// that references within the TaskRun could not be resolved
 	ReasonFailedResolution = "TaskRunResolutionFailed"
 
+	// ReasonResolvingTaskRef indicates that the TaskRun is waiting for
+	// its taskRef to be asynchronously resolved by a resolver.
+	ReasonResolvingTaskRef = "ResolvingTaskRef"
+
 	// ReasonFailedValidation indicated that the reason for failure status is
 	// that taskrun failed runtime validation
 	ReasonFailedValidation = "TaskRunValidationFailed"
@@ -61,15 +67,28 @@ const (
 	// is that the creation of the pod backing the TaskRun failed
 	ReasonPodCreationFailed = "PodCreationFailed"
 
+	// ReasonPodEvicted indicates that the TaskRun failed because its pod was evicted
+	// from its node
+	ReasonPodEvicted = "PodEvicted"
+
 	// ReasonPending indicates that the pod is in corev1.Pending, and the reason is not
 	// ReasonExceededNodeResources or isPodHitConfigError
 	ReasonPending = "Pending"
 
+	// imagePullBackOff and errImagePull are the reasons a container waits with while
+	// its image can't be pulled
+	imagePullBackOff = "ImagePullBackOff"
+	errImagePull     = "ErrImagePull"
+
 	// timeFormat is RFC3339 with millisecond
 	timeFormat = "2006-01-02T15:04:05.000Z07:00"
 )
 
-const oomKilled = "OOMKilled"
+const (
+	oomKilled = "OOMKilled"
+	// evicted is the reason of the status of an evicted pod
+	evicted = "Evicted"
+)
 
 // SidecarsReady returns true if all of the Pod's sidecars are Ready or
 // Terminated.
@@ -97,7 +116,7 @@ func SidecarsReady(podStatus corev1.PodStatus) bool {
 }
 
 // MakeTaskRunStatus returns a TaskRunStatus based on the Pod's status.
-func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev1.Pod) (v1beta1.TaskRunStatus, error) {
+func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev1.Pod) (v1beta1.TaskRunStatus, error) {
 	trs := &tr.Status
 	if trs.GetCondition(apis.ConditionSucceeded) == nil || trs.GetCondition(apis.ConditionSucceeded).Status == corev1.ConditionUnknown {
 		// If the taskRunStatus doesn't exist yet, it's because we just started running
@@ -106,15 +125,19 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 
 	sortPodContainerStatuses(pod.Status.ContainerStatuses, pod.Spec.Containers)
 
-	complete := areStepsComplete(pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	// When results are read from the logs of the results sidecar, the TaskRun
+	// isn't complete until the sidecar has printed them.
+	complete := (areStepsComplete(pod) && !isSidecarLogResultsRunning(pod)) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	messageLines := config.FromContextOrDefaults(ctx).FeatureFlags.SidecarFailureMessageLines
 
 	if complete {
-		updateCompletedTaskRunStatus(logger, trs, pod)
+		updateCompletedTaskRunStatus(logger, trs, pod, messageLines)
 	} else {
 		updateIncompleteTaskRunStatus(trs, pod)
 	}
 
 	trs.PodName = pod.Name
+	previousSteps := trs.Steps
 	trs.Steps = []v1beta1.StepState{}
 	trs.Sidecars = []v1beta1.SidecarState{}
 
@@ -128,12 +151,15 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 		}
 	}
 
+	// The sidecars are looked at first, so that the results of a TaskRun failed
+	// by one of them are left out like the ones of any failed TaskRun.
+	setTaskRunStatusBasedOnSidecarStatus(logger, sidecarStatuses, trs, pod, messageLines)
+
 	var merr *multierror.Error
 	if err := setTaskRunStatusBasedOnStepStatus(logger, stepStatuses, &tr); err != nil {
 		merr = multierror.Append(merr, err)
 	}
-
-	setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses, trs)
+	keepStepProgress(previousSteps, trs.Steps)
 
 	trs.TaskRunResults = removeDuplicateResults(trs.TaskRunResults)
 
@@ -145,6 +171,7 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 	var merr *multierror.Error
 
 	for _, s := range stepStatuses {
+		var attemptExitCodes []int32
 		if s.State.Terminated != nil && len(s.State.Terminated.Message) != 0 {
 			msg := s.State.Terminated.Message
 
@@ -163,7 +190,16 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 					logger.Errorf("error extracting the exit code of step %q in taskrun %q: %v", s.Name, tr.Name, err)
 					merr = multierror.Append(merr, err)
 				}
-				taskResults, pipelineResourceResults, filteredResults := filterResultsAndResources(results)
+				attemptExitCodes, err = extractAttemptExitCodesFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the exit codes of the attempts of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
+				var specResults []v1beta1.TaskResult
+				if tr.Status.TaskSpec != nil {
+					specResults = tr.Status.TaskSpec.Results
+				}
+				taskResults, pipelineResourceResults, filteredResults := filterResultsAndResources(logger, results, specResults)
 				if tr.IsSuccessful() {
 					trs.TaskRunResults = append(trs.TaskRunResults, taskResults...)
 					trs.ResourcesResult = append(trs.ResourcesResult, pipelineResourceResults...)
@@ -181,13 +217,17 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 				if exitCode != nil {
 					s.State.Terminated.ExitCode = *exitCode
 				}
+				if isStepSkipped(results) {
+					s.State.Terminated.Reason = v1beta1.StepSkippedReason
+				}
 			}
 		}
 		trs.Steps = append(trs.Steps, v1beta1.StepState{
-			ContainerState: *s.State.DeepCopy(),
-			Name:           trimStepPrefix(s.Name),
-			ContainerName:  s.Name,
-			ImageID:        s.ImageID,
+			ContainerState:   *s.State.DeepCopy(),
+			Name:             trimStepPrefix(s.Name),
+			ContainerName:    s.Name,
+			ImageID:          s.ImageID,
+			AttemptExitCodes: attemptExitCodes,
 		})
 	}
 
@@ -195,7 +235,7 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 
 }
 
-func setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses []corev1.ContainerStatus, trs *v1beta1.TaskRunStatus) {
+func setTaskRunStatusBasedOnSidecarStatus(logger *zap.SugaredLogger, sidecarStatuses []corev1.ContainerStatus, trs *v1beta1.TaskRunStatus, pod *corev1.Pod, messageLines int) {
 	for _, s := range sidecarStatuses {
 		trs.Sidecars = append(trs.Sidecars, v1beta1.SidecarState{
 			ContainerState: *s.State.DeepCopy(),
@@ -204,6 +244,59 @@ func setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses []corev1.ContainerStat
 			ImageID:        s.ImageID,
 		})
 	}
+
+	// Steps usually fail in confusing ways without the sidecars they rely on,
+	// so a sidecar which terminated abnormally while they were still running
+	// fails the TaskRun, whatever the outcome of the steps.
+	if getFailedSidecar(pod) != nil {
+		markStatusFailure(trs, v1beta1.TaskRunReasonSidecarFailed.String(), getFailureMessage(logger, pod, messageLines))
+		if trs.CompletionTime == nil {
+			trs.CompletionTime = &metav1.Time{Time: time.Now()}
+		}
+	}
+}
+
+// getFailedSidecar returns the status of the first sidecar which terminated
+// abnormally while steps were still running, or nil if there is none. The
+// sidecars stopped once the steps have completed are not taken into account,
+// nor are the ones of an evicted or OOM-killed pod, whose steps were stopped
+// along with them.
+func getFailedSidecar(pod *corev1.Pod) *corev1.ContainerStatus {
+	if pod.Status.Reason == evicted {
+		return nil
+	}
+	stepsRunning := false
+	var stepsFinishedAt time.Time
+	for _, s := range pod.Status.ContainerStatuses {
+		if !IsContainerStep(s.Name) {
+			continue
+		}
+		switch {
+		case s.State.Terminated == nil:
+			stepsRunning = true
+		case isOOMKilled(s):
+			return nil
+		case s.State.Terminated.FinishedAt.Time.After(stepsFinishedAt):
+			stepsFinishedAt = s.State.Terminated.FinishedAt.Time
+		}
+	}
+	for i, s := range pod.Status.ContainerStatuses {
+		if !isContainerSidecar(s.Name) || s.State.Terminated == nil {
+			continue
+		}
+		if s.State.Terminated.ExitCode == 0 && !isOOMKilled(s) {
+			continue
+		}
+		// A sidecar seen terminated while some steps haven't terminated failed
+		// while they were running. Once they all have, the sidecar failed while
+		// they were running unless it finished after the last of them: the
+		// finish times only have a precision of a second, so a sidecar finished
+		// in the same second as the last step is taken to have failed first.
+		if stepsRunning || !s.State.Terminated.FinishedAt.Time.After(stepsFinishedAt) {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
 }
 
 func createMessageFromResults(results []v1beta1.PipelineResourceResult) (string, error) {
@@ -217,16 +310,21 @@ func createMessageFromResults(results []v1beta1.PipelineResourceResult) (string,
 	return string(bytes), nil
 }
 
-func filterResultsAndResources(results []v1beta1.PipelineResourceResult) ([]v1beta1.TaskRunResult, []v1beta1.PipelineResourceResult, []v1beta1.PipelineResourceResult) {
+func filterResultsAndResources(logger *zap.SugaredLogger, results []v1beta1.PipelineResourceResult, specResults []v1beta1.TaskResult) ([]v1beta1.TaskRunResult, []v1beta1.PipelineResourceResult, []v1beta1.PipelineResourceResult) {
 	var taskResults []v1beta1.TaskRunResult
 	var pipelineResourceResults []v1beta1.PipelineResourceResult
 	var filteredResults []v1beta1.PipelineResourceResult
+	resultTypes := make(map[string]v1beta1.ResultsType, len(specResults))
+	for _, r := range specResults {
+		resultTypes[r.Name] = r.Type
+	}
 	for _, r := range results {
 		switch r.ResultType {
 		case v1beta1.TaskRunResultType:
-			taskRunResult := v1beta1.TaskRunResult{
-				Name:  r.Key,
-				Value: r.Value,
+			taskRunResult, err := decodeTaskRunResult(r, resultTypes[r.Key])
+			if err != nil {
+				logger.Errorf("result %q could not be decoded: %v", r.Key, err)
+				continue
 			}
 			taskResults = append(taskResults, taskRunResult)
 			filteredResults = append(filteredResults, r)
@@ -244,6 +342,31 @@ func filterResultsAndResources(results []v1beta1.PipelineResourceResult) ([]v1be
 	return taskResults, pipelineResourceResults, filteredResults
 }
 
+// decodeTaskRunResult converts a result written by a step to a TaskRunResult. The value of an
+// array or object result is written by the step as JSON and is decoded according to the declared type.
+func decodeTaskRunResult(r v1beta1.PipelineResourceResult, resultType v1beta1.ResultsType) (v1beta1.TaskRunResult, error) {
+	switch resultType {
+	case v1beta1.ResultsTypeArray, v1beta1.ResultsTypeObject:
+		var value v1beta1.ArrayOrString
+		if err := json.Unmarshal([]byte(r.Value), &value); err != nil {
+			return v1beta1.TaskRunResult{}, fmt.Errorf("the value is not a JSON %s: %w", resultType, err)
+		}
+		if string(value.Type) != string(resultType) {
+			return v1beta1.TaskRunResult{}, fmt.Errorf("the value is a JSON %s but the result is declared as %s", value.Type, resultType)
+		}
+		return v1beta1.TaskRunResult{
+			Name:  r.Key,
+			Type:  resultType,
+			Value: value,
+		}, nil
+	default:
+		return v1beta1.TaskRunResult{
+			Name:  r.Key,
+			Value: *v1beta1.NewArrayOrString(r.Value),
+		}, nil
+	}
+}
+
 func removeDuplicateResults(taskRunResult []v1beta1.TaskRunResult) []v1beta1.TaskRunResult {
 	if len(taskRunResult) == 0 {
 		return nil
@@ -292,10 +415,42 @@ func extractExitCodeFromResults(results []v1beta1.PipelineResourceResult) (*int3
 	return nil, nil
 }
 
-func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
+// isStepSkipped returns true if the results of a step record that it was skipped
+// because its when expressions evaluated to false.
+func isStepSkipped(results []v1beta1.PipelineResourceResult) bool {
+	for _, result := range results {
+		if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "Reason" && result.Value == v1beta1.StepSkippedReason {
+			return true
+		}
+	}
+	return false
+}
+
+func extractAttemptExitCodesFromResults(results []v1beta1.PipelineResourceResult) ([]int32, error) {
+	for _, result := range results {
+		if result.Key == "AttemptExitCodes" {
+			var exitCodes []int32
+			for _, v := range strings.Split(result.Value, ",") {
+				i, err := strconv.ParseInt(v, 10, 32)
+				if err != nil {
+					return nil, fmt.Errorf("could not parse int value %q in AttemptExitCodes field: %w", v, err)
+				}
+				exitCodes = append(exitCodes, int32(i))
+			}
+			return exitCodes, nil
+		}
+	}
+	return nil, nil
+}
+
+func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod, messageLines int) {
 	if DidTaskRunFail(pod) {
-		msg := getFailureMessage(logger, pod)
-		markStatusFailure(trs, v1beta1.TaskRunReasonFailed.String(), msg)
+		msg := getFailureMessage(logger, pod, messageLines)
+		reason := v1beta1.TaskRunReasonFailed.String()
+		if pod.Status.Reason == evicted {
+			reason = ReasonPodEvicted
+		}
+		markStatusFailure(trs, reason, msg)
 	} else {
 		markStatusSuccess(trs)
 	}
@@ -307,6 +462,11 @@ func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRu
 func updateIncompleteTaskRunStatus(trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	switch pod.Status.Phase {
 	case corev1.PodRunning:
+		if step := getPausedStep(pod); step != "" {
+			markStatusRunning(trs, v1beta1.TaskRunReasonPaused.String(),
+				fmt.Sprintf("Step %q is paused at a breakpoint; run %s/debug-continue or %s/debug-fail-continue in its container to resume it", step, debugScriptsDir, debugScriptsDir))
+			return
+		}
 		markStatusRunning(trs, v1beta1.TaskRunReasonRunning.String(), "Not all Steps in the Task have finished executing")
 	case corev1.PodPending:
 		switch {
@@ -320,6 +480,24 @@ func updateIncompleteTaskRunStatus(trs *v1beta1.TaskRunStatus, pod *corev1.Pod)
 	}
 }
 
+// getPausedStep returns the name of the step paused at a breakpoint, if any. Steps with
+// breakpoints have a readiness probe succeeding only while they are paused, so a paused step is
+// a running step with a readiness probe which is ready.
+func getPausedStep(pod *corev1.Pod) string {
+	probed := map[string]bool{}
+	for _, c := range pod.Spec.Containers {
+		if IsContainerStep(c.Name) && c.ReadinessProbe != nil {
+			probed[c.Name] = true
+		}
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if probed[s.Name] && s.State.Running != nil && s.Ready {
+			return trimStepPrefix(s.Name)
+		}
+	}
+	return ""
+}
+
 // DidTaskRunFail check the status of pod to decide if related taskrun is failed
 func DidTaskRunFail(pod *corev1.Pod) bool {
 	f := pod.Status.Phase == corev1.PodFailed
@@ -345,8 +523,22 @@ func areStepsComplete(pod *corev1.Pod) bool {
 	return stepsComplete
 }
 
-func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
-	// First, try to surface an error about the actual build step that failed.
+func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod, messageLines int) string {
+	// First, surface an error about a sidecar which terminated while the steps
+	// were running, since it's likely why they failed.
+	if status := getFailedSidecar(pod); status != nil {
+		term := status.State.Terminated
+		// Newline required at end to prevent yaml parser from breaking the log help text at 80 chars
+		msg := fmt.Sprintf("sidecar %q exited with code %d (reason: %q, image: %q) while steps were still running; for logs run: kubectl -n %s logs %s -c %s\n",
+			TrimSidecarPrefix(status.Name), term.ExitCode, term.Reason, status.ImageID,
+			pod.Namespace, pod.Name, status.Name)
+		if tail := lastLines(term.Message, messageLines); tail != "" {
+			msg += fmt.Sprintf("last lines of its termination message:\n%s\n", tail)
+		}
+		return msg
+	}
+
+	// Next, try to surface an error about the actual build step that failed.
 	for _, status := range pod.Status.ContainerStatuses {
 		term := status.State.Terminated
 		if term != nil {
@@ -387,6 +579,20 @@ func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
 	return "build failed for unspecified reasons."
 }
 
+// lastLines returns the last n lines of the message, or an empty string if n
+// is not positive.
+func lastLines(message string, n int) string {
+	message = strings.TrimRight(message, "\n")
+	if n <= 0 || message == "" {
+		return ""
+	}
+	lines := strings.Split(message, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
 // IsPodExceedingNodeResources returns true if the Pod's status indicates there
 // are insufficient resources to schedule the Pod.
 func IsPodExceedingNodeResources(pod *corev1.Pod) bool {
@@ -408,6 +614,25 @@ func isPodHitConfigError(pod *corev1.Pod) bool {
 	return false
 }
 
+// GetPendingFailure returns the reason and message of the failure of a TaskRun whose Pod is
+// pending because it doesn't fit on any node or the image of one of its containers can't be
+// pulled. It returns false if the Pod isn't pending for either of these reasons.
+func GetPendingFailure(pod *corev1.Pod) (v1beta1.TaskRunReason, string, bool) {
+	if pod.Status.Phase != corev1.PodPending {
+		return "", "", false
+	}
+	if IsPodExceedingNodeResources(pod) {
+		return v1beta1.TaskRunReasonExceededNodeResources, "TaskRun Pod exceeded available resources", true
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if wait := status.State.Waiting; wait != nil && (wait.Reason == imagePullBackOff || wait.Reason == errImagePull) {
+			return v1beta1.TaskRunReasonImagePullFailed, fmt.Sprintf("the image of container %q can't be pulled: %s", status.Name, wait.Message), true
+		}
+	}
+	return "", "", false
+}
+
 func getWaitingMessage(pod *corev1.Pod) string {
 	// First, try to surface reason for pending/unknown about the actual build step.
 	for _, status := range pod.Status.ContainerStatuses {
@@ -17,11 +17,13 @@ limitations under the License.
 package pod
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
@@ -171,6 +173,79 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Sidecars: []v1beta1.SidecarState{},
 			},
 		},
+	}, {
+		desc: "paused at a breakpoint",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: "foo",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:           "step-paused-step",
+					ReadinessProbe: &corev1.Probe{},
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-paused-step",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					Ready: true,
+				}},
+			},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusPending(v1beta1.TaskRunReasonPaused.String(), `Step "paused-step" is paused at a breakpoint; run /tekton/debug/scripts/debug-continue or /tekton/debug/scripts/debug-fail-continue in its container to resume it`),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					Name:          "paused-step",
+					ContainerName: "step-paused-step",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+			},
+		},
+	}, {
+		desc: "running with a breakpoint and not paused",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: "foo",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:           "step-running-step",
+					ReadinessProbe: &corev1.Probe{},
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-running-step",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				}},
+			},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusRunning(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					Name:          "running-step",
+					ContainerName: "step-running-step",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+			},
+		},
 	}, {
 		desc: "failure-terminated",
 		podStatus: corev1.PodStatus{
@@ -222,6 +297,22 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "failure-evicted",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusFailure(ReasonPodEvicted, "The node was low on resource: memory."),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps:    []v1beta1.StepState{},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "failed with OOM",
 		podStatus: corev1.PodStatus{
@@ -373,6 +464,36 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Sidecars: []v1beta1.SidecarState{},
 			},
 		},
+	}, {
+		desc: "pending-ImagePullBackOff",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-pull",
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: `Back-off pulling image "busybox:missing"`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusPending(ReasonPending, `build step "step-pull" is pending with reason "Back-off pulling image \"busybox:missing\""`),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{
+							Reason:  "ImagePullBackOff",
+							Message: `Back-off pulling image "busybox:missing"`,
+						},
+					},
+					Name:          "pull",
+					ContainerName: "step-pull",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+			},
+		},
 	}, {
 		desc: "with-sidecar-running",
 		podStatus: corev1.PodStatus{
@@ -484,7 +605,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 			}},
 		},
 		want: v1beta1.TaskRunStatus{
-			Status: statusRunning(),
+			Status: statusFailure(v1beta1.TaskRunReasonSidecarFailed.String(), "sidecar \"error\" exited with code 1 (reason: \"Error\", image: \"image-id\") while steps were still running; for logs run: kubectl -n foo logs pod -c sidecar-error\n"),
 			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 				Steps: []v1beta1.StepState{{
 					ContainerState: corev1.ContainerState{
@@ -505,6 +626,109 @@ func TestMakeTaskRunStatus(t *testing.T) {
 					ImageID:       "image-id",
 					ContainerName: "sidecar-error",
 				}},
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "with-sidecar-oomkilled-before-steps-completed",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-failed-step",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						FinishedAt: metav1.Time{Time: time.Date(2010, 1, 1, 1, 2, 0, 0, time.UTC)},
+					},
+				},
+			}, {
+				Name:    "sidecar-db",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   137,
+						Reason:     oomKilled,
+						FinishedAt: metav1.Time{Time: time.Date(2010, 1, 1, 1, 1, 30, 0, time.UTC)},
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusFailure(v1beta1.TaskRunReasonSidecarFailed.String(), "sidecar \"db\" exited with code 137 (reason: \"OOMKilled\", image: \"image-id\") while steps were still running; for logs run: kubectl -n foo logs pod -c sidecar-db\n"),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:   1,
+							FinishedAt: metav1.Time{Time: time.Date(2010, 1, 1, 1, 2, 0, 0, time.UTC)},
+						},
+					},
+					Name:          "failed-step",
+					ContainerName: "step-failed-step",
+				}},
+				Sidecars: []v1beta1.SidecarState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:   137,
+							Reason:     oomKilled,
+							FinishedAt: metav1.Time{Time: time.Date(2010, 1, 1, 1, 1, 30, 0, time.UTC)},
+						},
+					},
+					Name:          "db",
+					ImageID:       "image-id",
+					ContainerName: "sidecar-db",
+				}},
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "with-sidecar-terminated-after-steps-completed",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-step",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						FinishedAt: metav1.Time{Time: time.Date(2010, 1, 1, 1, 2, 0, 0, time.UTC)},
+					},
+				},
+			}, {
+				Name:    "sidecar-error",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						Reason:     "Error",
+						FinishedAt: metav1.Time{Time: time.Date(2010, 1, 1, 1, 2, 30, 0, time.UTC)},
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							FinishedAt: metav1.Time{Time: time.Date(2010, 1, 1, 1, 2, 0, 0, time.UTC)},
+						},
+					},
+					Name:          "step",
+					ContainerName: "step-step",
+				}},
+				Sidecars: []v1beta1.SidecarState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:   1,
+							Reason:     "Error",
+							FinishedAt: metav1.Time{Time: time.Date(2010, 1, 1, 1, 2, 30, 0, time.UTC)},
+						},
+					},
+					Name:          "error",
+					ImageID:       "image-id",
+					ContainerName: "sidecar-error",
+				}},
+				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
 	}, {
@@ -573,7 +797,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}},
 				TaskRunResults: []v1beta1.TaskRunResult{{
 					Name:  "resultName",
-					Value: "resultValue",
+					Value: *v1beta1.NewArrayOrString("resultValue"),
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -611,7 +835,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}},
 				TaskRunResults: []v1beta1.TaskRunResult{{
 					Name:  "resultName",
-					Value: "resultValue",
+					Value: *v1beta1.NewArrayOrString("resultValue"),
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -658,10 +882,10 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Sidecars: []v1beta1.SidecarState{},
 				TaskRunResults: []v1beta1.TaskRunResult{{
 					Name:  "resultNameOne",
-					Value: "resultValueThree",
+					Value: *v1beta1.NewArrayOrString("resultValueThree"),
 				}, {
 					Name:  "resultNameTwo",
-					Value: "resultValueTwo",
+					Value: *v1beta1.NewArrayOrString("resultValueTwo"),
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -751,7 +975,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}},
 				TaskRunResults: []v1beta1.TaskRunResult{{
 					Name:  "resultNameThree",
-					Value: "",
+					Value: *v1beta1.NewArrayOrString(""),
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -895,6 +1119,66 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "include the exit codes of the attempts of a retried step, including killed ones",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-flaky",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"AttemptExitCodes","value":"-1,2,0","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{},
+					},
+					Name:             "flaky",
+					ContainerName:    "step-flaky",
+					AttemptExitCodes: []int32{-1, 2, 0},
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "report a step skipped by its when expressions",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-optional",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:  "Completed",
+						Message: `[{"key":"Reason","value":"Skipped","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: v1beta1.StepSkippedReason,
+						},
+					},
+					Name:          "optional",
+					ContainerName: "step-optional",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			now := metav1.Now()
@@ -923,7 +1207,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 			}
 
 			logger, _ := logging.NewLogger("", "status")
-			got, err := MakeTaskRunStatus(logger, tr, &c.pod)
+			got, err := MakeTaskRunStatus(context.Background(), logger, tr, &c.pod)
 			if err != nil {
 				t.Errorf("MakeTaskRunResult: %s", err)
 			}
@@ -948,6 +1232,208 @@ func TestMakeTaskRunStatus(t *testing.T) {
 	}
 }
 
+func TestMakeTaskRunStatusTypedResults(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-bar",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"array","value":"[\"a\",\"b\"]","type":"TaskRunResult"},` +
+							`{"key":"object","value":"{\"url\":\"abc\",\"commit\":\"123\"}","type":"TaskRunResult"},` +
+							`{"key":"string","value":"[\"a\"]","type":"TaskRunResult"},` +
+							`{"key":"malformed","value":"a,b","type":"TaskRunResult"}]`,
+					},
+				},
+			}},
+		},
+	}
+	tr := v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				TaskSpec: &v1beta1.TaskSpec{
+					Results: []v1beta1.TaskResult{{
+						Name: "array", Type: v1beta1.ResultsTypeArray,
+					}, {
+						Name: "object", Type: v1beta1.ResultsTypeObject,
+					}, {
+						Name: "string",
+					}, {
+						Name: "malformed", Type: v1beta1.ResultsTypeArray,
+					}},
+				},
+			},
+		},
+	}
+
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(context.Background(), logger, tr, &pod)
+	if err != nil {
+		t.Errorf("MakeTaskRunResult: %s", err)
+	}
+
+	// The values of array and object results are decoded, and results that cannot be decoded are dropped
+	want := []v1beta1.TaskRunResult{{
+		Name:  "array",
+		Type:  v1beta1.ResultsTypeArray,
+		Value: *v1beta1.NewArrayOrString("a", "b"),
+	}, {
+		Name:  "object",
+		Type:  v1beta1.ResultsTypeObject,
+		Value: *v1beta1.NewObject(map[string]string{"url": "abc", "commit": "123"}),
+	}, {
+		Name:  "string",
+		Value: *v1beta1.NewArrayOrString(`["a"]`),
+	}}
+	if d := cmp.Diff(want, got.TaskRunResults); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMakeTaskRunStatusSidecarFailureMessageLines(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-running-step",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			}, {
+				Name:    "sidecar-db",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Reason:   "Error",
+						Message:  "starting database\nloading data\nout of disk space\n",
+					},
+				},
+			}},
+		},
+	}
+	for _, c := range []struct {
+		desc         string
+		messageLines string
+		want         string
+	}{{
+		desc:         "no lines",
+		messageLines: "0",
+		want:         "sidecar \"db\" exited with code 1 (reason: \"Error\", image: \"image-id\") while steps were still running; for logs run: kubectl -n foo logs pod -c sidecar-db\n",
+	}, {
+		desc:         "last lines",
+		messageLines: "2",
+		want: "sidecar \"db\" exited with code 1 (reason: \"Error\", image: \"image-id\") while steps were still running; for logs run: kubectl -n foo logs pod -c sidecar-db\n" +
+			"last lines of its termination message:\nloading data\nout of disk space\n",
+	}, {
+		desc:         "more lines than the message has",
+		messageLines: "10",
+		want: "sidecar \"db\" exited with code 1 (reason: \"Error\", image: \"image-id\") while steps were still running; for logs run: kubectl -n foo logs pod -c sidecar-db\n" +
+			"last lines of its termination message:\nstarting database\nloading data\nout of disk space\n",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			featureFlags, err := config.NewFeatureFlagsFromMap(map[string]string{
+				"sidecar-failure-message-lines": c.messageLines,
+			})
+			if err != nil {
+				t.Fatalf("Error creating the feature flags: %v", err)
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: featureFlags})
+
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(ctx, logger, v1beta1.TaskRun{}, &pod)
+			if err != nil {
+				t.Errorf("MakeTaskRunStatus: %s", err)
+			}
+			condition := got.GetCondition(apis.ConditionSucceeded)
+			if condition.Reason != v1beta1.TaskRunReasonSidecarFailed.String() {
+				t.Errorf("Expected the TaskRun to fail with reason %q but got %q", v1beta1.TaskRunReasonSidecarFailed, condition.Reason)
+			}
+			if d := cmp.Diff(c.want, condition.Message); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetFailedSidecar(t *testing.T) {
+	stepsFinishedAt := metav1.Time{Time: time.Date(2010, 1, 1, 1, 2, 0, 0, time.UTC)}
+	failedSidecar := func(finishedAt metav1.Time, reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name: "sidecar-db",
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: reason, FinishedAt: finishedAt},
+			},
+		}
+	}
+	terminatedStep := corev1.ContainerStatus{
+		Name: "step-done",
+		State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, FinishedAt: stepsFinishedAt},
+		},
+	}
+	for _, c := range []struct {
+		desc       string
+		podStatus  corev1.PodStatus
+		wantFailed bool
+	}{{
+		desc: "sidecar terminated while a step is running",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{terminatedStep, {
+				Name:  "step-running",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}, failedSidecar(metav1.Time{Time: stepsFinishedAt.Add(time.Minute)}, "Error")},
+		},
+		wantFailed: true,
+	}, {
+		desc: "sidecar terminated in the same second as the last step",
+		podStatus: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{terminatedStep, failedSidecar(stepsFinishedAt, "Error")},
+		},
+		wantFailed: true,
+	}, {
+		desc: "sidecar terminated after the last step",
+		podStatus: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{terminatedStep, failedSidecar(metav1.Time{Time: stepsFinishedAt.Add(time.Second)}, "Error")},
+		},
+	}, {
+		desc: "evicted pod",
+		podStatus: corev1.PodStatus{
+			Phase:  corev1.PodFailed,
+			Reason: "Evicted",
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-running",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}, failedSidecar(stepsFinishedAt, "Error")},
+		},
+	}, {
+		desc: "OOM-killed pod",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-oom",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: oomKilled, FinishedAt: stepsFinishedAt},
+				},
+			}, failedSidecar(stepsFinishedAt, oomKilled)},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got := getFailedSidecar(&corev1.Pod{Status: c.podStatus})
+			if (got != nil) != c.wantFailed {
+				t.Errorf("Expected a failed sidecar: %t, but got %v", c.wantFailed, got)
+			}
+		})
+	}
+}
+
 func TestMakeRunStatusJSONError(t *testing.T) {
 
 	pod := &corev1.Pod{
@@ -1043,7 +1529,7 @@ func TestMakeRunStatusJSONError(t *testing.T) {
 	}
 
 	logger, _ := logging.NewLogger("", "status")
-	gotTr, err := MakeTaskRunStatus(logger, tr, pod)
+	gotTr, err := MakeTaskRunStatus(context.Background(), logger, tr, pod)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
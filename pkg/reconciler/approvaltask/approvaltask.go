@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvaltask
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
+	runreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/run"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+const (
+	// ReasonApprovalPending indicates that the ApprovalTask is waiting for the decisions of its approvers
+	ReasonApprovalPending = "ApprovalPending"
+	// ReasonApproved indicates that the ApprovalTask was approved by enough approvers
+	ReasonApproved = "Approved"
+	// ReasonRejected indicates that the ApprovalTask was rejected by one of its approvers
+	ReasonRejected = "Rejected"
+	// ReasonInvalidApprovalTask indicates that the params or the recorded decisions of the ApprovalTask are invalid
+	ReasonInvalidApprovalTask = "InvalidApprovalTask"
+)
+
+// Reconciler implements controller.Reconciler for the Runs of ApprovalTasks.
+type Reconciler struct{}
+
+// Check that our Reconciler implements runreconciler.Interface
+var _ runreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind compares the decisions recorded on the Run of an ApprovalTask with the approvers
+// of the ApprovalTask, and marks the Run succeeded once enough approvers approved it, or failed
+// as soon as one of them rejected it. The Run fails when it is cancelled or times out before.
+func (c *Reconciler) ReconcileKind(ctx context.Context, run *v1alpha1.Run) pkgreconciler.Event {
+	logger := logging.FromContext(ctx)
+	if run.IsDone() {
+		return nil
+	}
+	if !run.HasStarted() {
+		run.Status.InitializeConditions()
+	}
+
+	if run.IsCancelled() {
+		run.Status.MarkRunFailed(v1alpha1.RunReasonCancelled, "Run %s/%s was cancelled", run.Namespace, run.Name)
+		return nil
+	}
+	if run.HasTimedOut() {
+		run.Status.MarkRunFailed(v1alpha1.RunReasonTimedOut, "Run %s/%s wasn't approved within %s", run.Namespace, run.Name, run.GetTimeout())
+		return nil
+	}
+
+	approvers, required, err := run.GetApprovalTaskApprovers()
+	if err != nil {
+		run.Status.MarkRunFailed(ReasonInvalidApprovalTask, "Run %s/%s is invalid: %v", run.Namespace, run.Name, err)
+		return nil
+	}
+	decisions, err := run.GetApprovalDecisions()
+	if err != nil {
+		run.Status.MarkRunFailed(ReasonInvalidApprovalTask, "Run %s/%s is invalid: %v", run.Namespace, run.Name, err)
+		return nil
+	}
+
+	status := v1alpha1.ApprovalTaskStatus{
+		Approvers:         approvers,
+		ApprovalsRequired: required,
+		Decisions:         latestDecisionsOfApprovers(decisions, approvers),
+	}
+	if err := run.Status.EncodeExtraFields(status); err != nil {
+		logger.Errorf("Failed to encode the status of ApprovalTask Run %s/%s: %v", run.Namespace, run.Name, err)
+		return err
+	}
+
+	var approvedBy []string
+	for _, d := range status.Decisions {
+		if d.Decision == v1alpha1.ApprovalDecisionReject {
+			message := fmt.Sprintf("Run %s/%s was rejected by %s", run.Namespace, run.Name, d.Name)
+			if d.Message != "" {
+				message += ": " + d.Message
+			}
+			run.Status.MarkRunFailed(ReasonRejected, message)
+			return nil
+		}
+		approvedBy = append(approvedBy, d.Name)
+	}
+	if len(approvedBy) >= required {
+		run.Status.Results = []v1alpha1.RunResult{{
+			Name:  v1alpha1.ApprovalTaskApprovedByResult,
+			Value: *runv1alpha1.NewRunResultValue(strings.Join(approvedBy, ",")),
+		}}
+		run.Status.MarkRunSucceeded(ReasonApproved, "Run %s/%s was approved by %s", run.Namespace, run.Name, strings.Join(approvedBy, ", "))
+		return nil
+	}
+
+	run.Status.MarkRunRunning(ReasonApprovalPending, "Run %s/%s has %d of the %d approvals required from %s",
+		run.Namespace, run.Name, len(approvedBy), required, strings.Join(approvers, ", "))
+	// Requeue the Run to time it out, decisions enqueue it before as they update it
+	if timeout := run.GetTimeout(); timeout != config.NoTimeoutDuration {
+		return controller.NewRequeueAfter(timeout - time.Since(run.Status.StartTime.Time))
+	}
+	return nil
+}
+
+// latestDecisionsOfApprovers returns the latest decision of each approver, in the order they were
+// made. The decisions of the users who aren't approvers are ignored.
+func latestDecisionsOfApprovers(decisions []v1alpha1.ApprovalDecision, approvers []string) []v1alpha1.ApprovalDecision {
+	latest := map[string]int{}
+	var approverDecisions []v1alpha1.ApprovalDecision
+	for _, d := range decisions {
+		if !d.IsApprover(approvers) {
+			continue
+		}
+		if i, ok := latest[d.Name]; ok {
+			approverDecisions = append(approverDecisions[:i], approverDecisions[i+1:]...)
+			for name, j := range latest {
+				if j > i {
+					latest[name] = j - 1
+				}
+			}
+		}
+		latest[d.Name] = len(approverDecisions)
+		approverDecisions = append(approverDecisions, d)
+	}
+	return approverDecisions
+}
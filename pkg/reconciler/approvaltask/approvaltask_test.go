@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvaltask
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+func approvalTaskRun(t *testing.T, required string, decisions ...v1alpha1.ApprovalDecision) *v1alpha1.Run {
+	t.Helper()
+	run := &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "approval",
+			Namespace:   "ns",
+			Annotations: map[string]string{},
+		},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{
+				APIVersion: "tekton.dev/v1alpha1",
+				Kind:       v1alpha1.ApprovalTaskKind,
+			},
+			Params: []v1beta1.Param{{
+				Name:  v1alpha1.ApprovalTaskApproversParam,
+				Value: *v1beta1.NewArrayOrString("alice", "group:release-managers"),
+			}, {
+				Name:  v1alpha1.ApprovalTaskApprovalsRequiredParam,
+				Value: *v1beta1.NewArrayOrString(required),
+			}},
+			Timeout: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+	if len(decisions) > 0 {
+		b, err := json.Marshal(decisions)
+		if err != nil {
+			t.Fatalf("failed to marshal decisions: %v", err)
+		}
+		run.Annotations[v1alpha1.ApprovalDecisionsAnnotation] = string(b)
+	}
+	return run
+}
+
+func decision(name string, groups []string, d v1alpha1.ApprovalDecisionType) v1alpha1.ApprovalDecision {
+	return v1alpha1.ApprovalDecision{Name: name, Groups: groups, Decision: d, Time: metav1.Unix(1000, 0)}
+}
+
+func TestReconcileKind(t *testing.T) {
+	managers := []string{"release-managers"}
+	for _, tc := range []struct {
+		name           string
+		run            *v1alpha1.Run
+		wantStatus     corev1.ConditionStatus
+		wantReason     string
+		wantApprovedBy string
+		wantDecisions  int
+	}{{
+		name:       "pending",
+		run:        approvalTaskRun(t, "2"),
+		wantStatus: corev1.ConditionUnknown,
+		wantReason: ReasonApprovalPending,
+	}, {
+		name:          "pending with one of two approvals",
+		run:           approvalTaskRun(t, "2", decision("alice", nil, v1alpha1.ApprovalDecisionApprove)),
+		wantStatus:    corev1.ConditionUnknown,
+		wantReason:    ReasonApprovalPending,
+		wantDecisions: 1,
+	}, {
+		name: "approved by a user and a member of a group",
+		run: approvalTaskRun(t, "2",
+			decision("alice", nil, v1alpha1.ApprovalDecisionApprove),
+			decision("bob", managers, v1alpha1.ApprovalDecisionApprove)),
+		wantStatus:     corev1.ConditionTrue,
+		wantReason:     ReasonApproved,
+		wantApprovedBy: "alice,bob",
+		wantDecisions:  2,
+	}, {
+		name: "approvals of the same user counted once",
+		run: approvalTaskRun(t, "2",
+			decision("alice", nil, v1alpha1.ApprovalDecisionApprove),
+			decision("alice", nil, v1alpha1.ApprovalDecisionApprove)),
+		wantStatus:    corev1.ConditionUnknown,
+		wantReason:    ReasonApprovalPending,
+		wantDecisions: 1,
+	}, {
+		name: "decisions of users who aren't approvers ignored",
+		run: approvalTaskRun(t, "1",
+			decision("mallory", []string{"developers"}, v1alpha1.ApprovalDecisionApprove),
+			decision("eve", nil, v1alpha1.ApprovalDecisionReject)),
+		wantStatus: corev1.ConditionUnknown,
+		wantReason: ReasonApprovalPending,
+	}, {
+		name:          "rejected",
+		run:           approvalTaskRun(t, "1", decision("bob", managers, v1alpha1.ApprovalDecisionReject)),
+		wantStatus:    corev1.ConditionFalse,
+		wantReason:    ReasonRejected,
+		wantDecisions: 1,
+	}, {
+		name: "rejection changed to approval",
+		run: approvalTaskRun(t, "1",
+			decision("alice", nil, v1alpha1.ApprovalDecisionReject),
+			decision("alice", nil, v1alpha1.ApprovalDecisionApprove)),
+		wantStatus:     corev1.ConditionTrue,
+		wantReason:     ReasonApproved,
+		wantApprovedBy: "alice",
+		wantDecisions:  1,
+	}, {
+		name:       "invalid params",
+		run:        approvalTaskRun(t, "none"),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonInvalidApprovalTask,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&Reconciler{}).ReconcileKind(context.Background(), tc.run)
+			if tc.wantStatus == corev1.ConditionUnknown {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay <= 0 || delay > time.Hour {
+					t.Errorf("expected the pending Run to be requeued within its timeout but got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			c := tc.run.Status.GetCondition(apis.ConditionSucceeded)
+			if c == nil || c.Status != tc.wantStatus || c.Reason != tc.wantReason {
+				t.Fatalf("expected condition %s with reason %s but got %v", tc.wantStatus, tc.wantReason, c)
+			}
+			if tc.run.Status.StartTime == nil {
+				t.Error("expected the start time to be set")
+			}
+			var wantResults []v1alpha1.RunResult
+			if tc.wantApprovedBy != "" {
+				wantResults = []v1alpha1.RunResult{{Name: v1alpha1.ApprovalTaskApprovedByResult, Value: *runv1alpha1.NewRunResultValue(tc.wantApprovedBy)}}
+			}
+			if d := cmp.Diff(wantResults, tc.run.Status.Results); d != "" {
+				t.Errorf("results %s", diff.PrintWantGot(d))
+			}
+			if tc.wantReason == ReasonInvalidApprovalTask {
+				return
+			}
+			var status v1alpha1.ApprovalTaskStatus
+			if err := tc.run.Status.DecodeExtraFields(&status); err != nil {
+				t.Fatalf("failed to decode the ApprovalTask status: %v", err)
+			}
+			if len(status.Decisions) != tc.wantDecisions {
+				t.Errorf("expected %d decisions in the status but got %v", tc.wantDecisions, status.Decisions)
+			}
+		})
+	}
+}
+
+func TestReconcileKind_CancelledOrTimedOut(t *testing.T) {
+	cancelled := approvalTaskRun(t, "1")
+	cancelled.Spec.Status = v1alpha1.RunSpecStatusCancelled
+
+	timedOut := approvalTaskRun(t, "1")
+	timedOut.Status.StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+
+	for _, tc := range []struct {
+		name       string
+		run        *v1alpha1.Run
+		wantReason string
+	}{{
+		name:       "cancelled",
+		run:        cancelled,
+		wantReason: v1alpha1.RunReasonCancelled,
+	}, {
+		name:       "timed out",
+		run:        timedOut,
+		wantReason: v1alpha1.RunReasonTimedOut,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := (&Reconciler{}).ReconcileKind(context.Background(), tc.run); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			c := tc.run.Status.GetCondition(apis.ConditionSucceeded)
+			if c == nil || c.Status != corev1.ConditionFalse || c.Reason != tc.wantReason {
+				t.Errorf("expected the Run to fail with reason %s but got %v", tc.wantReason, c)
+			}
+		})
+	}
+}
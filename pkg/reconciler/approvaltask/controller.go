@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvaltask
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	runinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run"
+	runreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/run"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+)
+
+// ControllerName holds the name of the ApprovalTask controller
+const ControllerName = "ApprovalTask"
+
+// NewController instantiates a new controller.Impl from knative.dev/pkg/controller
+// reconciling the Runs of ApprovalTasks
+func NewController() func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		runInformer := runinformer.Get(ctx)
+
+		c := &Reconciler{}
+		impl := runreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
+			return controller.Options{
+				AgentName:         ControllerName,
+				PromoteFilterFunc: isApprovalTask,
+			}
+		})
+
+		runInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: isApprovalTask,
+			Handler:    controller.HandleAll(impl.Enqueue),
+		})
+
+		return impl
+	}
+}
+
+// isApprovalTask returns true if the object is the Run of an ApprovalTask
+func isApprovalTask(obj interface{}) bool {
+	run, ok := obj.(*v1alpha1.Run)
+	return ok && run.IsApprovalTask()
+}
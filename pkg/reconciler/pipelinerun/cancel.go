@@ -35,7 +35,7 @@ import (
 	"knative.dev/pkg/apis"
 )
 
-var cancelTaskRunPatchBytes, cancelRunPatchBytes []byte
+var cancelTaskRunPatchBytes, cancelRunPatchBytes, cancelPipelineRunPatchBytes []byte
 
 func init() {
 	var err error
@@ -55,6 +55,14 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to marshal Run cancel patch bytes: %v", err)
 	}
+	cancelPipelineRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "add",
+		Path:      "/spec/status",
+		Value:     v1beta1.PipelineRunSpecStatusCancelled,
+	}})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun cancel patch bytes: %v", err)
+	}
 }
 
 func cancelRun(ctx context.Context, runName string, namespace string, clientSet clientset.Interface) error {
@@ -62,6 +70,11 @@ func cancelRun(ctx context.Context, runName string, namespace string, clientSet
 	return err
 }
 
+func cancelChildPipelineRun(ctx context.Context, pipelineRunName string, namespace string, clientSet clientset.Interface) error {
+	_, err := clientSet.TektonV1beta1().PipelineRuns(namespace).Patch(ctx, pipelineRunName, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, "")
+	return err
+}
+
 // cancelPipelineRun marks the PipelineRun as cancelled and any resolved TaskRun(s) too.
 func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) error {
 	errs := cancelPipelineTaskRuns(ctx, logger, pr, clientSet)
@@ -95,7 +108,7 @@ func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1bet
 	return nil
 }
 
-// cancelPipelineTaskRuns patches `TaskRun` and `Run` with canceled status
+// cancelPipelineTaskRuns patches `TaskRun`, `Run` and child `PipelineRun` with canceled status
 func cancelPipelineTaskRuns(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) []string {
 	errs := []string{}
 
@@ -118,6 +131,15 @@ func cancelPipelineTaskRuns(ctx context.Context, logger *zap.SugaredLogger, pr *
 			continue
 		}
 	}
+	// Loop over the child PipelineRuns in the PipelineRun status.
+	for childPipelineRunName := range pr.Status.ChildPipelineRuns {
+		logger.Infof("cancelling child PipelineRun %s", childPipelineRunName)
+
+		if err := cancelChildPipelineRun(ctx, childPipelineRunName, pr.Namespace, clientSet); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch PipelineRun `%s` with cancellation: %s", childPipelineRunName, err).Error())
+			continue
+		}
+	}
 
 	return errs
 }
@@ -36,6 +36,7 @@ func TestCancelPipelineRun(t *testing.T) {
 		pipelineRun *v1beta1.PipelineRun
 		taskRuns    []*v1beta1.TaskRun
 		runs        []*v1alpha1.Run
+		childRuns   []*v1beta1.PipelineRun
 	}{{
 		name: "no-resolved-taskrun",
 		pipelineRun: &v1beta1.PipelineRun{
@@ -96,6 +97,22 @@ func TestCancelPipelineRun(t *testing.T) {
 			{ObjectMeta: metav1.ObjectMeta{Name: "t1"}},
 			{ObjectMeta: metav1.ObjectMeta{Name: "t2"}},
 		},
+	}, {
+		name: "child-pipelineruns",
+		pipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-cancelled"},
+			Spec: v1beta1.PipelineRunSpec{
+				Status: v1beta1.PipelineRunSpecStatusCancelled,
+			},
+			Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				ChildPipelineRuns: map[string]*v1beta1.PipelineRunChildPipelineRunStatus{
+					"c1": {PipelineTaskName: "task-1"},
+				},
+			}},
+		},
+		childRuns: []*v1beta1.PipelineRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "c1"}},
+		},
 	}, {
 		name: "deprecated-state",
 		pipelineRun: &v1beta1.PipelineRun{
@@ -109,7 +126,7 @@ func TestCancelPipelineRun(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			d := test.Data{
-				PipelineRuns: []*v1beta1.PipelineRun{tc.pipelineRun},
+				PipelineRuns: append([]*v1beta1.PipelineRun{tc.pipelineRun}, tc.childRuns...),
 				TaskRuns:     tc.taskRuns,
 				Runs:         tc.runs,
 			}
@@ -147,6 +164,15 @@ func TestCancelPipelineRun(t *testing.T) {
 					}
 				}
 			}
+			for _, child := range tc.childRuns {
+				cpr, err := c.Pipeline.TektonV1beta1().PipelineRuns("").Get(ctx, child.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if cpr.Spec.Status != v1beta1.PipelineRunSpecStatusCancelled {
+					t.Errorf("expected child PipelineRun %q to be marked as cancelled, was %q", cpr.Name, cpr.Spec.Status)
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+)
+
+// queuedCondition returns the condition of a PipelineRun that can't start yet because
+// the PipelineRuns matching one of the concurrency rules already run at the limit of the
+// rule in its namespace, or nil if the PipelineRun can start. The PipelineRuns waiting
+// for a rule are admitted in the order they were created. The rules are applied in turn,
+// and a PipelineRun held by a rule isn't queued by the next ones, so that it doesn't take
+// a position it can't use in their queues. The child PipelineRuns of a PipelineTask are
+// never queued nor counted, since their parent already holds a slot and would otherwise
+// wait for them forever.
+func (c *Reconciler) queuedCondition(ctx context.Context, pr *v1beta1.PipelineRun) (*apis.Condition, error) {
+	cfg := config.FromContextOrDefaults(ctx)
+	if cfg.Concurrency == nil || len(cfg.Concurrency.Rules) == 0 || isChildPipelineRun(pr) {
+		return nil, nil
+	}
+	names := cfg.Concurrency.RuleNames()
+	matched := false
+	for _, name := range names {
+		if cfg.Concurrency.Rules[name].Matches(pipelineNameOf(pr), pr.Labels) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return nil, nil
+	}
+	others, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the PipelineRuns in namespace %s: %w", pr.Namespace, err)
+	}
+
+	// The PipelineRuns held by the rules applied so far
+	held := map[string]bool{}
+	for _, name := range names {
+		rule := cfg.Concurrency.Rules[name]
+		running := 0
+		var queue []*v1beta1.PipelineRun
+		if rule.Matches(pipelineNameOf(pr), pr.Labels) {
+			queue = append(queue, pr)
+		}
+		for _, other := range others {
+			if other.Name == pr.Name || held[other.Name] || other.IsDone() || isChildPipelineRun(other) || !rule.Matches(pipelineNameOf(other), other.Labels) {
+				continue
+			}
+			switch {
+			case other.HasStarted():
+				running++
+			case !other.IsPending() && !other.IsCancelled():
+				queue = append(queue, other)
+			}
+		}
+		sort.Slice(queue, func(i, j int) bool {
+			if !queue[i].CreationTimestamp.Equal(&queue[j].CreationTimestamp) {
+				return queue[i].CreationTimestamp.Before(&queue[j].CreationTimestamp)
+			}
+			return queue[i].Name < queue[j].Name
+		})
+
+		available := rule.Limit - running
+		if available < 0 {
+			available = 0
+		}
+		for i := available; i < len(queue); i++ {
+			if queue[i].Name != pr.Name {
+				held[queue[i].Name] = true
+				continue
+			}
+			return &apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: ReasonQueued,
+				Message: fmt.Sprintf("PipelineRun %q is queued at position %d of %d by concurrency rule %q, which allows %d PipelineRuns to run at once",
+					pr.Name, i-available+1, len(queue)-available, name, rule.Limit),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// pipelineNameOf returns the name of the Pipeline a PipelineRun runs. It is read from
+// the PipelineRef until the PipelineRun is labelled with the name of its Pipeline.
+func pipelineNameOf(pr *v1beta1.PipelineRun) string {
+	if name, ok := pr.Labels[pipeline.PipelineLabelKey]; ok {
+		return name
+	}
+	if pr.Spec.PipelineRef != nil {
+		return pr.Spec.PipelineRef.Name
+	}
+	return ""
+}
+
+// isChildPipelineRun returns true if the PipelineRun was created by a PipelineRun to run
+// one of its PipelineTasks.
+func isChildPipelineRun(pr *v1beta1.PipelineRun) bool {
+	owner := metav1.GetControllerOf(pr)
+	return owner != nil && owner.Kind == pipeline.PipelineRunControllerName && strings.HasPrefix(owner.APIVersion, pipeline.GroupName+"/")
+}
+
+// isQueued returns true if the PipelineRun is waiting for a concurrency rule to admit it.
+func isQueued(obj interface{}) bool {
+	pr, ok := obj.(*v1beta1.PipelineRun)
+	if !ok || pr.HasStarted() {
+		return false
+	}
+	condition := pr.Status.GetCondition(apis.ConditionSucceeded)
+	return condition != nil && condition.Reason == ReasonQueued
+}
+
+// enqueueQueuedPipelineRuns returns a function that enqueues the queued PipelineRuns in
+// the namespace of the object it is called with, so that they are admitted when a slot
+// is freed by a PipelineRun that finished or was deleted.
+func enqueueQueuedPipelineRuns(impl *controller.Impl, lister listers.PipelineRunLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		object, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			return
+		}
+		prs, err := lister.PipelineRuns(object.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			return
+		}
+		for _, pr := range prs {
+			if isQueued(pr) {
+				impl.EnqueueKey(types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name})
+			}
+		}
+	}
+}
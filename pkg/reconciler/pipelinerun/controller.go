@@ -24,6 +24,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
 	conditioninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/condition"
+	resolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/resolutionrequest"
 	runinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run"
 	clustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/clustertask"
 	pipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline"
@@ -37,6 +38,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -56,6 +58,7 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 		pipelineInformer := pipelineinformer.Get(ctx)
 		resourceInformer := resourceinformer.Get(ctx)
 		conditionInformer := conditioninformer.Get(ctx)
+		resolutionRequestInformer := resolutionrequestinformer.Get(ctx)
 
 		c := &Reconciler{
 			KubeClientSet:     kubeclientset,
@@ -69,12 +72,25 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 			runLister:         runInformer.Lister(),
 			resourceLister:    resourceInformer.Lister(),
 			conditionLister:   conditionInformer.Lister(),
+			namespaceLister:   namespaceinformer.Get(ctx).Lister(),
 			cloudEventClient:  cloudeventclient.Get(ctx),
 			metrics:           pipelinerunmetrics.Get(ctx),
 			pvcHandler:        volumeclaim.NewPVCHandler(kubeclientset, logger),
 		}
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
-			configStore := config.NewStore(logger.Named("config-store"))
+			configStore := config.NewStore(logger.Named("config-store"), func(name string, _ interface{}) {
+				// Changing the concurrency rules may admit queued PipelineRuns.
+				if name == config.GetConcurrencyConfigName() {
+					impl.FilteredGlobalResync(isQueued, pipelineRunInformer.Informer())
+				}
+				// Changing the pruner configuration may prune finished PipelineRuns.
+				if name == config.GetPrunerConfigName() {
+					impl.FilteredGlobalResync(func(obj interface{}) bool {
+						pr, ok := obj.(*v1beta1.PipelineRun)
+						return ok && pr.IsDone()
+					}, pipelineRunInformer.Informer())
+				}
+			})
 			configStore.WatchConfigs(cmw)
 			return controller.Options{
 				AgentName:   pipeline.PipelineRunControllerName,
@@ -83,6 +99,24 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 		})
 
 		pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+		// Child PipelineRuns also enqueue the PipelineRun which created them
+		pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
+
+		// PipelineRuns that finish or are deleted free a slot for the PipelineRuns queued behind them
+		enqueueQueued := enqueueQueuedPipelineRuns(impl, pipelineRunInformer.Lister())
+		pipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldPR, oldOK := oldObj.(*v1beta1.PipelineRun)
+				newPR, newOK := newObj.(*v1beta1.PipelineRun)
+				if oldOK && newOK && !oldPR.IsDone() && newPR.IsDone() {
+					enqueueQueued(newObj)
+				}
+			},
+			DeleteFunc: enqueueQueued,
+		})
 
 		taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
@@ -92,6 +126,10 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 		})
+		resolutionRequestInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
 
 		return impl
 	}
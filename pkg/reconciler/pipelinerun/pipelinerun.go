@@ -19,6 +19,7 @@ package pipelinerun
 import (
 	"context"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"path/filepath"
 	"reflect"
@@ -48,6 +49,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
 	tresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/remote/resolution"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -56,6 +58,7 @@ import (
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
@@ -103,6 +106,9 @@ const (
 	ReasonCancelledDeprecated = pipelinerunmetrics.ReasonCancelledDeprecated
 	// ReasonPending indicates that a PipelineRun is pending.
 	ReasonPending = "PipelineRunPending"
+	// ReasonQueued indicates that a PipelineRun is waiting for a concurrency rule
+	// to admit it.
+	ReasonQueued = "PipelineRunQueued"
 	// ReasonCouldntCancel indicates that a PipelineRun was cancelled but attempting to update
 	// all of the running TaskRuns as cancelled failed.
 	ReasonCouldntCancel = "PipelineRunCouldntCancel"
@@ -112,6 +118,12 @@ const (
 	// ReasonRequiredWorkspaceMarkedOptional indicates an optional workspace
 	// has been passed to a Task that is expecting a non-optional workspace
 	ReasonRequiredWorkspaceMarkedOptional = "RequiredWorkspaceMarkedOptional"
+	// ReasonResolvingPipelineRef indicates that the PipelineRun is waiting for
+	// its pipelineRef to be asynchronously resolved by a resolver.
+	ReasonResolvingPipelineRef = "ResolvingPipelineRef"
+	// ReasonResolvingTaskRef indicates that the PipelineRun is waiting for
+	// the taskRef of one of its PipelineTasks to be asynchronously resolved by a resolver.
+	ReasonResolvingTaskRef = "ResolvingTaskRef"
 )
 
 // Reconciler implements controller.Reconciler for Configuration resources.
@@ -129,6 +141,7 @@ type Reconciler struct {
 	clusterTaskLister listers.ClusterTaskLister
 	resourceLister    resourcelisters.PipelineResourceLister
 	conditionLister   listersv1alpha1.ConditionLister
+	namespaceLister   corev1listers.NamespaceLister
 	cloudEventClient  cloudevent.CEClient
 	metrics           *pipelinerunmetrics.Recorder
 	pvcHandler        volumeclaim.PvcHandler
@@ -149,6 +162,19 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 	// Read the initial condition
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
 
+	// A PipelineRun that is queued by a concurrency rule doesn't start until the rule admits it.
+	if !pr.HasStarted() && !pr.IsPending() && !pr.IsCancelled() && !pr.IsDone() {
+		queued, err := c.queuedCondition(ctx, pr)
+		if err != nil {
+			logger.Errorf("Failed to check the concurrency rules for pipelinerun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		if queued != nil {
+			pr.Status.SetCondition(queued)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+		}
+	}
+
 	if !pr.HasStarted() && !pr.IsPending() {
 		pr.Status.InitializeConditions()
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
@@ -198,13 +224,20 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to update Run status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
+		if err := c.updateChildPipelineRunsStatusDirectly(pr); err != nil {
+			logger.Errorf("Failed to update child PipelineRun status for PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
 		go func(metrics *pipelinerunmetrics.Recorder) {
 			err := metrics.DurationAndCount(pr)
 			if err != nil {
 				logger.Warnf("Failed to log the metrics : %v", err)
 			}
 		}(c.metrics)
-		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+		if err := c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil); err != nil {
+			return err
+		}
+		return c.prune(ctx, pr)
 	}
 
 	if err := propagatePipelineNameLabelToPipelineRun(pr); err != nil {
@@ -214,6 +247,11 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 
 	// If the pipelinerun is cancelled, cancel tasks and update status
 	if pr.IsCancelled() {
+		// Child PipelineRuns created right before the cancellation may not be in the status yet
+		if err := c.updateChildPipelineRunsStatusFromInformer(ctx, pr); err != nil {
+			logger.Errorf("Error while syncing the child PipelineRuns status: %v", err.Error())
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
 		err := cancelPipelineRun(ctx, logger, pr, c.PipelineClientSet)
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 	}
@@ -228,7 +266,12 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 
 	// Reconcile this copy of the pipelinerun and then write back any status or label
 	// updates regardless of whether the reconciliation errored out.
-	if err = c.reconcile(ctx, pr, getPipelineFunc); err != nil {
+	err = c.reconcile(ctx, pr, getPipelineFunc)
+	// A requeue is asked for when the retry of a TaskRun waits for its backoff
+	retryRequeue, retryBackoff := controller.IsRequeueKey(err)
+	if retryRequeue {
+		err = nil
+	} else if err != nil {
 		logger.Errorf("Reconcile error: %v", err.Error())
 	}
 
@@ -239,8 +282,13 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 	if pr.Status.StartTime != nil {
 		// Compute the time since the task started.
 		elapsed := time.Since(pr.Status.StartTime.Time)
-		// Snooze this resource until the timeout has elapsed.
-		return controller.NewRequeueAfter(pr.GetTimeout(ctx) - elapsed)
+		// Snooze this resource until the timeout or the backoff of a retry has elapsed.
+		timeout := pr.GetTimeout(ctx)
+		wait := timeout - elapsed
+		if retryRequeue && (timeout == 0 || retryBackoff < wait) {
+			wait = retryBackoff
+		}
+		return controller.NewRequeueAfter(wait)
 	}
 	return nil
 }
@@ -274,7 +322,7 @@ func (c *Reconciler) resolvePipelineState(
 	pst := resources.PipelineRunState{}
 	// Resolve each task individually because they each could have a different reference context (remote or local).
 	for _, task := range tasks {
-		fn, err := tresources.GetTaskFunc(ctx, c.KubeClientSet, c.PipelineClientSet, task.TaskRef, pr.Namespace, pr.Spec.ServiceAccountName)
+		fn, err := tresources.GetTaskFunc(ctx, c.KubeClientSet, c.PipelineClientSet, pr, task.TaskRef, pr.Namespace, pr.Spec.ServiceAccountName)
 		if err != nil {
 			// This Run has failed, so we need to mark it as failed and stop reconciling it
 			pr.Status.MarkFailed(ReasonCouldntGetTask, "Pipeline %s/%s can't be Run; task %s could not be fetched: %s",
@@ -291,11 +339,17 @@ func (c *Reconciler) resolvePipelineState(
 			func(name string) (*v1alpha1.Run, error) {
 				return c.runLister.Runs(pr.Namespace).Get(name)
 			},
+			func(name string) (*v1beta1.PipelineRun, error) {
+				return c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(name)
+			},
 			func(name string) (*v1alpha1.Condition, error) {
 				return c.conditionLister.Conditions(pr.Namespace).Get(name)
 			},
 			task, providedResources,
 		)
+		if goerrors.Is(err, resolution.ErrorRequestInProgress) {
+			return nil, err
+		}
 		if err != nil {
 			switch err := err.(type) {
 			case *resources.TaskNotFoundError:
@@ -315,6 +369,19 @@ func (c *Reconciler) resolvePipelineState(
 		}
 		pst = append(pst, resolvedTask)
 	}
+	if err := pst.ApplyMatrixResults(pr.Name,
+		func(name string) (*v1beta1.TaskRun, error) {
+			return c.taskRunLister.TaskRuns(pr.Namespace).Get(name)
+		},
+		func(name string) (*v1alpha1.Run, error) {
+			return c.runLister.Runs(pr.Namespace).Get(name)
+		},
+	); err != nil {
+		pr.Status.MarkFailed(ReasonFailedValidation,
+			"PipelineRun %s/%s can't be Run; couldn't resolve all references: %s",
+			pipelineMeta.Namespace, pr.Name, err)
+		return nil, controller.NewPermanentError(err)
+	}
 	return pst, nil
 }
 
@@ -337,6 +404,11 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	}
 
 	pipelineMeta, pipelineSpec, err := resources.GetPipelineData(ctx, pr, getPipelineFunc)
+	if goerrors.Is(err, resolution.ErrorRequestInProgress) {
+		// The PipelineRun is reconciled again when the resolver updates its ResolutionRequest.
+		pr.Status.MarkRunning(ReasonResolvingPipelineRef, "PipelineRun %s/%s awaiting remote resource", pr.Namespace, pr.Name)
+		return nil
+	}
 	if err != nil {
 		logger.Errorf("Failed to determine Pipeline spec to use for pipelinerun %s: %v", pr.Name, err)
 		pr.Status.MarkFailed(ReasonCouldntGetPipeline,
@@ -430,6 +502,15 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// Ensure that the PipelineRun provides all the keys of the object parameters required by the Pipeline
+	if err := resources.ValidateObjectParamRequiredKeys(pipelineSpec.Params, pr.Spec.Params); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.MarkFailed(ReasonParameterMissing,
+			"PipelineRun %s parameters is missing object keys required by Pipeline %s's parameters: %s",
+			pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	// Ensure that the parameters from the PipelineRun are overriding Pipeline parameters with the same type.
 	// Weird substitution issues can occur if this is not validated (ApplyParameters() does not verify type).
 	err = resources.ValidateParamTypesMatching(pipelineSpec, pr)
@@ -480,6 +561,11 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		tasks = append(tasks, pipelineSpec.Finally...)
 	}
 	pipelineRunState, err := c.resolvePipelineState(ctx, tasks, pipelineMeta, pr, providedResources)
+	if goerrors.Is(err, resolution.ErrorRequestInProgress) {
+		// The PipelineRun is reconciled again when the resolver updates its ResolutionRequest.
+		pr.Status.MarkRunning(ReasonResolvingTaskRef, "PipelineRun %s/%s awaiting remote resource", pr.Namespace, pr.Name)
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -495,8 +581,18 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	}
 
 	for _, rprt := range pipelineRunFacts.State {
-		if !rprt.IsCustomTask() {
-			err := taskrun.ValidateResolvedTaskResources(rprt.PipelineTask.Params, rprt.ResolvedTaskResources)
+		if !rprt.IsCustomTask() && !rprt.IsChildPipeline() {
+			params := rprt.PipelineTask.Params
+			if rprt.IsMatrixed() {
+				combinations := resources.FanOut(rprt.PipelineTask.Matrix)
+				if len(combinations) == 0 {
+					// a matrix without combinations is skipped, so no TaskRun is created from it
+					continue
+				}
+				// every combination passes the same string parameters, so validating one of them is enough
+				params = appendMatrixParams(params, combinations[0])
+			}
+			err := taskrun.ValidateResolvedTaskResources(params, rprt.ResolvedTaskResources)
 			if err != nil {
 				logger.Errorf("Failed to validate pipelinerun %q with error %v", pr.Name, err)
 				pr.Status.MarkFailed(ReasonFailedValidation, err.Error())
@@ -589,17 +685,26 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	pr.Status.StartTime = pipelineRunFacts.State.AdjustStartTime(pr.Status.StartTime)
 	pr.Status.TaskRuns = pipelineRunFacts.State.GetTaskRunsStatus(pr)
 	pr.Status.Runs = pipelineRunFacts.State.GetRunsStatus(pr)
+	pr.Status.ChildPipelineRuns = pipelineRunFacts.State.GetChildPipelineRunsStatus(pr)
 	pr.Status.SkippedTasks = pipelineRunFacts.GetSkippedTasks()
+	pr.Status.ToleratedFailures = pipelineRunFacts.GetToleratedFailures()
 	if after.Status == corev1.ConditionTrue {
-		pr.Status.PipelineResults = resources.ApplyTaskResultsToPipelineResults(pipelineSpec.Results, pr.Status.TaskRuns, pr.Status.Runs)
+		pr.Status.PipelineResults = resources.ApplyTaskResultsToPipelineResults(pipelineSpec.Results, pr.Status.TaskRuns, pr.Status.Runs, pr.Status.ChildPipelineRuns)
 	}
 
 	logger.Infof("PipelineRun %s status is being set to %s", pr.Name, after)
+	if after.Status == corev1.ConditionUnknown {
+		if wait := pipelineRunFacts.State.RetryBackoffRemaining(time.Now()); wait > 0 {
+			// Requeue the PipelineRun to retry the TaskRuns once their backoff has elapsed
+			return controller.NewRequeueAfter(wait)
+		}
+	}
 	return nil
 }
 
 // processRunTimeouts custom tasks are requested to cancel, if they have timed out. Custom tasks can do any cleanup
-// during this step.
+// during this step. Child PipelineRuns handle their own timeout, they are only cancelled when the PipelineRun times out
+// here; a cancelled PipelineRun cancels them together with its TaskRuns and Runs.
 func (c *Reconciler) processRunTimeouts(ctx context.Context, pr *v1beta1.PipelineRun, pipelineState resources.PipelineRunState) error {
 	errs := []string{}
 	logger := logging.FromContext(ctx)
@@ -608,12 +713,29 @@ func (c *Reconciler) processRunTimeouts(ctx context.Context, pr *v1beta1.Pipelin
 	}
 	for _, rprt := range pipelineState {
 		if rprt.IsCustomTask() {
-			if rprt.Run != nil && !rprt.Run.IsCancelled() && (pr.IsTimedOut() || (rprt.Run.HasTimedOut() && !rprt.Run.IsDone())) {
-				logger.Infof("Cancelling run task: %s due to timeout.", rprt.RunName)
-				err := cancelRun(ctx, rprt.RunName, pr.Namespace, c.PipelineClientSet)
+			runs := rprt.Runs
+			if !rprt.IsMatrixed() {
+				runs = []*v1alpha1.Run{rprt.Run}
+			}
+			for _, run := range runs {
+				if run != nil && !run.IsCancelled() && (pr.IsTimedOut() || (run.HasTimedOut() && !run.IsDone())) {
+					logger.Infof("Cancelling run task: %s due to timeout.", run.Name)
+					err := cancelRun(ctx, run.Name, pr.Namespace, c.PipelineClientSet)
+					if err != nil {
+						errs = append(errs,
+							fmt.Errorf("failed to patch Run `%s` with cancellation: %s", run.Name, err).Error())
+					}
+				}
+			}
+		}
+		if rprt.IsChildPipeline() {
+			childPipelineRun := rprt.ChildPipelineRun
+			if childPipelineRun != nil && !childPipelineRun.IsCancelled() && !childPipelineRun.IsDone() && pr.IsTimedOut() {
+				logger.Infof("Cancelling child PipelineRun: %s due to timeout.", childPipelineRun.Name)
+				err := cancelChildPipelineRun(ctx, childPipelineRun.Name, pr.Namespace, c.PipelineClientSet)
 				if err != nil {
 					errs = append(errs,
-						fmt.Errorf("failed to patch Run `%s` with cancellation: %s", rprt.RunName, err).Error())
+						fmt.Errorf("failed to patch PipelineRun `%s` with cancellation: %s", childPipelineRun.Name, err).Error())
 				}
 			}
 		}
@@ -675,23 +797,42 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 		if rprt == nil || rprt.Skip(pipelineRunFacts).IsSkipped || rprt.IsFinallySkipped(pipelineRunFacts).IsSkipped {
 			continue
 		}
+		if !rprt.IsMatrixed() && rprt.TaskRun != nil && rprt.RetryBackoffRemaining(rprt.TaskRun, time.Now()) > 0 {
+			// The retry of the failed TaskRun waits for the backoff of the RetryStrategy
+			continue
+		}
 		if rprt.ResolvedConditionChecks == nil || rprt.ResolvedConditionChecks.IsSuccess() {
-			if rprt.IsCustomTask() {
-				if rprt.IsFinalTask(pipelineRunFacts) {
-					rprt.Run, err = c.createRun(ctx, rprt, pr, getFinallyTaskRunTimeout)
-				} else {
-					rprt.Run, err = c.createRun(ctx, rprt, pr, getTaskRunTimeout)
+			timeoutFunc := getTaskRunTimeout
+			if rprt.IsFinalTask(pipelineRunFacts) {
+				timeoutFunc = getFinallyTaskRunTimeout
+			}
+			switch {
+			case rprt.IsChildPipeline():
+				rprt.ChildPipelineRun, err = c.createChildPipelineRun(ctx, rprt, pr, timeoutFunc)
+				if err != nil {
+					recorder.Eventf(pr, corev1.EventTypeWarning, "ChildPipelineRunCreationFailed", "Failed to create child PipelineRun %q: %v", rprt.ChildPipelineRunName, err)
+					return fmt.Errorf("error creating child PipelineRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.ChildPipelineRunName, rprt.PipelineTask.Name, pr.Name, err)
 				}
+			case rprt.IsCustomTask() && rprt.IsMatrixed():
+				rprt.Runs, err = c.createRuns(ctx, rprt, pr, timeoutFunc)
+				if err != nil {
+					recorder.Eventf(pr, corev1.EventTypeWarning, "RunsCreationFailed", "Failed to create Runs %q: %v", rprt.RunNames, err)
+					return fmt.Errorf("error creating Runs called %s for PipelineTask %s from PipelineRun %s: %w", rprt.RunNames, rprt.PipelineTask.Name, pr.Name, err)
+				}
+			case rprt.IsCustomTask():
+				rprt.Run, err = c.createRun(ctx, rprt.RunName, nil, rprt, pr, timeoutFunc)
 				if err != nil {
 					recorder.Eventf(pr, corev1.EventTypeWarning, "RunCreationFailed", "Failed to create Run %q: %v", rprt.RunName, err)
 					return fmt.Errorf("error creating Run called %s for PipelineTask %s from PipelineRun %s: %w", rprt.RunName, rprt.PipelineTask.Name, pr.Name, err)
 				}
-			} else {
-				if rprt.IsFinalTask(pipelineRunFacts) {
-					rprt.TaskRun, err = c.createTaskRun(ctx, rprt, pr, as.StorageBasePath(pr), getFinallyTaskRunTimeout)
-				} else {
-					rprt.TaskRun, err = c.createTaskRun(ctx, rprt, pr, as.StorageBasePath(pr), getTaskRunTimeout)
+			case rprt.IsMatrixed():
+				rprt.TaskRuns, err = c.createTaskRuns(ctx, rprt, pr, as.StorageBasePath(pr), timeoutFunc)
+				if err != nil {
+					recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunsCreationFailed", "Failed to create TaskRuns %q: %v", rprt.TaskRunNames, err)
+					return fmt.Errorf("error creating TaskRuns called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunNames, rprt.PipelineTask.Name, pr.Name, err)
 				}
+			default:
+				rprt.TaskRun, err = c.createTaskRun(ctx, rprt.TaskRunName, nil, rprt, pr, as.StorageBasePath(pr), timeoutFunc)
 				if err != nil {
 					recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunName, err)
 					return fmt.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
@@ -742,12 +883,56 @@ func (c *Reconciler) updateRunsStatusDirectly(pr *v1beta1.PipelineRun) error {
 	return nil
 }
 
+func (c *Reconciler) updateChildPipelineRunsStatusDirectly(pr *v1beta1.PipelineRun) error {
+	for childPipelineRunName := range pr.Status.ChildPipelineRuns {
+		prcprs := pr.Status.ChildPipelineRuns[childPipelineRunName]
+		childPipelineRun, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(childPipelineRunName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving PipelineRun %s: %w", childPipelineRunName, err)
+			}
+		} else {
+			prcprs.Status = &childPipelineRun.Status
+		}
+	}
+	return nil
+}
+
 type getTimeoutFunc func(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) *metav1.Duration
 
-func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string, getTimeoutFunc getTimeoutFunc) (*v1beta1.TaskRun, error) {
+// createTaskRuns creates the TaskRuns of a matrixed PipelineTask which don't exist yet, one per combination
+// of its Matrix parameters, and retries the ones that failed, haven't exhausted their retries and
+// don't wait for the backoff of the RetryStrategy
+func (c *Reconciler) createTaskRuns(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string, getTimeoutFunc getTimeoutFunc) ([]*v1beta1.TaskRun, error) {
+	rprt.PipelineTask = resources.ApplyPipelineTaskContexts(rprt.PipelineTask)
+	combinations := resources.FanOut(rprt.PipelineTask.Matrix)
+	if len(combinations) != len(rprt.TaskRunNames) {
+		return nil, fmt.Errorf("expected %d TaskRuns for the matrix of PipelineTask %s but got %d names", len(combinations), rprt.PipelineTask.Name, len(rprt.TaskRunNames))
+	}
+	var taskRuns []*v1beta1.TaskRun
+	for i, taskRunName := range rprt.TaskRunNames {
+		var taskRun *v1beta1.TaskRun
+		if i < len(rprt.TaskRuns) {
+			taskRun = rprt.TaskRuns[i]
+		}
+		if taskRun == nil || (rprt.IsTaskRunRetriable(taskRun) && rprt.RetryBackoffRemaining(taskRun, time.Now()) == 0) {
+			var err error
+			taskRun, err = c.createTaskRun(ctx, taskRunName, combinations[i], rprt, pr, storageBasePath, getTimeoutFunc)
+			if err != nil {
+				return nil, err
+			}
+		}
+		taskRuns = append(taskRuns, taskRun)
+	}
+	return taskRuns, nil
+}
+
+// createTaskRun creates the TaskRun called taskRunName for the PipelineTask, passing it the PipelineTask
+// params followed by matrixParams, or retries it if it already exists
+func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, matrixParams []v1beta1.Param, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string, getTimeoutFunc getTimeoutFunc) (*v1beta1.TaskRun, error) {
 	logger := logging.FromContext(ctx)
 
-	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(rprt.TaskRunName)
+	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
 	if tr != nil {
 		// Don't modify the lister cache's copy.
 		tr = tr.DeepCopy()
@@ -766,17 +951,20 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 	taskRunSpec := pr.GetTaskRunSpec(rprt.PipelineTask.Name)
 	tr = &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            rprt.TaskRunName,
+			Name:            taskRunName,
 			Namespace:       pr.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
 			Labels:          combineTaskRunAndTaskSpecLabels(pr, rprt.PipelineTask),
 			Annotations:     combineTaskRunAndTaskSpecAnnotations(pr, rprt.PipelineTask),
 		},
 		Spec: v1beta1.TaskRunSpec{
-			Params:             rprt.PipelineTask.Params,
+			Params:             appendMatrixParams(rprt.PipelineTask.Params, matrixParams),
 			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
 			Timeout:            getTimeoutFunc(ctx, pr, rprt),
 			PodTemplate:        taskRunSpec.TaskPodTemplate,
+			StepTemplate:       taskRunSpec.StepTemplate,
+			StepOverrides:      taskRunSpec.StepOverrides,
+			SidecarOverrides:   taskRunSpec.SidecarOverrides,
 		}}
 
 	if rprt.ResolvedTaskResources.TaskName != "" {
@@ -798,16 +986,43 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 	}
 
 	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
-	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", rprt.TaskRunName, rprt.PipelineTask.Name)
+	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", taskRunName, rprt.PipelineTask.Name)
 	return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
 }
 
-func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, getTimeoutFunc getTimeoutFunc) (*v1alpha1.Run, error) {
+// createRuns creates the Runs of a matrixed PipelineTask which don't exist yet, one per combination
+// of its Matrix parameters
+func (c *Reconciler) createRuns(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, getTimeoutFunc getTimeoutFunc) ([]*v1alpha1.Run, error) {
+	combinations := resources.FanOut(rprt.PipelineTask.Matrix)
+	if len(combinations) != len(rprt.RunNames) {
+		return nil, fmt.Errorf("expected %d Runs for the matrix of PipelineTask %s but got %d names", len(combinations), rprt.PipelineTask.Name, len(rprt.RunNames))
+	}
+	var runs []*v1alpha1.Run
+	for i, runName := range rprt.RunNames {
+		var run *v1alpha1.Run
+		if i < len(rprt.Runs) {
+			run = rprt.Runs[i]
+		}
+		if run == nil {
+			var err error
+			run, err = c.createRun(ctx, runName, combinations[i], rprt, pr, getTimeoutFunc)
+			if err != nil {
+				return nil, err
+			}
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// createRun creates the Run called runName for the PipelineTask, passing it the PipelineTask
+// params followed by matrixParams
+func (c *Reconciler) createRun(ctx context.Context, runName string, matrixParams []v1beta1.Param, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, getTimeoutFunc getTimeoutFunc) (*v1alpha1.Run, error) {
 	logger := logging.FromContext(ctx)
 	taskRunSpec := pr.GetTaskRunSpec(rprt.PipelineTask.Name)
 	r := &v1alpha1.Run{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            rprt.RunName,
+			Name:            runName,
 			Namespace:       pr.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
 			Labels:          getTaskrunLabels(pr, rprt.PipelineTask.Name, true),
@@ -815,7 +1030,7 @@ func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipe
 		},
 		Spec: v1alpha1.RunSpec{
 			Ref:                rprt.PipelineTask.TaskRef,
-			Params:             rprt.PipelineTask.Params,
+			Params:             appendMatrixParams(rprt.PipelineTask.Params, matrixParams),
 			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
 			Timeout:            getTimeoutFunc(ctx, pr, rprt),
 			PodTemplate:        taskRunSpec.TaskPodTemplate,
@@ -851,10 +1066,59 @@ func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipe
 		r.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
 	}
 
-	logger.Infof("Creating a new Run object %s", rprt.RunName)
+	logger.Infof("Creating a new Run object %s", runName)
 	return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(ctx, r, metav1.CreateOptions{})
 }
 
+// createChildPipelineRun creates the child PipelineRun running the Pipeline referenced or embedded by the
+// PipelineTask, passing it the PipelineTask params and workspaces
+func (c *Reconciler) createChildPipelineRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, getTimeoutFunc getTimeoutFunc) (*v1beta1.PipelineRun, error) {
+	logger := logging.FromContext(ctx)
+	rprt.PipelineTask = resources.ApplyPipelineTaskContexts(rprt.PipelineTask)
+	taskRunSpec := pr.GetTaskRunSpec(rprt.PipelineTask.Name)
+	// The child PipelineRun is labelled with the name of the Pipeline it runs, not with the
+	// one of its parent, so that it isn't taken for a run of the parent's Pipeline.
+	labels := getTaskrunLabels(pr, rprt.PipelineTask.Name, true)
+	delete(labels, pipeline.PipelineLabelKey)
+	labels[pipeline.ParentPipelineRunLabelKey] = pr.Name
+	childPipelineRun := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.ChildPipelineRunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			Labels:          labels,
+			Annotations:     getTaskrunAnnotations(pr),
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:        rprt.PipelineTask.PipelineRef,
+			PipelineSpec:       rprt.PipelineTask.PipelineSpec,
+			Params:             rprt.PipelineTask.Params,
+			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
+			Timeout:            getTimeoutFunc(ctx, pr, rprt),
+			PodTemplate:        taskRunSpec.TaskPodTemplate,
+			StepTemplate:       taskRunSpec.StepTemplate,
+		},
+	}
+
+	var err error
+	childPipelineRun.Spec.Workspaces, _, err = getTaskrunWorkspaces(pr, rprt)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Creating a new child PipelineRun object %s for pipeline task %s", rprt.ChildPipelineRunName, rprt.PipelineTask.Name)
+	return c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Create(ctx, childPipelineRun, metav1.CreateOptions{})
+}
+
+// appendMatrixParams returns the params of a PipelineTask followed by the params of one combination
+// of its Matrix, without modifying the PipelineTask params
+func appendMatrixParams(params []v1beta1.Param, matrixParams []v1beta1.Param) []v1beta1.Param {
+	if len(matrixParams) == 0 {
+		return params
+	}
+	return append(append([]v1beta1.Param{}, params...), matrixParams...)
+}
+
 func getTaskrunWorkspaces(pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) ([]v1beta1.WorkspaceBinding, string, error) {
 	var workspaces []v1beta1.WorkspaceBinding
 	var pipelinePVCWorkspaceName string
@@ -949,6 +1213,8 @@ func propagatePipelineNameLabelToPipelineRun(pr *v1beta1.PipelineRun) error {
 		pr.ObjectMeta.Labels[pipeline.PipelineLabelKey] = pr.Spec.PipelineRef.Name
 	case pr.Spec.PipelineSpec != nil:
 		pr.ObjectMeta.Labels[pipeline.PipelineLabelKey] = pr.Name
+	case pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Resolver != "":
+		// The label is set to the name of the Pipeline once the resolver has resolved it.
 	default:
 		return fmt.Errorf("pipelineRun %s not providing PipelineRef or PipelineSpec", pr.Name)
 	}
@@ -1191,8 +1457,9 @@ func (c *Reconciler) makeConditionCheckContainer(ctx context.Context, rprt *reso
 			Resources: &v1beta1.TaskRunResources{
 				Inputs: rcc.ToTaskResourceBindings(),
 			},
-			Timeout:     getTaskRunTimeout(ctx, pr, rprt),
-			PodTemplate: taskRunSpec.TaskPodTemplate,
+			Timeout:      getTaskRunTimeout(ctx, pr, rprt),
+			PodTemplate:  taskRunSpec.TaskPodTemplate,
+			StepTemplate: taskRunSpec.StepTemplate,
 		}}
 
 	cctr, err := c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
@@ -1229,6 +1496,21 @@ func (c *Reconciler) updatePipelineRunStatusFromInformer(ctx context.Context, pr
 	}
 	updatePipelineRunStatusFromRuns(logger, pr, runs)
 
+	return c.updateChildPipelineRunsStatusFromInformer(ctx, pr)
+}
+
+// updateChildPipelineRunsStatusFromInformer adds the child PipelineRuns created by the PipelineRun
+// to its status, using the label that createChildPipelineRun sets on each of them.
+func (c *Reconciler) updateChildPipelineRunsStatusFromInformer(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	logger := logging.FromContext(ctx)
+
+	childLabels := map[string]string{pipeline.ParentPipelineRunLabelKey: pr.Name}
+	childPipelineRuns, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(k8slabels.SelectorFromSet(childLabels))
+	if err != nil {
+		logger.Errorf("could not list child PipelineRuns %#v", err)
+		return err
+	}
+	updatePipelineRunStatusFromChildPipelineRuns(logger, pr, childPipelineRuns)
 	return nil
 }
 
@@ -1349,3 +1631,30 @@ func updatePipelineRunStatusFromRuns(logger *zap.SugaredLogger, pr *v1beta1.Pipe
 		}
 	}
 }
+
+func updatePipelineRunStatusFromChildPipelineRuns(logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, childPipelineRuns []*v1beta1.PipelineRun) {
+	// If no child PipelineRun was found, nothing to be done. We never remove child pipelineruns from the status
+	if len(childPipelineRuns) == 0 {
+		return
+	}
+	if pr.Status.ChildPipelineRuns == nil {
+		pr.Status.ChildPipelineRuns = make(map[string]*v1beta1.PipelineRunChildPipelineRunStatus)
+	}
+	// Loop over all the child PipelineRuns associated to PipelineTasks
+	for _, childPipelineRun := range childPipelineRuns {
+		// Only process PipelineRuns that are owned by this PipelineRun.
+		if len(childPipelineRun.OwnerReferences) < 1 || childPipelineRun.OwnerReferences[0].UID != pr.ObjectMeta.UID {
+			logger.Debugf("Found a PipelineRun %s that is not owned by this PipelineRun", childPipelineRun.Name)
+			continue
+		}
+		lbls := childPipelineRun.GetLabels()
+		pipelineTaskName := lbls[pipeline.PipelineTaskLabelKey]
+		if _, ok := pr.Status.ChildPipelineRuns[childPipelineRun.Name]; !ok {
+			// This child pipelinerun was missing from the status.
+			pr.Status.ChildPipelineRuns[childPipelineRun.Name] = &v1beta1.PipelineRunChildPipelineRunStatus{
+				PipelineTaskName: pipelineTaskName,
+				Status:           &childPipelineRun.Status,
+			}
+		}
+	}
+}
@@ -18,6 +18,7 @@ package pipelinerun
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http/httptest"
@@ -38,6 +39,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	taskrunresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
@@ -49,6 +51,7 @@ import (
 	"gomodules.xyz/jsonpatch/v2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
@@ -99,7 +102,7 @@ type PipelineRunTest struct {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, concurrencyExists, prunerExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -113,6 +116,12 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetArtifactPVCConfigName() {
 			artifactPVCExists = true
 		}
+		if cm.Name == config.GetConcurrencyConfigName() {
+			concurrencyExists = true
+		}
+		if cm.Name == config.GetPrunerConfigName() {
+			prunerExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -138,6 +147,18 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !concurrencyExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetConcurrencyConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !prunerExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetPrunerConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getPipelineRunController returns an instance of the PipelineRun controller/reconciler that has been seeded with
@@ -1459,6 +1480,105 @@ func TestReconcileOnCompletedPipelineRun(t *testing.T) {
 	}
 }
 
+func TestReconcileOnCompletedPipelineRunPruned(t *testing.T) {
+	now := time.Now()
+	pipelineRun := func(name string, completedAgo time.Duration) *v1beta1.PipelineRun {
+		completionTime := metav1.NewTime(now.Add(-completedAgo))
+		return &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "foo",
+				Labels:    map[string]string{pipeline.PipelineLabelKey: "test-pipeline"},
+			},
+			Spec: v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "test-pipeline"}},
+			Status: v1beta1.PipelineRunStatus{
+				Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}}},
+				PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					StartTime:      &completionTime,
+					CompletionTime: &completionTime,
+				},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		pruner      map[string]string
+		namespace   *corev1.Namespace
+		reconcile   string
+		wantRequeue bool
+		wantPruned  []string
+		wantKept    []string
+	}{{
+		name:       "keep",
+		pruner:     map[string]string{"keep": "2"},
+		reconcile:  "recent",
+		wantPruned: []string{"oldest"},
+		wantKept:   []string{"older", "recent"},
+	}, {
+		name:        "ttl not expired",
+		pruner:      map[string]string{"ttl": "1h"},
+		reconcile:   "recent",
+		wantRequeue: true,
+		wantKept:    []string{"oldest", "older", "recent"},
+	}, {
+		name:       "ttl expired",
+		pruner:     map[string]string{"ttl": "1h"},
+		reconcile:  "older",
+		wantPruned: []string{"older"},
+		wantKept:   []string{"oldest", "recent"},
+	}, {
+		name: "namespace override",
+		namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Annotations: map[string]string{config.PrunerKeepAnnotation: "1"},
+		}},
+		reconcile:  "recent",
+		wantPruned: []string{"oldest", "older"},
+		wantKept:   []string{"recent"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{
+					pipelineRun("oldest", 3*time.Hour),
+					pipelineRun("older", 2*time.Hour),
+					pipelineRun("recent", time.Minute),
+				},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetPrunerConfigName(), Namespace: system.Namespace()},
+					Data:       tc.pruner,
+				}},
+			}
+			if tc.namespace != nil {
+				d.Namespaces = []*corev1.Namespace{tc.namespace}
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			err := prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/"+tc.reconcile)
+			if ok, _ := controller.IsRequeueKey(err); ok != tc.wantRequeue {
+				t.Errorf("Expected the PipelineRun to be requeued: %t, but got error %v", tc.wantRequeue, err)
+			} else if !ok && err != nil {
+				t.Fatalf("Unexpected error when reconciling completed PipelineRun : %v", err)
+			}
+			clients := prt.TestAssets.Clients
+			for _, name := range tc.wantPruned {
+				if _, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+					t.Errorf("Expected PipelineRun %s to be pruned but got %v", name, err)
+				}
+			}
+			for _, name := range tc.wantKept {
+				if _, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, name, metav1.GetOptions{}); err != nil {
+					t.Errorf("Expected PipelineRun %s to be kept but got %v", name, err)
+				}
+			}
+		})
+	}
+}
+
 func TestReconcileOnCancelledPipelineRunDeprecated(t *testing.T) {
 	// TestReconcileOnCancelledPipelineRunDeprecated runs "Reconcile" on a PipelineRun that has been cancelled.
 	// It verifies that reconcile is successful, the pipeline status updated and events generated.
@@ -2438,6 +2558,296 @@ func TestReconcileOnPendingPipelineRun(t *testing.T) {
 	}
 }
 
+func TestReconcileOnQueuedPipelineRun(t *testing.T) {
+	// TestReconcileOnQueuedPipelineRun runs "Reconcile" on PipelineRuns matched by a concurrency rule.
+	// It verifies that the PipelineRuns above the limit of the rule are queued in the order they
+	// were created and that the first queued PipelineRun starts once a slot is free.
+	created := metav1.Now()
+	pipelineRun := func(name string, offset time.Duration, status duckv1beta1.Status, startTime *metav1.Time) *v1beta1.PipelineRun {
+		return &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "foo",
+				CreationTimestamp: metav1.NewTime(created.Add(offset)),
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef:        &v1beta1.PipelineRef{Name: "test-pipeline"},
+				ServiceAccountName: "test-sa",
+			},
+			Status: v1beta1.PipelineRunStatus{
+				Status:                  status,
+				PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{StartTime: startTime},
+			},
+		}
+	}
+	running := duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: v1beta1.PipelineRunReasonRunning.String(),
+	}}}
+	succeeded := duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+		Reason: v1beta1.PipelineRunReasonSuccessful.String(),
+	}}}
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name: "hello-world",
+				TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+					Steps: []v1beta1.Step{{Container: corev1.Container{Image: "busybox", Command: []string{"/mycmd"}}}},
+				}},
+			}},
+		},
+	}}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetConcurrencyConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"deploy": "pipeline: test-pipeline\nlimit: 1\n",
+		},
+	}}
+
+	for _, tc := range []struct {
+		name        string
+		first       *v1beta1.PipelineRun
+		wantQueued  map[string]string
+		wantStarted string
+	}{{
+		name:  "slot taken",
+		first: pipelineRun("first", 0, running, &created),
+		wantQueued: map[string]string{
+			"second": `PipelineRun "second" is queued at position 1 of 2 by concurrency rule "deploy", which allows 1 PipelineRuns to run at once`,
+			"third":  `PipelineRun "third" is queued at position 2 of 2 by concurrency rule "deploy", which allows 1 PipelineRuns to run at once`,
+		},
+	}, {
+		name:  "slot free",
+		first: pipelineRun("first", 0, succeeded, &created),
+		wantQueued: map[string]string{
+			"third": `PipelineRun "third" is queued at position 1 of 1 by concurrency rule "deploy", which allows 1 PipelineRuns to run at once`,
+		},
+		wantStarted: "second",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{
+					tc.first,
+					pipelineRun("second", time.Minute, duckv1beta1.Status{}, nil),
+					pipelineRun("third", 2*time.Minute, duckv1beta1.Status{}, nil),
+				},
+				Pipelines:  ps,
+				ConfigMaps: cms,
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			for name, wantMessage := range tc.wantQueued {
+				reconciledRun, _ := prt.reconcileRun("foo", name, nil, false)
+				condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+				if condition == nil || !condition.IsUnknown() || condition.Reason != ReasonQueued {
+					t.Fatalf("Expected PipelineRun %s to be queued but got condition %v", name, condition)
+				}
+				if d := cmp.Diff(wantMessage, condition.Message); d != "" {
+					t.Errorf("Unexpected queued message for PipelineRun %s %s", name, diff.PrintWantGot(d))
+				}
+				if reconciledRun.Status.StartTime != nil {
+					t.Errorf("Start time of queued PipelineRun %s should be nil, not: %s", name, reconciledRun.Status.StartTime)
+				}
+			}
+			if tc.wantStarted != "" {
+				reconciledRun, clients := prt.reconcileRun("foo", tc.wantStarted, nil, false)
+				condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+				if condition == nil || condition.Reason != v1beta1.PipelineRunReasonRunning.String() {
+					t.Errorf("Expected PipelineRun %s to be running but got condition %v", tc.wantStarted, condition)
+				}
+				if reconciledRun.Status.StartTime == nil {
+					t.Errorf("Expected PipelineRun %s to have a start time", tc.wantStarted)
+				}
+				if len(getTaskRunCreations(t, clients.Pipeline.Actions())) != 1 {
+					t.Errorf("Expected a TaskRun to be created for PipelineRun %s", tc.wantStarted)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileOnQueuedPipelineRunSeveralRules(t *testing.T) {
+	// TestReconcileOnQueuedPipelineRunSeveralRules runs "Reconcile" on PipelineRuns matched by two
+	// concurrency rules. It verifies that a PipelineRun held by the first rule doesn't take a position
+	// in the queue of the second one, so that the PipelineRuns after it can start.
+	created := metav1.Now()
+	pipelineRun := func(name, pipelineName string, offset time.Duration, lbls map[string]string, startTime *metav1.Time) *v1beta1.PipelineRun {
+		pr := &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "foo",
+				Labels:            lbls,
+				CreationTimestamp: metav1.NewTime(created.Add(offset)),
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef:        &v1beta1.PipelineRef{Name: pipelineName},
+				ServiceAccountName: "test-sa",
+			},
+		}
+		if startTime != nil {
+			pr.Status.StartTime = startTime
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: v1beta1.PipelineRunReasonRunning.String(),
+			})
+		}
+		return pr
+	}
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name: "hello-world",
+				TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+					Steps: []v1beta1.Step{{Container: corev1.Container{Image: "busybox", Command: []string{"/mycmd"}}}},
+				}},
+			}},
+		},
+	}}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetConcurrencyConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"a-team":     "selector:\n  team: blue\nlimit: 1\n",
+			"b-pipeline": "pipeline: test-pipeline\nlimit: 1\n",
+		},
+	}}
+	blue := map[string]string{"team": "blue"}
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{
+			pipelineRun("running", "other-pipeline", 0, blue, &created),
+			pipelineRun("held", "test-pipeline", time.Minute, blue, nil),
+			pipelineRun("next", "test-pipeline", 2*time.Minute, nil, nil),
+		},
+		Pipelines:  ps,
+		ConfigMaps: cms,
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, _ := prt.reconcileRun("foo", "held", nil, false)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	wantMessage := `PipelineRun "held" is queued at position 1 of 1 by concurrency rule "a-team", which allows 1 PipelineRuns to run at once`
+	if condition == nil || condition.Reason != ReasonQueued || condition.Message != wantMessage {
+		t.Errorf("Expected PipelineRun held to be queued by the first rule but got condition %v", condition)
+	}
+
+	reconciledRun, clients := prt.reconcileRun("foo", "next", nil, false)
+	condition = reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Reason != v1beta1.PipelineRunReasonRunning.String() {
+		t.Errorf("Expected PipelineRun next to be running but got condition %v", condition)
+	}
+	if len(getTaskRunCreations(t, clients.Pipeline.Actions())) != 1 {
+		t.Errorf("Expected a TaskRun to be created for PipelineRun next")
+	}
+}
+
+func TestReconcileOnQueuedPipelineRunChildPipeline(t *testing.T) {
+	// TestReconcileOnQueuedPipelineRunChildPipeline runs "Reconcile" on the child PipelineRun of a
+	// PipelineRun that holds the only slot of a concurrency rule. It verifies that the child starts,
+	// even if it has the labels of its parent, and that it isn't counted against the limit of the rule.
+	created := metav1.Now()
+	parent := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "parent",
+			Namespace:         "foo",
+			CreationTimestamp: created,
+			Labels:            map[string]string{pipeline.PipelineLabelKey: "test-pipeline"},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:        &v1beta1.PipelineRef{Name: "test-pipeline"},
+			ServiceAccountName: "test-sa",
+		},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: v1beta1.PipelineRunReasonRunning.String(),
+			}}},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{StartTime: &created},
+		},
+	}
+	childPipelineSpec := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "build",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{Image: "busybox", Command: []string{"/mycmd"}}}},
+			}},
+		}},
+	}
+	child := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "parent-child",
+			Namespace:         "foo",
+			CreationTimestamp: metav1.NewTime(created.Add(2 * time.Minute)),
+			OwnerReferences:   []metav1.OwnerReference{*kmeta.NewControllerRef(parent)},
+			Labels: map[string]string{
+				pipeline.PipelineLabelKey:     "test-pipeline",
+				pipeline.PipelineRunLabelKey:  "parent",
+				pipeline.PipelineTaskLabelKey: "child",
+			},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec:       childPipelineSpec,
+			ServiceAccountName: "test-sa",
+		},
+	}
+	second := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "second",
+			Namespace:         "foo",
+			CreationTimestamp: metav1.NewTime(created.Add(time.Minute)),
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:        &v1beta1.PipelineRef{Name: "test-pipeline"},
+			ServiceAccountName: "test-sa",
+		},
+	}
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:         "child",
+				PipelineSpec: childPipelineSpec,
+			}},
+		},
+	}}
+	cms := append(getConfigMapsWithEnabledAlphaAPIFields(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetConcurrencyConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"deploy": "pipeline: test-pipeline\nlimit: 1\n",
+		},
+	})
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{parent, child, second},
+		Pipelines:    ps,
+		ConfigMaps:   cms,
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "parent-child", nil, false)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Reason != v1beta1.PipelineRunReasonRunning.String() {
+		t.Errorf("Expected child PipelineRun to be running but got condition %v", condition)
+	}
+	if len(getTaskRunCreations(t, clients.Pipeline.Actions())) != 1 {
+		t.Errorf("Expected a TaskRun to be created for the child PipelineRun")
+	}
+
+	reconciledRun, _ = prt.reconcileRun("foo", "second", nil, false)
+	condition = reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	wantMessage := `PipelineRun "second" is queued at position 1 of 1 by concurrency rule "deploy", which allows 1 PipelineRuns to run at once`
+	if condition == nil || condition.Reason != ReasonQueued || condition.Message != wantMessage {
+		t.Errorf("Expected PipelineRun second to be queued behind its parent only but got condition %v", condition)
+	}
+}
+
 func TestReconcileWithTimeout(t *testing.T) {
 	// TestReconcileWithTimeout runs "Reconcile" on a PipelineRun that has timed out.
 	// It verifies that reconcile is successful, the pipeline status updated and events generated.
@@ -3005,6 +3415,106 @@ func TestReconcileWithTimeoutAndRetry(t *testing.T) {
 	}
 }
 
+func TestReconcileWithRetryBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		completedSince time.Duration
+		wantRetries    int
+		wantCondition  corev1.ConditionStatus
+		wantRequeue    bool
+	}{{
+		name:           "retry waits for the backoff",
+		completedSince: time.Second,
+		wantRetries:    0,
+		wantCondition:  corev1.ConditionFalse,
+		wantRequeue:    true,
+	}, {
+		name:           "retry after the backoff",
+		completedSince: 2 * time.Minute,
+		wantRetries:    1,
+		wantCondition:  corev1.ConditionUnknown,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1beta1.Pipeline{{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-retry", Namespace: "foo"},
+				Spec: v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name:    "hello-world-1",
+						TaskRef: &v1beta1.TaskRef{Name: "hello-world"},
+						Retries: 2,
+						RetryStrategy: &v1beta1.RetryStrategy{
+							Backoff: &v1beta1.Backoff{
+								Duration: &metav1.Duration{Duration: time.Minute},
+							},
+							OnReasons: []string{podconvert.ReasonPodEvicted},
+						},
+					}},
+				},
+			}}
+			prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-retry-run-with-backoff", tb.PipelineRunNamespace("foo"),
+				tb.PipelineRunSpec("test-pipeline-retry",
+					tb.PipelineRunServiceAccountName("test-sa"),
+					tb.PipelineRunTimeout(12*time.Hour),
+				),
+				tb.PipelineRunStatus(
+					tb.PipelineRunStartTime(time.Now().Add(-time.Hour))),
+			)}
+			ts := []*v1beta1.Task{
+				tb.Task("hello-world", tb.TaskNamespace("foo")),
+			}
+			trs := []*v1beta1.TaskRun{
+				tb.TaskRun("hello-world-1",
+					tb.TaskRunNamespace("foo"),
+					tb.TaskRunStatus(
+						tb.PodName("my-pod-name"),
+						tb.StatusCondition(apis.Condition{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+							Reason: podconvert.ReasonPodEvicted,
+						}),
+					)),
+			}
+			trs[0].Status.CompletionTime = &metav1.Time{Time: time.Now().Add(-tc.completedSince)}
+			prs[0].Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"hello-world-1": {
+					PipelineTaskName: "hello-world-1",
+					Status:           &trs[0].Status,
+				},
+			}
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+				ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			err := prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/test-pipeline-retry-run-with-backoff")
+			requeue, delay := controller.IsRequeueKey(err)
+			if !requeue {
+				t.Fatalf("Expected the PipelineRun to be requeued, got %v", err)
+			}
+			if tc.wantRequeue && delay > time.Minute {
+				t.Errorf("Expected the PipelineRun to be requeued within the backoff of 1m, got %s", delay)
+			}
+
+			tr, err := prt.TestAssets.Clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "hello-world-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get the TaskRun: %v", err)
+			}
+			if len(tr.Status.RetriesStatus) != tc.wantRetries {
+				t.Errorf("Expected %d retries but got %d", tc.wantRetries, len(tr.Status.RetriesStatus))
+			}
+			if status := tr.Status.GetCondition(apis.ConditionSucceeded).Status; status != tc.wantCondition {
+				t.Errorf("Succeeded expected to be %s but is %s", tc.wantCondition, status)
+			}
+		})
+	}
+}
+
 func TestReconcilePropagateAnnotations(t *testing.T) {
 	names.TestingSeed()
 
@@ -6317,7 +6827,7 @@ func TestReconcileWithTaskResultsInFinalTasks(t *testing.T) {
 				}},
 			},
 			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
-				TaskRunResults: []v1beta1.TaskRunResult{{Name: "aResult", Value: "aResultValue"}},
+				TaskRunResults: []v1beta1.TaskRunResult{{Name: "aResult", Value: *v1beta1.NewArrayOrString("aResultValue")}},
 			},
 		},
 	}, {
@@ -6608,35 +7118,97 @@ func TestReconcile_RemotePipelineRef(t *testing.T) {
 	}
 }
 
-// TestReconcile_OptionalWorkspacesOmitted checks that an optional workspace declared by
-// a Task and a Pipeline can be omitted by a PipelineRun and the run will still start
-// successfully without an error.
-func TestReconcile_OptionalWorkspacesOmitted(t *testing.T) {
+func TestReconcile_PipelineRefResolver(t *testing.T) {
 	names.TestingSeed()
 
-	ctx := context.Background()
-	cfg := config.NewStore(logtesting.TestLogger(t))
-	ctx = cfg.ToContext(ctx)
-
 	prs := []*v1beta1.PipelineRun{{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-success", Namespace: "foo"},
 		Spec: v1beta1.PipelineRunSpec{
 			ServiceAccountName: "test-sa",
-			PipelineSpec: &v1beta1.PipelineSpec{
-				Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
-					Name:     "optional-workspace",
-					Optional: true,
-				}},
-				Tasks: []v1beta1.PipelineTask{{
-					Name: "unit-test-1",
-					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
-						Workspaces: []v1beta1.WorkspaceDeclaration{{
-							Name:     "ws",
-							Optional: true,
-						}},
-						Steps: []v1beta1.Step{{
-							Container: corev1.Container{
-								Image: "foo:latest",
+			PipelineRef: &v1beta1.PipelineRef{ResolverRef: v1beta1.ResolverRef{
+				Resolver: "git",
+				Params:   []v1beta1.Param{{Name: "path", Value: *v1beta1.NewArrayOrString("pipeline.yaml")}},
+			}},
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: prs[0].Spec.ServiceAccountName, Namespace: "foo"},
+		}},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	// The PipelineRun waits for the resolver to fulfill its ResolutionRequest.
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-success", nil, false)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionUnknown || condition.Reason != ReasonResolvingPipelineRef {
+		t.Errorf("Expected PipelineRun to be resolving its Pipeline but got condition %v", condition)
+	}
+	rrs, err := clients.Pipeline.TektonV1alpha1().ResolutionRequests("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil || len(rrs.Items) != 1 {
+		t.Fatalf("Expected 1 ResolutionRequest but got %v, %v", rrs, err)
+	}
+
+	// Once the Pipeline is resolved, the PipelineRun runs it.
+	rr := &rrs.Items[0]
+	rr.Status.Data = base64.StdEncoding.EncodeToString([]byte(`apiVersion: tekton.dev/v1beta1
+kind: Pipeline
+metadata:
+  name: resolved
+spec:
+  tasks:
+  - name: unit-test-1
+    taskSpec:
+      steps:
+      - image: busybox
+        command: ["/mycmd"]
+`))
+	rr.Status.MarkSucceeded()
+	if _, err := clients.Pipeline.TektonV1alpha1().ResolutionRequests("foo").UpdateStatus(prt.TestAssets.Ctx, rr, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	clients.Pipeline.ClearActions()
+	reconciledRun, clients = prt.reconcileRun("foo", "test-pipeline-run-success", nil, false)
+	condition = reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Reason != v1beta1.PipelineRunReasonRunning.String() {
+		t.Errorf("Expected PipelineRun to be running but got condition %v", condition)
+	}
+	if len(getTaskRunCreations(t, clients.Pipeline.Actions())) != 1 {
+		t.Errorf("Expected a TaskRun to be created for the resolved Pipeline")
+	}
+}
+
+// TestReconcile_OptionalWorkspacesOmitted checks that an optional workspace declared by
+// a Task and a Pipeline can be omitted by a PipelineRun and the run will still start
+// successfully without an error.
+func TestReconcile_OptionalWorkspacesOmitted(t *testing.T) {
+	names.TestingSeed()
+
+	ctx := context.Background()
+	cfg := config.NewStore(logtesting.TestLogger(t))
+	ctx = cfg.ToContext(ctx)
+
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-success", Namespace: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+					Name:     "optional-workspace",
+					Optional: true,
+				}},
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "unit-test-1",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+						Workspaces: []v1beta1.WorkspaceDeclaration{{
+							Name:     "ws",
+							Optional: true,
+						}},
+						Steps: []v1beta1.Step{{
+							Container: corev1.Container{
+								Image: "foo:latest",
 							},
 						}},
 					}},
@@ -6793,7 +7365,7 @@ func TestReconcile_DependencyValidationsImmediatelyFailPipelineRun(t *testing.T)
 				}},
 				Results: []v1beta1.PipelineResult{{
 					Name:  "pr",
-					Value: "$(tasks.pt0.results.r)",
+					Value: *v1beta1.NewArrayOrString("$(tasks.pt0.results.r)"),
 				}},
 			},
 		},
@@ -6884,3 +7456,608 @@ func getTaskRunWithTaskSpec(tr, pr, p, t string, labels, annotations map[string]
 			tb.TaskRunServiceAccountName(config.DefaultServiceAccountValue),
 		))
 }
+
+func TestReconcile_Matrix(t *testing.T) {
+	names.TestingSeed()
+
+	taskSpec := v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name: "platform", Type: v1beta1.ParamTypeString,
+		}, {
+			Name: "browser", Type: v1beta1.ParamTypeString,
+		}, {
+			Name: "version", Type: v1beta1.ParamTypeString,
+		}},
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Image: "foo:latest",
+			},
+		}},
+	}
+	pipelineSpec := &v1beta1.PipelineSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name: "platforms", Type: v1beta1.ParamTypeArray,
+		}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name:     "matrixed",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: taskSpec},
+			Params: []v1beta1.Param{{
+				Name: "version", Value: *v1beta1.NewArrayOrString("v1"),
+			}},
+			Matrix: []v1beta1.Param{{
+				Name: "platform", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"$(params.platforms[*])"}},
+			}, {
+				Name: "browser", Value: *v1beta1.NewArrayOrString("chrome", "safari"),
+			}},
+		}, {
+			Name:     "after",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: taskSpec},
+			RunAfter: []string{"matrixed"},
+			Params: []v1beta1.Param{{
+				Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+			}, {
+				Name: "browser", Value: *v1beta1.NewArrayOrString("chrome"),
+			}, {
+				Name: "version", Value: *v1beta1.NewArrayOrString("v1"),
+			}},
+		}},
+	}
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			PipelineSpec:       pipelineSpec,
+			Params: []v1beta1.Param{{
+				Name: "platforms", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+			}},
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sa", Namespace: "foo"},
+		}},
+		ConfigMaps: getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+	// A TaskRun is created for each combination of the matrix, and the dependent task waits for all of them
+	wantParams := map[string][]v1beta1.Param{
+		"pr-matrixed-0": {{Name: "version", Value: *v1beta1.NewArrayOrString("v1")}, {Name: "platform", Value: *v1beta1.NewArrayOrString("linux")}, {Name: "browser", Value: *v1beta1.NewArrayOrString("chrome")}},
+		"pr-matrixed-1": {{Name: "version", Value: *v1beta1.NewArrayOrString("v1")}, {Name: "platform", Value: *v1beta1.NewArrayOrString("linux")}, {Name: "browser", Value: *v1beta1.NewArrayOrString("safari")}},
+		"pr-matrixed-2": {{Name: "version", Value: *v1beta1.NewArrayOrString("v1")}, {Name: "platform", Value: *v1beta1.NewArrayOrString("mac")}, {Name: "browser", Value: *v1beta1.NewArrayOrString("chrome")}},
+		"pr-matrixed-3": {{Name: "version", Value: *v1beta1.NewArrayOrString("v1")}, {Name: "platform", Value: *v1beta1.NewArrayOrString("mac")}, {Name: "browser", Value: *v1beta1.NewArrayOrString("safari")}},
+	}
+	gotParams := map[string][]v1beta1.Param{}
+	for _, tr := range getTaskRunCreations(t, clients.Pipeline.Actions()) {
+		if tr.Labels[pipeline.PipelineTaskLabelKey] != "matrixed" {
+			t.Errorf("Expected only TaskRuns for the matrixed task to be created but got %s", tr.Name)
+		}
+		gotParams[tr.Name] = tr.Spec.Params
+	}
+	if d := cmp.Diff(wantParams, gotParams); d != "" {
+		t.Errorf("Unexpected TaskRuns created for the matrixed task. Diff %s", diff.PrintWantGot(d))
+	}
+	for name := range wantParams {
+		if prtrs, ok := reconciledRun.Status.TaskRuns[name]; !ok || prtrs.PipelineTaskName != "matrixed" {
+			t.Errorf("Expected PipelineRun status to include TaskRun %s but was %v", name, reconciledRun.Status.TaskRuns)
+		}
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionUnknown {
+		t.Errorf("Expected PipelineRun status to be in progress, but was %v", condition)
+	}
+}
+
+func TestReconcile_MatrixCompleted(t *testing.T) {
+	names.TestingSeed()
+
+	taskSpec := v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name: "platform", Type: v1beta1.ParamTypeString,
+		}},
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Image: "foo:latest",
+			},
+		}},
+	}
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:     "matrixed",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: taskSpec},
+					Matrix: []v1beta1.Param{{
+						Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+					}},
+				}, {
+					Name:     "after",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: taskSpec},
+					RunAfter: []string{"matrixed"},
+					Params: []v1beta1.Param{{
+						Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+					}},
+				}},
+			},
+		},
+	}}
+	var trs []*v1beta1.TaskRun
+	for _, name := range []string{"pr-matrixed-0", "pr-matrixed-1"} {
+		trs = append(trs, &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "foo",
+				Labels:    map[string]string{pipeline.PipelineTaskLabelKey: "matrixed"},
+			},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+					}},
+				},
+			},
+		})
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sa", Namespace: "foo"},
+		}},
+		ConfigMaps: getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+	// Once all the TaskRuns of the matrixed task succeeded, the dependent task is scheduled
+	created := getTaskRunCreations(t, clients.Pipeline.Actions())
+	if len(created) != 1 || created[0].Labels[pipeline.PipelineTaskLabelKey] != "after" {
+		t.Fatalf("Expected only the TaskRun for the dependent task to be created but got %v", created)
+	}
+	for _, tr := range trs {
+		if _, ok := reconciledRun.Status.TaskRuns[tr.Name]; !ok {
+			t.Errorf("Expected PipelineRun status to include TaskRun %s but was %v", tr.Name, reconciledRun.Status.TaskRuns)
+		}
+	}
+}
+
+func TestReconcile_MatrixArrayResult(t *testing.T) {
+	names.TestingSeed()
+
+	taskSpec := v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name: "platform", Type: v1beta1.ParamTypeString,
+		}},
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Image: "foo:latest",
+			},
+		}},
+	}
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "platforms",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+						Results: []v1beta1.TaskResult{{Name: "platforms", Type: v1beta1.ResultsTypeArray}},
+						Steps: []v1beta1.Step{{
+							Container: corev1.Container{
+								Image: "foo:latest",
+							},
+						}},
+					}},
+				}, {
+					Name:     "matrixed",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: taskSpec},
+					Matrix: []v1beta1.Param{{
+						Name: "platform", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"$(tasks.platforms.results.platforms[*])"}},
+					}},
+				}},
+			},
+		},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+					"pr-platforms": {PipelineTaskName: "platforms"},
+				},
+			},
+		},
+	}}
+	trs := []*v1beta1.TaskRun{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pr-platforms",
+			Namespace: "foo",
+			Labels:    map[string]string{pipeline.PipelineTaskLabelKey: "platforms"},
+		},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				TaskRunResults: []v1beta1.TaskRunResult{{
+					Name:  "platforms",
+					Type:  v1beta1.ResultsTypeArray,
+					Value: *v1beta1.NewArrayOrString("linux", "mac", "windows"),
+				}},
+			},
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sa", Namespace: "foo"},
+		}},
+		ConfigMaps: getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+	// The array result expands to one combination per value, and a TaskRun is created for each of them
+	wantParams := map[string][]v1beta1.Param{
+		"pr-matrixed-0": {{Name: "platform", Value: *v1beta1.NewArrayOrString("linux")}},
+		"pr-matrixed-1": {{Name: "platform", Value: *v1beta1.NewArrayOrString("mac")}},
+		"pr-matrixed-2": {{Name: "platform", Value: *v1beta1.NewArrayOrString("windows")}},
+	}
+	gotParams := map[string][]v1beta1.Param{}
+	for _, tr := range getTaskRunCreations(t, clients.Pipeline.Actions()) {
+		gotParams[tr.Name] = tr.Spec.Params
+	}
+	if d := cmp.Diff(wantParams, gotParams); d != "" {
+		t.Errorf("Unexpected TaskRuns created for the matrixed task. Diff %s", diff.PrintWantGot(d))
+	}
+	for name := range wantParams {
+		if _, ok := reconciledRun.Status.TaskRuns[name]; !ok {
+			t.Errorf("Expected PipelineRun status to include TaskRun %s but was %v", name, reconciledRun.Status.TaskRuns)
+		}
+	}
+}
+
+func TestReconcile_MatrixEmpty(t *testing.T) {
+	names.TestingSeed()
+
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Params: []v1beta1.ParamSpec{{
+					Name: "platforms", Type: v1beta1.ParamTypeArray,
+				}},
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "matrixed",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+						Params: []v1beta1.ParamSpec{{
+							Name: "platform", Type: v1beta1.ParamTypeString,
+						}},
+						Steps: []v1beta1.Step{{
+							Container: corev1.Container{
+								Image: "foo:latest",
+							},
+						}},
+					}},
+					Matrix: []v1beta1.Param{{
+						Name: "platform", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"$(params.platforms[*])"}},
+					}},
+				}},
+			},
+			Params: []v1beta1.Param{{
+				Name: "platforms", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}},
+			}},
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sa", Namespace: "foo"},
+		}},
+		ConfigMaps: getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+	// A matrix without combinations has nothing to run, so the task is skipped and the PipelineRun completes
+	for _, action := range clients.Pipeline.Actions() {
+		if action.Matches("create", "taskruns") {
+			t.Errorf("Expected client to not have created a TaskRun, but it did")
+		}
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Errorf("Expected PipelineRun to succeed, but was %v", condition)
+	}
+	if d := cmp.Diff([]v1beta1.SkippedTask{{Name: "matrixed"}}, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Errorf("Unexpected skipped tasks. Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_ChildPipeline(t *testing.T) {
+	names.TestingSeed()
+
+	childPipelineSpec := &v1beta1.PipelineSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name: "platform", Type: v1beta1.ParamTypeString,
+		}},
+		Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "source"}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "build",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{Image: "foo:latest"}}},
+			}},
+		}},
+	}
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "ws"}},
+				Tasks: []v1beta1.PipelineTask{{
+					Name:         "child",
+					PipelineSpec: childPipelineSpec,
+					Params: []v1beta1.Param{{
+						Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+					}},
+					Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+						Name: "source", Workspace: "ws",
+					}},
+				}},
+			},
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:     "ws",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sa", Namespace: "foo"},
+		}},
+		ConfigMaps: getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "pr", wantEvents, false)
+
+	var created *v1beta1.PipelineRun
+	for _, a := range clients.Pipeline.Actions() {
+		if action, ok := a.(ktesting.CreateAction); ok && a.GetVerb() == "create" {
+			if output, ok := action.GetObject().(*v1beta1.PipelineRun); ok {
+				created = output
+			}
+		}
+	}
+	if created == nil {
+		t.Fatalf("Expected a child PipelineRun to be created but got actions %v", clients.Pipeline.Actions())
+	}
+	wantChild := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pr-child-9l9zj",
+			Namespace: "foo",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "tekton.dev/v1beta1",
+				Kind:               "PipelineRun",
+				Name:               "pr",
+				Controller:         &trueb,
+				BlockOwnerDeletion: &trueb,
+			}},
+			Labels: map[string]string{
+				"tekton.dev/pipelineRun":           "pr",
+				"tekton.dev/pipelineTask":          "child",
+				pipeline.MemberOfLabelKey:          v1beta1.PipelineTasks,
+				pipeline.ParentPipelineRunLabelKey: "pr",
+			},
+			Annotations: map[string]string{},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: childPipelineSpec,
+			Params: []v1beta1.Param{{
+				Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+			}},
+			ServiceAccountName: "test-sa",
+			Timeout:            &metav1.Duration{Duration: time.Hour},
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:     "source",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}
+	if d := cmp.Diff(wantChild, created); d != "" {
+		t.Errorf("Unexpected child PipelineRun created. Diff %s", diff.PrintWantGot(d))
+	}
+	if status, ok := reconciledRun.Status.ChildPipelineRuns[wantChild.Name]; !ok || status.PipelineTaskName != "child" {
+		t.Errorf("Expected PipelineRun status to include the child PipelineRun but was %v", reconciledRun.Status.ChildPipelineRuns)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionUnknown {
+		t.Errorf("Expected PipelineRun status to be in progress, but was %v", condition)
+	}
+}
+
+func TestReconcile_ChildPipelineCompleted(t *testing.T) {
+	names.TestingSeed()
+
+	taskSpec := v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name: "digest", Type: v1beta1.ParamTypeString,
+		}},
+		Steps: []v1beta1.Step{{Container: corev1.Container{Image: "foo:latest"}}},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo", UID: "parent-uid"},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "child",
+					PipelineSpec: &v1beta1.PipelineSpec{
+						Tasks: []v1beta1.PipelineTask{{
+							Name:     "build",
+							TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: taskSpec},
+						}},
+						Results: []v1beta1.PipelineResult{{
+							Name: "digest", Value: *v1beta1.NewArrayOrString("$(tasks.build.results.digest)"),
+						}},
+					},
+				}, {
+					Name:     "deploy",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: taskSpec},
+					Params: []v1beta1.Param{{
+						Name: "digest", Value: *v1beta1.NewArrayOrString("$(tasks.child.results.digest)"),
+					}},
+				}},
+			},
+		},
+	}
+	child := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "pr-child",
+			Namespace:       "foo",
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			Labels: map[string]string{
+				pipeline.PipelineRunLabelKey:       "pr",
+				pipeline.PipelineTaskLabelKey:      "child",
+				pipeline.ParentPipelineRunLabelKey: "pr",
+			},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: pr.Spec.PipelineSpec.Tasks[0].PipelineSpec,
+		},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+					Reason: v1beta1.PipelineRunReasonSuccessful.String(),
+				}},
+			},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineResults: []v1beta1.PipelineRunResult{{
+					Name: "digest", Value: *v1beta1.NewArrayOrString("sha256:abc"),
+				}},
+			},
+		},
+	}
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr, child},
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sa", Namespace: "foo"},
+		}},
+		ConfigMaps: getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+	// The child PipelineRun is picked up from the informer and its results are passed to the dependent task
+	created := getTaskRunCreations(t, clients.Pipeline.Actions())
+	if len(created) != 1 || created[0].Labels[pipeline.PipelineTaskLabelKey] != "deploy" {
+		t.Fatalf("Expected only the TaskRun for the dependent task to be created but got %v", created)
+	}
+	wantParams := []v1beta1.Param{{Name: "digest", Value: *v1beta1.NewArrayOrString("sha256:abc")}}
+	if d := cmp.Diff(wantParams, created[0].Spec.Params); d != "" {
+		t.Errorf("Unexpected params for the dependent TaskRun. Diff %s", diff.PrintWantGot(d))
+	}
+	if status, ok := reconciledRun.Status.ChildPipelineRuns["pr-child"]; !ok || status.PipelineTaskName != "child" {
+		t.Errorf("Expected PipelineRun status to include the child PipelineRun but was %v", reconciledRun.Status.ChildPipelineRuns)
+	}
+}
+
+func TestReconcile_ChildPipelineCancelled(t *testing.T) {
+	// TestReconcile_ChildPipelineCancelled runs "Reconcile" on a cancelled PipelineRun whose child
+	// PipelineRun was created but not yet recorded in its status. It verifies that the child
+	// PipelineRun is recovered from its label and cancelled with the parent.
+	childPipelineSpec := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "build",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{Image: "foo:latest"}}},
+			}},
+		}},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo", UID: "parent-uid"},
+		Spec: v1beta1.PipelineRunSpec{
+			Status: v1beta1.PipelineRunSpecStatusCancelled,
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:         "child",
+					PipelineSpec: childPipelineSpec,
+				}},
+			},
+		},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}
+	child := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "pr-child",
+			Namespace:       "foo",
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			Labels: map[string]string{
+				pipeline.PipelineRunLabelKey:       "pr",
+				pipeline.PipelineTaskLabelKey:      "child",
+				pipeline.ParentPipelineRunLabelKey: "pr",
+			},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: childPipelineSpec,
+		},
+	}
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr, child},
+		ConfigMaps:   getConfigMapsWithEnabledAlphaAPIFields(),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Warning Failed PipelineRun \"pr\" was cancelled",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "pr", wantEvents, false)
+
+	if reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason != ReasonCancelled {
+		t.Errorf("Expected PipelineRun to be cancelled, but condition reason is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if _, ok := reconciledRun.Status.ChildPipelineRuns["pr-child"]; !ok {
+		t.Errorf("Expected PipelineRun status to include the child PipelineRun but was %v", reconciledRun.Status.ChildPipelineRuns)
+	}
+	cancelled := false
+	for _, action := range clients.Pipeline.Actions() {
+		if patch, ok := action.(ktesting.PatchAction); ok && action.GetResource().Resource == "pipelineruns" && patch.GetName() == "pr-child" {
+			cancelled = true
+		}
+	}
+	if !cancelled {
+		t.Errorf("Expected the child PipelineRun to be cancelled but got actions %v", clients.Pipeline.Actions())
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/logging"
+)
+
+// prune deletes the oldest finished PipelineRuns of the Pipeline of the finished PipelineRun
+// beyond the number kept by the pruner, and deletes the PipelineRun once it outlives the ttl
+// of the pruner. The TaskRuns, Runs and Pods of a PipelineRun are deleted along with it.
+// PipelineRuns controlled by another object are deleted along with their owner and aren't pruned.
+func (c *Reconciler) prune(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	if metav1.GetControllerOf(pr) != nil || pr.Status.CompletionTime == nil {
+		return nil
+	}
+	finished := map[string]*v1beta1.PipelineRun{pr.Name: pr}
+	return pruner.Prune(ctx, c.namespaceLister, pr.Namespace, pruner.Run{Name: pr.Name, CompletionTime: pr.Status.CompletionTime.Time}, func() ([]pruner.Run, error) {
+		return c.finishedPipelineRuns(pr, finished)
+	}, func(name, reason string) error {
+		return c.deletePruned(ctx, finished[name], reason)
+	})
+}
+
+// finishedPipelineRuns returns the finished PipelineRuns of the Pipeline of pr, which are
+// also added to finished by name.
+func (c *Reconciler) finishedPipelineRuns(pr *v1beta1.PipelineRun, finished map[string]*v1beta1.PipelineRun) ([]pruner.Run, error) {
+	name, ok := pr.Labels[pipeline.PipelineLabelKey]
+	if !ok {
+		return nil, nil
+	}
+	prs, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(labels.SelectorFromSet(labels.Set{pipeline.PipelineLabelKey: name}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the PipelineRuns of namespace %s: %w", pr.Namespace, err)
+	}
+
+	var runs []pruner.Run
+	for _, other := range prs {
+		if !other.IsDone() || other.Status.CompletionTime == nil || metav1.GetControllerOf(other) != nil {
+			continue
+		}
+		finished[other.Name] = other
+		runs = append(runs, pruner.Run{Name: other.Name, CompletionTime: other.Status.CompletionTime.Time})
+	}
+	return runs, nil
+}
+
+// deletePruned deletes the PipelineRun pruned for the given reason and records it in the metrics.
+func (c *Reconciler) deletePruned(ctx context.Context, pr *v1beta1.PipelineRun, reason string) error {
+	logger := logging.FromContext(ctx)
+	err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Delete(ctx, pr.Name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to prune PipelineRun %s/%s: %w", pr.Namespace, pr.Name, err)
+	}
+	logger.Infof("Pruned PipelineRun %s/%s (%s)", pr.Namespace, pr.Name, reason)
+	if err := c.metrics.Pruned(pr, reason); err != nil {
+		logger.Warnf("Failed to log the metrics : %v", err)
+	}
+	return nil
+}
@@ -31,30 +31,51 @@ func ApplyParameters(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.
 	// This assumes that the PipelineRun inputs have been validated against what the Pipeline requests.
 
 	// stringReplacements is used for standard single-string stringReplacements, while arrayReplacements contains arrays
-	// that need to be further processed.
+	// and objectReplacements contains objects that need to be further processed.
 	stringReplacements := map[string]string{}
 	arrayReplacements := map[string][]string{}
+	objectReplacements := map[string]map[string]string{}
 
 	// Set all the default stringReplacements
 	for _, p := range p.Params {
 		if p.Default != nil {
-			if p.Default.Type == v1beta1.ParamTypeString {
+			switch p.Default.Type {
+			case v1beta1.ParamTypeString:
 				stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.StringVal
-			} else {
+			case v1beta1.ParamTypeObject:
+				objectReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.ObjectVal
+			default:
 				arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.ArrayVal
 			}
 		}
 	}
 	// Set and overwrite params with the ones from the PipelineRun
 	for _, p := range pr.Spec.Params {
-		if p.Value.Type == v1beta1.ParamTypeString {
+		switch p.Value.Type {
+		case v1beta1.ParamTypeString:
 			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
-		} else {
+		case v1beta1.ParamTypeObject:
+			// the keys that are not provided by the PipelineRun keep their default value
+			objectValue := map[string]string{}
+			for k, v := range objectReplacements[fmt.Sprintf("params.%s", p.Name)] {
+				objectValue[k] = v
+			}
+			for k, v := range p.Value.ObjectVal {
+				objectValue[k] = v
+			}
+			objectReplacements[fmt.Sprintf("params.%s", p.Name)] = objectValue
+		default:
 			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.ArrayVal
 		}
 	}
+	// The keys of objects are substituted individually as strings
+	for name, objectValue := range objectReplacements {
+		for k, v := range objectValue {
+			stringReplacements[fmt.Sprintf("%s.%s", name, k)] = v
+		}
+	}
 
-	return ApplyReplacements(p, stringReplacements, arrayReplacements)
+	return ApplyReplacements(p, stringReplacements, arrayReplacements, objectReplacements)
 }
 
 // ApplyContexts applies the substitution from $(context.(pipelineRun|pipeline).*) with the specified values.
@@ -66,7 +87,7 @@ func ApplyContexts(spec *v1beta1.PipelineSpec, pipelineName string, pr *v1beta1.
 		"context.pipelineRun.namespace": pr.Namespace,
 		"context.pipelineRun.uid":       string(pr.ObjectMeta.UID),
 	}
-	return ApplyReplacements(spec, replacements, map[string][]string{})
+	return ApplyReplacements(spec, replacements, map[string][]string{}, map[string]map[string]string{})
 }
 
 // ApplyPipelineTaskContexts applies the substitution from $(context.pipelineTask.*) with the specified values.
@@ -76,24 +97,27 @@ func ApplyPipelineTaskContexts(pt *v1beta1.PipelineTask) *v1beta1.PipelineTask {
 	replacements := map[string]string{
 		"context.pipelineTask.retries": strconv.Itoa(pt.Retries),
 	}
-	pt.Params = replaceParamValues(pt.Params, replacements, map[string][]string{})
+	pt.Params = replaceParamValues(pt.Params, replacements, map[string][]string{}, nil)
+	pt.Matrix = replaceParamValues(pt.Matrix, replacements, map[string][]string{}, nil)
 	return pt
 }
 
-// ApplyTaskResults applies the ResolvedResultRef to each PipelineTask.Params and Pipeline.WhenExpressions in targets
+// ApplyTaskResults applies the ResolvedResultRef to each PipelineTask.Params, PipelineTask.Matrix and Pipeline.WhenExpressions in targets
 func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResultRefs) {
 	stringReplacements := resolvedResultRefs.getStringReplacements()
+	arrayReplacements := resolvedResultRefs.getArrayReplacements()
 	for _, resolvedPipelineRunTask := range targets {
 		// also make substitution for resolved condition checks
 		for _, resolvedConditionCheck := range resolvedPipelineRunTask.ResolvedConditionChecks {
 			pipelineTaskCondition := resolvedConditionCheck.PipelineTaskCondition.DeepCopy()
-			pipelineTaskCondition.Params = replaceParamValues(pipelineTaskCondition.Params, stringReplacements, nil)
+			pipelineTaskCondition.Params = replaceParamValues(pipelineTaskCondition.Params, stringReplacements, nil, nil)
 			resolvedConditionCheck.PipelineTaskCondition = pipelineTaskCondition
 		}
 		if resolvedPipelineRunTask.PipelineTask != nil {
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
-			pipelineTask.Params = replaceParamValues(pipelineTask.Params, stringReplacements, nil)
-			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(stringReplacements, nil)
+			pipelineTask.Params = replaceParamValues(pipelineTask.Params, stringReplacements, arrayReplacements, nil)
+			pipelineTask.Matrix = replaceParamValues(pipelineTask.Matrix, stringReplacements, arrayReplacements, nil)
+			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(stringReplacements, arrayReplacements)
 			resolvedPipelineRunTask.PipelineTask = pipelineTask
 		}
 	}
@@ -104,7 +128,8 @@ func ApplyPipelineTaskStateContext(state PipelineRunState, replacements map[stri
 	for _, resolvedPipelineRunTask := range state {
 		if resolvedPipelineRunTask.PipelineTask != nil {
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
-			pipelineTask.Params = replaceParamValues(pipelineTask.Params, replacements, nil)
+			pipelineTask.Params = replaceParamValues(pipelineTask.Params, replacements, nil, nil)
+			pipelineTask.Matrix = replaceParamValues(pipelineTask.Matrix, replacements, nil, nil)
 			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(replacements, nil)
 			resolvedPipelineRunTask.PipelineTask = pipelineTask
 		}
@@ -124,33 +149,35 @@ func ApplyWorkspaces(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.
 		key := fmt.Sprintf("workspaces.%s.bound", boundWorkspace.Name)
 		replacements[key] = "true"
 	}
-	return ApplyReplacements(p, replacements, map[string][]string{})
+	return ApplyReplacements(p, replacements, map[string][]string{}, map[string]map[string]string{})
 }
 
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
-func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string, arrayReplacements map[string][]string) *v1beta1.PipelineSpec {
+func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) *v1beta1.PipelineSpec {
 	p = p.DeepCopy()
 
 	for i := range p.Tasks {
-		p.Tasks[i].Params = replaceParamValues(p.Tasks[i].Params, replacements, arrayReplacements)
+		p.Tasks[i].Params = replaceParamValues(p.Tasks[i].Params, replacements, arrayReplacements, objectReplacements)
+		p.Tasks[i].Matrix = replaceParamValues(p.Tasks[i].Matrix, replacements, arrayReplacements, nil)
 		for j := range p.Tasks[i].Conditions {
 			c := p.Tasks[i].Conditions[j]
-			c.Params = replaceParamValues(c.Params, replacements, arrayReplacements)
+			c.Params = replaceParamValues(c.Params, replacements, arrayReplacements, nil)
 		}
 		p.Tasks[i].WhenExpressions = p.Tasks[i].WhenExpressions.ReplaceWhenExpressionsVariables(replacements, arrayReplacements)
 	}
 
 	for i := range p.Finally {
-		p.Finally[i].Params = replaceParamValues(p.Finally[i].Params, replacements, arrayReplacements)
+		p.Finally[i].Params = replaceParamValues(p.Finally[i].Params, replacements, arrayReplacements, objectReplacements)
+		p.Finally[i].Matrix = replaceParamValues(p.Finally[i].Matrix, replacements, arrayReplacements, nil)
 		p.Finally[i].WhenExpressions = p.Finally[i].WhenExpressions.ReplaceWhenExpressionsVariables(replacements, arrayReplacements)
 	}
 
 	return p
 }
 
-func replaceParamValues(params []v1beta1.Param, stringReplacements map[string]string, arrayReplacements map[string][]string) []v1beta1.Param {
+func replaceParamValues(params []v1beta1.Param, stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) []v1beta1.Param {
 	for i := range params {
-		params[i].Value.ApplyReplacements(stringReplacements, arrayReplacements)
+		params[i].Value.ApplyReplacements(stringReplacements, arrayReplacements, objectReplacements)
 	}
 	return params
 }
@@ -163,7 +190,8 @@ func replaceParamValues(params []v1beta1.Param, stringReplacements map[string]st
 func ApplyTaskResultsToPipelineResults(
 	results []v1beta1.PipelineResult,
 	taskRunStatuses map[string]*v1beta1.PipelineRunTaskRunStatus,
-	runStatuses map[string]*v1beta1.PipelineRunRunStatus) []v1beta1.PipelineRunResult {
+	runStatuses map[string]*v1beta1.PipelineRunRunStatus,
+	childPipelineRunStatuses map[string]*v1beta1.PipelineRunChildPipelineRunStatus) []v1beta1.PipelineRunResult {
 
 	taskStatuses := map[string]*v1beta1.PipelineRunTaskRunStatus{}
 	for _, trStatus := range taskRunStatuses {
@@ -173,36 +201,59 @@ func ApplyTaskResultsToPipelineResults(
 	for _, runStatus := range runStatuses {
 		customTaskStatuses[runStatus.PipelineTaskName] = runStatus
 	}
+	childPipelineStatuses := map[string]*v1beta1.PipelineRunChildPipelineRunStatus{}
+	for _, childPipelineRunStatus := range childPipelineRunStatuses {
+		childPipelineStatuses[childPipelineRunStatus.PipelineTaskName] = childPipelineRunStatus
+	}
 
 	var runResults []v1beta1.PipelineRunResult
 	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	objectReplacements := map[string]map[string]string{}
 	for _, pipelineResult := range results {
 		variablesInPipelineResult, _ := v1beta1.GetVarSubstitutionExpressionsForPipelineResult(pipelineResult)
 		validPipelineResult := true
 		for _, variable := range variablesInPipelineResult {
-			if _, isMemoized := stringReplacements[variable]; isMemoized {
+			refs := v1beta1.NewPipelineResultRefs([]string{variable})
+			if len(refs) != 1 {
+				validPipelineResult = false
 				continue
 			}
-			variableParts := strings.Split(variable, ".")
-			if len(variableParts) == 4 && variableParts[0] == "tasks" && variableParts[2] == "results" {
-				taskName, resultName := variableParts[1], variableParts[3]
-				if resultValue := taskResultValue(taskName, resultName, taskStatuses); resultValue != nil {
-					stringReplacements[variable] = *resultValue
-				} else if resultValue := runResultValue(taskName, resultName, customTaskStatuses); resultValue != nil {
-					stringReplacements[variable] = *resultValue
-				} else {
-					validPipelineResult = false
+			ref := refs[0]
+			variable = strings.TrimSuffix(variable, "[*]")
+			if isMemoized(variable, stringReplacements, arrayReplacements, objectReplacements) {
+				continue
+			}
+			var resultValue *v1beta1.ArrayOrString
+			if resultValue = taskResultValue(ref.PipelineTask, ref.Result, taskStatuses); resultValue == nil {
+				if resultValue = runResultValue(ref.PipelineTask, ref.Result, customTaskStatuses); resultValue == nil {
+					resultValue = childPipelineRunResultValue(ref.PipelineTask, ref.Result, childPipelineStatuses)
 				}
-			} else {
+			}
+			if resultValue == nil {
 				validPipelineResult = false
+				continue
 			}
-		}
-		if validPipelineResult {
-			finalValue := pipelineResult.Value
-			for variable, value := range stringReplacements {
-				v := fmt.Sprintf("$(%s)", variable)
-				finalValue = strings.ReplaceAll(finalValue, v, value)
+			switch {
+			case ref.Property != "":
+				propertyValue, ok := resultValue.ObjectVal[ref.Property]
+				if resultValue.Type != v1beta1.ParamTypeObject || !ok {
+					validPipelineResult = false
+					continue
+				}
+				stringReplacements[variable] = propertyValue
+			case resultValue.Type == v1beta1.ParamTypeArray:
+				arrayReplacements[variable] = resultValue.ArrayVal
+			case resultValue.Type == v1beta1.ParamTypeObject:
+				objectReplacements[variable] = resultValue.ObjectVal
+			default:
+				stringReplacements[variable] = resultValue.StringVal
 			}
+		}
+		if !validPipelineResult {
+			continue
+		}
+		if finalValue, ok := applyPipelineResultReplacements(pipelineResult.Value, stringReplacements, arrayReplacements, objectReplacements); ok {
 			runResults = append(runResults, v1beta1.PipelineRunResult{
 				Name:  pipelineResult.Name,
 				Value: finalValue,
@@ -213,9 +264,73 @@ func ApplyTaskResultsToPipelineResults(
 	return runResults
 }
 
+func isMemoized(variable string, stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) bool {
+	_, isString := stringReplacements[variable]
+	_, isArray := arrayReplacements[variable]
+	_, isObject := objectReplacements[variable]
+	return isString || isArray || isObject
+}
+
+// applyPipelineResultReplacements computes the value of a PipelineResult from the values of the results it references.
+// A value that is a single reference to an array or object result takes the value of that result, while array and
+// object results can otherwise only be used as whole elements of an array value. false is returned if an array or
+// object result is used in any other way.
+func applyPipelineResultReplacements(value v1beta1.ArrayOrString, stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) (v1beta1.ArrayOrString, bool) {
+	if value.Type == v1beta1.ParamTypeString {
+		for variable, arrayValue := range arrayReplacements {
+			if isWholeReference(value.StringVal, variable) {
+				return v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: arrayValue}, true
+			}
+		}
+		for variable, objectValue := range objectReplacements {
+			if isWholeReference(value.StringVal, variable) {
+				return *v1beta1.NewObject(objectValue), true
+			}
+		}
+	}
+	var values []string
+	switch value.Type {
+	case v1beta1.ParamTypeArray:
+		values = value.ArrayVal
+	case v1beta1.ParamTypeObject:
+		for _, v := range value.ObjectVal {
+			values = append(values, v)
+		}
+	default:
+		values = []string{value.StringVal}
+	}
+	for _, v := range values {
+		for variable := range arrayReplacements {
+			if containsReference(v, variable) && (value.Type != v1beta1.ParamTypeArray || !isWholeReference(v, variable)) {
+				return v1beta1.ArrayOrString{}, false
+			}
+		}
+		for variable := range objectReplacements {
+			if containsReference(v, variable) {
+				return v1beta1.ArrayOrString{}, false
+			}
+		}
+	}
+	finalValue := *value.DeepCopy()
+	finalValue.ApplyReplacements(stringReplacements, arrayReplacements, nil)
+	return finalValue, true
+}
+
+// isWholeReference returns true if the given value is a single reference to the given variable,
+// in the form $(variable) or $(variable[*])
+func isWholeReference(value, variable string) bool {
+	return value == fmt.Sprintf("$(%s)", variable) || value == fmt.Sprintf("$(%s[*])", variable)
+}
+
+// containsReference returns true if the given value contains a reference to the given variable,
+// in the form $(variable) or $(variable[*])
+func containsReference(value, variable string) bool {
+	return strings.Contains(value, fmt.Sprintf("$(%s)", variable)) || strings.Contains(value, fmt.Sprintf("$(%s[*])", variable))
+}
+
 // taskResultValue checks if a TaskRun result exists for a given pipeline task and result name.
 // A nil pointer is returned if the variable is invalid for any reason.
-func taskResultValue(taskName string, resultName string, taskStatuses map[string]*v1beta1.PipelineRunTaskRunStatus) *string {
+func taskResultValue(taskName string, resultName string, taskStatuses map[string]*v1beta1.PipelineRunTaskRunStatus) *v1beta1.ArrayOrString {
 
 	status, taskExists := taskStatuses[taskName]
 	if !taskExists || status.Status == nil {
@@ -237,7 +352,7 @@ func taskResultValue(taskName string, resultName string, taskStatuses map[string
 
 // runResultValue checks if a Run result exists for a given pipeline task and result name.
 // A nil pointer is returned if the variable is invalid for any reason.
-func runResultValue(taskName string, resultName string, runStatuses map[string]*v1beta1.PipelineRunRunStatus) *string {
+func runResultValue(taskName string, resultName string, runStatuses map[string]*v1beta1.PipelineRunRunStatus) *v1beta1.ArrayOrString {
 
 	status, runExists := runStatuses[taskName]
 	if !runExists || status.Status == nil {
@@ -251,7 +366,30 @@ func runResultValue(taskName string, resultName string, runStatuses map[string]*
 
 	for _, runResult := range status.Status.Results {
 		if runResult.Name == resultName {
-			return &runResult.Value
+			value := runResultValueToArrayOrString(runResult.Value)
+			return &value
+		}
+	}
+	return nil
+}
+
+// childPipelineRunResultValue checks if a child PipelineRun result exists for a given pipeline task and result name.
+// A nil pointer is returned if the variable is invalid for any reason.
+func childPipelineRunResultValue(taskName string, resultName string, childPipelineRunStatuses map[string]*v1beta1.PipelineRunChildPipelineRunStatus) *v1beta1.ArrayOrString {
+
+	status, childPipelineRunExists := childPipelineRunStatuses[taskName]
+	if !childPipelineRunExists || status.Status == nil {
+		return nil
+	}
+
+	cond := status.Status.GetCondition(apis.ConditionSucceeded)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		return nil
+	}
+
+	for _, pipelineRunResult := range status.Status.PipelineResults {
+		if pipelineRunResult.Name == resultName {
+			return &pipelineRunResult.Value
 		}
 	}
 	return nil
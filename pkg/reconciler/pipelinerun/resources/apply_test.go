@@ -271,6 +271,43 @@ func TestApplyParameters(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name: "object parameter",
+		original: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "git", Type: v1beta1.ParamTypeObject, Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}, "revision": {Type: v1beta1.ParamTypeString}}, Default: v1beta1.NewObject(map[string]string{"url": "default-url", "revision": "main"})},
+			},
+			Tasks: []v1beta1.PipelineTask{{
+				Params: []v1beta1.Param{
+					{Name: "url", Value: *v1beta1.NewArrayOrString("$(params.git.url)")},
+					{Name: "git", Value: *v1beta1.NewArrayOrString("$(params.git[*])")},
+					{Name: "repo", Value: *v1beta1.NewObject(map[string]string{"url": "$(params.git.url)", "commit": "$(params.git.revision)"})},
+				},
+				WhenExpressions: v1beta1.WhenExpressions{{
+					Input:    "$(params.git.revision)",
+					Operator: selection.In,
+					Values:   []string{"main"},
+				}},
+			}},
+		},
+		params: []v1beta1.Param{{Name: "git", Value: *v1beta1.NewObject(map[string]string{"revision": "v1"})}},
+		expected: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "git", Type: v1beta1.ParamTypeObject, Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}, "revision": {Type: v1beta1.ParamTypeString}}, Default: v1beta1.NewObject(map[string]string{"url": "default-url", "revision": "main"})},
+			},
+			Tasks: []v1beta1.PipelineTask{{
+				Params: []v1beta1.Param{
+					{Name: "url", Value: *v1beta1.NewArrayOrString("default-url")},
+					{Name: "git", Value: *v1beta1.NewObject(map[string]string{"url": "default-url", "revision": "v1"})},
+					{Name: "repo", Value: *v1beta1.NewObject(map[string]string{"url": "default-url", "commit": "v1"})},
+				},
+				WhenExpressions: v1beta1.WhenExpressions{{
+					Input:    "v1",
+					Operator: selection.In,
+					Values:   []string{"main"},
+				}},
+			}},
+		},
 	}} {
 		tt := tt // capture range variable
 		t.Run(tt.name, func(t *testing.T) {
@@ -366,6 +403,65 @@ func TestApplyTaskResults_MinimalExpression(t *testing.T) {
 	}
 }
 
+func TestApplyTaskResults_ArrayAndObjectResults(t *testing.T) {
+	resolvedResultRefs := ResolvedResultRefs{{
+		Value: *v1beta1.NewArrayOrString("a", "b"),
+		ResultReference: v1beta1.ResultRef{
+			PipelineTask: "aTask",
+			Result:       "anArrayResult",
+		},
+		FromTaskRun: "aTaskRun",
+	}, {
+		Value: *v1beta1.NewArrayOrString("abc"),
+		ResultReference: v1beta1.ResultRef{
+			PipelineTask: "aTask",
+			Result:       "anObjectResult",
+			Property:     "url",
+		},
+		FromTaskRun: "aTaskRun",
+	}}
+	targets := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "bTask",
+			TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+			Params: []v1beta1.Param{{
+				Name:  "bParam",
+				Value: *v1beta1.NewArrayOrString("first", "$(tasks.aTask.results.anArrayResult[*])"),
+			}, {
+				Name:  "cParam",
+				Value: *v1beta1.NewArrayOrString("url is $(tasks.aTask.results.anObjectResult.url)"),
+			}},
+			WhenExpressions: []v1beta1.WhenExpression{{
+				Input:    "$(tasks.aTask.results.anObjectResult.url)",
+				Operator: selection.In,
+				Values:   []string{"$(tasks.aTask.results.anArrayResult[*])"},
+			}},
+		},
+	}}
+	want := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "bTask",
+			TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+			Params: []v1beta1.Param{{
+				Name:  "bParam",
+				Value: *v1beta1.NewArrayOrString("first", "a", "b"),
+			}, {
+				Name:  "cParam",
+				Value: *v1beta1.NewArrayOrString("url is abc"),
+			}},
+			WhenExpressions: []v1beta1.WhenExpression{{
+				Input:    "abc",
+				Operator: selection.In,
+				Values:   []string{"a", "b"},
+			}},
+		},
+	}}
+	ApplyTaskResults(targets, resolvedResultRefs)
+	if d := cmp.Diff(want, targets); d != "" {
+		t.Fatalf("ApplyTaskResults() %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyTaskResults_EmbeddedExpression(t *testing.T) {
 	for _, tt := range []struct {
 		name               string
@@ -436,6 +532,33 @@ func TestApplyTaskResults_EmbeddedExpression(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		name: "Test result substitution on embedded variable substitution expression - cel in when expressions",
+		resolvedResultRefs: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("85"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "coverage",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+		targets: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:            "bTask",
+				TaskRef:         &v1beta1.TaskRef{Name: "bTask"},
+				WhenExpressions: []v1beta1.WhenExpression{{CEL: "int($(tasks.aTask.results.coverage)) >= 80"}},
+			},
+		}},
+		want: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				WhenExpressions: []v1beta1.WhenExpression{{
+					CEL:          "int($(tasks.aTask.results.coverage)) >= 80",
+					CELVariables: map[string]string{"tasks.aTask.results.coverage": "85"},
+				}},
+			},
+		}},
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			ApplyTaskResults(tt.targets, tt.resolvedResultRefs)
@@ -683,11 +806,12 @@ func TestApplyWorkspaces(t *testing.T) {
 
 func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 	for _, tc := range []struct {
-		description string
-		results     []v1beta1.PipelineResult
-		statuses    map[string]*v1beta1.PipelineRunTaskRunStatus
-		runStatuses map[string]*v1beta1.PipelineRunRunStatus
-		expected    []v1beta1.PipelineRunResult
+		description              string
+		results                  []v1beta1.PipelineResult
+		statuses                 map[string]*v1beta1.PipelineRunTaskRunStatus
+		runStatuses              map[string]*v1beta1.PipelineRunRunStatus
+		childPipelineRunStatuses map[string]*v1beta1.PipelineRunChildPipelineRunStatus
+		expected                 []v1beta1.PipelineRunResult
 	}{{
 		description: "no-pipeline-results-no-returned-results",
 		results:     []v1beta1.PipelineResult{},
@@ -704,7 +828,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
@@ -715,7 +839,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		description: "invalid-result-variable-no-returned-result",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.pt1_results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1_results.foo)"),
 		}},
 		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
 			"task1": {
@@ -730,18 +854,70 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
 			},
 		},
 		expected: nil,
+	}, {
+		description: "finally-task-result-returned",
+		results: []v1beta1.PipelineResult{{
+			Name:  "foo",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.foo)"),
+		}, {
+			Name:  "bar",
+			Value: *v1beta1.NewArrayOrString("$(finally.final-task.results.bar)"),
+		}},
+		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
+			"task1": {
+				PipelineTaskName: "pt1",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "foo",
+							Value: *v1beta1.NewArrayOrString("do"),
+						}},
+					},
+				},
+			},
+			"final-taskrun": {
+				PipelineTaskName: "final-task",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "bar",
+							Value: *v1beta1.NewArrayOrString("rae"),
+						}},
+					},
+				},
+			},
+		},
+		expected: []v1beta1.PipelineRunResult{{
+			Name:  "foo",
+			Value: *v1beta1.NewArrayOrString("do"),
+		}, {
+			Name:  "bar",
+			Value: *v1beta1.NewArrayOrString("rae"),
+		}},
 	}, {
 		description: "no-taskrun-results-no-returned-results",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.pt1.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.foo)"),
 		}},
 		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
 			"task1": {
@@ -764,7 +940,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		description: "invalid-taskrun-name-no-returned-result",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.pt1.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.foo)"),
 		}},
 		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
 			"task1": {
@@ -779,7 +955,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
@@ -790,7 +966,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		description: "invalid-result-name-no-returned-result",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.pt1.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.foo)"),
 		}},
 		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
 			"task1": {
@@ -805,7 +981,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "definitely-not-foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
@@ -816,7 +992,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		description: "unsuccessful-taskrun-no-returned-result",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.pt1.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.foo)"),
 		}},
 		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
 			"task1": {
@@ -831,7 +1007,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
@@ -842,10 +1018,10 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		description: "mixed-success-tasks-some-returned-results",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.pt1.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.foo)"),
 		}, {
 			Name:  "bar",
-			Value: "$(tasks.pt2.results.bar)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt2.results.bar)"),
 		}},
 		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
 			"task1": {
@@ -860,7 +1036,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "do",
+							Value: *v1beta1.NewArrayOrString("do"),
 						}},
 					},
 				},
@@ -877,7 +1053,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "bar",
-							Value: "rae",
+							Value: *v1beta1.NewArrayOrString("rae"),
 						}},
 					},
 				},
@@ -885,16 +1061,16 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		},
 		expected: []v1beta1.PipelineRunResult{{
 			Name:  "bar",
-			Value: "rae",
+			Value: *v1beta1.NewArrayOrString("rae"),
 		}},
 	}, {
 		description: "multiple-results-multiple-successful-tasks",
 		results: []v1beta1.PipelineResult{{
 			Name:  "pipeline-result-1",
-			Value: "$(tasks.pt1.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.foo)"),
 		}, {
 			Name:  "pipeline-result-2",
-			Value: "$(tasks.pt1.results.foo), $(tasks.pt2.results.baz), $(tasks.pt1.results.bar), $(tasks.pt2.results.baz), $(tasks.pt1.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.foo), $(tasks.pt2.results.baz), $(tasks.pt1.results.bar), $(tasks.pt2.results.baz), $(tasks.pt1.results.foo)"),
 		}},
 		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
 			"task1": {
@@ -909,10 +1085,10 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "do",
+							Value: *v1beta1.NewArrayOrString("do"),
 						}, {
 							Name:  "bar",
-							Value: "mi",
+							Value: *v1beta1.NewArrayOrString("mi"),
 						}},
 					},
 				},
@@ -929,7 +1105,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "baz",
-							Value: "rae",
+							Value: *v1beta1.NewArrayOrString("rae"),
 						}},
 					},
 				},
@@ -937,16 +1113,16 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		},
 		expected: []v1beta1.PipelineRunResult{{
 			Name:  "pipeline-result-1",
-			Value: "do",
+			Value: *v1beta1.NewArrayOrString("do"),
 		}, {
 			Name:  "pipeline-result-2",
-			Value: "do, rae, mi, rae, do",
+			Value: *v1beta1.NewArrayOrString("do, rae, mi, rae, do"),
 		}},
 	}, {
 		description: "no-run-results-no-returned-results",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.customtask.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.customtask.results.foo)"),
 		}},
 		runStatuses: map[string]*v1beta1.PipelineRunRunStatus{
 			"task1": {
@@ -969,7 +1145,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		description: "wrong-customtask-name-no-returned-result",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.customtask.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.customtask.results.foo)"),
 		}},
 		runStatuses: map[string]*v1beta1.PipelineRunRunStatus{
 			"task1": {
@@ -984,7 +1160,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					RunStatusFields: runv1alpha1.RunStatusFields{
 						Results: []runv1alpha1.RunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *runv1alpha1.NewRunResultValue("bar"),
 						}},
 					},
 				},
@@ -995,7 +1171,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		description: "right-customtask-name-wrong-result-name-no-returned-result",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.customtask.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.customtask.results.foo)"),
 		}},
 		runStatuses: map[string]*v1beta1.PipelineRunRunStatus{
 			"task1": {
@@ -1010,7 +1186,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					RunStatusFields: runv1alpha1.RunStatusFields{
 						Results: []runv1alpha1.RunResult{{
 							Name:  "notfoo",
-							Value: "bar",
+							Value: *runv1alpha1.NewRunResultValue("bar"),
 						}},
 					},
 				},
@@ -1021,7 +1197,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		description: "unsuccessful-run-no-returned-result",
 		results: []v1beta1.PipelineResult{{
 			Name:  "foo",
-			Value: "$(tasks.customtask.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.customtask.results.foo)"),
 		}},
 		runStatuses: map[string]*v1beta1.PipelineRunRunStatus{
 			"task1": {
@@ -1036,7 +1212,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					RunStatusFields: runv1alpha1.RunStatusFields{
 						Results: []runv1alpha1.RunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *runv1alpha1.NewRunResultValue("bar"),
 						}},
 					},
 				},
@@ -1047,10 +1223,10 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		description: "multiple-results-custom-and-normal-tasks",
 		results: []v1beta1.PipelineResult{{
 			Name:  "pipeline-result-1",
-			Value: "$(tasks.customtask.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.customtask.results.foo)"),
 		}, {
 			Name:  "pipeline-result-2",
-			Value: "$(tasks.customtask.results.foo), $(tasks.normaltask.results.baz), $(tasks.customtask.results.bar), $(tasks.normaltask.results.baz), $(tasks.customtask.results.foo)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.customtask.results.foo), $(tasks.normaltask.results.baz), $(tasks.customtask.results.bar), $(tasks.normaltask.results.baz), $(tasks.customtask.results.foo)"),
 		}},
 		runStatuses: map[string]*v1beta1.PipelineRunRunStatus{
 			"task1": {
@@ -1065,10 +1241,10 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					RunStatusFields: runv1alpha1.RunStatusFields{
 						Results: []runv1alpha1.RunResult{{
 							Name:  "foo",
-							Value: "do",
+							Value: *runv1alpha1.NewRunResultValue("do"),
 						}, {
 							Name:  "bar",
-							Value: "mi",
+							Value: *runv1alpha1.NewRunResultValue("mi"),
 						}},
 					},
 				},
@@ -1087,7 +1263,61 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "baz",
-							Value: "rae",
+							Value: *v1beta1.NewArrayOrString("rae"),
+						}},
+					},
+				},
+			},
+		},
+		expected: []v1beta1.PipelineRunResult{{
+			Name:  "pipeline-result-1",
+			Value: *v1beta1.NewArrayOrString("do"),
+		}, {
+			Name:  "pipeline-result-2",
+			Value: *v1beta1.NewArrayOrString("do, rae, mi, rae, do"),
+		}},
+	}, {
+		description: "child-pipeline-results",
+		results: []v1beta1.PipelineResult{{
+			Name:  "pipeline-result-1",
+			Value: *v1beta1.NewArrayOrString("$(tasks.childpipeline.results.foo)"),
+		}, {
+			Name:  "pipeline-result-2",
+			Value: *v1beta1.NewArrayOrString("$(tasks.childpipeline.results.foo), $(tasks.normaltask.results.baz)"),
+		}},
+		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
+			"task1": {
+				PipelineTaskName: "normaltask",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "baz",
+							Value: *v1beta1.NewArrayOrString("rae"),
+						}},
+					},
+				},
+			},
+		},
+		childPipelineRunStatuses: map[string]*v1beta1.PipelineRunChildPipelineRunStatus{
+			"pipelinerun1": {
+				PipelineTaskName: "childpipeline",
+				Status: &v1beta1.PipelineRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						PipelineResults: []v1beta1.PipelineRunResult{{
+							Name:  "foo",
+							Value: *v1beta1.NewArrayOrString("do"),
 						}},
 					},
 				},
@@ -1095,14 +1325,104 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 		},
 		expected: []v1beta1.PipelineRunResult{{
 			Name:  "pipeline-result-1",
-			Value: "do",
+			Value: *v1beta1.NewArrayOrString("do"),
 		}, {
 			Name:  "pipeline-result-2",
-			Value: "do, rae, mi, rae, do",
+			Value: *v1beta1.NewArrayOrString("do, rae"),
+		}},
+	}, {
+		description: "failed-child-pipeline-results-are-not-used",
+		results: []v1beta1.PipelineResult{{
+			Name:  "pipeline-result-1",
+			Value: *v1beta1.NewArrayOrString("$(tasks.childpipeline.results.foo)"),
+		}},
+		childPipelineRunStatuses: map[string]*v1beta1.PipelineRunChildPipelineRunStatus{
+			"pipelinerun1": {
+				PipelineTaskName: "childpipeline",
+				Status: &v1beta1.PipelineRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+						}},
+					},
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						PipelineResults: []v1beta1.PipelineRunResult{{
+							Name:  "foo",
+							Value: *v1beta1.NewArrayOrString("do"),
+						}},
+					},
+				},
+			},
+		},
+		expected: nil,
+	}, {
+		description: "array-and-object-results",
+		results: []v1beta1.PipelineResult{{
+			Name:  "whole-array",
+			Type:  v1beta1.ResultsTypeArray,
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.arr[*])"),
+		}, {
+			Name:  "array-of-elements",
+			Type:  v1beta1.ResultsTypeArray,
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.obj.url)", "$(tasks.pt1.results.foo)"),
+		}, {
+			Name:  "whole-object",
+			Type:  v1beta1.ResultsTypeObject,
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.obj)"),
+		}, {
+			Name:  "object-key",
+			Value: *v1beta1.NewArrayOrString("url is $(tasks.pt1.results.obj.url)"),
+		}, {
+			Name:  "array-embedded-in-string",
+			Value: *v1beta1.NewArrayOrString("values are $(tasks.pt1.results.arr[*])"),
+		}, {
+			Name:  "missing-object-key",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.obj.commit)"),
+		}},
+		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
+			"task1": {
+				PipelineTaskName: "pt1",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "foo",
+							Value: *v1beta1.NewArrayOrString("bar"),
+						}, {
+							Name:  "arr",
+							Type:  v1beta1.ResultsTypeArray,
+							Value: *v1beta1.NewArrayOrString("a", "b"),
+						}, {
+							Name:  "obj",
+							Type:  v1beta1.ResultsTypeObject,
+							Value: *v1beta1.NewObject(map[string]string{"url": "abc"}),
+						}},
+					},
+				},
+			},
+		},
+		expected: []v1beta1.PipelineRunResult{{
+			Name:  "whole-array",
+			Value: *v1beta1.NewArrayOrString("a", "b"),
+		}, {
+			Name:  "array-of-elements",
+			Value: *v1beta1.NewArrayOrString("abc", "bar"),
+		}, {
+			Name:  "whole-object",
+			Value: *v1beta1.NewObject(map[string]string{"url": "abc"}),
+		}, {
+			Name:  "object-key",
+			Value: *v1beta1.NewArrayOrString("url is abc"),
 		}},
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
-			received := ApplyTaskResultsToPipelineResults(tc.results, tc.statuses, tc.runStatuses)
+			received := ApplyTaskResultsToPipelineResults(tc.results, tc.statuses, tc.runStatuses, tc.childPipelineRunStatuses)
 			if d := cmp.Diff(tc.expected, received); d != "" {
 				t.Errorf(diff.PrintWantGot(d))
 			}
@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/kmeta"
+)
+
+// FanOut returns the combinations of the values of the given Matrix parameters, each
+// combination being a list of string Params with one value from each Matrix parameter.
+// The combinations are ordered so that the values of the last Matrix parameter vary
+// fastest, which keeps the index of a combination stable across reconciles.
+func FanOut(matrix []v1beta1.Param) [][]v1beta1.Param {
+	if len(matrix) == 0 {
+		return nil
+	}
+	combinations := [][]v1beta1.Param{{}}
+	for _, param := range matrix {
+		var next [][]v1beta1.Param
+		for _, combination := range combinations {
+			for _, value := range param.Value.ArrayVal {
+				c := make([]v1beta1.Param, 0, len(combination)+1)
+				c = append(c, combination...)
+				c = append(c, v1beta1.Param{Name: param.Name, Value: *v1beta1.NewArrayOrString(value)})
+				next = append(next, c)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// GetMatrixedChildNames returns the names of the TaskRuns or Runs of a matrixed PipelineTask, one per
+// combination. The names are derived from the combination index so that they are the same on every reconcile.
+func GetMatrixedChildNames(prName, ptName string, combinationsCount int) []string {
+	var names []string
+	for i := 0; i < combinationsCount; i++ {
+		names = append(names, kmeta.ChildName(prName, fmt.Sprintf("-%s-%d", ptName, i)))
+	}
+	return names
+}
+
+// ApplyMatrixResults substitutes the results referenced by the Matrix of each matrixed PipelineTask in the state,
+// once they can be resolved, and resolves the TaskRuns or Runs of the resulting combinations. A reference to an
+// array result expands to all of its values, so the combinations are only known once the results are substituted.
+func (state PipelineRunState) ApplyMatrixResults(prName string, getTaskRun resources.GetTaskRun, getRun GetRun) error {
+	for _, rprt := range state {
+		if !rprt.IsMatrixed() {
+			continue
+		}
+		resolvedResultRefs, ok := resolveMatrixResultRefs(state, rprt.PipelineTask.Matrix)
+		if !ok || len(resolvedResultRefs) == 0 {
+			continue
+		}
+		pipelineTask := rprt.PipelineTask.DeepCopy()
+		pipelineTask.Matrix = replaceParamValues(pipelineTask.Matrix, resolvedResultRefs.getStringReplacements(), resolvedResultRefs.getArrayReplacements(), nil)
+		rprt.PipelineTask = pipelineTask
+		if err := rprt.resolveMatrixedChildren(prName, getTaskRun, getRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveMatrixResultRefs resolves the results referenced by the Matrix parameters, returning false if any of
+// them cannot be resolved yet
+func resolveMatrixResultRefs(state PipelineRunState, matrix []v1beta1.Param) (ResolvedResultRefs, bool) {
+	var resolvedResultRefs ResolvedResultRefs
+	for _, param := range matrix {
+		refs, err := extractResultRefsForParam(state, param)
+		if err != nil {
+			return nil, false
+		}
+		resolvedResultRefs = append(resolvedResultRefs, refs...)
+	}
+	return resolvedResultRefs, true
+}
+
+// resolveMatrixedChildren names the TaskRuns or Runs of a matrixed PipelineTask after the combinations of its
+// Matrix and retrieves the ones which already exist.
+func (t *ResolvedPipelineRunTask) resolveMatrixedChildren(prName string, getTaskRun resources.GetTaskRun, getRun GetRun) error {
+	childNames := GetMatrixedChildNames(prName, t.PipelineTask.Name, len(FanOut(t.PipelineTask.Matrix)))
+	if t.IsCustomTask() {
+		t.RunNames, t.Runs = childNames, nil
+		for _, runName := range t.RunNames {
+			run, err := getRun(runName)
+			if err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving Run %s: %w", runName, err)
+			}
+			t.Runs = append(t.Runs, run)
+		}
+		return nil
+	}
+	t.TaskRunNames, t.TaskRuns = childNames, nil
+	for _, taskRunName := range t.TaskRunNames {
+		tr, err := getTaskRun(taskRunName)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("error retrieving TaskRun %s: %w", taskRunName, err)
+		}
+		t.TaskRuns = append(t.TaskRuns, tr)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFanOut(t *testing.T) {
+	tests := []struct {
+		name             string
+		matrix           []v1beta1.Param
+		wantCombinations [][]v1beta1.Param
+	}{{
+		name: "no matrix",
+	}, {
+		name: "single parameter",
+		matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac", "windows"),
+		}},
+		wantCombinations: [][]v1beta1.Param{{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("mac"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("windows"),
+		}}},
+	}, {
+		name: "multiple parameters",
+		matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}, {
+			Name: "go-version", Value: *v1beta1.NewArrayOrString("1.15", "1.16", "1.17"),
+		}},
+		wantCombinations: [][]v1beta1.Param{{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+		}, {
+			Name: "go-version", Value: *v1beta1.NewArrayOrString("1.15"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+		}, {
+			Name: "go-version", Value: *v1beta1.NewArrayOrString("1.16"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux"),
+		}, {
+			Name: "go-version", Value: *v1beta1.NewArrayOrString("1.17"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("mac"),
+		}, {
+			Name: "go-version", Value: *v1beta1.NewArrayOrString("1.15"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("mac"),
+		}, {
+			Name: "go-version", Value: *v1beta1.NewArrayOrString("1.16"),
+		}}, {{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("mac"),
+		}, {
+			Name: "go-version", Value: *v1beta1.NewArrayOrString("1.17"),
+		}}},
+	}, {
+		name: "parameter without values",
+		matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}, {
+			Name: "go-version", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := cmp.Diff(tt.wantCombinations, FanOut(tt.matrix)); d != "" {
+				t.Errorf("FanOut() diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetMatrixedChildNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		prName    string
+		ptName    string
+		count     int
+		wantNames []string
+	}{{
+		name:   "no combinations",
+		prName: "pipelinerun",
+		ptName: "task",
+	}, {
+		name:      "names derived from the combination index",
+		prName:    "pipelinerun",
+		ptName:    "task",
+		count:     3,
+		wantNames: []string{"pipelinerun-task-0", "pipelinerun-task-1", "pipelinerun-task-2"},
+	}, {
+		name:   "long names are kept unique",
+		prName: "pipelinerun-with-a-really-long-name-to-trigger-truncation",
+		ptName: "task-with-a-really-long-name",
+		count:  2,
+		wantNames: []string{
+			"pipelinerun-with-a-really-long-a39b813e38cee598ad6edb9e51c10cd3",
+			"pipelinerun-with-a-really-long-6bcaad4ccd839f6de7c04b653037c890",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := cmp.Diff(tt.wantNames, GetMatrixedChildNames(tt.prName, tt.ptName, tt.count)); d != "" {
+				t.Errorf("GetMatrixedChildNames() diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApplyMatrixResults(t *testing.T) {
+	platforms := makeSucceeded(trs[0])
+	platforms.Status.TaskRunResults = []v1beta1.TaskRunResult{{
+		Name:  "platforms",
+		Type:  v1beta1.ResultsTypeArray,
+		Value: *v1beta1.NewArrayOrString("linux", "mac", "windows"),
+	}}
+	taskRun := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-matrixed-2"}}
+	getTaskRun := func(name string) (*v1beta1.TaskRun, error) {
+		if name == taskRun.Name {
+			return taskRun, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("taskrun"), name)
+	}
+	getRun := func(name string) (*v1alpha1.Run, error) {
+		return nil, kerrors.NewNotFound(v1beta1.Resource("run"), name)
+	}
+
+	for _, tc := range []struct {
+		name             string
+		producer         *v1beta1.TaskRun
+		wantMatrix       []v1beta1.Param
+		wantTaskRunNames []string
+		wantTaskRuns     []*v1beta1.TaskRun
+	}{{
+		name:     "result not available yet",
+		producer: nil,
+		wantMatrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("$(tasks.producer.results.platforms[*])", "freebsd"),
+		}},
+		wantTaskRunNames: []string{"pipelinerun-matrixed-0", "pipelinerun-matrixed-1"},
+		wantTaskRuns:     []*v1beta1.TaskRun{nil, nil},
+	}, {
+		name:     "array result expands to all of its values",
+		producer: platforms,
+		wantMatrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac", "windows", "freebsd"),
+		}},
+		wantTaskRunNames: []string{"pipelinerun-matrixed-0", "pipelinerun-matrixed-1", "pipelinerun-matrixed-2", "pipelinerun-matrixed-3"},
+		wantTaskRuns:     []*v1beta1.TaskRun{nil, nil, taskRun, nil},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := PipelineRunState{{
+				PipelineTask: &v1beta1.PipelineTask{Name: "producer", TaskRef: &v1beta1.TaskRef{Name: "task"}},
+				TaskRunName:  "pipelinerun-mytask1",
+				TaskRun:      tc.producer,
+			}, {
+				PipelineTask: &v1beta1.PipelineTask{
+					Name:    "matrixed",
+					TaskRef: &v1beta1.TaskRef{Name: "task"},
+					Matrix: []v1beta1.Param{{
+						Name: "platform", Value: *v1beta1.NewArrayOrString("$(tasks.producer.results.platforms[*])", "freebsd"),
+					}},
+				},
+				TaskRunNames: []string{"pipelinerun-matrixed-0", "pipelinerun-matrixed-1"},
+				TaskRuns:     []*v1beta1.TaskRun{nil, nil},
+			}}
+			if err := state.ApplyMatrixResults("pipelinerun", getTaskRun, getRun); err != nil {
+				t.Fatalf("ApplyMatrixResults: %v", err)
+			}
+			if d := cmp.Diff(tc.wantMatrix, state[1].PipelineTask.Matrix); d != "" {
+				t.Errorf("Matrix diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantTaskRunNames, state[1].TaskRunNames); d != "" {
+				t.Errorf("TaskRunNames diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantTaskRuns, state[1].TaskRuns); d != "" {
+				t.Errorf("TaskRuns diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
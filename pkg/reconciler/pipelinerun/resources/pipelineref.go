@@ -26,7 +26,9 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	"github.com/tektoncd/pipeline/pkg/remote/resolution"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -68,19 +70,18 @@ func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clien
 			if err != nil {
 				return nil, err
 			}
-			if pipeline, ok := obj.(v1beta1.PipelineObject); ok {
-				pipeline.SetDefaults(ctx)
-				return pipeline, nil
-			}
-
-			if pipeline, ok := obj.(*v1alpha1.Pipeline); ok {
-				betaPipeline := &v1beta1.Pipeline{}
-				err := pipeline.ConvertTo(ctx, betaPipeline)
-				betaPipeline.SetDefaults(ctx)
-				return betaPipeline, err
+			return readRuntimeObjectAsPipeline(ctx, obj)
+		}, nil
+	case pr != nil && pr.Resolver != "":
+		// Return an inline function that implements GetPipeline by requesting the pipeline from the resolver. The
+		// request is fulfilled out of band, so this returns resolution.ErrorRequestInProgress until it is.
+		return func(ctx context.Context, name string) (v1beta1.PipelineObject, error) {
+			resolver := resolution.NewResolver(ctx, tekton, pipelineRun, string(pr.Resolver), pr.Params)
+			obj, err := resolver.Get("pipeline", name)
+			if err != nil {
+				return nil, err
 			}
-
-			return nil, fmt.Errorf("failed to convert obj %s into Pipeline", obj.GetObjectKind().GroupVersionKind().String())
+			return readRuntimeObjectAsPipeline(ctx, obj)
 		}, nil
 	default:
 		// Even if there is no task ref, we should try to return a local resolver.
@@ -107,3 +108,22 @@ func (l *LocalPipelineRefResolver) GetPipeline(ctx context.Context, name string)
 	}
 	return l.Tektonclient.TektonV1beta1().Pipelines(l.Namespace).Get(ctx, name, metav1.GetOptions{})
 }
+
+// readRuntimeObjectAsPipeline tries to convert a generic runtime.Object
+// into a v1beta1.PipelineObject type so that its meta and spec fields
+// can be read.
+func readRuntimeObjectAsPipeline(ctx context.Context, obj runtime.Object) (v1beta1.PipelineObject, error) {
+	if pipeline, ok := obj.(v1beta1.PipelineObject); ok {
+		pipeline.SetDefaults(ctx)
+		return pipeline, nil
+	}
+
+	if pipeline, ok := obj.(*v1alpha1.Pipeline); ok {
+		betaPipeline := &v1beta1.Pipeline{}
+		err := pipeline.ConvertTo(ctx, betaPipeline)
+		betaPipeline.SetDefaults(ctx)
+		return betaPipeline, err
+	}
+
+	return nil, fmt.Errorf("failed to convert obj %s into Pipeline", obj.GetObjectKind().GroupVersionKind().String())
+}
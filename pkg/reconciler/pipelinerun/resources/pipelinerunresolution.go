@@ -18,6 +18,7 @@ package resources
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"strconv"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/names"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/remote/resolution"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"knative.dev/pkg/apis"
 )
@@ -49,6 +51,7 @@ const (
 	IsGracefullyCancelledSkip SkippingReason = "IsGracefullyCancelledSkip"
 	IsGracefullyStoppedSkip   SkippingReason = "IsGracefullyStoppedSkip"
 	MissingResultsSkip        SkippingReason = "MissingResultsSkip"
+	EmptyMatrixSkip           SkippingReason = "EmptyMatrixSkip"
 	None                      SkippingReason = "None"
 )
 
@@ -82,10 +85,20 @@ func (e *ConditionNotFoundError) Error() string {
 type ResolvedPipelineRunTask struct {
 	TaskRunName string
 	TaskRun     *v1beta1.TaskRun
+	// If the PipelineTask is matrixed, TaskRunNames and TaskRuns will be set instead,
+	// with one entry per combination. TaskRuns that do not exist yet are nil.
+	TaskRunNames []string
+	TaskRuns     []*v1beta1.TaskRun
 	// If the PipelineTask is a Custom Task, RunName and Run will be set.
-	CustomTask            bool
-	RunName               string
-	Run                   *v1alpha1.Run
+	CustomTask bool
+	RunName    string
+	Run        *v1alpha1.Run
+	// If the PipelineTask is a matrixed Custom Task, RunNames and Runs will be set instead.
+	RunNames []string
+	Runs     []*v1alpha1.Run
+	// If the PipelineTask runs a Pipeline, ChildPipelineRunName and ChildPipelineRun will be set.
+	ChildPipelineRunName  string
+	ChildPipelineRun      *v1beta1.PipelineRun
 	PipelineTask          *v1beta1.PipelineTask
 	ResolvedTaskResources *resources.ResolvedTaskResources
 	// ConditionChecks ~~TaskRuns but for evaling conditions
@@ -99,14 +112,8 @@ func (t ResolvedPipelineRunTask) IsDone(facts *PipelineRunFacts) bool {
 
 // IsRunning returns true only if the task is neither succeeded, cancelled nor failed
 func (t ResolvedPipelineRunTask) IsRunning() bool {
-	if t.IsCustomTask() {
-		if t.Run == nil {
-			return false
-		}
-	} else {
-		if t.TaskRun == nil {
-			return false
-		}
+	if !t.hasChildren() {
+		return false
 	}
 	return !t.IsSuccessful() && !t.IsFailure() && !t.IsCancelled()
 }
@@ -116,64 +123,301 @@ func (t ResolvedPipelineRunTask) IsCustomTask() bool {
 	return t.CustomTask
 }
 
+// IsChildPipeline returns true if the PipelineTask runs a Pipeline in a child PipelineRun.
+func (t ResolvedPipelineRunTask) IsChildPipeline() bool {
+	return t.PipelineTask != nil && t.PipelineTask.IsChildPipeline()
+}
+
+// IsMatrixed returns true if the PipelineTask fans out to a TaskRun or Run per combination of its Matrix.
+func (t ResolvedPipelineRunTask) IsMatrixed() bool {
+	return t.PipelineTask != nil && t.PipelineTask.IsMatrixed()
+}
+
+// hasChildren returns true if at least one TaskRun, Run or child PipelineRun was created for the PipelineTask
+func (t ResolvedPipelineRunTask) hasChildren() bool {
+	switch {
+	case t.IsChildPipeline():
+		return t.ChildPipelineRun != nil
+	case t.IsCustomTask() && t.IsMatrixed():
+		for _, run := range t.Runs {
+			if run != nil {
+				return true
+			}
+		}
+		return false
+	case t.IsCustomTask():
+		return t.Run != nil
+	case t.IsMatrixed():
+		for _, taskRun := range t.TaskRuns {
+			if taskRun != nil {
+				return true
+			}
+		}
+		return false
+	default:
+		return t.TaskRun != nil
+	}
+}
+
 // IsSuccessful returns true only if the run has completed successfully
+// A matrixed PipelineTask is successful only if all its TaskRuns or Runs are successful
 func (t ResolvedPipelineRunTask) IsSuccessful() bool {
-	if t.IsCustomTask() {
+	switch {
+	case t.IsChildPipeline():
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+	case t.IsCustomTask() && t.IsMatrixed():
+		if len(t.Runs) == 0 {
+			return false
+		}
+		for _, run := range t.Runs {
+			if run == nil || !run.IsSuccessful() {
+				return false
+			}
+		}
+		return true
+	case t.IsCustomTask():
 		return t.Run != nil && t.Run.IsSuccessful()
+	case t.IsMatrixed():
+		if len(t.TaskRuns) == 0 {
+			return false
+		}
+		for _, taskRun := range t.TaskRuns {
+			if taskRun == nil || !taskRun.IsSuccessful() {
+				return false
+			}
+		}
+		return true
+	default:
+		return t.TaskRun != nil && t.TaskRun.IsSuccessful()
 	}
-	return t.TaskRun != nil && t.TaskRun.IsSuccessful()
 }
 
 // IsFailure returns true only if the run has failed and will not be retried.
+// A matrixed PipelineTask has failed when all its TaskRuns or Runs are done and at least one of them failed
 func (t ResolvedPipelineRunTask) IsFailure() bool {
-	if t.IsCustomTask() {
+	switch {
+	case t.IsChildPipeline():
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+	case t.IsCustomTask() && t.IsMatrixed():
+		if len(t.Runs) == 0 {
+			return false
+		}
+		atLeastOneFailed := false
+		for _, run := range t.Runs {
+			if run == nil || !run.IsDone() {
+				return false
+			}
+			atLeastOneFailed = atLeastOneFailed || !run.IsSuccessful()
+		}
+		return atLeastOneFailed
+	case t.IsCustomTask():
 		return t.Run != nil && t.Run.IsDone() && !t.Run.IsSuccessful()
+	case t.IsMatrixed():
+		if len(t.TaskRuns) == 0 {
+			return false
+		}
+		atLeastOneFailed := false
+		for _, taskRun := range t.TaskRuns {
+			if taskRun == nil {
+				return false
+			}
+			failed := isTaskRunFailure(taskRun, t.PipelineTask)
+			if !failed && !taskRun.IsSuccessful() {
+				return false
+			}
+			atLeastOneFailed = atLeastOneFailed || failed
+		}
+		return atLeastOneFailed
+	default:
+		return isTaskRunFailure(t.TaskRun, t.PipelineTask)
 	}
-	if t.TaskRun == nil {
+}
+
+// isTaskRunFailure returns true only if the TaskRun has failed and will not be retried,
+// either because its retries are exhausted or because the RetryStrategy of the
+// PipelineTask doesn't retry the reason of its failure.
+func isTaskRunFailure(taskRun *v1beta1.TaskRun, pt *v1beta1.PipelineTask) bool {
+	if taskRun == nil {
 		return false
 	}
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-	retriesDone := len(t.TaskRun.Status.RetriesStatus)
-	retries := t.PipelineTask.Retries
-	return c.IsFalse() && (retriesDone >= retries || c.Reason == v1beta1.TaskRunReasonCancelled.String())
+	c := taskRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !c.IsFalse() {
+		return false
+	}
+	retriesDone := len(taskRun.Status.RetriesStatus)
+	return retriesDone >= pt.Retries || c.Reason == v1beta1.TaskRunReasonCancelled.String() || !pt.RetryStrategy.RetriesOn(c.Reason)
 }
 
 // IsCancelled returns true only if the run is cancelled
+// A matrixed PipelineTask is cancelled when all its TaskRuns or Runs are done and at least one of them was cancelled
 func (t ResolvedPipelineRunTask) IsCancelled() bool {
-	if t.IsCustomTask() {
-		if t.Run == nil {
+	switch {
+	case t.IsChildPipeline():
+		return isChildPipelineRunCancelled(t.ChildPipelineRun)
+	case t.IsCustomTask() && t.IsMatrixed():
+		if len(t.Runs) == 0 {
+			return false
+		}
+		atLeastOneCancelled := false
+		for _, run := range t.Runs {
+			if run == nil || !run.IsDone() {
+				return false
+			}
+			atLeastOneCancelled = atLeastOneCancelled || isRunCancelled(run)
+		}
+		return atLeastOneCancelled
+	case t.IsCustomTask():
+		return isRunCancelled(t.Run)
+	case t.IsMatrixed():
+		if len(t.TaskRuns) == 0 {
 			return false
 		}
-		c := t.Run.Status.GetCondition(apis.ConditionSucceeded)
-		return c != nil && c.IsFalse() && c.Reason == v1alpha1.RunReasonCancelled
+		atLeastOneCancelled := false
+		for _, taskRun := range t.TaskRuns {
+			if taskRun == nil || !taskRun.IsDone() {
+				return false
+			}
+			atLeastOneCancelled = atLeastOneCancelled || isTaskRunCancelled(taskRun)
+		}
+		return atLeastOneCancelled
+	default:
+		return isTaskRunCancelled(t.TaskRun)
+	}
+}
+
+// IsToleratedFailure returns true only if the run has failed, and will not be retried, but its
+// PipelineTask tolerates the failure because its OnError is continue. A cancelled run isn't tolerated.
+func (t ResolvedPipelineRunTask) IsToleratedFailure() bool {
+	return t.PipelineTask.ToleratesFailure() && t.IsFailure() && !t.IsCancelled()
+}
+
+func isRunCancelled(run *v1alpha1.Run) bool {
+	if run == nil {
+		return false
 	}
-	if t.TaskRun == nil {
+	c := run.Status.GetCondition(apis.ConditionSucceeded)
+	return c != nil && c.IsFalse() && c.Reason == v1alpha1.RunReasonCancelled
+}
+
+func isChildPipelineRunCancelled(pipelineRun *v1beta1.PipelineRun) bool {
+	if pipelineRun == nil {
 		return false
 	}
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
+	c := pipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+	return c != nil && c.IsFalse() && c.Reason == v1beta1.PipelineRunReasonCancelled.String()
+}
+
+func isTaskRunCancelled(taskRun *v1beta1.TaskRun) bool {
+	if taskRun == nil {
+		return false
+	}
+	c := taskRun.Status.GetCondition(apis.ConditionSucceeded)
 	return c != nil && c.IsFalse() && c.Reason == v1beta1.TaskRunReasonCancelled.String()
 }
 
 // IsStarted returns true only if the PipelineRunTask itself has a TaskRun or
 // Run associated that has a Succeeded-type condition.
+// A matrixed PipelineTask is started as soon as one of its TaskRuns or Runs is started
 func (t ResolvedPipelineRunTask) IsStarted() bool {
-	if t.IsCustomTask() {
+	switch {
+	case t.IsChildPipeline():
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded) != nil
+	case t.IsCustomTask() && t.IsMatrixed():
+		for _, run := range t.Runs {
+			if run != nil && run.Status.GetCondition(apis.ConditionSucceeded) != nil {
+				return true
+			}
+		}
+		return false
+	case t.IsCustomTask():
 		return t.Run != nil && t.Run.Status.GetCondition(apis.ConditionSucceeded) != nil
-
+	case t.IsMatrixed():
+		for _, taskRun := range t.TaskRuns {
+			if taskRun != nil && taskRun.Status.GetCondition(apis.ConditionSucceeded) != nil {
+				return true
+			}
+		}
+		return false
+	default:
+		return t.TaskRun != nil && t.TaskRun.Status.GetCondition(apis.ConditionSucceeded) != nil
 	}
-	return t.TaskRun != nil && t.TaskRun.Status.GetCondition(apis.ConditionSucceeded) != nil
 }
 
 // IsConditionStatusFalse returns true when a task has succeeded condition with status set to false
 // it includes task failed after retries are exhausted, cancelled tasks, and time outs
+// A matrixed PipelineTask has its condition status set to false when one of its TaskRuns or Runs does
 func (t ResolvedPipelineRunTask) IsConditionStatusFalse() bool {
-	if t.IsStarted() {
-		if t.IsCustomTask() {
-			return t.Run.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+	if !t.IsStarted() {
+		return false
+	}
+	switch {
+	case t.IsChildPipeline():
+		return t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+	case t.IsCustomTask() && t.IsMatrixed():
+		for _, run := range t.Runs {
+			if run != nil && run.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+				return true
+			}
 		}
+		return false
+	case t.IsCustomTask():
+		return t.Run.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+	case t.IsMatrixed():
+		for _, taskRun := range t.TaskRuns {
+			if taskRun != nil && taskRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+				return true
+			}
+		}
+		return false
+	default:
 		return t.TaskRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
 	}
-	return false
+}
+
+// conditionReason returns the reason of the succeeded condition of the run of the PipelineTask, or an empty string
+// if it isn't started. The reason of a matrixed PipelineTask is the one of its first failed TaskRun or Run, or else
+// the one of its first TaskRun or Run.
+func (t ResolvedPipelineRunTask) conditionReason() string {
+	var conditions []*apis.Condition
+	switch {
+	case t.IsChildPipeline():
+		if t.ChildPipelineRun != nil {
+			conditions = append(conditions, t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded))
+		}
+	case t.IsCustomTask() && t.IsMatrixed():
+		for _, run := range t.Runs {
+			if run != nil {
+				conditions = append(conditions, run.Status.GetCondition(apis.ConditionSucceeded))
+			}
+		}
+	case t.IsCustomTask():
+		if t.Run != nil {
+			conditions = append(conditions, t.Run.Status.GetCondition(apis.ConditionSucceeded))
+		}
+	case t.IsMatrixed():
+		for _, taskRun := range t.TaskRuns {
+			if taskRun != nil {
+				conditions = append(conditions, taskRun.Status.GetCondition(apis.ConditionSucceeded))
+			}
+		}
+	default:
+		if t.TaskRun != nil {
+			conditions = append(conditions, t.TaskRun.Status.GetCondition(apis.ConditionSucceeded))
+		}
+	}
+	reason := ""
+	for _, c := range conditions {
+		if c == nil {
+			continue
+		}
+		if c.IsFalse() {
+			return c.Reason
+		}
+		if reason == "" {
+			reason = c.Reason
+		}
+	}
+	return reason
 }
 
 func (t *ResolvedPipelineRunTask) checkParentsDone(facts *PipelineRunFacts) bool {
@@ -210,6 +454,8 @@ func (t *ResolvedPipelineRunTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 		skippingReason = MissingResultsSkip
 	case t.skipBecauseWhenExpressionsEvaluatedToFalse(facts):
 		skippingReason = WhenExpressionsSkip
+	case t.skipBecauseMatrixIsEmpty(facts):
+		skippingReason = EmptyMatrixSkip
 	default:
 		skippingReason = None
 	}
@@ -226,6 +472,7 @@ func (t *ResolvedPipelineRunTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 // (3) its parent task was skipped
 // (4) Pipeline is in stopping state (one of the PipelineTasks failed)
 // (5) Pipeline is gracefully cancelled or stopped
+// (6) it is a matrixed task with no combinations to run
 // Note that this means Skip returns false if a conditionCheck is in progress
 func (t *ResolvedPipelineRunTask) Skip(facts *PipelineRunFacts) TaskSkipStatus {
 	if facts.SkipCache == nil {
@@ -259,11 +506,18 @@ func (t *ResolvedPipelineRunTask) skipBecauseWhenExpressionsEvaluatedToFalse(fac
 	return false
 }
 
+// skipBecauseMatrixIsEmpty returns true if a matrixed task has no combinations to run once its parameters and
+// results are substituted, which happens when one of its Matrix parameters is an empty array
+func (t *ResolvedPipelineRunTask) skipBecauseMatrixIsEmpty(facts *PipelineRunFacts) bool {
+	return t.IsMatrixed() && t.checkParentsDone(facts) && len(FanOut(t.PipelineTask.Matrix)) == 0
+}
+
 // skipBecauseParentTaskWasSkipped loops through the parent tasks and checks if the parent task skipped:
-//    if yes, is it because of when expressions and are when expressions?
-//        if yes, it ignores this parent skip and continue evaluating other parent tasks
-//        if no, it returns true to skip the current task because this parent task was skipped
-//    if no, it continues checking the other parent tasks
+//
+//	if yes, is it because of when expressions and are when expressions?
+//	    if yes, it ignores this parent skip and continue evaluating other parent tasks
+//	    if no, it returns true to skip the current task because this parent task was skipped
+//	if no, it continues checking the other parent tasks
 func (t *ResolvedPipelineRunTask) skipBecauseParentTaskWasSkipped(facts *PipelineRunFacts) bool {
 	stateMap := facts.State.ToMap()
 	node := facts.TasksGraph.Nodes[t.PipelineTask.Name]
@@ -287,7 +541,7 @@ func (t *ResolvedPipelineRunTask) skipBecauseResultReferencesAreMissing(facts *P
 	if t.checkParentsDone(facts) && t.hasResultReferences() {
 		resolvedResultRefs, pt, err := ResolveResultRefs(facts.State, PipelineRunState{t})
 		rprt := facts.State.ToMap()[pt]
-		if err != nil && (t.IsFinalTask(facts) || rprt.Skip(facts).SkippingReason == WhenExpressionsSkip) {
+		if err != nil && (t.IsFinalTask(facts) || rprt.Skip(facts).SkippingReason == WhenExpressionsSkip || rprt.IsToleratedFailure()) {
 			return true
 		}
 		ApplyTaskResults(PipelineRunState{t}, resolvedResultRefs)
@@ -314,6 +568,8 @@ func (t *ResolvedPipelineRunTask) IsFinallySkipped(facts *PipelineRunFacts) Task
 			skippingReason = MissingResultsSkip
 		case t.skipBecauseWhenExpressionsEvaluatedToFalse(facts):
 			skippingReason = WhenExpressionsSkip
+		case t.skipBecauseMatrixIsEmpty(facts):
+			skippingReason = EmptyMatrixSkip
 		default:
 			skippingReason = None
 		}
@@ -331,6 +587,9 @@ func (t *ResolvedPipelineRunTask) IsFinallySkipped(facts *PipelineRunFacts) Task
 // GetRun is a function that will retrieve a Run by name.
 type GetRun func(name string) (*v1alpha1.Run, error)
 
+// GetPipelineRun is a function that will retrieve a PipelineRun by name.
+type GetPipelineRun func(name string) (*v1beta1.PipelineRun, error)
+
 // GetResourcesFromBindings will retrieve all Resources bound in PipelineRun pr and return a map
 // from the declared name of the PipelineResource (which is how the PipelineResource will
 // be referred to in the PipelineRun) to the PipelineResource, obtained via getResource.
@@ -452,6 +711,7 @@ func ResolvePipelineRunTask(
 	getTask resources.GetTask,
 	getTaskRun resources.GetTaskRun,
 	getRun GetRun,
+	getPipelineRun GetPipelineRun,
 	getCondition GetCondition,
 	task v1beta1.PipelineTask,
 	providedResources map[string]*resourcev1alpha1.PipelineResource,
@@ -461,16 +721,26 @@ func ResolvePipelineRunTask(
 		PipelineTask: &task,
 	}
 	rprt.CustomTask = isCustomTask(ctx, rprt)
-	if rprt.IsCustomTask() {
+	switch {
+	case rprt.IsChildPipeline():
+		rprt.ChildPipelineRunName = getChildPipelineRunName(pipelineRun.Status.ChildPipelineRuns, task.Name, pipelineRun.Name)
+		childPipelineRun, err := getPipelineRun(rprt.ChildPipelineRunName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error retrieving PipelineRun %s: %w", rprt.ChildPipelineRunName, err)
+		}
+		rprt.ChildPipelineRun = childPipelineRun
+	case rprt.IsCustomTask() && rprt.IsMatrixed():
+		if err := rprt.resolveMatrixedChildren(pipelineRun.Name, getTaskRun, getRun); err != nil {
+			return nil, err
+		}
+	case rprt.IsCustomTask():
 		rprt.RunName = getRunName(pipelineRun.Status.Runs, task.Name, pipelineRun.Name)
 		run, err := getRun(rprt.RunName)
 		if err != nil && !errors.IsNotFound(err) {
 			return nil, fmt.Errorf("error retrieving Run %s: %w", rprt.RunName, err)
 		}
 		rprt.Run = run
-	} else {
-		rprt.TaskRunName = GetTaskRunName(pipelineRun.Status.TaskRuns, task.Name, pipelineRun.Name)
-
+	default:
 		// Find the Task that this PipelineTask is using
 		var (
 			t        v1beta1.TaskObject
@@ -478,16 +748,30 @@ func ResolvePipelineRunTask(
 			spec     v1beta1.TaskSpec
 			taskName string
 			kind     v1beta1.TaskKind
+			taskRun  *v1beta1.TaskRun
 		)
 
-		taskRun, err := getTaskRun(rprt.TaskRunName)
-		if err != nil {
-			if !errors.IsNotFound(err) {
-				return nil, fmt.Errorf("error retrieving TaskRun %s: %w", rprt.TaskRunName, err)
+		if rprt.IsMatrixed() {
+			if err := rprt.resolveMatrixedChildren(pipelineRun.Name, getTaskRun, getRun); err != nil {
+				return nil, err
+			}
+			for _, tr := range rprt.TaskRuns {
+				// Any of the TaskRuns can provide the stored TaskSpec
+				if tr != nil {
+					taskRun = tr
+				}
+			}
+		} else {
+			rprt.TaskRunName = GetTaskRunName(pipelineRun.Status.TaskRuns, task.Name, pipelineRun.Name)
+			taskRun, err = getTaskRun(rprt.TaskRunName)
+			if err != nil {
+				if !errors.IsNotFound(err) {
+					return nil, fmt.Errorf("error retrieving TaskRun %s: %w", rprt.TaskRunName, err)
+				}
+			}
+			if taskRun != nil {
+				rprt.TaskRun = taskRun
 			}
-		}
-		if taskRun != nil {
-			rprt.TaskRun = taskRun
 		}
 
 		if task.TaskRef != nil {
@@ -497,6 +781,9 @@ func ResolvePipelineRunTask(
 				taskName = task.TaskRef.Name
 			} else {
 				t, err = getTask(ctx, task.TaskRef.Name)
+				if goerrors.Is(err, resolution.ErrorRequestInProgress) {
+					return nil, err
+				}
 				if err != nil {
 					return nil, &TaskNotFoundError{
 						Name: task.TaskRef.Name,
@@ -567,6 +854,18 @@ func getRunName(runsStatus map[string]*v1beta1.PipelineRunRunStatus, ptName, prN
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
 }
 
+// getChildPipelineRunName should return a unique name for a child `PipelineRun` if one has not already
+// been defined, and the existing one otherwise.
+func getChildPipelineRunName(childPipelineRunsStatus map[string]*v1beta1.PipelineRunChildPipelineRunStatus, ptName, prName string) string {
+	for k, v := range childPipelineRunsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
+
 func resolveConditionChecks(pt *v1beta1.PipelineTask, taskRunStatus map[string]*v1beta1.PipelineRunTaskRunStatus, taskRunName string, getTaskRun resources.GetTaskRun, getCondition GetCondition, providedResources map[string]*resourcev1alpha1.PipelineResource) ([]*ResolvedConditionCheck, error) {
 	rccs := []*ResolvedConditionCheck{}
 	for i := range pt.Conditions {
@@ -666,6 +965,13 @@ func (t *ResolvedPipelineRunTask) hasResultReferences() bool {
 			}
 		}
 	}
+	for _, param := range t.PipelineTask.Matrix {
+		if ps, ok := v1beta1.GetVarSubstitutionExpressionsForParam(param); ok {
+			if v1beta1.LooksLikeContainsResultRefs(ps) {
+				return true
+			}
+		}
+	}
 	for _, we := range t.PipelineTask.WhenExpressions {
 		if ps, ok := we.GetVarSubstitutionExpressions(); ok {
 			if v1beta1.LooksLikeContainsResultRefs(ps) {
@@ -48,6 +48,9 @@ import (
 func nopGetRun(string) (*v1alpha1.Run, error) {
 	return nil, errors.New("GetRun should not be called")
 }
+func nopGetPipelineRun(string) (*v1beta1.PipelineRun, error) {
+	return nil, errors.New("GetPipelineRun should not be called")
+}
 func nopGetTask(context.Context, string) (v1beta1.TaskObject, error) {
 	return nil, errors.New("GetTask should not be called")
 }
@@ -1278,6 +1281,40 @@ func TestIsSkipped(t *testing.T) {
 			"mytask18": true,
 			"mytask19": false,
 		},
+	}, {
+		name: "matrixed-tasks-with-and-without-combinations",
+		state: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "matrixed",
+				TaskRef: &v1beta1.TaskRef{Name: "task"},
+				Matrix: []v1beta1.Param{{
+					Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+				}},
+			},
+			TaskRunNames: []string{"pipelinerun-matrixed-0", "pipelinerun-matrixed-1"},
+			TaskRuns:     []*v1beta1.TaskRun{nil, nil},
+		}, {
+			// skipped because the array param substituted in its matrix is empty
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "matrixed-empty",
+				TaskRef: &v1beta1.TaskRef{Name: "task"},
+				Matrix: []v1beta1.Param{{
+					Name: "platform", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray},
+				}},
+			},
+		}, {
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:     "after-matrixed-empty",
+				TaskRef:  &v1beta1.TaskRef{Name: "task"},
+				RunAfter: []string{"matrixed-empty"},
+			},
+			TaskRunName: "pipelinerun-after-matrixed-empty",
+		}},
+		expected: map[string]bool{
+			"matrixed":             false,
+			"matrixed-empty":       true,
+			"after-matrixed-empty": true,
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			d, err := dagFromState(tc.state)
@@ -1745,7 +1782,7 @@ func TestResolvePipelineRun(t *testing.T) {
 
 	pipelineState := PipelineRunState{}
 	for _, task := range p.Spec.Tasks {
-		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, task, providedResources)
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, task, providedResources)
 		if err != nil {
 			t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 		}
@@ -1843,7 +1880,7 @@ func TestResolvePipelineRun_CustomTask(t *testing.T) {
 	})
 	ctx = cfg.ToContext(ctx)
 	for _, task := range pts {
-		ps, err := ResolvePipelineRunTask(ctx, pr, nopGetTask, nopGetTaskRun, getRun, nopGetCondition, task, nil)
+		ps, err := ResolvePipelineRunTask(ctx, pr, nopGetTask, nopGetTaskRun, getRun, nopGetPipelineRun, nopGetCondition, task, nil)
 		if err != nil {
 			t.Fatalf("ResolvePipelineRunTask: %v", err)
 		}
@@ -1894,7 +1931,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	}
 	pipelineState := PipelineRunState{}
 	for _, task := range pts {
-		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, task, providedResources)
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, task, providedResources)
 		if err != nil {
 			t.Errorf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 		}
@@ -1939,7 +1976,7 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 	switch err := err.(type) {
 	case nil:
 		t.Fatalf("Expected error getting non-existent Tasks for Pipeline %s but got none", p.Name)
@@ -1985,7 +2022,7 @@ func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 				},
 			}
 			pipelineState := PipelineRunState{}
-			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, tt.p.Spec.Tasks[0], providedResources)
+			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, tt.p.Spec.Tasks[0], providedResources)
 			if err == nil {
 				t.Fatalf("Expected error when bindings are in incorrect state for Pipeline %s but got none: %s", p.ObjectMeta.Name, err)
 			}
@@ -2035,7 +2072,7 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 	getTask := func(_ context.Context, name string) (v1beta1.TaskObject, error) { return task, nil }
 	getTaskRun := func(name string) (*v1beta1.TaskRun, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
-	resolvedTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, p.Spec.Tasks[0], providedResources)
+	resolvedTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, p.Spec.Tasks[0], providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -2089,7 +2126,7 @@ func TestResolvedPipelineRun_PipelineTaskHasOptionalResources(t *testing.T) {
 	getTaskRun := func(name string) (*v1beta1.TaskRun, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
 
-	actualTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, p.Spec.Tasks[0], providedResources)
+	actualTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, p.Spec.Tasks[0], providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -2186,7 +2223,7 @@ func TestResolveConditionChecks(t *testing.T) {
 		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, tc.getTaskRun, nopGetRun, getCondition, pt, providedResources)
+			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, tc.getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 			}
@@ -2276,7 +2313,7 @@ func TestResolveConditionChecks_MultipleConditions(t *testing.T) {
 		ResolvedResources:     providedResources,
 	}}
 
-	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 	}
@@ -2318,7 +2355,7 @@ func TestResolveConditionChecks_ConditionDoesNotExist(t *testing.T) {
 		},
 	}
 
-	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 
 	switch err := err.(type) {
 	case nil:
@@ -2386,7 +2423,7 @@ func TestResolveConditionCheck_UseExistingConditionCheckName(t *testing.T) {
 		},
 	}
 
-	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 	}
@@ -2460,7 +2497,7 @@ func TestResolvedConditionCheck_WithResources(t *testing.T) {
 		wantErr:           true,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, tc.providedResources)
+			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, tc.providedResources)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2765,7 +2802,7 @@ func TestResolvePipeline_WhenExpressions(t *testing.T) {
 	}
 
 	t.Run("When Expressions exist", func(t *testing.T) {
-		_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+		_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 		if err != nil {
 			t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 		}
@@ -2887,7 +2924,7 @@ func TestIsCustomTask(t *testing.T) {
 				},
 			})
 			ctx = cfg.ToContext(ctx)
-			rprt, err := ResolvePipelineRunTask(ctx, pr, getTask, getTaskRun, getRun, getCondition, tc.pt, nil)
+			rprt, err := ResolvePipelineRunTask(ctx, pr, getTask, getTaskRun, getRun, nopGetPipelineRun, getCondition, tc.pt, nil)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 			}
@@ -3072,3 +3109,294 @@ func TestResolvedPipelineRunTask_IsFinalTask(t *testing.T) {
 	}
 
 }
+
+func TestResolvePipelineRun_Matrixed(t *testing.T) {
+	matrix := []v1beta1.Param{{
+		Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+	}, {
+		Name: "browser", Value: *v1beta1.NewArrayOrString("chrome", "safari"),
+	}}
+	pts := []v1beta1.PipelineTask{{
+		Name:    "matrixed",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Matrix:  matrix,
+	}, {
+		Name:    "matrixed-customtask",
+		TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+		Matrix:  matrix,
+	}}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+	}
+	taskRun := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-matrixed-1"}}
+	getTask := func(_ context.Context, name string) (v1beta1.TaskObject, error) { return task, nil }
+	getTaskRun := func(name string) (*v1beta1.TaskRun, error) {
+		if name == taskRun.Name {
+			return taskRun, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("taskrun"), name)
+	}
+	run := &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-matrixed-customtask-3"}}
+	getRun := func(name string) (*v1alpha1.Run, error) {
+		if name == run.Name {
+			return run, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("run"), name)
+	}
+	nopGetCondition := func(string) (*v1alpha1.Condition, error) { return nil, errors.New("GetCondition should not be called") }
+	ctx := context.Background()
+	cfg := config.NewStore(logtesting.TestLogger(t))
+	cfg.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName()},
+		Data: map[string]string{
+			"enable-custom-tasks": "true",
+		},
+	})
+	ctx = cfg.ToContext(ctx)
+	pipelineState := PipelineRunState{}
+	for _, task := range pts {
+		ps, err := ResolvePipelineRunTask(ctx, pr, getTask, getTaskRun, getRun, nopGetPipelineRun, nopGetCondition, task, nil)
+		if err != nil {
+			t.Fatalf("ResolvePipelineRunTask: %v", err)
+		}
+		pipelineState = append(pipelineState, ps)
+	}
+
+	expectedState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		TaskRunNames: []string{"pipelinerun-matrixed-0", "pipelinerun-matrixed-1", "pipelinerun-matrixed-2", "pipelinerun-matrixed-3"},
+		TaskRuns:     []*v1beta1.TaskRun{nil, taskRun, nil, nil},
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskName: task.Name,
+			TaskSpec: &task.Spec,
+			Inputs:   map[string]*resourcev1alpha1.PipelineResource{},
+			Outputs:  map[string]*resourcev1alpha1.PipelineResource{},
+		},
+	}, {
+		PipelineTask: &pts[1],
+		CustomTask:   true,
+		RunNames:     []string{"pipelinerun-matrixed-customtask-0", "pipelinerun-matrixed-customtask-1", "pipelinerun-matrixed-customtask-2", "pipelinerun-matrixed-customtask-3"},
+		Runs:         []*v1alpha1.Run{nil, nil, nil, run},
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvedPipelineRunTask_Matrixed(t *testing.T) {
+	pt := &v1beta1.PipelineTask{
+		Name:    "matrixed",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Matrix: []v1beta1.Param{{
+			Name: "platform", Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}},
+	}
+	ptWithRetries := pt.DeepCopy()
+	ptWithRetries.Retries = 1
+	customPt := &v1beta1.PipelineTask{
+		Name:    "matrixed",
+		TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+		Matrix:  pt.Matrix,
+	}
+	tcs := []struct {
+		name          string
+		rprt          ResolvedPipelineRunTask
+		wantStarted   bool
+		wantRunning   bool
+		wantSucceeded bool
+		wantFailed    bool
+		wantCancelled bool
+	}{{
+		name: "no taskruns created",
+		rprt: ResolvedPipelineRunTask{PipelineTask: pt, TaskRuns: []*v1beta1.TaskRun{nil, nil}},
+	}, {
+		name:        "some taskruns created",
+		rprt:        ResolvedPipelineRunTask{PipelineTask: pt, TaskRuns: []*v1beta1.TaskRun{makeSucceeded(trs[0]), nil}},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name:        "some taskruns running",
+		rprt:        ResolvedPipelineRunTask{PipelineTask: pt, TaskRuns: []*v1beta1.TaskRun{makeSucceeded(trs[0]), makeStarted(trs[1])}},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name:          "all taskruns succeeded",
+		rprt:          ResolvedPipelineRunTask{PipelineTask: pt, TaskRuns: []*v1beta1.TaskRun{makeSucceeded(trs[0]), makeSucceeded(trs[1])}},
+		wantStarted:   true,
+		wantSucceeded: true,
+	}, {
+		name:        "one taskrun failed while another is running",
+		rprt:        ResolvedPipelineRunTask{PipelineTask: pt, TaskRuns: []*v1beta1.TaskRun{makeFailed(trs[0]), makeStarted(trs[1])}},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name:        "one taskrun failed and the others are done",
+		rprt:        ResolvedPipelineRunTask{PipelineTask: pt, TaskRuns: []*v1beta1.TaskRun{makeFailed(trs[0]), makeSucceeded(trs[1])}},
+		wantStarted: true,
+		wantFailed:  true,
+	}, {
+		name:        "one taskrun failed and will be retried",
+		rprt:        ResolvedPipelineRunTask{PipelineTask: ptWithRetries, TaskRuns: []*v1beta1.TaskRun{makeFailed(trs[0]), makeSucceeded(trs[1])}},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name:          "one taskrun cancelled and the others are done",
+		rprt:          ResolvedPipelineRunTask{PipelineTask: pt, TaskRuns: []*v1beta1.TaskRun{withCancelled(makeFailed(trs[0])), makeSucceeded(trs[1])}},
+		wantStarted:   true,
+		wantFailed:    true,
+		wantCancelled: true,
+	}, {
+		name:          "all runs succeeded",
+		rprt:          ResolvedPipelineRunTask{PipelineTask: customPt, CustomTask: true, Runs: []*v1alpha1.Run{makeRunSucceeded(runs[0]), makeRunSucceeded(runs[1])}},
+		wantStarted:   true,
+		wantSucceeded: true,
+	}, {
+		name:        "one run failed while another is running",
+		rprt:        ResolvedPipelineRunTask{PipelineTask: customPt, CustomTask: true, Runs: []*v1alpha1.Run{makeRunFailed(runs[0]), makeRunStarted(runs[1])}},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name:        "one run failed and the others are done",
+		rprt:        ResolvedPipelineRunTask{PipelineTask: customPt, CustomTask: true, Runs: []*v1alpha1.Run{makeRunFailed(runs[0]), makeRunSucceeded(runs[1])}},
+		wantStarted: true,
+		wantFailed:  true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rprt.IsStarted(); got != tc.wantStarted {
+				t.Errorf("expected IsStarted: %t but got %t", tc.wantStarted, got)
+			}
+			if got := tc.rprt.IsRunning(); got != tc.wantRunning {
+				t.Errorf("expected IsRunning: %t but got %t", tc.wantRunning, got)
+			}
+			if got := tc.rprt.IsSuccessful(); got != tc.wantSucceeded {
+				t.Errorf("expected IsSuccessful: %t but got %t", tc.wantSucceeded, got)
+			}
+			if got := tc.rprt.IsFailure(); got != tc.wantFailed {
+				t.Errorf("expected IsFailure: %t but got %t", tc.wantFailed, got)
+			}
+			if got := tc.rprt.IsCancelled(); got != tc.wantCancelled {
+				t.Errorf("expected IsCancelled: %t but got %t", tc.wantCancelled, got)
+			}
+		})
+	}
+}
+
+func TestResolvePipelineRun_ChildPipeline(t *testing.T) {
+	names.TestingSeed()
+	pts := []v1beta1.PipelineTask{{
+		Name:        "child",
+		PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+	}, {
+		Name: "child-not-created",
+		PipelineSpec: &v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{Name: "task", TaskRef: &v1beta1.TaskRef{Name: "task"}}},
+		},
+	}}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				ChildPipelineRuns: map[string]*v1beta1.PipelineRunChildPipelineRunStatus{
+					"pipelinerun-child-abcde": {PipelineTaskName: "child"},
+				},
+			},
+		},
+	}
+	childPipelineRun := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-child-abcde"}}
+	getPipelineRun := func(name string) (*v1beta1.PipelineRun, error) {
+		if name == childPipelineRun.Name {
+			return childPipelineRun, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("pipelinerun"), name)
+	}
+	nopGetCondition := func(string) (*v1alpha1.Condition, error) { return nil, errors.New("GetCondition should not be called") }
+	pipelineState := PipelineRunState{}
+	for _, task := range pts {
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, nopGetTask, nopGetTaskRun, nopGetRun, getPipelineRun, nopGetCondition, task, nil)
+		if err != nil {
+			t.Fatalf("ResolvePipelineRunTask: %v", err)
+		}
+		pipelineState = append(pipelineState, ps)
+	}
+
+	expectedState := PipelineRunState{{
+		PipelineTask:         &pts[0],
+		ChildPipelineRunName: "pipelinerun-child-abcde",
+		ChildPipelineRun:     childPipelineRun,
+	}, {
+		PipelineTask:         &pts[1],
+		ChildPipelineRunName: "pipelinerun-child-not-created-9l9zj",
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvedPipelineRunTask_ChildPipeline(t *testing.T) {
+	pt := &v1beta1.PipelineTask{
+		Name:        "child",
+		PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+	}
+	childPipelineRun := func(status corev1.ConditionStatus, reason string) *v1beta1.PipelineRun {
+		pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-child"}}
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: status,
+			Reason: reason,
+		})
+		return pr
+	}
+	tcs := []struct {
+		name          string
+		rprt          ResolvedPipelineRunTask
+		wantStarted   bool
+		wantRunning   bool
+		wantSucceeded bool
+		wantFailed    bool
+		wantCancelled bool
+	}{{
+		name: "child pipelinerun not created",
+		rprt: ResolvedPipelineRunTask{PipelineTask: pt},
+	}, {
+		name:        "child pipelinerun running",
+		rprt:        ResolvedPipelineRunTask{PipelineTask: pt, ChildPipelineRun: childPipelineRun(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())},
+		wantStarted: true,
+		wantRunning: true,
+	}, {
+		name:          "child pipelinerun succeeded",
+		rprt:          ResolvedPipelineRunTask{PipelineTask: pt, ChildPipelineRun: childPipelineRun(corev1.ConditionTrue, v1beta1.PipelineRunReasonSuccessful.String())},
+		wantStarted:   true,
+		wantSucceeded: true,
+	}, {
+		name:        "child pipelinerun failed",
+		rprt:        ResolvedPipelineRunTask{PipelineTask: pt, ChildPipelineRun: childPipelineRun(corev1.ConditionFalse, v1beta1.PipelineRunReasonFailed.String())},
+		wantStarted: true,
+		wantFailed:  true,
+	}, {
+		name:          "child pipelinerun cancelled",
+		rprt:          ResolvedPipelineRunTask{PipelineTask: pt, ChildPipelineRun: childPipelineRun(corev1.ConditionFalse, v1beta1.PipelineRunReasonCancelled.String())},
+		wantStarted:   true,
+		wantFailed:    true,
+		wantCancelled: true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rprt.IsStarted(); got != tc.wantStarted {
+				t.Errorf("expected IsStarted: %t but got %t", tc.wantStarted, got)
+			}
+			if got := tc.rprt.IsRunning(); got != tc.wantRunning {
+				t.Errorf("expected IsRunning: %t but got %t", tc.wantRunning, got)
+			}
+			if got := tc.rprt.IsSuccessful(); got != tc.wantSucceeded {
+				t.Errorf("expected IsSuccessful: %t but got %t", tc.wantSucceeded, got)
+			}
+			if got := tc.rprt.IsFailure(); got != tc.wantFailed {
+				t.Errorf("expected IsFailure: %t but got %t", tc.wantFailed, got)
+			}
+			if got := tc.rprt.IsCancelled(); got != tc.wantCancelled {
+				t.Errorf("expected IsCancelled: %t but got %t", tc.wantCancelled, got)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package resources
 
 import (
 	"fmt"
+	"hash/fnv"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
@@ -35,6 +37,8 @@ const (
 	PipelineTaskStatusPrefix = "tasks."
 	// PipelineTaskStatusSuffix is a suffix of the param representing execution state of pipelineTask
 	PipelineTaskStatusSuffix = ".status"
+	// PipelineTaskReasonSuffix is a suffix of the param representing the reason of the execution state of pipelineTask
+	PipelineTaskReasonSuffix = ".reason"
 )
 
 // PipelineRunState is a slice of ResolvedPipelineRunTasks the represents the current execution
@@ -71,6 +75,8 @@ type pipelineRunStatusCount struct {
 	Succeeded int
 	// failed tasks count
 	Failed int
+	// failed tasks count tolerated because their onError is continue
+	ToleratedFailures int
 	// cancelled tasks count
 	Cancelled int
 	// number of tasks which are still pending, have not executed
@@ -94,9 +100,7 @@ func (state PipelineRunState) ToMap() map[string]*ResolvedPipelineRunTask {
 // IsBeforeFirstTaskRun returns true if the PipelineRun has not yet started its first TaskRun
 func (state PipelineRunState) IsBeforeFirstTaskRun() bool {
 	for _, t := range state {
-		if t.IsCustomTask() && t.Run != nil {
-			return false
-		} else if t.TaskRun != nil {
+		if t.hasChildren() {
 			return false
 		}
 	}
@@ -125,6 +129,19 @@ func (state PipelineRunState) AdjustStartTime(unadjustedStartTime *metav1.Time)
 				adjustedStartTime = &rprt.TaskRun.CreationTimestamp
 			}
 		}
+		for _, taskRun := range rprt.TaskRuns {
+			if taskRun != nil && taskRun.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
+				adjustedStartTime = &taskRun.CreationTimestamp
+			}
+		}
+		for _, run := range rprt.Runs {
+			if run != nil && run.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
+				adjustedStartTime = &run.CreationTimestamp
+			}
+		}
+		if rprt.ChildPipelineRun != nil && rprt.ChildPipelineRun.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
+			adjustedStartTime = &rprt.ChildPipelineRun.CreationTimestamp
+		}
 	}
 	return adjustedStartTime.DeepCopy()
 }
@@ -135,7 +152,25 @@ func (state PipelineRunState) AdjustStartTime(unadjustedStartTime *metav1.Time)
 func (state PipelineRunState) GetTaskRunsStatus(pr *v1beta1.PipelineRun) map[string]*v1beta1.PipelineRunTaskRunStatus {
 	status := make(map[string]*v1beta1.PipelineRunTaskRunStatus)
 	for _, rprt := range state {
-		if rprt.IsCustomTask() {
+		if rprt.IsCustomTask() || rprt.IsChildPipeline() {
+			continue
+		}
+		if rprt.IsMatrixed() {
+			// each TaskRun of a matrixed PipelineTask is reported on its own
+			for _, taskRun := range rprt.TaskRuns {
+				if taskRun == nil {
+					continue
+				}
+				prtrs := pr.Status.TaskRuns[taskRun.Name]
+				if prtrs == nil {
+					prtrs = &v1beta1.PipelineRunTaskRunStatus{
+						PipelineTaskName: rprt.PipelineTask.Name,
+						WhenExpressions:  rprt.PipelineTask.WhenExpressions,
+					}
+				}
+				prtrs.Status = &taskRun.Status
+				status[taskRun.Name] = prtrs
+			}
 			continue
 		}
 		if rprt.TaskRun == nil && rprt.ResolvedConditionChecks == nil {
@@ -194,6 +229,24 @@ func (state PipelineRunState) GetRunsStatus(pr *v1beta1.PipelineRun) map[string]
 		if !rprt.IsCustomTask() {
 			continue
 		}
+		if rprt.IsMatrixed() {
+			// each Run of a matrixed PipelineTask is reported on its own
+			for _, run := range rprt.Runs {
+				if run == nil {
+					continue
+				}
+				prrs := pr.Status.Runs[run.Name]
+				if prrs == nil {
+					prrs = &v1beta1.PipelineRunRunStatus{
+						PipelineTaskName: rprt.PipelineTask.Name,
+						WhenExpressions:  rprt.PipelineTask.WhenExpressions,
+					}
+				}
+				prrs.Status = &run.Status
+				status[run.Name] = prrs
+			}
+			continue
+		}
 		if rprt.Run == nil && rprt.ResolvedConditionChecks == nil {
 			continue
 		}
@@ -220,6 +273,29 @@ func (state PipelineRunState) GetRunsStatus(pr *v1beta1.PipelineRun) map[string]
 	return status
 }
 
+// GetChildPipelineRunsStatus returns a map of child pipelinerun name and the child pipelinerun.
+// Ignore a nil child pipelinerun in pipelineRunState, otherwise, capture the child pipelinerun object from PipelineRun Status.
+// Update child pipelinerun status based on the pipelineRunState before returning it in the map.
+func (state PipelineRunState) GetChildPipelineRunsStatus(pr *v1beta1.PipelineRun) map[string]*v1beta1.PipelineRunChildPipelineRunStatus {
+	status := map[string]*v1beta1.PipelineRunChildPipelineRunStatus{}
+	for _, rprt := range state {
+		if !rprt.IsChildPipeline() || rprt.ChildPipelineRun == nil {
+			continue
+		}
+
+		prcprs := pr.Status.ChildPipelineRuns[rprt.ChildPipelineRunName]
+		if prcprs == nil {
+			prcprs = &v1beta1.PipelineRunChildPipelineRunStatus{
+				PipelineTaskName: rprt.PipelineTask.Name,
+				WhenExpressions:  rprt.PipelineTask.WhenExpressions,
+			}
+		}
+		prcprs.Status = &rprt.ChildPipelineRun.Status
+		status[rprt.ChildPipelineRunName] = prcprs
+	}
+	return status
+}
+
 // getNextTasks returns a list of tasks which should be executed next i.e.
 // a list of tasks from candidateTasks which aren't yet indicated in state to be running and
 // a list of cancelled/failed tasks from candidateTasks which haven't exhausted their retries
@@ -227,32 +303,111 @@ func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*Resolv
 	tasks := []*ResolvedPipelineRunTask{}
 	for _, t := range state {
 		if _, ok := candidateTasks[t.PipelineTask.Name]; ok {
-			if t.TaskRun == nil && t.Run == nil {
-				tasks = append(tasks, t)
-			} else if t.TaskRun != nil { // only TaskRun currently supports retry
-				status := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-				if status != nil && status.IsFalse() {
-					if !(t.TaskRun.IsCancelled() || status.Reason == v1beta1.TaskRunReasonCancelled.String() || status.Reason == ReasonConditionCheckFailed) {
-						if len(t.TaskRun.Status.RetriesStatus) < t.PipelineTask.Retries {
-							tasks = append(tasks, t)
-						}
-					}
+			if t.IsMatrixed() {
+				if t.hasPendingMatrixedChildren() {
+					tasks = append(tasks, t)
 				}
+				continue
+			}
+			if !t.hasChildren() {
+				tasks = append(tasks, t)
+			} else if t.TaskRun != nil && t.IsTaskRunRetriable(t.TaskRun) { // only TaskRun currently supports retry
+				tasks = append(tasks, t)
 			}
 		}
 	}
 	return tasks
 }
 
+// hasPendingMatrixedChildren returns true if a matrixed PipelineTask has TaskRuns or Runs which
+// were not created yet, or failed TaskRuns which haven't exhausted their retries
+func (t *ResolvedPipelineRunTask) hasPendingMatrixedChildren() bool {
+	if t.IsCustomTask() {
+		for _, run := range t.Runs {
+			if run == nil {
+				return true
+			}
+		}
+		return false
+	}
+	for _, taskRun := range t.TaskRuns {
+		if taskRun == nil || t.IsTaskRunRetriable(taskRun) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTaskRunRetriable returns true if the given TaskRun of the PipelineTask failed and
+// hasn't exhausted its retries
+func (t *ResolvedPipelineRunTask) IsTaskRunRetriable(taskRun *v1beta1.TaskRun) bool {
+	status := taskRun.Status.GetCondition(apis.ConditionSucceeded)
+	if status == nil || !status.IsFalse() {
+		return false
+	}
+	if taskRun.IsCancelled() || status.Reason == v1beta1.TaskRunReasonCancelled.String() || status.Reason == ReasonConditionCheckFailed {
+		return false
+	}
+	if !t.PipelineTask.RetryStrategy.RetriesOn(status.Reason) {
+		return false
+	}
+	return len(taskRun.Status.RetriesStatus) < t.PipelineTask.Retries
+}
+
+// RetryBackoffRemaining returns how long the retry of the given failed TaskRun of the
+// PipelineTask waits for the backoff of its RetryStrategy, or zero if it can be retried now.
+// The backoff grows exponentially with the retries done and is jittered down to half of it,
+// deterministically for the TaskRun and retry so that every reconcile computes the same wait.
+func (t *ResolvedPipelineRunTask) RetryBackoffRemaining(taskRun *v1beta1.TaskRun, now time.Time) time.Duration {
+	if t.PipelineTask.RetryStrategy == nil || taskRun.Status.CompletionTime == nil || !t.IsTaskRunRetriable(taskRun) {
+		return 0
+	}
+	retriesDone := len(taskRun.Status.RetriesStatus)
+	delay := t.PipelineTask.RetryStrategy.Backoff.Delay(retriesDone)
+	if delay <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s/%d", taskRun.Name, retriesDone)
+	half := delay / 2
+	jittered := half + time.Duration(h.Sum64()%uint64(delay-half+1))
+	if remaining := taskRun.Status.CompletionTime.Add(jittered).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// RetryBackoffRemaining returns the shortest wait of the failed TaskRuns of the PipelineRun
+// waiting for the backoff of their retry, or zero if none is waiting.
+func (state PipelineRunState) RetryBackoffRemaining(now time.Time) time.Duration {
+	var shortest time.Duration
+	for _, t := range state {
+		taskRuns := t.TaskRuns
+		if !t.IsMatrixed() {
+			taskRuns = []*v1beta1.TaskRun{t.TaskRun}
+		}
+		for _, taskRun := range taskRuns {
+			if taskRun == nil {
+				continue
+			}
+			if remaining := t.RetryBackoffRemaining(taskRun, now); remaining > 0 && (shortest == 0 || remaining < shortest) {
+				shortest = remaining
+			}
+		}
+	}
+	return shortest
+}
+
 // IsStopping returns true if the PipelineRun won't be scheduling any new Task because
 // at least one task already failed or was cancelled in the specified dag
+// the failure of a task whose onError is continue is tolerated and doesn't stop the PipelineRun
 func (facts *PipelineRunFacts) IsStopping() bool {
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
 			if t.IsCancelled() {
 				return true
 			}
-			if t.IsFailure() {
+			if t.IsFailure() && !t.IsToleratedFailure() {
 				return true
 			}
 		}
@@ -340,7 +495,7 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 	// get the count of successful tasks, failed tasks, cancelled tasks, skipped task, and incomplete tasks
 	s := facts.getPipelineTasksCount()
 	// completed task is a collection of successful, failed, cancelled tasks (skipped tasks are reported separately)
-	cmTasks := s.Succeeded + s.Failed + s.Cancelled
+	cmTasks := s.Succeeded + s.Failed + s.ToleratedFailures + s.Cancelled
 
 	// The completion reason is set from the TaskRun completion reason
 	// by default, set it to ReasonRunning
@@ -351,9 +506,9 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 		status := corev1.ConditionTrue
 		reason := v1beta1.PipelineRunReasonSuccessful.String()
 		message := fmt.Sprintf("Tasks Completed: %d (Failed: %d, Cancelled %d), Skipped: %d",
-			cmTasks, s.Failed, s.Cancelled, s.Skipped)
-		// Set reason to ReasonCompleted - At least one is skipped
-		if s.Skipped > 0 {
+			cmTasks, s.Failed, s.Cancelled, s.Skipped) + toleratedFailuresMessage(s)
+		// Set reason to ReasonCompleted - At least one is skipped or its failure is tolerated
+		if s.Skipped > 0 || s.ToleratedFailures > 0 {
 			reason = v1beta1.PipelineRunReasonCompleted.String()
 		}
 
@@ -402,8 +557,17 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 		Status: corev1.ConditionUnknown,
 		Reason: reason,
 		Message: fmt.Sprintf("Tasks Completed: %d (Failed: %d, Cancelled %d), Incomplete: %d, Skipped: %d",
-			cmTasks, s.Failed, s.Cancelled, s.Incomplete, s.Skipped),
+			cmTasks, s.Failed, s.Cancelled, s.Incomplete, s.Skipped) + toleratedFailuresMessage(s),
+	}
+}
+
+// toleratedFailuresMessage returns the part of the message of the PipelineRun condition reporting
+// the failures tolerated because the onError of their tasks is continue, if any
+func toleratedFailuresMessage(s pipelineRunStatusCount) string {
+	if s.ToleratedFailures == 0 {
+		return ""
 	}
+	return fmt.Sprintf(", Failures Tolerated: %d", s.ToleratedFailures)
 }
 
 // GetSkippedTasks constructs a list of SkippedTask struct to be included in the PipelineRun Status
@@ -432,10 +596,24 @@ func (facts *PipelineRunFacts) GetSkippedTasks() []v1beta1.SkippedTask {
 	return skipped
 }
 
+// GetToleratedFailures constructs a list of ToleratedFailure struct to be included in the PipelineRun Status
+func (facts *PipelineRunFacts) GetToleratedFailures() []v1beta1.ToleratedFailure {
+	var tolerated []v1beta1.ToleratedFailure
+	for _, rprt := range facts.State {
+		if rprt.IsToleratedFailure() {
+			tolerated = append(tolerated, v1beta1.ToleratedFailure{
+				Name:   rprt.PipelineTask.Name,
+				Reason: rprt.conditionReason(),
+			})
+		}
+	}
+	return tolerated
+}
+
 // GetPipelineTaskStatus returns the status of a PipelineTask depending on its taskRun
 // the checks are implemented such that the finally tasks are requesting status of the dag tasks
 func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
-	// construct a map of tasks.<pipelineTask>.status and its state
+	// construct a map of tasks.<pipelineTask>.status and tasks.<pipelineTask>.reason and their state
 	tStatus := make(map[string]string)
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
@@ -452,6 +630,12 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 				s = PipelineTaskStateNone
 			}
 			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskStatusSuffix] = s
+			// the reason is the one of the condition of the run, None if the pipelineTask didn't run
+			reason := t.conditionReason()
+			if reason == "" {
+				reason = PipelineTaskStateNone
+			}
+			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskReasonSuffix] = reason
 		}
 	}
 	// initialize aggregate status of all dag tasks to None
@@ -462,6 +646,12 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 		aggregateStatus = v1beta1.PipelineRunReasonSuccessful.String()
 		for _, t := range facts.State {
 			if facts.isDAGTask(t.PipelineTask.Name) {
+				// if any of the dag task failed but its failure is tolerated, change the aggregate status
+				// to completed but continue checking for any other failure
+				if t.IsToleratedFailure() {
+					aggregateStatus = v1beta1.PipelineRunReasonCompleted.String()
+					continue
+				}
 				// if any of the dag task failed, change the aggregate status to failed and return
 				if t.IsConditionStatusFalse() {
 					aggregateStatus = v1beta1.PipelineRunReasonFailed.String()
@@ -480,12 +670,12 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 }
 
 // successfulOrSkippedTasks returns a list of the names of all of the PipelineTasks in state
-// which have successfully completed or skipped
+// which have successfully completed or skipped, or failed with a tolerated failure
 func (facts *PipelineRunFacts) successfulOrSkippedDAGTasks() []string {
 	tasks := []string{}
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
-			if t.IsSuccessful() || t.Skip(facts).IsSkipped {
+			if t.IsSuccessful() || t.Skip(facts).IsSkipped || t.IsToleratedFailure() {
 				tasks = append(tasks, t.PipelineTask.Name)
 			}
 		}
@@ -519,11 +709,12 @@ func (facts *PipelineRunFacts) checkFinalTasksDone() bool {
 // getPipelineTasksCount returns the count of successful tasks, failed tasks, cancelled tasks, skipped task, and incomplete tasks
 func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 	s := pipelineRunStatusCount{
-		Skipped:    0,
-		Succeeded:  0,
-		Failed:     0,
-		Cancelled:  0,
-		Incomplete: 0,
+		Skipped:           0,
+		Succeeded:         0,
+		Failed:            0,
+		ToleratedFailures: 0,
+		Cancelled:         0,
+		Incomplete:        0,
 	}
 	for _, t := range facts.State {
 		switch {
@@ -533,6 +724,9 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 		// increment cancelled counter since the task is cancelled
 		case t.IsCancelled():
 			s.Cancelled++
+		// increment tolerated failure counter since the task has failed but its onError is continue
+		case t.IsToleratedFailure():
+			s.ToleratedFailures++
 		// increment failure counter since the task has failed
 		case t.IsFailure():
 			s.Failed++
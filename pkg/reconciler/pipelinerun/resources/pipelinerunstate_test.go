@@ -1446,7 +1446,9 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}, {
@@ -1455,7 +1457,9 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}, {
@@ -1464,7 +1468,9 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}, {
@@ -1473,7 +1479,9 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			v1beta1.PipelineTasksAggregateStatus:                              v1beta1.PipelineRunReasonFailed.String(),
 		},
 	}, {
@@ -1482,7 +1490,9 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[0], pts[1]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonSuccessful.String(),
+			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			v1beta1.PipelineTasksAggregateStatus:                              v1beta1.PipelineRunReasonSuccessful.String(),
 		},
 	}, {
@@ -1498,6 +1508,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[9]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[9].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[9].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}, {
@@ -1512,6 +1523,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[10]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			v1beta1.PipelineTasksAggregateStatus:                               v1beta1.PipelineRunReasonCompleted.String(),
 		},
 	}, {
@@ -1532,7 +1544,9 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[0], pts[11]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:  PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:  PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[11].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[11].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			v1beta1.PipelineTasksAggregateStatus:                               PipelineTaskStateNone,
 		},
 	}, {
@@ -1541,6 +1555,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[4]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[4].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[4].Name + PipelineTaskReasonSuffix: v1beta1.TaskRunSpecStatusCancelled,
 			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}, {
@@ -1562,9 +1577,27 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[0], pts[10]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:  v1beta1.PipelineRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:  PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
 			v1beta1.PipelineTasksAggregateStatus:                               v1beta1.PipelineRunReasonFailed.String(),
 		},
+	}, {
+		name: "task-timed-out",
+		state: PipelineRunState{{
+			PipelineTask: &pts[0],
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      withReason(makeFailed(trs[0]), v1beta1.TaskRunReasonTimedOut.String()),
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		}},
+		dagTasks: []v1beta1.PipelineTask{pts[0]},
+		expectedStatus: map[string]string{
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonFailed.String(),
+			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix: v1beta1.TaskRunReasonTimedOut.String(),
+			v1beta1.PipelineTasksAggregateStatus:                              v1beta1.PipelineRunReasonFailed.String(),
+		},
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1693,3 +1726,250 @@ func TestPipelineRunFacts_IsRunning(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTaskRunRetriableWithRetryStrategy(t *testing.T) {
+	pt := v1beta1.PipelineTask{
+		Name:    "mytask",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Retries: 2,
+		RetryStrategy: &v1beta1.RetryStrategy{
+			OnReasons: []string{"PodEvicted", "TaskRunTimeout"},
+		},
+	}
+	for _, tc := range []struct {
+		name          string
+		reason        string
+		wantRetriable bool
+		wantFailure   bool
+	}{{
+		name:          "reason retried",
+		reason:        "PodEvicted",
+		wantRetriable: true,
+		wantFailure:   false,
+	}, {
+		name:          "reason not retried",
+		reason:        v1beta1.TaskRunReasonFailed.String(),
+		wantRetriable: false,
+		wantFailure:   true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := makeFailed(trs[0])
+			tr.Status.Conditions[0].Reason = tc.reason
+			rprt := &ResolvedPipelineRunTask{
+				PipelineTask: &pt,
+				TaskRunName:  tr.Name,
+				TaskRun:      tr,
+			}
+			if got := rprt.IsTaskRunRetriable(tr); got != tc.wantRetriable {
+				t.Errorf("IsTaskRunRetriable() = %t, want %t", got, tc.wantRetriable)
+			}
+			if got := rprt.IsFailure(); got != tc.wantFailure {
+				t.Errorf("IsFailure() = %t, want %t", got, tc.wantFailure)
+			}
+		})
+	}
+}
+
+func TestRetryBackoffRemaining(t *testing.T) {
+	completed := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	pt := v1beta1.PipelineTask{
+		Name:    "mytask",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Retries: 3,
+		RetryStrategy: &v1beta1.RetryStrategy{
+			Backoff: &v1beta1.Backoff{
+				Duration:    &metav1.Duration{Duration: 10 * time.Second},
+				MaxDuration: &metav1.Duration{Duration: 30 * time.Second},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name        string
+		retriesDone int
+		since       time.Duration
+		// the backoff is jittered between half of the delay and the delay
+		wantMin time.Duration
+		wantMax time.Duration
+	}{{
+		name:        "first retry",
+		retriesDone: 0,
+		wantMin:     5 * time.Second,
+		wantMax:     10 * time.Second,
+	}, {
+		name:        "second retry",
+		retriesDone: 1,
+		wantMin:     10 * time.Second,
+		wantMax:     20 * time.Second,
+	}, {
+		name:        "capped by the max duration",
+		retriesDone: 2,
+		wantMin:     15 * time.Second,
+		wantMax:     30 * time.Second,
+	}, {
+		name:        "backoff elapsed",
+		retriesDone: 0,
+		since:       10 * time.Second,
+		wantMin:     0,
+		wantMax:     0,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := makeFailed(trs[0])
+			tr.Status.CompletionTime = &metav1.Time{Time: completed}
+			for i := 0; i < tc.retriesDone; i++ {
+				tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, v1beta1.TaskRunStatus{})
+			}
+			rprt := &ResolvedPipelineRunTask{
+				PipelineTask: &pt,
+				TaskRunName:  tr.Name,
+				TaskRun:      tr,
+			}
+			got := rprt.RetryBackoffRemaining(tr, completed.Add(tc.since))
+			if got < tc.wantMin || got > tc.wantMax {
+				t.Errorf("RetryBackoffRemaining() = %s, want between %s and %s", got, tc.wantMin, tc.wantMax)
+			}
+			if again := rprt.RetryBackoffRemaining(tr, completed.Add(tc.since)); again != got {
+				t.Errorf("RetryBackoffRemaining() isn't deterministic: got %s then %s", got, again)
+			}
+			state := PipelineRunState{rprt}
+			if stateGot := state.RetryBackoffRemaining(completed.Add(tc.since)); stateGot != got {
+				t.Errorf("PipelineRunState.RetryBackoffRemaining() = %s, want %s", stateGot, got)
+			}
+		})
+	}
+}
+
+func TestPipelineRunFacts_ToleratedFailures(t *testing.T) {
+	lint := v1beta1.PipelineTask{
+		Name:    "lint",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		OnError: v1beta1.PipelineTaskContinue,
+	}
+	deploy := v1beta1.PipelineTask{
+		Name:     "deploy",
+		TaskRef:  &v1beta1.TaskRef{Name: "task"},
+		RunAfter: []string{"lint"},
+	}
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "somepipelinerun"}}
+	newFacts := func(t *testing.T, state PipelineRunState) *PipelineRunFacts {
+		t.Helper()
+		d, err := dagFromState(state)
+		if err != nil {
+			t.Fatalf("Unexpected error while buildig DAG for state %v: %v", state, err)
+		}
+		return &PipelineRunFacts{
+			State:           state,
+			TasksGraph:      d,
+			FinalTasksGraph: &dag.Graph{},
+		}
+	}
+
+	t.Run("dependent task runs after a tolerated failure", func(t *testing.T) {
+		facts := newFacts(t, PipelineRunState{{
+			PipelineTask: &lint,
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      makeFailed(trs[0]),
+		}, {
+			PipelineTask: &deploy,
+			TaskRunName:  "pipelinerun-mytask2",
+		}})
+		if facts.IsStopping() {
+			t.Errorf("Expected the PipelineRun not to be stopping after a tolerated failure")
+		}
+		next, err := facts.DAGExecutionQueue()
+		if err != nil {
+			t.Fatalf("Unexpected error getting the DAG execution queue: %v", err)
+		}
+		if len(next) != 1 || next[0].PipelineTask.Name != "deploy" {
+			t.Errorf("Expected the task deploy to be scheduled but got %v", next)
+		}
+		wantCondition := &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1beta1.PipelineRunReasonRunning.String(),
+			Message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0, Failures Tolerated: 1",
+		}
+		if d := cmp.Diff(wantCondition, facts.GetPipelineConditionStatus(pr, zap.NewNop().Sugar())); d != "" {
+			t.Errorf("Mismatch in condition %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("pipelinerun completes with a tolerated failure", func(t *testing.T) {
+		facts := newFacts(t, PipelineRunState{{
+			PipelineTask: &lint,
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      withReason(makeFailed(trs[0]), v1beta1.TaskRunReasonTimedOut.String()),
+		}, {
+			PipelineTask: &deploy,
+			TaskRunName:  "pipelinerun-mytask2",
+			TaskRun:      makeSucceeded(trs[1]),
+		}})
+		wantCondition := &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  v1beta1.PipelineRunReasonCompleted.String(),
+			Message: "Tasks Completed: 2 (Failed: 0, Cancelled 0), Skipped: 0, Failures Tolerated: 1",
+		}
+		if d := cmp.Diff(wantCondition, facts.GetPipelineConditionStatus(pr, zap.NewNop().Sugar())); d != "" {
+			t.Errorf("Mismatch in condition %s", diff.PrintWantGot(d))
+		}
+		wantTolerated := []v1beta1.ToleratedFailure{{
+			Name:   "lint",
+			Reason: v1beta1.TaskRunReasonTimedOut.String(),
+		}}
+		if d := cmp.Diff(wantTolerated, facts.GetToleratedFailures()); d != "" {
+			t.Errorf("Mismatch in tolerated failures %s", diff.PrintWantGot(d))
+		}
+		status := facts.GetPipelineTaskStatus()
+		if got := status[v1beta1.PipelineTasksAggregateStatus]; got != v1beta1.PipelineRunReasonCompleted.String() {
+			t.Errorf("Expected the aggregate status of the tasks to be %s but got %s", v1beta1.PipelineRunReasonCompleted, got)
+		}
+		if got := status["tasks.lint.status"]; got != v1beta1.TaskRunReasonFailed.String() {
+			t.Errorf("Expected the status of the task lint to be %s but got %s", v1beta1.TaskRunReasonFailed, got)
+		}
+	})
+
+	t.Run("dependent task consuming the results of a tolerated failure is skipped", func(t *testing.T) {
+		report := v1beta1.PipelineTask{
+			Name:    "report",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Params: []v1beta1.Param{{
+				Name:  "findings",
+				Value: *v1beta1.NewArrayOrString("$(tasks.lint.results.findings)"),
+			}},
+		}
+		facts := newFacts(t, PipelineRunState{{
+			PipelineTask: &lint,
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      makeFailed(trs[0]),
+		}, {
+			PipelineTask: &report,
+			TaskRunName:  "pipelinerun-mytask2",
+		}})
+		want := TaskSkipStatus{IsSkipped: true, SkippingReason: MissingResultsSkip}
+		if d := cmp.Diff(want, facts.State[1].Skip(facts)); d != "" {
+			t.Errorf("Mismatch in skip status %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("cancelled task is not tolerated", func(t *testing.T) {
+		facts := newFacts(t, PipelineRunState{{
+			PipelineTask: &lint,
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      withCancelled(makeFailed(trs[0])),
+		}, {
+			PipelineTask: &deploy,
+			TaskRunName:  "pipelinerun-mytask2",
+		}})
+		if !facts.IsStopping() {
+			t.Errorf("Expected the PipelineRun to be stopping after a cancelled task")
+		}
+		if tolerated := facts.GetToleratedFailures(); len(tolerated) != 0 {
+			t.Errorf("Expected no tolerated failures but got %v", tolerated)
+		}
+	})
+}
+
+func withReason(tr *v1beta1.TaskRun, reason string) *v1beta1.TaskRun {
+	tr.Status.Conditions[0].Reason = reason
+	return tr
+}
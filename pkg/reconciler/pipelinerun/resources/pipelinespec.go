@@ -34,7 +34,7 @@ func GetPipelineData(ctx context.Context, pipelineRun *v1beta1.PipelineRun, getP
 	pipelineMeta := metav1.ObjectMeta{}
 	pipelineSpec := v1beta1.PipelineSpec{}
 	switch {
-	case pipelineRun.Spec.PipelineRef != nil && pipelineRun.Spec.PipelineRef.Name != "":
+	case pipelineRun.Spec.PipelineRef != nil && (pipelineRun.Spec.PipelineRef.Name != "" || pipelineRun.Spec.PipelineRef.Resolver != ""):
 		// Get related pipeline for pipelinerun
 		t, err := getPipeline(ctx, pipelineRun.Spec.PipelineRef.Name)
 		if err != nil {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
 )
 
 // ResolvedResultRefs represents all of the ResolvedResultRef for a pipeline task
@@ -35,6 +36,7 @@ type ResolvedResultRef struct {
 	ResultReference v1beta1.ResultRef
 	FromTaskRun     string
 	FromRun         string
+	FromPipelineRun string
 }
 
 // ResolveResultRef resolves any ResultReference that are found in the target ResolvedPipelineRunTask
@@ -104,6 +106,9 @@ func removeDup(refs ResolvedResultRefs) ResolvedResultRefs {
 		if order[i].Result > order[j].Result {
 			return false
 		}
+		if order[i].Property > order[j].Property {
+			return false
+		}
 		return true
 	})
 
@@ -137,16 +142,27 @@ func resolveResultRef(pipelineState PipelineRunState, resultRef *v1beta1.ResultR
 	if !referencedPipelineTask.IsSuccessful() {
 		return nil, resultRef.PipelineTask, fmt.Errorf("task %q referenced by result was not successful", referencedPipelineTask.PipelineTask.Name)
 	}
+	if referencedPipelineTask.IsMatrixed() {
+		return nil, resultRef.PipelineTask, fmt.Errorf("results of the matrixed task %q cannot be referenced", referencedPipelineTask.PipelineTask.Name)
+	}
 
-	var runName, taskRunName, resultValue string
+	var runName, taskRunName, pipelineRunName string
+	var resultValue v1beta1.ArrayOrString
 	var err error
-	if referencedPipelineTask.IsCustomTask() {
+	switch {
+	case referencedPipelineTask.IsChildPipeline():
+		pipelineRunName = referencedPipelineTask.ChildPipelineRun.Name
+		resultValue, err = findPipelineResultForParam(referencedPipelineTask.ChildPipelineRun, resultRef)
+		if err != nil {
+			return nil, resultRef.PipelineTask, err
+		}
+	case referencedPipelineTask.IsCustomTask():
 		runName = referencedPipelineTask.Run.Name
 		resultValue, err = findRunResultForParam(referencedPipelineTask.Run, resultRef)
 		if err != nil {
 			return nil, resultRef.PipelineTask, err
 		}
-	} else {
+	default:
 		taskRunName = referencedPipelineTask.TaskRun.Name
 		resultValue, err = findTaskResultForParam(referencedPipelineTask.TaskRun, resultRef)
 		if err != nil {
@@ -154,43 +170,88 @@ func resolveResultRef(pipelineState PipelineRunState, resultRef *v1beta1.ResultR
 		}
 	}
 
+	if resultRef.Property != "" {
+		propertyValue, ok := resultValue.ObjectVal[resultRef.Property]
+		if resultValue.Type != v1beta1.ParamTypeObject || !ok {
+			return nil, resultRef.PipelineTask, fmt.Errorf("Could not find key %s in object result %s for task %s", resultRef.Property, resultRef.Result, resultRef.PipelineTask)
+		}
+		resultValue = *v1beta1.NewArrayOrString(propertyValue)
+	}
+
 	return &ResolvedResultRef{
-		Value:           *v1beta1.NewArrayOrString(resultValue),
+		Value:           resultValue,
 		FromTaskRun:     taskRunName,
 		FromRun:         runName,
+		FromPipelineRun: pipelineRunName,
 		ResultReference: *resultRef,
 	}, "", nil
 }
 
-func findRunResultForParam(run *v1alpha1.Run, reference *v1beta1.ResultRef) (string, error) {
+func findRunResultForParam(run *v1alpha1.Run, reference *v1beta1.ResultRef) (v1beta1.ArrayOrString, error) {
 	results := run.Status.Results
+	for _, result := range results {
+		if result.Name == reference.Result {
+			return runResultValueToArrayOrString(result.Value), nil
+		}
+	}
+	return v1beta1.ArrayOrString{}, fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
+}
+
+// runResultValueToArrayOrString returns the value of a Run result as an ArrayOrString of the same type.
+func runResultValueToArrayOrString(value runv1alpha1.RunResultValue) v1beta1.ArrayOrString {
+	switch value.Type {
+	case runv1alpha1.RunResultTypeArray:
+		return v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: append([]string{}, value.ArrayVal...)}
+	case runv1alpha1.RunResultTypeObject:
+		return *v1beta1.NewObject(value.ObjectVal).DeepCopy()
+	default:
+		return *v1beta1.NewArrayOrString(value.StringVal)
+	}
+}
+
+func findPipelineResultForParam(pipelineRun *v1beta1.PipelineRun, reference *v1beta1.ResultRef) (v1beta1.ArrayOrString, error) {
+	results := pipelineRun.Status.PipelineResults
 	for _, result := range results {
 		if result.Name == reference.Result {
 			return result.Value, nil
 		}
 	}
-	return "", fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
+	return v1beta1.ArrayOrString{}, fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
 }
 
-func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultRef) (string, error) {
+func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultRef) (v1beta1.ArrayOrString, error) {
 	results := taskRun.Status.TaskRunStatusFields.TaskRunResults
 	for _, result := range results {
 		if result.Name == reference.Result {
 			return result.Value, nil
 		}
 	}
-	return "", fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
+	return v1beta1.ArrayOrString{}, fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
 }
 
 func (rs ResolvedResultRefs) getStringReplacements() map[string]string {
 	replacements := map[string]string{}
 	for _, r := range rs {
-		replaceTarget := r.getReplaceTarget()
-		replacements[replaceTarget] = r.Value.StringVal
+		if r.Value.Type == v1beta1.ParamTypeString {
+			replacements[r.getReplaceTarget()] = r.Value.StringVal
+		}
+	}
+	return replacements
+}
+
+func (rs ResolvedResultRefs) getArrayReplacements() map[string][]string {
+	replacements := map[string][]string{}
+	for _, r := range rs {
+		if r.Value.Type == v1beta1.ParamTypeArray {
+			replacements[r.getReplaceTarget()] = r.Value.ArrayVal
+		}
 	}
 	return replacements
 }
 
 func (r *ResolvedResultRef) getReplaceTarget() string {
+	if r.ResultReference.Property != "" {
+		return fmt.Sprintf("%s.%s.%s.%s.%s", v1beta1.ResultTaskPart, r.ResultReference.PipelineTask, v1beta1.ResultResultPart, r.ResultReference.Result, r.ResultReference.Property)
+	}
 	return fmt.Sprintf("%s.%s.%s.%s", v1beta1.ResultTaskPart, r.ResultReference.PipelineTask, v1beta1.ResultResultPart, r.ResultReference.Result)
 }
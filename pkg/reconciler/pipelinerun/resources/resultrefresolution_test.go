@@ -10,12 +10,14 @@ import (
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 var (
@@ -89,7 +91,7 @@ var pipelineRunState = PipelineRunState{{
 			RunStatusFields: v1alpha1.RunStatusFields{
 				Results: []v1alpha1.RunResult{{
 					Name:  "aResult",
-					Value: "aResultValue",
+					Value: *runv1alpha1.NewRunResultValue("aResultValue"),
 				}},
 			},
 		},
@@ -107,6 +109,35 @@ var pipelineRunState = PipelineRunState{{
 			Value: *v1beta1.NewArrayOrString("$(tasks.aCustomPipelineTask.results.aResult)"),
 		}},
 	},
+}, {
+	ChildPipelineRunName: "aPipelineRun",
+	ChildPipelineRun: &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "aPipelineRun"},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{successCondition},
+			},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineResults: []v1beta1.PipelineRunResult{{
+					Name:  "aResult",
+					Value: *v1beta1.NewArrayOrString("aResultValue"),
+				}},
+			},
+		},
+	},
+	PipelineTask: &v1beta1.PipelineTask{
+		Name:        "aChildPipelineTask",
+		PipelineRef: &v1beta1.PipelineRef{Name: "aPipeline"},
+	},
+}, {
+	PipelineTask: &v1beta1.PipelineTask{
+		Name:    "bTask",
+		TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+		Params: []v1beta1.Param{{
+			Name:  "bParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aChildPipelineTask.results.aResult)"),
+		}},
+	},
 }}
 
 func TestTaskParamResolver_ResolveResultRefs(t *testing.T) {
@@ -301,7 +332,7 @@ func TestTaskParamResolver_ResolveResultRefs(t *testing.T) {
 					RunStatusFields: v1alpha1.RunStatusFields{
 						Results: []v1alpha1.RunResult{{
 							Name:  "aResult",
-							Value: "aResultValue",
+							Value: *runv1alpha1.NewRunResultValue("aResultValue"),
 						}},
 					},
 				},
@@ -567,6 +598,19 @@ func TestResolveResultRef(t *testing.T) {
 			FromRun: "aRun",
 		}},
 		wantErr: false,
+	}, {
+		name:             "Test successful result references resolution - params - child PipelineRun",
+		pipelineRunState: pipelineRunState,
+		target:           pipelineRunState[8],
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("aResultValue"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aChildPipelineTask",
+				Result:       "aResult",
+			},
+			FromPipelineRun: "aPipelineRun",
+		}},
+		wantErr: false,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			got, pt, err := ResolveResultRef(tt.pipelineRunState, tt.target)
@@ -594,3 +638,173 @@ func TestResolveResultRef(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveResultRef_ArrayAndObjectResults(t *testing.T) {
+	producer := &ResolvedPipelineRunTask{
+		TaskRunName: "aTaskRun",
+		TaskRun: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "aTaskRun"},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{successCondition}},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					TaskRunResults: []v1beta1.TaskRunResult{{
+						Name:  "anArrayResult",
+						Type:  v1beta1.ResultsTypeArray,
+						Value: *v1beta1.NewArrayOrString("a", "b"),
+					}, {
+						Name:  "anObjectResult",
+						Type:  v1beta1.ResultsTypeObject,
+						Value: *v1beta1.NewObject(map[string]string{"url": "abc"}),
+					}},
+				},
+			},
+		},
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "aTask",
+			TaskRef: &v1beta1.TaskRef{Name: "aTask"},
+		},
+	}
+	for _, tt := range []struct {
+		name    string
+		params  []v1beta1.Param
+		want    ResolvedResultRefs
+		wantErr bool
+	}{{
+		name: "whole array result",
+		params: []v1beta1.Param{{
+			Name:  "bParam",
+			Value: *v1beta1.NewArrayOrString("first", "$(tasks.aTask.results.anArrayResult[*])"),
+		}},
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("a", "b"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "anArrayResult",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+	}, {
+		name: "object result key",
+		params: []v1beta1.Param{{
+			Name:  "bParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.anObjectResult.url)"),
+		}},
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("abc"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "anObjectResult",
+				Property:     "url",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+	}, {
+		name: "missing object result key",
+		params: []v1beta1.Param{{
+			Name:  "bParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.anObjectResult.commit)"),
+		}},
+		wantErr: true,
+	}, {
+		name: "key of an array result",
+		params: []v1beta1.Param{{
+			Name:  "bParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.anArrayResult.url)"),
+		}},
+		wantErr: true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ResolvedPipelineRunTask{
+				PipelineTask: &v1beta1.PipelineTask{
+					Name:    "bTask",
+					TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+					Params:  tt.params,
+				},
+			}
+			got, _, err := ResolveResultRef(PipelineRunState{producer, target}, target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveResultRef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Fatalf("ResolveResultRef %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolveResultRef_ArrayAndObjectRunResults(t *testing.T) {
+	producer := &ResolvedPipelineRunTask{
+		CustomTask: true,
+		RunName:    "aRun",
+		Run: &v1alpha1.Run{
+			ObjectMeta: metav1.ObjectMeta{Name: "aRun"},
+			Status: v1alpha1.RunStatus{
+				Status: duckv1.Status{Conditions: []apis.Condition{successCondition}},
+				RunStatusFields: v1alpha1.RunStatusFields{
+					Results: []v1alpha1.RunResult{{
+						Name:  "anArrayResult",
+						Value: *runv1alpha1.NewRunResultValue("a", "b"),
+					}, {
+						Name:  "anObjectResult",
+						Value: *runv1alpha1.NewRunResultObject(map[string]string{"url": "abc"}),
+					}},
+				},
+			},
+		},
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "aCustomPipelineTask",
+			TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example", Name: "aTask"},
+		},
+	}
+	for _, tt := range []struct {
+		name   string
+		params []v1beta1.Param
+		want   ResolvedResultRefs
+	}{{
+		name: "whole array result",
+		params: []v1beta1.Param{{
+			Name:  "bParam",
+			Value: *v1beta1.NewArrayOrString("first", "$(tasks.aCustomPipelineTask.results.anArrayResult[*])"),
+		}},
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("a", "b"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aCustomPipelineTask",
+				Result:       "anArrayResult",
+			},
+			FromRun: "aRun",
+		}},
+	}, {
+		name: "object result key",
+		params: []v1beta1.Param{{
+			Name:  "bParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aCustomPipelineTask.results.anObjectResult.url)"),
+		}},
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("abc"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aCustomPipelineTask",
+				Result:       "anObjectResult",
+				Property:     "url",
+			},
+			FromRun: "aRun",
+		}},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ResolvedPipelineRunTask{
+				PipelineTask: &v1beta1.PipelineTask{
+					Name:    "bTask",
+					TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+					Params:  tt.params,
+				},
+			}
+			got, _, err := ResolveResultRef(PipelineRunState{producer, target}, target)
+			if err != nil {
+				t.Fatalf("ResolveResultRef() error = %v", err)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Fatalf("ResolveResultRef %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
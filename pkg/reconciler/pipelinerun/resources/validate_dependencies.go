@@ -47,7 +47,7 @@ func ValidatePipelineResults(ps *v1beta1.PipelineSpec, state PipelineRunState) e
 	ptMap := state.ToMap()
 	for _, result := range ps.Results {
 		expressions, _ := v1beta1.GetVarSubstitutionExpressionsForPipelineResult(result)
-		refs := v1beta1.NewResultRefs(expressions)
+		refs := v1beta1.NewPipelineResultRefs(expressions)
 		for _, ref := range refs {
 			if err := validateResultRef(ref, ptMap); err != nil {
 				return fmt.Errorf("invalid pipeline result %q: %s", result.Name, err)
@@ -71,6 +71,9 @@ func validateResultRef(ref *v1beta1.ResultRef, ptMap map[string]*ResolvedPipelin
 		// custom task executes.
 		return nil
 	}
+	if ptMap[ref.PipelineTask].IsChildPipeline() {
+		return validatePipelineResultRef(ref, ptMap[ref.PipelineTask].PipelineTask)
+	}
 	if ptMap[ref.PipelineTask].ResolvedTaskResources == nil || ptMap[ref.PipelineTask].ResolvedTaskResources.TaskSpec == nil {
 		return fmt.Errorf("unable to validate result referencing pipeline task %q: task spec not found", ref.PipelineTask)
 	}
@@ -86,6 +89,21 @@ func validateResultRef(ref *v1beta1.ResultRef, ptMap map[string]*ResolvedPipelin
 	return nil
 }
 
+// validatePipelineResultRef searches the results of the Pipeline run by the given PipelineTask for
+// the result referenced by the ResultRef. A referenced Pipeline is only fetched by its child
+// PipelineRun, so its results can only be validated when the Pipeline is embedded.
+func validatePipelineResultRef(ref *v1beta1.ResultRef, pt *v1beta1.PipelineTask) error {
+	if pt.PipelineSpec == nil {
+		return nil
+	}
+	for _, pipelineResult := range pt.PipelineSpec.Results {
+		if pipelineResult.Name == ref.Result {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not a named result returned by pipeline task %q", ref.Result, ref.PipelineTask)
+}
+
 // validateOptionalWorkspaces validates that any workspaces in the Pipeline that are
 // marked as optional are also marked optional in the Tasks that receive them. This
 // prevents a situation where a Task requires a workspace but a Pipeline does not offer
@@ -101,6 +119,10 @@ func ValidateOptionalWorkspaces(pipelineWorkspaces []v1beta1.PipelineWorkspaceDe
 	for _, rprt := range state {
 		for _, pws := range rprt.PipelineTask.Workspaces {
 			if optionalWorkspaces.Has(pws.Workspace) {
+				if rprt.ResolvedTaskResources == nil || rprt.ResolvedTaskResources.TaskSpec == nil {
+					// custom tasks and child pipelines don't declare Task workspaces
+					continue
+				}
 				for _, tws := range rprt.ResolvedTaskResources.TaskSpec.Workspaces {
 					if tws.Name == pws.Name {
 						if !tws.Optional {
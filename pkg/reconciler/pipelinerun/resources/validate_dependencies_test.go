@@ -86,6 +86,43 @@ func TestValidatePipelineTaskResults_ValidStates(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		desc: "correct use of child pipeline results",
+		state: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt1",
+				PipelineSpec: &v1beta1.PipelineSpec{
+					Results: []v1beta1.PipelineResult{{
+						Name:  "result",
+						Value: *v1beta1.NewArrayOrString("$(tasks.t.results.result)"),
+					}},
+				},
+			},
+		}, {
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt2",
+				Params: []v1beta1.Param{{
+					Name:  "p",
+					Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.result)"),
+				}},
+			},
+		}},
+	}, {
+		desc: "referenced pipeline results are not validated",
+		state: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:        "pt1",
+				PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			},
+		}, {
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt2",
+				Params: []v1beta1.Param{{
+					Name:  "p",
+					Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.a-referenced-pipeline-result)"),
+				}},
+			},
+		}},
 	}} {
 		t.Run(tc.desc, func(t *testing.T) {
 			if err := ValidatePipelineTaskResults(tc.state); err != nil {
@@ -217,6 +254,34 @@ func TestValidatePipelineTaskResults_IncorrectResultName(t *testing.T) {
 	}
 }
 
+// TestValidatePipelineTaskResults_IncorrectChildPipelineResultName tests that a result variable
+// with a Result misnamed in an embedded child Pipeline is correctly caught by the validatePipelineTaskResults func.
+func TestValidatePipelineTaskResults_IncorrectChildPipelineResultName(t *testing.T) {
+	state := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name: "pt1",
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Results: []v1beta1.PipelineResult{{
+					Name:  "not-the-result-youre-looking-for",
+					Value: *v1beta1.NewArrayOrString("$(tasks.t.results.result)"),
+				}},
+			},
+		},
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{
+			Name: "pt2",
+			Params: []v1beta1.Param{{
+				Name:  "p1",
+				Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.result1)"),
+			}},
+		},
+	}}
+	err := ValidatePipelineTaskResults(state)
+	if err == nil || !strings.Contains(err.Error(), `"result1" is not a named result returned by pipeline task "pt1"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 // TestValidatePipelineTaskResults_MissingTaskSpec tests that a malformed PipelineTask
 // with a name but no spec results in a validation error being returned.
 func TestValidatePipelineTaskResults_MissingTaskSpec(t *testing.T) {
@@ -256,7 +321,7 @@ func TestValidatePipelineResults_ValidStates(t *testing.T) {
 		spec: &v1beta1.PipelineSpec{
 			Results: []v1beta1.PipelineResult{{
 				Name:  "foo-result",
-				Value: "just a text pipeline result",
+				Value: *v1beta1.NewArrayOrString("just a text pipeline result"),
 			}},
 		},
 		state: nil,
@@ -265,7 +330,7 @@ func TestValidatePipelineResults_ValidStates(t *testing.T) {
 		spec: &v1beta1.PipelineSpec{
 			Results: []v1beta1.PipelineResult{{
 				Name:  "foo-result",
-				Value: "test $(tasks.pt1.results.result1) 123",
+				Value: *v1beta1.NewArrayOrString("test $(tasks.pt1.results.result1) 123"),
 			}},
 		},
 		state: PipelineRunState{{
@@ -281,6 +346,27 @@ func TestValidatePipelineResults_ValidStates(t *testing.T) {
 				},
 			},
 		}},
+	}, {
+		desc: "correct use of finally task and result names",
+		spec: &v1beta1.PipelineSpec{
+			Results: []v1beta1.PipelineResult{{
+				Name:  "foo-result",
+				Value: *v1beta1.NewArrayOrString("$(finally.final-task.results.result1)"),
+			}},
+		},
+		state: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "final-task",
+			},
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskName: "t",
+				TaskSpec: &v1beta1.TaskSpec{
+					Results: []v1beta1.TaskResult{{
+						Name: "result1",
+					}},
+				},
+			},
+		}},
 	}} {
 		t.Run(tc.desc, func(t *testing.T) {
 			if err := ValidatePipelineResults(tc.spec, tc.state); err != nil {
@@ -296,7 +382,7 @@ func TestValidatePipelineResults_IncorrectTaskName(t *testing.T) {
 	spec := &v1beta1.PipelineSpec{
 		Results: []v1beta1.PipelineResult{{
 			Name:  "foo-result",
-			Value: "$(tasks.pt1.results.result1)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.result1)"),
 		}},
 	}
 	state := PipelineRunState{}
@@ -312,7 +398,7 @@ func TestValidatePipelineResults_IncorrectResultName(t *testing.T) {
 	spec := &v1beta1.PipelineSpec{
 		Results: []v1beta1.PipelineResult{{
 			Name:  "foo-result",
-			Value: "$(tasks.pt1.results.result1)",
+			Value: *v1beta1.NewArrayOrString("$(tasks.pt1.results.result1)"),
 		}},
 	}
 	state := PipelineRunState{{
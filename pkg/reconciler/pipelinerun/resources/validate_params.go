@@ -73,3 +73,26 @@ func ValidateRequiredParametersProvided(pipelineParameters *[]v1beta1.ParamSpec,
 	}
 	return nil
 }
+
+// ValidateObjectParamRequiredKeys validates that the values provided by the PipelineRun for object parameters
+// contain all the keys declared in the properties of the Pipeline's parameters which have no default value for them.
+func ValidateObjectParamRequiredKeys(pipelineParameters []v1beta1.ParamSpec, pipelineRunParameters []v1beta1.Param) error {
+	missingKeys := map[string][]string{}
+	for _, param := range pipelineRunParameters {
+		if param.Value.Type != v1beta1.ParamTypeObject {
+			continue
+		}
+		for _, paramSpec := range pipelineParameters {
+			if paramSpec.Name != param.Name || paramSpec.Type != v1beta1.ParamTypeObject {
+				continue
+			}
+			if keys := paramSpec.MissingObjectKeys(param.Value.ObjectVal); len(keys) != 0 {
+				missingKeys[param.Name] = keys
+			}
+		}
+	}
+	if len(missingKeys) != 0 {
+		return fmt.Errorf("PipelineRun missing object keys for parameters: %v", missingKeys)
+	}
+	return nil
+}
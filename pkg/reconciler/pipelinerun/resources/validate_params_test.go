@@ -188,3 +188,48 @@ func TestValidateRequiredParametersProvided_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateObjectParamRequiredKeys(t *testing.T) {
+	gitProperties := map[string]v1beta1.PropertySpec{
+		"url":      {Type: v1beta1.ParamTypeString},
+		"revision": {Type: v1beta1.ParamTypeString},
+	}
+	for _, tc := range []struct {
+		name    string
+		pp      []v1beta1.ParamSpec
+		prp     []v1beta1.Param
+		wantErr bool
+	}{{
+		name: "all keys provided",
+		pp: []v1beta1.ParamSpec{
+			{Name: "git", Type: v1beta1.ParamTypeObject, Properties: gitProperties},
+		},
+		prp: []v1beta1.Param{
+			{Name: "git", Value: *v1beta1.NewObject(map[string]string{"url": "abc", "revision": "main"})},
+		},
+	}, {
+		name: "missing keys provided in default",
+		pp: []v1beta1.ParamSpec{
+			{Name: "git", Type: v1beta1.ParamTypeObject, Properties: gitProperties, Default: v1beta1.NewObject(map[string]string{"url": "abc", "revision": "main"})},
+		},
+		prp: []v1beta1.Param{
+			{Name: "git", Value: *v1beta1.NewObject(map[string]string{"revision": "v1"})},
+		},
+	}, {
+		name: "missing keys",
+		pp: []v1beta1.ParamSpec{
+			{Name: "git", Type: v1beta1.ParamTypeObject, Properties: gitProperties},
+		},
+		prp: []v1beta1.Param{
+			{Name: "git", Value: *v1beta1.NewObject(map[string]string{"url": "abc"})},
+		},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateObjectParamRequiredKeys(tc.pp, tc.prp)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateObjectParamRequiredKeys() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
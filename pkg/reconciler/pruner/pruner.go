@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// ReasonTTL is the reason recorded for the runs deleted because they outlived the ttl.
+	ReasonTTL = "ttl"
+	// ReasonKeep is the reason recorded for the runs deleted because more recent runs
+	// of the same Task or Pipeline are kept.
+	ReasonKeep = "keep"
+)
+
+// ConfigFor returns the pruner configuration of the runs in the namespace, which is the
+// configuration of the pruner ConfigMap overridden by the annotations of the namespace.
+// The namespace is read from the informer cache.
+func ConfigFor(ctx context.Context, namespaceLister corev1listers.NamespaceLister, namespace string) (*config.Pruner, error) {
+	cfg := config.FromContextOrDefaults(ctx).Pruner
+	if cfg == nil {
+		cfg = &config.Pruner{}
+	}
+	ns, err := namespaceLister.Get(namespace)
+	if apierrors.IsNotFound(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	return cfg.WithNamespaceOverrides(ns.Annotations)
+}
+
+// Prune deletes the finished runs returned by finished that aren't among the ones kept by
+// the pruner configuration of the namespace of run, and deletes run once it outlives the
+// ttl, in which case a requeue is returned until it does. finished returns the finished
+// runs counted along with run, the runs of the same Task or Pipeline, and is only called
+// when the configuration keeps a number of runs. deleteRun deletes the named run for the
+// given reason.
+func Prune(ctx context.Context, namespaceLister corev1listers.NamespaceLister, namespace string, run Run, finished func() ([]Run, error), deleteRun func(name, reason string) error) error {
+	cfg, err := ConfigFor(ctx, namespaceLister, namespace)
+	if err != nil {
+		return err
+	}
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	if cfg.Keep > 0 {
+		runs, err := finished()
+		if err != nil {
+			return err
+		}
+		for _, name := range Excess(runs, cfg.Keep) {
+			if err := deleteRun(name, ReasonKeep); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cfg.TTL > 0 {
+		if remaining := Remaining(run.CompletionTime, cfg.TTL, time.Now()); remaining > 0 {
+			return controller.NewRequeueAfter(remaining)
+		}
+		return deleteRun(run.Name, ReasonTTL)
+	}
+	return nil
+}
+
+// Run is a finished run considered by the pruner.
+type Run struct {
+	Name           string
+	CompletionTime time.Time
+}
+
+// Excess returns the names of the runs that aren't among the keep most recently
+// completed ones, oldest first.
+func Excess(runs []Run, keep int) []string {
+	if len(runs) <= keep {
+		return nil
+	}
+	sorted := make([]Run, len(runs))
+	copy(sorted, runs)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].CompletionTime.Equal(sorted[j].CompletionTime) {
+			return sorted[i].CompletionTime.Before(sorted[j].CompletionTime)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	var names []string
+	for _, run := range sorted[:len(sorted)-keep] {
+		names = append(names, run.Name)
+	}
+	return names
+}
+
+// Remaining returns how long a run that completed at completionTime is kept
+// before it outlives the ttl.
+func Remaining(completionTime time.Time, ttl time.Duration, now time.Time) time.Duration {
+	return completionTime.Add(ttl).Sub(now)
+}
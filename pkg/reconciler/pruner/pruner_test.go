@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+)
+
+func TestConfigFor(t *testing.T) {
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Pruner: &config.Pruner{Keep: 5, TTL: time.Hour},
+	})
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range []*corev1.Namespace{{
+		ObjectMeta: metav1.ObjectMeta{Name: "plain"},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "overridden",
+			Annotations: map[string]string{config.PrunerKeepAnnotation: "2"},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "invalid",
+			Annotations: map[string]string{config.PrunerTTLAnnotation: "never"},
+		},
+	}} {
+		if err := indexer.Add(ns); err != nil {
+			t.Fatalf("error adding namespace %s: %v", ns.Name, err)
+		}
+	}
+	namespaceLister := corev1listers.NewNamespaceLister(indexer)
+
+	for _, tc := range []struct {
+		namespace string
+		want      *config.Pruner
+		wantErr   bool
+	}{{
+		namespace: "plain",
+		want:      &config.Pruner{Keep: 5, TTL: time.Hour},
+	}, {
+		namespace: "overridden",
+		want:      &config.Pruner{Keep: 2, TTL: time.Hour},
+	}, {
+		namespace: "missing",
+		want:      &config.Pruner{Keep: 5, TTL: time.Hour},
+	}, {
+		namespace: "invalid",
+		wantErr:   true,
+	}} {
+		t.Run(tc.namespace, func(t *testing.T) {
+			got, err := ConfigFor(ctx, namespaceLister, tc.namespace)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected error but received nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigFor: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Now()
+	namespaceLister := corev1listers.NewNamespaceLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}))
+	run := Run{Name: "run", CompletionTime: now.Add(-time.Hour)}
+	others := []Run{run, {Name: "older", CompletionTime: now.Add(-2 * time.Hour)}}
+
+	for _, tc := range []struct {
+		name        string
+		pruner      *config.Pruner
+		wantDeleted []string
+		wantRequeue bool
+	}{{
+		name:   "disabled",
+		pruner: &config.Pruner{},
+	}, {
+		name:        "keep",
+		pruner:      &config.Pruner{Keep: 1},
+		wantDeleted: []string{"older/keep"},
+	}, {
+		name:        "ttl outlived",
+		pruner:      &config.Pruner{TTL: 30 * time.Minute},
+		wantDeleted: []string{"run/ttl"},
+	}, {
+		name:        "ttl not outlived",
+		pruner:      &config.Pruner{Keep: 1, TTL: 2 * time.Hour},
+		wantDeleted: []string{"older/keep"},
+		wantRequeue: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := config.ToContext(context.Background(), &config.Config{Pruner: tc.pruner})
+			listed := false
+			var deleted []string
+			err := Prune(ctx, namespaceLister, "ns", run, func() ([]Run, error) {
+				listed = true
+				return others, nil
+			}, func(name, reason string) error {
+				deleted = append(deleted, name+"/"+reason)
+				return nil
+			})
+			if requeue, _ := controller.IsRequeueKey(err); requeue != tc.wantRequeue {
+				t.Errorf("Prune() = %v, want a requeue: %t", err, tc.wantRequeue)
+			} else if !requeue && err != nil {
+				t.Fatalf("Prune() = %v", err)
+			}
+			if d := cmp.Diff(tc.wantDeleted, deleted); d != "" {
+				t.Errorf("deleted %s", diff.PrintWantGot(d))
+			}
+			if wantListed := tc.pruner.Keep > 0; listed != wantListed {
+				t.Errorf("listed the finished runs: %t, want %t", listed, wantListed)
+			}
+		})
+	}
+}
+
+func TestExcess(t *testing.T) {
+	now := time.Now()
+	runs := []Run{
+		{Name: "newest", CompletionTime: now},
+		{Name: "oldest", CompletionTime: now.Add(-3 * time.Hour)},
+		{Name: "older-b", CompletionTime: now.Add(-2 * time.Hour)},
+		{Name: "older-a", CompletionTime: now.Add(-2 * time.Hour)},
+	}
+	for _, tc := range []struct {
+		name string
+		keep int
+		want []string
+	}{{
+		name: "keep all",
+		keep: 4,
+	}, {
+		name: "keep more than all",
+		keep: 10,
+	}, {
+		name: "keep some",
+		keep: 2,
+		want: []string{"oldest", "older-a"},
+	}, {
+		name: "keep one",
+		keep: 1,
+		want: []string{"oldest", "older-a", "older-b"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, Excess(runs, tc.keep)); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	now := time.Now()
+	if got := Remaining(now.Add(-time.Hour), 3*time.Hour, now); got != 2*time.Hour {
+		t.Errorf("Remaining() = %s, want %s", got, 2*time.Hour)
+	}
+	if got := Remaining(now.Add(-2*time.Hour), time.Hour, now); got > 0 {
+		t.Errorf("Remaining() = %s, want a run that outlived its ttl", got)
+	}
+}
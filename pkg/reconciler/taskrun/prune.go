@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/logging"
+)
+
+// prune deletes the oldest finished TaskRuns of the Task of the finished TaskRun beyond
+// the number kept by the pruner, and deletes the TaskRun once it outlives the ttl of the
+// pruner. TaskRuns controlled by another object, such as the TaskRuns of a PipelineRun,
+// are deleted along with their owner and aren't pruned.
+func (c *Reconciler) prune(ctx context.Context, tr *v1beta1.TaskRun) error {
+	if metav1.GetControllerOf(tr) != nil || tr.Status.CompletionTime == nil {
+		return nil
+	}
+	finished := map[string]*v1beta1.TaskRun{tr.Name: tr}
+	return pruner.Prune(ctx, c.namespaceLister, tr.Namespace, pruner.Run{Name: tr.Name, CompletionTime: tr.Status.CompletionTime.Time}, func() ([]pruner.Run, error) {
+		return c.finishedTaskRuns(tr, finished)
+	}, func(name, reason string) error {
+		return c.deletePruned(ctx, finished[name], reason)
+	})
+}
+
+// finishedTaskRuns returns the finished TaskRuns of the Task of tr, which are also added to
+// finished by name.
+func (c *Reconciler) finishedTaskRuns(tr *v1beta1.TaskRun, finished map[string]*v1beta1.TaskRun) ([]pruner.Run, error) {
+	var selector labels.Selector
+	if name, ok := tr.Labels[pipeline.TaskLabelKey]; ok {
+		selector = labels.SelectorFromSet(labels.Set{pipeline.TaskLabelKey: name})
+	} else if name, ok := tr.Labels[pipeline.ClusterTaskLabelKey]; ok {
+		selector = labels.SelectorFromSet(labels.Set{pipeline.ClusterTaskLabelKey: name})
+	} else {
+		// The TaskRun has an embedded spec, so it isn't counted with other TaskRuns.
+		return nil, nil
+	}
+	trs, err := c.taskRunLister.TaskRuns(tr.Namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the TaskRuns of namespace %s: %w", tr.Namespace, err)
+	}
+
+	var runs []pruner.Run
+	for _, other := range trs {
+		if !other.IsDone() || other.Status.CompletionTime == nil || metav1.GetControllerOf(other) != nil {
+			continue
+		}
+		finished[other.Name] = other
+		runs = append(runs, pruner.Run{Name: other.Name, CompletionTime: other.Status.CompletionTime.Time})
+	}
+	return runs, nil
+}
+
+// deletePruned deletes the TaskRun pruned for the given reason and records it in the metrics.
+func (c *Reconciler) deletePruned(ctx context.Context, tr *v1beta1.TaskRun, reason string) error {
+	logger := logging.FromContext(ctx)
+	err := c.PipelineClientSet.TektonV1beta1().TaskRuns(tr.Namespace).Delete(ctx, tr.Name, metav1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to prune TaskRun %s/%s: %w", tr.Namespace, tr.Name, err)
+	}
+	logger.Infof("Pruned TaskRun %s/%s (%s)", tr.Namespace, tr.Name, reason)
+	if err := c.metrics.Pruned(tr, reason); err != nil {
+		logger.Warnf("Failed to log the metrics : %v", err)
+	}
+	return nil
+}
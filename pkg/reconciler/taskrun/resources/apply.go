@@ -44,11 +44,16 @@ func ApplyParameters(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1
 	// Set all the default stringReplacements
 	for _, p := range defaults {
 		if p.Default != nil {
-			if p.Default.Type == v1beta1.ParamTypeString {
+			switch p.Default.Type {
+			case v1beta1.ParamTypeString:
 				stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.StringVal
 				// FIXME(vdemeester) Remove that with deprecating v1beta1
 				stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default.StringVal
-			} else {
+			case v1beta1.ParamTypeObject:
+				for key, value := range p.Default.ObjectVal {
+					stringReplacements[fmt.Sprintf("params.%s.%s", p.Name, key)] = value
+				}
+			default:
 				arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.ArrayVal
 				// FIXME(vdemeester) Remove that with deprecating v1beta1
 				arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default.ArrayVal
@@ -57,11 +62,18 @@ func ApplyParameters(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1
 	}
 	// Set and overwrite params with the ones from the TaskRun
 	for _, p := range tr.Spec.Params {
-		if p.Value.Type == v1beta1.ParamTypeString {
+		switch p.Value.Type {
+		case v1beta1.ParamTypeString:
 			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
 			// FIXME(vdemeester) Remove that with deprecating v1beta1
 			stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value.StringVal
-		} else {
+		case v1beta1.ParamTypeObject:
+			// the keys of an object are substituted individually, and the keys that are
+			// not provided by the TaskRun keep their default value
+			for key, value := range p.Value.ObjectVal {
+				stringReplacements[fmt.Sprintf("params.%s.%s", p.Name, key)] = value
+			}
+		default:
 			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.ArrayVal
 			// FIXME(vdemeester) Remove that with deprecating v1beta1
 			arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value.ArrayVal
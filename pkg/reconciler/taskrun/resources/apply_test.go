@@ -575,6 +575,46 @@ func TestApplyParameters(t *testing.T) {
 	}
 }
 
+func TestApplyObjectParameters(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name: "git",
+			Type: v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{
+				"url":      {Type: v1beta1.ParamTypeString},
+				"revision": {Type: v1beta1.ParamTypeString},
+			},
+			Default: v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main"}),
+		}},
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "clone",
+				Image: "git",
+				Args:  []string{"clone", "$(params.git.url)", "--branch=$(params.git.revision)"},
+				Env:   []corev1.EnvVar{{Name: "URL", Value: "$(params.git.url)"}},
+			},
+			Script: "git checkout $(params.git.revision)",
+		}},
+	}
+	tr := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "git",
+				Value: *v1beta1.NewObject(map[string]string{"revision": "v0.1.0"}),
+			}},
+		},
+	}
+	want := applyMutation(ts, func(spec *v1beta1.TaskSpec) {
+		spec.Steps[0].Args = []string{"clone", "https://github.com/tektoncd/pipeline", "--branch=v0.1.0"}
+		spec.Steps[0].Env[0].Value = "https://github.com/tektoncd/pipeline"
+		spec.Steps[0].Script = "git checkout v0.1.0"
+	})
+	got := resources.ApplyParameters(ts, tr, ts.Params...)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParameters() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyResources(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetStepAction is a function used to retrieve the StepAction referenced by a Step.
+type GetStepAction func(context.Context, *v1beta1.Ref) (*v1alpha1.StepAction, error)
+
+// GetStepActionFunc is a factory function that returns a GetStepAction function. Like GetTaskFunc, it
+// requires a kubeclient, tektonclient, namespace, and service account in case it needs to find the
+// StepAction in cluster or authorize against an external repository. Each reference is looked up
+// in a remote image if it has a bundle, or in the cluster otherwise.
+func GetStepActionFunc(k8s kubernetes.Interface, tekton clientset.Interface, namespace, saName string) GetStepAction {
+	return func(ctx context.Context, ref *v1beta1.Ref) (*v1alpha1.StepAction, error) {
+		cfg := config.FromContextOrDefaults(ctx)
+		if cfg.FeatureFlags.EnableTektonOCIBundles && ref.Bundle != "" {
+			kc, err := k8schain.New(ctx, k8s, k8schain.Options{
+				Namespace:          namespace,
+				ServiceAccountName: saName,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get keychain: %w", err)
+			}
+			obj, err := oci.NewResolver(ref.Bundle, kc).Get("stepaction", ref.Name)
+			if err != nil {
+				return nil, err
+			}
+			stepAction, ok := obj.(*v1alpha1.StepAction)
+			if !ok {
+				return nil, fmt.Errorf("failed to convert obj %s into StepAction", obj.GetObjectKind().GroupVersionKind().String())
+			}
+			stepAction.SetDefaults(ctx)
+			return stepAction, nil
+		}
+
+		if namespace == "" {
+			return nil, fmt.Errorf("Must specify namespace to resolve reference to step action %s", ref.Name)
+		}
+		stepAction, err := tekton.TektonV1alpha1().StepActions(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		stepAction.SetDefaults(ctx)
+		return stepAction, nil
+	}
+}
+
+// GetStepActionsData returns the Steps of the TaskSpec, with each Step that references a StepAction
+// replaced by the Step the StepAction defines. The params passed by the Step are substituted in the
+// StepAction, and the results of the StepAction are added to the results of the TaskSpec.
+func GetStepActionsData(ctx context.Context, taskSpec v1beta1.TaskSpec, getStepAction GetStepAction) (*v1beta1.TaskSpec, error) {
+	ts := taskSpec.DeepCopy()
+	results := map[string]bool{}
+	for _, r := range ts.Results {
+		results[r.Name] = true
+	}
+	for i, s := range ts.Steps {
+		if s.Ref == nil {
+			continue
+		}
+		stepAction, err := getStepAction(ctx, s.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the StepAction %q of step %d: %w", s.Ref.Name, i, err)
+		}
+		step, err := stepFromStepAction(s, stepAction.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply the StepAction %q to step %d: %w", s.Ref.Name, i, err)
+		}
+		ts.Steps[i] = step
+
+		for _, r := range stepAction.Spec.Results {
+			if !results[r.Name] {
+				ts.Results = append(ts.Results, r)
+				results[r.Name] = true
+			}
+		}
+	}
+	return ts, nil
+}
+
+// stepFromStepAction returns the Step that references the StepAction, with the fields
+// defined by the StepAction and the params passed by the Step substituted in them.
+func stepFromStepAction(s v1beta1.Step, spec v1alpha1.StepActionSpec) (v1beta1.Step, error) {
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	values := map[string]v1beta1.ArrayOrString{}
+	for _, p := range spec.Params {
+		if p.Default != nil {
+			values[p.Name] = *p.Default
+		}
+	}
+	for _, p := range s.Params {
+		values[p.Name] = p.Value
+	}
+
+	var missing []string
+	for _, p := range spec.Params {
+		value, ok := values[p.Name]
+		if !ok {
+			missing = append(missing, p.Name)
+			continue
+		}
+		switch value.Type {
+		case v1beta1.ParamTypeArray:
+			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = value.ArrayVal
+		case v1beta1.ParamTypeObject:
+			for key, v := range value.ObjectVal {
+				stringReplacements[fmt.Sprintf("params.%s.%s", p.Name, key)] = v
+			}
+		default:
+			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = value.StringVal
+		}
+	}
+	if len(missing) > 0 {
+		return v1beta1.Step{}, fmt.Errorf("missing values for these params which have no default values: %v", missing)
+	}
+
+	step := spec.ToStep()
+	v1beta1.ApplyStepReplacements(&step, stringReplacements, arrayReplacements)
+
+	// The other fields of the Step, such as its name, resources and volume mounts,
+	// are kept as they are.
+	composed := *s.DeepCopy()
+	composed.Image = step.Image
+	composed.Command = step.Command
+	composed.Args = step.Args
+	composed.Env = step.Env
+	composed.WorkingDir = step.WorkingDir
+	composed.Script = step.Script
+	composed.Ref = nil
+	composed.Params = nil
+	return composed, nil
+}
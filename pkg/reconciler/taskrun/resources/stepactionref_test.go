@@ -0,0 +1,270 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestGetStepActionFunc(t *testing.T) {
+	// Set up a fake registry to push an image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cfg := config.NewStore(logtesting.TestLogger(t))
+	cfg.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName()},
+		Data: map[string]string{
+			"enable-tekton-oci-bundles": "true",
+		},
+	})
+	ctx = cfg.ToContext(ctx)
+
+	stepAction := func(namespace, image string) *v1alpha1.StepAction {
+		return &v1alpha1.StepAction{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1alpha1", Kind: "StepAction"},
+			ObjectMeta: metav1.ObjectMeta{Name: "simple", Namespace: namespace},
+			Spec: v1alpha1.StepActionSpec{
+				Image:  image,
+				Params: []v1beta1.ParamSpec{{Name: "foo"}},
+			},
+		}
+	}
+	// The retrieved StepAction has its defaults set.
+	withDefaults := func(sa *v1alpha1.StepAction) *v1alpha1.StepAction {
+		sa.Spec.Params[0].Type = v1beta1.ParamTypeString
+		return sa
+	}
+
+	for _, tc := range []struct {
+		name     string
+		local    *v1alpha1.StepAction
+		remote   *v1alpha1.StepAction
+		ref      *v1beta1.Ref
+		expected *v1alpha1.StepAction
+	}{{
+		name:     "local-step-action",
+		local:    stepAction("default", "local-image"),
+		remote:   stepAction("", "remote-image"),
+		ref:      &v1beta1.Ref{Name: "simple"},
+		expected: withDefaults(stepAction("default", "local-image")),
+	}, {
+		name:   "remote-step-action",
+		local:  stepAction("default", "local-image"),
+		remote: stepAction("", "remote-image"),
+		ref: &v1beta1.Ref{
+			Name:   "simple",
+			Bundle: u.Host + "/remote-step-action",
+		},
+		expected: withDefaults(stepAction("", "remote-image")),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tektonclient := fake.NewSimpleClientset(tc.local)
+			kubeclient := fakek8s.NewSimpleClientset(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "default",
+				},
+			})
+
+			if _, err := test.CreateImage(u.Host+"/"+tc.name, tc.remote); err != nil {
+				t.Fatalf("failed to upload test image: %s", err.Error())
+			}
+
+			fn := resources.GetStepActionFunc(kubeclient, tektonclient, "default", "default")
+			got, err := fn(ctx, tc.ref)
+			if err != nil {
+				t.Fatalf("failed to call step action fn: %s", err.Error())
+			}
+			if d := cmp.Diff(tc.expected, got); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetStepActionFunc_NotFound(t *testing.T) {
+	tektonclient := fake.NewSimpleClientset()
+	kubeclient := fakek8s.NewSimpleClientset()
+
+	fn := resources.GetStepActionFunc(kubeclient, tektonclient, "default", "default")
+	if _, err := fn(context.Background(), &v1beta1.Ref{Name: "missing"}); err == nil {
+		t.Fatal("expected error when the StepAction doesn't exist but got none")
+	}
+}
+
+func TestGetStepActionsData(t *testing.T) {
+	stepActions := map[string]*v1alpha1.StepAction{
+		"git-clone": {
+			ObjectMeta: metav1.ObjectMeta{Name: "git-clone"},
+			Spec: v1alpha1.StepActionSpec{
+				Image:      "alpine/git",
+				Args:       []string{"clone", "$(params.url)", "$(params.flags[*])"},
+				Env:        []corev1.EnvVar{{Name: "REVISION", Value: "$(params.revision)"}},
+				WorkingDir: "$(params.dir.path)",
+				Params: []v1beta1.ParamSpec{{
+					Name: "url",
+					Type: v1beta1.ParamTypeString,
+				}, {
+					Name:    "revision",
+					Type:    v1beta1.ParamTypeString,
+					Default: v1beta1.NewArrayOrString("main"),
+				}, {
+					Name:    "flags",
+					Type:    v1beta1.ParamTypeArray,
+					Default: v1beta1.NewArrayOrString("--depth", "1"),
+				}, {
+					Name: "dir",
+					Type: v1beta1.ParamTypeObject,
+					Properties: map[string]v1beta1.PropertySpec{
+						"path": {Type: v1beta1.ParamTypeString},
+					},
+				}},
+				Results: []v1beta1.TaskResult{{Name: "commit"}, {Name: "url"}},
+			},
+		},
+	}
+	getStepAction := func(ctx context.Context, ref *v1beta1.Ref) (*v1alpha1.StepAction, error) {
+		if sa, ok := stepActions[ref.Name]; ok {
+			return sa, nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	for _, tc := range []struct {
+		name     string
+		taskSpec v1beta1.TaskSpec
+		want     *v1beta1.TaskSpec
+	}{{
+		name: "no step refs",
+		taskSpec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{Name: "foo", Image: "busybox"}}},
+		},
+		want: &v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{Name: "foo", Image: "busybox"}}},
+		},
+	}, {
+		name: "step ref with params and defaults",
+		taskSpec: v1beta1.TaskSpec{
+			Results: []v1beta1.TaskResult{{Name: "url", Description: "the url"}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "foo", Image: "busybox"},
+			}, {
+				Container: corev1.Container{
+					Name:         "clone",
+					VolumeMounts: []corev1.VolumeMount{{Name: "source", MountPath: "/source"}},
+				},
+				Ref: &v1beta1.Ref{Name: "git-clone"},
+				Params: []v1beta1.Param{{
+					Name:  "url",
+					Value: *v1beta1.NewArrayOrString("https://github.com/tektoncd/pipeline"),
+				}, {
+					Name:  "dir",
+					Value: *v1beta1.NewObject(map[string]string{"path": "/source"}),
+				}},
+			}},
+		},
+		want: &v1beta1.TaskSpec{
+			Results: []v1beta1.TaskResult{{Name: "url", Description: "the url"}, {Name: "commit"}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "foo", Image: "busybox"},
+			}, {
+				Container: corev1.Container{
+					Name:         "clone",
+					Image:        "alpine/git",
+					Args:         []string{"clone", "https://github.com/tektoncd/pipeline", "--depth", "1"},
+					Env:          []corev1.EnvVar{{Name: "REVISION", Value: "main"}},
+					WorkingDir:   "/source",
+					VolumeMounts: []corev1.VolumeMount{{Name: "source", MountPath: "/source"}},
+				},
+			}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resources.GetStepActionsData(context.Background(), tc.taskSpec, getStepAction)
+			if err != nil {
+				t.Fatalf("GetStepActionsData: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetStepActionsData_Error(t *testing.T) {
+	stepAction := &v1alpha1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "echo"},
+		Spec: v1alpha1.StepActionSpec{
+			Image:  "busybox",
+			Script: "echo $(params.message)",
+			Params: []v1beta1.ParamSpec{{Name: "message", Type: v1beta1.ParamTypeString}},
+		},
+	}
+	for _, tc := range []struct {
+		name          string
+		step          v1beta1.Step
+		getStepAction resources.GetStepAction
+		wantErr       string
+	}{{
+		name: "step action not found",
+		step: v1beta1.Step{Ref: &v1beta1.Ref{Name: "echo"}},
+		getStepAction: func(context.Context, *v1beta1.Ref) (*v1alpha1.StepAction, error) {
+			return nil, errors.New("not found")
+		},
+		wantErr: `failed to resolve the StepAction "echo" of step 0: not found`,
+	}, {
+		name: "missing param",
+		step: v1beta1.Step{Ref: &v1beta1.Ref{Name: "echo"}},
+		getStepAction: func(context.Context, *v1beta1.Ref) (*v1alpha1.StepAction, error) {
+			return stepAction, nil
+		},
+		wantErr: "missing values for these params which have no default values: [message]",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := v1beta1.TaskSpec{Steps: []v1beta1.Step{tc.step}}
+			_, err := resources.GetStepActionsData(context.Background(), ts, tc.getStepAction)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected error containing %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -27,8 +27,11 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	"github.com/tektoncd/pipeline/pkg/remote/resolution"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/kmeta"
 )
 
 // GetTaskKind returns the referenced Task kind (Task, ClusterTask, ...) if the TaskRun is using TaskRef.
@@ -57,14 +60,15 @@ func GetTaskFuncFromTaskRun(ctx context.Context, k8s kubernetes.Interface, tekto
 			}, nil
 		}, nil
 	}
-	return GetTaskFunc(ctx, k8s, tekton, taskrun.Spec.TaskRef, taskrun.Namespace, taskrun.Spec.ServiceAccountName)
+	return GetTaskFunc(ctx, k8s, tekton, taskrun, taskrun.Spec.TaskRef, taskrun.Namespace, taskrun.Spec.ServiceAccountName)
 }
 
 // GetTaskFunc is a factory function that will use the given TaskRef as context to return a valid GetTask function. It
 // also requires a kubeclient, tektonclient, namespace, and service account in case it needs to find that task in
-// cluster or authorize against an external repositroy. It will figure out whether it needs to look in the cluster or in
-// a remote image to fetch the  reference. It will also return the "kind" of the task being referenced.
-func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, tr *v1beta1.TaskRef, namespace, saName string) (GetTask, error) {
+// cluster or authorize against an external repositroy. It will figure out whether it needs to look in the cluster, in
+// a remote image or request it from a resolver to fetch the reference. The owner owns the requests made to resolvers.
+// It will also return the "kind" of the task being referenced.
+func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, owner kmeta.OwnerRefableAccessor, tr *v1beta1.TaskRef, namespace, saName string) (GetTask, error) {
 	cfg := config.FromContextOrDefaults(ctx)
 	kind := v1alpha1.NamespacedTaskKind
 	if tr != nil && tr.Kind != "" {
@@ -92,30 +96,18 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 			if err != nil {
 				return nil, err
 			}
-
-			// If the resolved object is already a v1beta1.{Cluster}Task, it should be returnable as a
-			// v1beta1.TaskObject.
-			if ti, ok := obj.(v1beta1.TaskObject); ok {
-				ti.SetDefaults(ctx)
-				return ti, nil
-			}
-
-			// If this object is not already a v1beta1 object, figure out what type it is actually and try to coerce it
-			// into a v1beta1.TaskInterface compatible object.
-			switch tt := obj.(type) {
-			case *v1alpha1.Task:
-				betaTask := &v1beta1.Task{}
-				err := tt.ConvertTo(ctx, betaTask)
-				betaTask.SetDefaults(ctx)
-				return betaTask, err
-			case *v1alpha1.ClusterTask:
-				betaTask := &v1beta1.ClusterTask{}
-				err := tt.ConvertTo(ctx, betaTask)
-				betaTask.SetDefaults(ctx)
-				return betaTask, err
+			return readRuntimeObjectAsTask(ctx, obj)
+		}, nil
+	case tr != nil && tr.Resolver != "":
+		// Return an inline function that implements GetTask by requesting the task from the resolver. The
+		// request is fulfilled out of band, so this returns resolution.ErrorRequestInProgress until it is.
+		return func(ctx context.Context, name string) (v1beta1.TaskObject, error) {
+			resolver := resolution.NewResolver(ctx, tekton, owner, string(tr.Resolver), tr.Params)
+			obj, err := resolver.Get(strings.ToLower(string(kind)), name)
+			if err != nil {
+				return nil, err
 			}
-
-			return nil, fmt.Errorf("failed to convert obj %s into Task", obj.GetObjectKind().GroupVersionKind().String())
+			return readRuntimeObjectAsTask(ctx, obj)
 		}, nil
 	default:
 		// Even if there is no task ref, we should try to return a local resolver.
@@ -128,6 +120,35 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 	}
 }
 
+// readRuntimeObjectAsTask tries to convert a generic runtime.Object
+// into a v1beta1.TaskObject type so that its meta and spec fields
+// can be read.
+func readRuntimeObjectAsTask(ctx context.Context, obj runtime.Object) (v1beta1.TaskObject, error) {
+	// If the resolved object is already a v1beta1.{Cluster}Task, it should be returnable as a
+	// v1beta1.TaskObject.
+	if ti, ok := obj.(v1beta1.TaskObject); ok {
+		ti.SetDefaults(ctx)
+		return ti, nil
+	}
+
+	// If this object is not already a v1beta1 object, figure out what type it is actually and try to coerce it
+	// into a v1beta1.TaskInterface compatible object.
+	switch tt := obj.(type) {
+	case *v1alpha1.Task:
+		betaTask := &v1beta1.Task{}
+		err := tt.ConvertTo(ctx, betaTask)
+		betaTask.SetDefaults(ctx)
+		return betaTask, err
+	case *v1alpha1.ClusterTask:
+		betaTask := &v1beta1.ClusterTask{}
+		err := tt.ConvertTo(ctx, betaTask)
+		betaTask.SetDefaults(ctx)
+		return betaTask, err
+	}
+
+	return nil, fmt.Errorf("failed to convert obj %s into Task", obj.GetObjectKind().GroupVersionKind().String())
+}
+
 // LocalTaskRefResolver uses the current cluster to resolve a task reference.
 type LocalTaskRefResolver struct {
 	Namespace    string
@@ -18,6 +18,8 @@ package resources_test
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"net/http/httptest"
 	"net/url"
 	"testing"
@@ -31,6 +33,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/remote/resolution"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
@@ -251,7 +254,7 @@ func TestGetTaskFunc(t *testing.T) {
 				t.Fatalf("failed to upload test image: %s", err.Error())
 			}
 
-			fn, err := resources.GetTaskFunc(ctx, kubeclient, tektonclient, tc.ref, "default", "default")
+			fn, err := resources.GetTaskFunc(ctx, kubeclient, tektonclient, nil, tc.ref, "default", "default")
 			if err != nil {
 				t.Fatalf("failed to get task fn: %s", err.Error())
 			}
@@ -268,6 +271,62 @@ func TestGetTaskFunc(t *testing.T) {
 	}
 }
 
+func TestGetTaskFunc_Resolver(t *testing.T) {
+	ctx := context.Background()
+	tektonclient := fake.NewSimpleClientset()
+	kubeclient := fakek8s.NewSimpleClientset()
+	owner := &v1beta1.TaskRun{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "TaskRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "default"},
+	}
+	ref := &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{
+		Resolver: "git",
+		Params:   []v1beta1.Param{{Name: "path", Value: *v1beta1.NewArrayOrString("task.yaml")}},
+	}}
+
+	fn, err := resources.GetTaskFunc(ctx, kubeclient, tektonclient, owner, ref, "default", "default")
+	if err != nil {
+		t.Fatalf("failed to get task fn: %s", err.Error())
+	}
+	if _, err := fn(ctx, ref.Name); !errors.Is(err, resolution.ErrorRequestInProgress) {
+		t.Fatalf("expected the resolution to be in progress but got %v", err)
+	}
+
+	// Fulfill the ResolutionRequest as the resolver would.
+	rrs, err := tektonclient.TektonV1alpha1().ResolutionRequests("default").List(ctx, metav1.ListOptions{})
+	if err != nil || len(rrs.Items) != 1 {
+		t.Fatalf("expected 1 ResolutionRequest but got %v, %v", rrs, err)
+	}
+	rr := &rrs.Items[0]
+	rr.Status.Data = base64.StdEncoding.EncodeToString([]byte(`apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: resolved
+spec:
+  steps:
+  - image: busybox
+`))
+	rr.Status.MarkSucceeded()
+	if _, err := tektonclient.TektonV1alpha1().ResolutionRequests("default").UpdateStatus(ctx, rr, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := fn(ctx, ref.Name)
+	if err != nil {
+		t.Fatalf("failed to call taskfn: %s", err.Error())
+	}
+	expected := &v1beta1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "resolved"},
+		Spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{Image: "busybox"}}},
+		},
+	}
+	if d := cmp.Diff(expected, task); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestGetTaskFuncFromTaskRunSpecAlreadyFetched(t *testing.T) {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
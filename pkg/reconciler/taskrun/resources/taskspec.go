@@ -34,12 +34,13 @@ type GetClusterTask func(name string) (v1beta1.TaskObject, error)
 
 // GetTaskData will retrieve the Task metadata and Spec associated with the
 // provided TaskRun. This can come from a reference Task or from the TaskRun's
-// metadata and embedded TaskSpec.
-func GetTaskData(ctx context.Context, taskRun *v1beta1.TaskRun, getTask GetTask) (*metav1.ObjectMeta, *v1beta1.TaskSpec, error) {
+// metadata and embedded TaskSpec. The Steps that reference a StepAction are
+// resolved with getStepAction.
+func GetTaskData(ctx context.Context, taskRun *v1beta1.TaskRun, getTask GetTask, getStepAction GetStepAction) (*metav1.ObjectMeta, *v1beta1.TaskSpec, error) {
 	taskMeta := metav1.ObjectMeta{}
 	taskSpec := v1beta1.TaskSpec{}
 	switch {
-	case taskRun.Spec.TaskRef != nil && taskRun.Spec.TaskRef.Name != "":
+	case taskRun.Spec.TaskRef != nil && (taskRun.Spec.TaskRef.Name != "" || taskRun.Spec.TaskRef.Resolver != ""):
 		// Get related task for taskrun
 		t, err := getTask(ctx, taskRun.Spec.TaskRef.Name)
 		if err != nil {
@@ -54,5 +55,9 @@ func GetTaskData(ctx context.Context, taskRun *v1beta1.TaskRun, getTask GetTask)
 	default:
 		return nil, nil, fmt.Errorf("taskRun %s not providing TaskRef or TaskSpec", taskRun.Name)
 	}
-	return &taskMeta, &taskSpec, nil
+	ts, err := GetStepActionsData(ctx, taskSpec, getStepAction)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when resolving the steps of taskRun %s: %w", taskRun.Name, err)
+	}
+	return &taskMeta, ts, nil
 }
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,7 +49,7 @@ func TestGetTaskSpec_Ref(t *testing.T) {
 		},
 	}
 	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, error) { return task, nil }
-	taskMeta, taskSpec, err := GetTaskData(context.Background(), tr, gt)
+	taskMeta, taskSpec, err := GetTaskData(context.Background(), tr, gt, nil)
 
 	if err != nil {
 		t.Fatalf("Did not expect error getting task spec but got: %s", err)
@@ -79,7 +80,7 @@ func TestGetTaskSpec_Embedded(t *testing.T) {
 	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
 		return nil, errors.New("shouldn't be called")
 	}
-	taskMeta, taskSpec, err := GetTaskData(context.Background(), tr, gt)
+	taskMeta, taskSpec, err := GetTaskData(context.Background(), tr, gt, nil)
 
 	if err != nil {
 		t.Fatalf("Did not expect error getting task spec but got: %s", err)
@@ -94,6 +95,51 @@ func TestGetTaskSpec_Embedded(t *testing.T) {
 	}
 }
 
+func TestGetTaskSpec_StepRef(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mytaskrun",
+		},
+		Spec: v1beta1.TaskRunSpec{
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Container: corev1.Container{Name: "step1"},
+					Ref:       &v1beta1.Ref{Name: "echo"},
+					Params: []v1beta1.Param{{
+						Name:  "message",
+						Value: *v1beta1.NewArrayOrString("hello"),
+					}},
+				}},
+			},
+		},
+	}
+	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
+		return nil, errors.New("shouldn't be called")
+	}
+	gsa := func(ctx context.Context, ref *v1beta1.Ref) (*v1alpha1.StepAction, error) {
+		return &v1alpha1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: ref.Name},
+			Spec: v1alpha1.StepActionSpec{
+				Image:  "busybox",
+				Script: "echo $(params.message)",
+				Params: []v1beta1.ParamSpec{{Name: "message", Type: v1beta1.ParamTypeString}},
+			},
+		}, nil
+	}
+	_, taskSpec, err := GetTaskData(context.Background(), tr, gt, gsa)
+
+	if err != nil {
+		t.Fatalf("Did not expect error getting task spec but got: %s", err)
+	}
+
+	if len(taskSpec.Steps) != 1 || taskSpec.Steps[0].Name != "step1" || taskSpec.Steps[0].Image != "busybox" || taskSpec.Steps[0].Script != "echo hello" {
+		t.Errorf("Task Spec not resolved as expected, expected the Step to be composed from the StepAction but got: %v", taskSpec)
+	}
+	if tr.Spec.TaskSpec.Steps[0].Ref == nil {
+		t.Errorf("Expected the TaskRun's embedded Task spec not to be modified")
+	}
+}
+
 func TestGetTaskSpec_Invalid(t *testing.T) {
 	tr := &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -103,7 +149,7 @@ func TestGetTaskSpec_Invalid(t *testing.T) {
 	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
 		return nil, errors.New("shouldn't be called")
 	}
-	_, _, err := GetTaskData(context.Background(), tr, gt)
+	_, _, err := GetTaskData(context.Background(), tr, gt, nil)
 	if err == nil {
 		t.Fatalf("Expected error resolving spec with no embedded or referenced task spec but didn't get error")
 	}
@@ -123,7 +169,7 @@ func TestGetTaskSpec_Error(t *testing.T) {
 	gt := func(ctx context.Context, n string) (v1beta1.TaskObject, error) {
 		return nil, errors.New("something went wrong")
 	}
-	_, _, err := GetTaskData(context.Background(), tr, gt)
+	_, _, err := GetTaskData(context.Background(), tr, gt, nil)
 	if err == nil {
 		t.Fatalf("Expected error when unable to find referenced Task but got none")
 	}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -45,6 +46,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/remote/resolution"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
 	_ "github.com/tektoncd/pipeline/pkg/taskrunmetrics/fake" // Make sure the taskrunmetrics are setup
 	"github.com/tektoncd/pipeline/pkg/workspace"
@@ -52,6 +54,8 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
@@ -59,6 +63,15 @@ import (
 	pkgreconciler "knative.dev/pkg/reconciler"
 )
 
+// stepProgressInterval is the interval at which the progress of the running
+// steps of a TaskRun is read when the step progress is enabled.
+const stepProgressInterval = 30 * time.Second
+
+// resolutionRequeueInterval is the interval at which a TaskRun whose Task is
+// being resolved is reconciled again, in case the resolver never updates its
+// ResolutionRequest.
+const resolutionRequeueInterval = time.Minute
+
 // Reconciler implements controller.Reconciler for Configuration resources.
 type Reconciler struct {
 	KubeClientSet     kubernetes.Interface
@@ -70,10 +83,17 @@ type Reconciler struct {
 	taskLister        listers.TaskLister
 	clusterTaskLister listers.ClusterTaskLister
 	resourceLister    resourcelisters.PipelineResourceLister
+	namespaceLister   corev1listers.NamespaceLister
 	cloudEventClient  cloudevent.CEClient
 	entrypointCache   podconvert.EntrypointCache
 	metrics           *taskrunmetrics.Recorder
 	pvcHandler        volumeclaim.PvcHandler
+
+	// stepProgressReadTimes holds the time the progress of the running steps of
+	// each TaskRun was last read from their logs, by TaskRun UID. It is only kept
+	// in memory, so the progress is read again after the controller restarts. The
+	// time of a TaskRun is dropped once it is done, or deleted.
+	stepProgressReadTimes sync.Map
 }
 
 // Check that our Reconciler implements taskrunreconciler.Interface
@@ -110,6 +130,7 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	// If the TaskRun is complete, run some post run fixtures when applicable
 	if tr.IsDone() {
 		logger.Infof("taskrun done : %s \n", tr.Name)
+		c.stepProgressReadTimes.Delete(tr.UID)
 
 		// We may be reading a version of the object that was stored at an older version
 		// and may not have had all of the assumed default specified.
@@ -146,7 +167,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 				logger.Warnf("Failed to log the metrics : %v", err)
 			}
 		}(c.metrics)
-		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil)
+		if err := c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil); err != nil {
+			return err
+		}
+		return c.prune(ctx, tr)
 	}
 
 	// If the TaskRun is cancelled, kill resources and update status
@@ -168,6 +192,20 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	// taskrun, runs API convertions. Errors that come out of prepare are
 	// permanent one, so in case of error we update, emit events and return
 	_, rtr, err := c.prepare(ctx, tr)
+	if errors.Is(err, resolution.ErrorRequestInProgress) {
+		// The TaskRun is reconciled again when the resolver updates its ResolutionRequest,
+		// snooze it in case the resolver never does, and no later than its timeout.
+		if err := c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil); err != nil {
+			return err
+		}
+		requeueAfter := resolutionRequeueInterval
+		if timeout := tr.GetTimeout(ctx); timeout != config.NoTimeoutDuration {
+			if remaining := timeout - time.Since(tr.Status.StartTime.Time); remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+		}
+		return controller.NewRequeueAfter(requeueAfter)
+	}
 	if err != nil {
 		logger.Errorf("TaskRun prepare error: %v", err.Error())
 		// We only return an error if update failed, otherwise we don't want to
@@ -190,13 +228,59 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	}
 
 	if tr.Status.StartTime != nil {
-		// Compute the time since the task started.
-		elapsed := time.Since(tr.Status.StartTime.Time)
-		// Snooze this resource until the timeout has elapsed.
-		return controller.NewRequeueAfter(tr.GetTimeout(ctx) - elapsed)
+		var requeue bool
+		var requeueAfter time.Duration
+		if timeout := tr.GetTimeout(ctx); timeout != config.NoTimeoutDuration {
+			// Snooze this resource until the timeout has elapsed.
+			requeue, requeueAfter = true, timeout-time.Since(tr.Status.StartTime.Time)
+		}
+		// The logs the progress of the running steps is read from don't
+		// trigger reconciles, so they are read again periodically, whether
+		// the TaskRun has a timeout or not.
+		if config.FromContextOrDefaults(ctx).FeatureFlags.EnableStepProgress && hasRunningSteps(tr) {
+			if delay := c.stepProgressReadDelay(tr, time.Now()); !requeue || delay < requeueAfter {
+				requeue, requeueAfter = true, delay
+			}
+		}
+		if requeue {
+			return controller.NewRequeueAfter(requeueAfter)
+		}
 	}
 	return nil
 }
+
+// hasRunningSteps returns true if any of the steps of the TaskRun is running.
+func hasRunningSteps(tr *v1beta1.TaskRun) bool {
+	for _, step := range tr.Status.Steps {
+		if step.Running != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// stepProgressReadDelay returns the time remaining until the progress of the running steps of
+// the TaskRun is read from their logs again, which is at most once per stepProgressInterval.
+func (c *Reconciler) stepProgressReadDelay(tr *v1beta1.TaskRun, now time.Time) time.Duration {
+	readTime, ok := c.stepProgressReadTimes.Load(tr.UID)
+	if !ok {
+		return 0
+	}
+	return readTime.(time.Time).Add(stepProgressInterval).Sub(now)
+}
+
+// forgetTaskRun drops the time the progress of the steps of the deleted TaskRun
+// was last read, in case it was deleted before it was done.
+func (c *Reconciler) forgetTaskRun(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if tr, ok := obj.(*v1beta1.TaskRun); ok {
+		c.stepProgressReadTimes.Delete(tr.UID)
+	}
+}
+
+// stopSidecars stops the sidecars of the Pod of the done TaskRun.
 func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1beta1.TaskRun) (*corev1.Pod, error) {
 	logger := logging.FromContext(ctx)
 	// do not continue without knowing the associated pod
@@ -282,7 +366,13 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		return nil, nil, err
 	}
 
-	taskMeta, taskSpec, err := resources.GetTaskData(ctx, tr, getTaskfunc)
+	getStepActionfunc := resources.GetStepActionFunc(c.KubeClientSet, c.PipelineClientSet, tr.Namespace, tr.Spec.ServiceAccountName)
+	taskMeta, taskSpec, err := resources.GetTaskData(ctx, tr, getTaskfunc, getStepActionfunc)
+	if errors.Is(err, resolution.ErrorRequestInProgress) {
+		message := fmt.Sprintf("TaskRun %s/%s awaiting remote resource", tr.Namespace, tr.Name)
+		tr.Status.MarkResourceOngoing(podconvert.ReasonResolvingTaskRef, message)
+		return nil, nil, err
+	}
 	if err != nil {
 		logger.Errorf("Failed to determine Task spec to use for taskrun %s: %v", tr.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
@@ -345,6 +435,18 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
+	if err := validateOverrides(taskSpec, &tr.Spec); err != nil {
+		logger.Errorf("TaskRun %q overrides are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
+	if err := validateBreakpoints(taskSpec, &tr.Spec); err != nil {
+		logger.Errorf("TaskRun %q breakpoints are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
 	if err := c.updateTaskRunWithDefaultWorkspaces(ctx, tr, taskSpec); err != nil {
 		logger.Errorf("Failed to update taskrun %s with default workspace: %v", tr.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
@@ -450,13 +552,76 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 			return err
 		}
 	}
+	if err := podconvert.UpdateSidecarsReady(ctx, c.KubeClientSet, *pod); err != nil {
+		return err
+	}
 
 	// Convert the Pod's status to the equivalent TaskRun Status.
-	tr.Status, err = podconvert.MakeTaskRunStatus(logger, *tr, pod)
+	tr.Status, err = podconvert.MakeTaskRunStatus(ctx, logger, *tr, pod)
 	if err != nil {
 		return err
 	}
 
+	// The progress the running steps print is read from their logs on a best
+	// effort basis, and not on every reconcile since reading logs is costly.
+	if now := time.Now(); config.FromContextOrDefaults(ctx).FeatureFlags.EnableStepProgress && hasRunningSteps(tr) && c.stepProgressReadDelay(tr, now) <= 0 {
+		if err := podconvert.SetStepProgressFromLogs(ctx, c.KubeClientSet, tr, pod); err != nil {
+			logger.Warnf("Failed to read the progress of the steps of taskrun %s: %v", tr.Name, err)
+		}
+		c.stepProgressReadTimes.Store(tr.UID, now)
+	}
+	if tr.IsDone() {
+		c.stepProgressReadTimes.Delete(tr.UID)
+	}
+
+	// The steps still running when a sidecar fails the TaskRun are stopped
+	// along with the Pod.
+	if condition := tr.Status.GetCondition(apis.ConditionSucceeded); condition != nil && condition.Reason == v1beta1.TaskRunReasonSidecarFailed.String() {
+		for _, step := range tr.Status.Steps {
+			if step.Terminated == nil {
+				return c.failTaskRun(ctx, tr, v1beta1.TaskRunReasonSidecarFailed, condition.Message)
+			}
+		}
+	}
+
+	// Results that don't fit in the termination message are read from the logs
+	// of the results sidecar once the TaskRun has completed successfully. The
+	// TaskRun is kept running while the logs can't be read, so that reading
+	// them is retried.
+	if tr.IsSuccessful() {
+		maxResultSize := config.FromContextOrDefaults(ctx).FeatureFlags.MaxResultSize
+		if err := podconvert.SetTaskRunResultsFromSidecarLogs(ctx, logger, c.KubeClientSet, tr, pod, maxResultSize); err != nil {
+			logger.Errorf("Failed to read the results of taskrun %s: %v", tr.Name, err)
+			if errors.Is(err, podconvert.ErrResultLargerThanAllowedLimit) {
+				tr.Status.MarkResourceFailed(v1beta1.TaskRunReasonResultLargerThanAllowedLimit, err)
+				return controller.NewPermanentError(err)
+			}
+			tr.Status.MarkResourceOngoing(v1beta1.TaskRunReasonRunning, fmt.Sprintf("Reading the results of the TaskRun: %v", err))
+			tr.Status.CompletionTime = nil
+			return err
+		}
+	}
+
+	// A Pod that doesn't fit on any node or whose images can't be pulled fails
+	// the TaskRun once it has been pending for longer than the pending timeout,
+	// so that the failure can be retried for its reason.
+	if pendingTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultPendingTimeoutMinutes) * time.Minute; pendingTimeout > 0 {
+		if reason, message, ok := podconvert.GetPendingFailure(pod); ok {
+			pending := time.Since(pod.CreationTimestamp.Time)
+			if pending >= pendingTimeout {
+				return c.failTaskRun(ctx, tr, reason, fmt.Sprintf("%s after %s", message, pendingTimeout))
+			}
+			// Nothing else reconciles the TaskRun once the pending timeout
+			// has elapsed, so it is requeued for then unless it times out
+			// earlier.
+			requeueAfter := pendingTimeout - pending
+			if timeout := tr.GetTimeout(ctx); timeout != config.NoTimeoutDuration && timeout-time.Since(tr.Status.StartTime.Time) < requeueAfter {
+				requeueAfter = timeout - time.Since(tr.Status.StartTime.Time)
+			}
+			return controller.NewRequeueAfter(requeueAfter)
+		}
+	}
+
 	logger.Infof("Successfully reconciled taskrun %s/%s with status: %#v", tr.Name, tr.Namespace, tr.Status.GetCondition(apis.ConditionSucceeded))
 	return nil
 }
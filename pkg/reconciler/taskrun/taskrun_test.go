@@ -18,6 +18,7 @@ package taskrun
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http/httptest"
@@ -55,8 +56,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/changeset"
 	cminformer "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
@@ -268,7 +271,7 @@ func getRunName(tr *v1beta1.TaskRun) string {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, concurrencyExists, prunerExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -282,6 +285,12 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetArtifactPVCConfigName() {
 			artifactPVCExists = true
 		}
+		if cm.Name == config.GetConcurrencyConfigName() {
+			concurrencyExists = true
+		}
+		if cm.Name == config.GetPrunerConfigName() {
+			prunerExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -307,6 +316,18 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !concurrencyExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetConcurrencyConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !prunerExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetPrunerConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getTaskRunController returns an instance of the TaskRun controller/reconciler that has been seeded with
@@ -1965,6 +1986,104 @@ func TestReconcileTaskRunWithPermanentError(t *testing.T) {
 	}
 }
 
+func TestReconcileTaskRunWithResolverWithoutTimeout(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "foo"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{
+				Resolver: "git",
+				Params:   []v1beta1.Param{{Name: "path", Value: *v1beta1.NewArrayOrString("task.yaml")}},
+			}},
+			Timeout: &metav1.Duration{Duration: 0},
+		},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{tr},
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+
+	err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr))
+	if ok, delay := controller.IsRequeueKey(err); !ok || delay != resolutionRequeueInterval {
+		t.Errorf("Expected the TaskRun to be requeued after %s while its Task is resolved but got %v", resolutionRequeueInterval, err)
+	}
+}
+
+func TestReconcileTaskRunWithResolver(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "foo"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{
+				Resolver: "git",
+				Params:   []v1beta1.Param{{Name: "path", Value: *v1beta1.NewArrayOrString("task.yaml")}},
+			}},
+		},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{tr},
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	// The TaskRun waits for the resolver to fulfill its ResolutionRequest.
+	err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr))
+	if ok, delay := controller.IsRequeueKey(err); !ok || delay <= 0 || delay > resolutionRequeueInterval {
+		t.Fatalf("Expected the TaskRun to be requeued within %s while its Task is resolved but got %v", resolutionRequeueInterval, err)
+	}
+	reconciledRun, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != podconvert.ReasonResolvingTaskRef {
+		t.Errorf("Expected TaskRun to be resolving its Task but got condition %v", condition)
+	}
+	rrs, err := clients.Pipeline.TektonV1alpha1().ResolutionRequests("foo").List(testAssets.Ctx, metav1.ListOptions{})
+	if err != nil || len(rrs.Items) != 1 {
+		t.Fatalf("Expected 1 ResolutionRequest but got %v, %v", rrs, err)
+	}
+
+	// Once the Task is resolved, the TaskRun runs it.
+	rr := &rrs.Items[0]
+	rr.Status.Data = base64.StdEncoding.EncodeToString([]byte(`apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: resolved
+spec:
+  steps:
+  - name: simple-step
+    image: busybox
+    command: ["/mycmd"]
+`))
+	rr.Status.MarkSucceeded()
+	if _, err := clients.Pipeline.TektonV1alpha1().ResolutionRequests("foo").UpdateStatus(testAssets.Ctx, rr, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err != nil {
+		if ok, _ := controller.IsRequeueKey(err); !ok {
+			t.Fatalf("Expected no error reconciling the TaskRun but got %v", err)
+		}
+	}
+	reconciledRun, err = clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if reconciledRun.Status.PodName == "" {
+		t.Errorf("Expected a Pod to be created for the resolved Task but got status %v", reconciledRun.Status)
+	}
+	if reconciledRun.Status.TaskSpec == nil || len(reconciledRun.Status.TaskSpec.Steps) != 1 {
+		t.Errorf("Expected the resolved TaskSpec to be stored in the status but got %v", reconciledRun.Status.TaskSpec)
+	}
+}
+
 func TestReconcilePodFetchError(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun-run-success",
 		tb.TaskRunNamespace("foo"),
@@ -2111,6 +2230,282 @@ func TestReconcilePodUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestReconcileStepProgressRequeue(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		featureFlags map[string]string
+		timeout      *metav1.Duration
+		wantMax      time.Duration
+		wantMin      time.Duration
+	}{{
+		desc:    "step progress disabled",
+		wantMin: stepProgressInterval,
+		wantMax: time.Hour,
+	}, {
+		desc:         "structured step logs enabled without step progress",
+		featureFlags: map[string]string{"enable-structured-step-logs": "true"},
+		wantMin:      stepProgressInterval,
+		wantMax:      time.Hour,
+	}, {
+		desc:         "step progress enabled",
+		featureFlags: map[string]string{"enable-step-progress": "true"},
+		wantMin:      stepProgressInterval - time.Second,
+		wantMax:      stepProgressInterval,
+	}, {
+		desc:         "step progress enabled without timeout",
+		featureFlags: map[string]string{"enable-step-progress": "true"},
+		timeout:      &metav1.Duration{Duration: 0},
+		wantMin:      stepProgressInterval - time.Second,
+		wantMax:      stepProgressInterval,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun-step-progress", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)))
+			taskRun.Spec.Timeout = tc.timeout
+			pod, err := makePod(taskRun, simpleTask)
+			if err != nil {
+				t.Fatalf("MakePod: %v", err)
+			}
+			pod.Status = corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-simple-step",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				}},
+			}
+			taskRun.Status = v1beta1.TaskRunStatus{
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					PodName: pod.Name,
+				},
+			}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{simpleTask},
+				Pods:     []*corev1.Pod{pod},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+					Data:       tc.featureFlags,
+				}},
+			}
+
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+
+			err = c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			requeue, after := controller.IsRequeueKey(err)
+			if !requeue {
+				t.Fatalf("Wanted a wrapped requeue error, but got %v", err)
+			}
+			if after > tc.wantMax || after < tc.wantMin {
+				t.Errorf("Expected the TaskRun to be requeued after between %s and %s but got %s", tc.wantMin, tc.wantMax, after)
+			}
+		})
+	}
+}
+
+func TestStepProgressReadDelay(t *testing.T) {
+	now := time.Now()
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{UID: "uid"}}
+	c := &Reconciler{}
+	if delay := c.stepProgressReadDelay(tr, now); delay != 0 {
+		t.Errorf("Expected the progress never read to be read at once but got a delay of %s", delay)
+	}
+	c.stepProgressReadTimes.Store(tr.UID, now.Add(-20*time.Second))
+	if delay := c.stepProgressReadDelay(tr, now); delay != 10*time.Second {
+		t.Errorf("Expected the progress read 20s ago to be read again in 10s but got %s", delay)
+	}
+	c.stepProgressReadTimes.Store(tr.UID, now.Add(-time.Minute))
+	if delay := c.stepProgressReadDelay(tr, now); delay > 0 {
+		t.Errorf("Expected the progress read a minute ago to be read at once but got a delay of %s", delay)
+	}
+}
+
+func TestForgetTaskRun(t *testing.T) {
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{UID: "uid"}}
+	for _, tc := range []struct {
+		desc string
+		obj  interface{}
+	}{{
+		desc: "deleted TaskRun",
+		obj:  tr,
+	}, {
+		desc: "tombstone",
+		obj:  cache.DeletedFinalStateUnknown{Key: "foo/test-taskrun", Obj: tr},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &Reconciler{}
+			c.stepProgressReadTimes.Store(tr.UID, time.Now())
+			c.forgetTaskRun(tc.obj)
+			if _, ok := c.stepProgressReadTimes.Load(tr.UID); ok {
+				t.Error("Expected the time the progress of the deleted TaskRun was read to be dropped")
+			}
+		})
+	}
+}
+
+func TestReconcilePodSidecarFailed(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-sidecar-failed", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)))
+
+	pod, err := makePod(taskRun, simpleTask)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	pod.Status = corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name: "step-simple-step",
+			State: corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{},
+			},
+		}, {
+			Name:    "sidecar-db",
+			ImageID: "image-id",
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Reason:   "Error",
+				},
+			},
+		}},
+	}
+	taskRun.Status = v1beta1.TaskRunStatus{
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: pod.Name,
+		},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		Tasks:    []*v1beta1.Task{simpleTask},
+		Pods:     []*corev1.Pod{pod},
+	}
+
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Fatalf("Unexpected error when Reconcile(): %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if d := cmp.Diff(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionFalse,
+		Reason:  v1beta1.TaskRunReasonSidecarFailed.String(),
+		Message: fmt.Sprintf("sidecar \"db\" exited with code 1 (reason: \"Error\", image: \"image-id\") while steps were still running; for logs run: kubectl -n foo logs %s -c sidecar-db\n", pod.Name),
+	}, newTr.Status.GetCondition(apis.ConditionSucceeded), ignoreLastTransitionTime); d != "" {
+		t.Errorf("Did not get expected condition %s", diff.PrintWantGot(d))
+	}
+	if len(newTr.Status.Steps) != 1 || newTr.Status.Steps[0].Terminated == nil || newTr.Status.Steps[0].Terminated.Reason != v1beta1.TaskRunReasonSidecarFailed.String() {
+		t.Errorf("Expected the running step to be terminated with reason %q but got %v", v1beta1.TaskRunReasonSidecarFailed, newTr.Status.Steps)
+	}
+	if _, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, pod.Name, metav1.GetOptions{}); !k8sapierrors.IsNotFound(err) {
+		t.Errorf("Expected the Pod of the TaskRun to be deleted but got %v", err)
+	}
+}
+
+func TestReconcilePodPendingTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		podStatus  corev1.PodStatus
+		pendingFor time.Duration
+		wantReason v1beta1.TaskRunReason
+	}{{
+		desc: "image can't be pulled",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-simple-step",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+			}},
+		},
+		pendingFor: 10 * time.Minute,
+		wantReason: v1beta1.TaskRunReasonImagePullFailed,
+	}, {
+		desc: "pod doesn't fit on any node",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/1 nodes are available: 1 Insufficient cpu.",
+			}},
+		},
+		pendingFor: 10 * time.Minute,
+		wantReason: v1beta1.TaskRunReasonExceededNodeResources,
+	}, {
+		desc: "image can't be pulled within the pending timeout",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-simple-step",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull"}},
+			}},
+		},
+		pendingFor: time.Minute,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun-pending", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)))
+			pod, err := makePod(taskRun, simpleTask)
+			if err != nil {
+				t.Fatalf("MakePod: %v", err)
+			}
+			pod.CreationTimestamp = metav1.Time{Time: time.Now().Add(-tc.pendingFor)}
+			pod.Status = tc.podStatus
+			taskRun.Status = v1beta1.TaskRunStatus{
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					PodName:   pod.Name,
+					StartTime: &metav1.Time{Time: time.Now().Add(-tc.pendingFor)},
+				},
+			}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{simpleTask},
+				Pods:     []*corev1.Pod{pod},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+					Data: map[string]string{
+						"default-pending-timeout-minutes": "5",
+					},
+				}},
+			}
+
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			err = c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			newTr, getErr := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if getErr != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, getErr)
+			}
+			if tc.wantReason == "" {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay > 4*time.Minute || delay <= 3*time.Minute {
+					t.Errorf("Expected the TaskRun to be requeued once its pending timeout elapses but got %v", err)
+				}
+				if newTr.IsDone() {
+					t.Errorf("Expected the TaskRun to keep waiting for its Pod but got %v", newTr.Status.GetCondition(apis.ConditionSucceeded))
+				}
+				return
+			}
+			if condition := newTr.Status.GetCondition(apis.ConditionSucceeded); condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != tc.wantReason.String() {
+				t.Errorf("Expected the TaskRun to fail with reason %q but got %v", tc.wantReason, condition)
+			}
+			if _, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, pod.Name, metav1.GetOptions{}); !k8sapierrors.IsNotFound(err) {
+				t.Errorf("Expected the Pod of the TaskRun to be deleted but got %v", err)
+			}
+		})
+	}
+}
+
 func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	taskSt := &apis.Condition{
 		Type:    apis.ConditionSucceeded,
@@ -2145,6 +2540,105 @@ func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	}
 }
 
+func TestReconcileOnCompletedTaskRunPruned(t *testing.T) {
+	now := time.Now()
+	taskRun := func(name string, completedAgo time.Duration, owners ...metav1.OwnerReference) *v1beta1.TaskRun {
+		completionTime := metav1.NewTime(now.Add(-completedAgo))
+		return &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "foo",
+				Labels:          map[string]string{pipeline.TaskLabelKey: "test-task"},
+				OwnerReferences: owners,
+			},
+			Spec: v1beta1.TaskRunSpec{TaskRef: &v1beta1.TaskRef{Name: "test-task"}},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}}},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					StartTime:      &completionTime,
+					CompletionTime: &completionTime,
+				},
+			},
+		}
+	}
+	isController := true
+	owner := metav1.OwnerReference{
+		APIVersion: "tekton.dev/v1beta1",
+		Kind:       "PipelineRun",
+		Name:       "test-pipelinerun",
+		Controller: &isController,
+	}
+
+	for _, tc := range []struct {
+		name        string
+		pruner      map[string]string
+		reconcile   string
+		wantRequeue bool
+		wantPruned  []string
+		wantKept    []string
+	}{{
+		name:        "keep",
+		pruner:      map[string]string{"keep": "1", "ttl": "1h"},
+		reconcile:   "recent",
+		wantRequeue: true,
+		wantPruned:  []string{"expired"},
+		wantKept:    []string{"recent", "owned"},
+	}, {
+		name:       "ttl",
+		pruner:     map[string]string{"ttl": "1h"},
+		reconcile:  "expired",
+		wantPruned: []string{"expired"},
+		wantKept:   []string{"recent", "owned"},
+	}, {
+		name:      "owned",
+		pruner:    map[string]string{"ttl": "1h"},
+		reconcile: "owned",
+		wantKept:  []string{"expired", "recent", "owned"},
+	}, {
+		name:      "disabled",
+		reconcile: "expired",
+		wantKept:  []string{"expired", "recent", "owned"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{
+					taskRun("expired", 2*time.Hour),
+					taskRun("recent", time.Minute),
+					taskRun("owned", 3*time.Hour, owner),
+				},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetPrunerConfigName(), Namespace: system.Namespace()},
+					Data:       tc.pruner,
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			err := c.Reconciler.Reconcile(testAssets.Ctx, "foo/"+tc.reconcile)
+			if ok, _ := controller.IsRequeueKey(err); ok != tc.wantRequeue {
+				t.Errorf("Expected the TaskRun to be requeued: %t, but got error %v", tc.wantRequeue, err)
+			} else if !ok && err != nil {
+				t.Fatalf("Unexpected error when reconciling completed TaskRun : %v", err)
+			}
+			for _, name := range tc.wantPruned {
+				if _, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, name, metav1.GetOptions{}); !k8sapierrors.IsNotFound(err) {
+					t.Errorf("Expected TaskRun %s to be pruned but got %v", name, err)
+				}
+			}
+			for _, name := range tc.wantKept {
+				if _, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, name, metav1.GetOptions{}); err != nil {
+					t.Errorf("Expected TaskRun %s to be kept but got %v", name, err)
+				}
+			}
+		})
+	}
+}
+
 func TestReconcileOnCancelledTaskRun(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun-run-cancelled",
 		tb.TaskRunNamespace("foo"),
@@ -3828,3 +4322,115 @@ func TestPodAdoption(t *testing.T) {
 	}
 
 }
+
+func TestValidateOverrides(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{Container: corev1.Container{
+			Name: "build",
+		}}},
+		Sidecars: []v1beta1.Sidecar{{Container: corev1.Container{
+			Name: "cache",
+		}}},
+	}
+	tsWithComputeResources := ts.DeepCopy()
+	tsWithComputeResources.ComputeResources = &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	for _, tc := range []struct {
+		name    string
+		ts      *v1beta1.TaskSpec
+		trs     *v1beta1.TaskRunSpec
+		wantErr bool
+	}{{
+		name: "no overrides",
+		trs:  &v1beta1.TaskRunSpec{},
+	}, {
+		name: "task compute resources replaced by the taskrun",
+		ts:   tsWithComputeResources,
+		trs: &v1beta1.TaskRunSpec{
+			ComputeResources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		},
+	}, {
+		name: "step override of a task with compute resources",
+		ts:   tsWithComputeResources,
+		trs: &v1beta1.TaskRunSpec{
+			StepOverrides: []v1beta1.TaskRunStepOverride{{Name: "build"}},
+		},
+		wantErr: true,
+	}, {
+		name: "overrides of existing step and sidecar",
+		trs: &v1beta1.TaskRunSpec{
+			StepOverrides:    []v1beta1.TaskRunStepOverride{{Name: "build"}},
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{Name: "cache"}},
+		},
+	}, {
+		name: "override of unknown step",
+		trs: &v1beta1.TaskRunSpec{
+			StepOverrides: []v1beta1.TaskRunStepOverride{{Name: "push"}},
+		},
+		wantErr: true,
+	}, {
+		name: "override of unknown sidecar",
+		trs: &v1beta1.TaskRunSpec{
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{Name: "build"}},
+		},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskSpec := ts
+			if tc.ts != nil {
+				taskSpec = tc.ts
+			}
+			err := validateOverrides(taskSpec, tc.trs)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateOverrides() error = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateBreakpoints(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{Container: corev1.Container{
+			Name: "build",
+		}}, {Container: corev1.Container{
+			Name: "test",
+		}}},
+	}
+	for _, tc := range []struct {
+		name    string
+		trs     *v1beta1.TaskRunSpec
+		wantErr bool
+	}{{
+		name: "no debug",
+		trs:  &v1beta1.TaskRunSpec{},
+	}, {
+		name: "breakpoints at existing steps",
+		trs: &v1beta1.TaskRunSpec{Debug: &v1beta1.TaskRunDebug{
+			Breakpoint:  []string{"onFailure"},
+			BeforeSteps: []string{"test"},
+			AfterSteps:  []string{"build", "test"},
+		}},
+	}, {
+		name: "breakpoint before unknown step",
+		trs: &v1beta1.TaskRunSpec{Debug: &v1beta1.TaskRunDebug{
+			BeforeSteps: []string{"push"},
+		}},
+		wantErr: true,
+	}, {
+		name: "breakpoint after unknown step",
+		trs: &v1beta1.TaskRunSpec{Debug: &v1beta1.TaskRunDebug{
+			AfterSteps: []string{"push"},
+		}},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateBreakpoints(ts, tc.trs)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateBreakpoints() error = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
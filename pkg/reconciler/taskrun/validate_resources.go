@@ -105,6 +105,26 @@ func validateParams(paramSpecs []v1beta1.ParamSpec, params []v1beta1.Param) erro
 		return fmt.Errorf("param types don't match the user-specified type: %s", wrongTypeParamNames)
 	}
 
+	// Make sure the values of object params provide all the keys declared in their properties, unless
+	// the default value of the param provides them.
+	missingKeys := map[string][]string{}
+	for _, param := range params {
+		if param.Value.Type != v1beta1.ParamTypeObject {
+			continue
+		}
+		for _, paramSpec := range paramSpecs {
+			if paramSpec.Name != param.Name {
+				continue
+			}
+			if keys := paramSpec.MissingObjectKeys(param.Value.ObjectVal); len(keys) != 0 {
+				missingKeys[param.Name] = keys
+			}
+		}
+	}
+	if len(missingKeys) != 0 {
+		return fmt.Errorf("missing keys for these object params: %v", missingKeys)
+	}
+
 	return nil
 }
 
@@ -128,3 +148,48 @@ func ValidateResolvedTaskResources(params []v1beta1.Param, rtr *resources.Resolv
 
 	return nil
 }
+
+// validateOverrides validates that the step and sidecar overrides of the TaskRun name
+// Steps and Sidecars of its Task, and that the step overrides don't conflict with the
+// compute resource budget of the Task, which replaces the compute resources of the steps.
+func validateOverrides(ts *v1beta1.TaskSpec, trs *v1beta1.TaskRunSpec) error {
+	if ts.ComputeResources != nil && len(trs.StepOverrides) > 0 {
+		return fmt.Errorf("invalid step overrides: the Task sets the compute resources of its steps with computeResources, which the TaskRun can only replace with computeResources")
+	}
+	steps := map[string]bool{}
+	for _, s := range ts.Steps {
+		steps[s.Name] = true
+	}
+	for _, o := range trs.StepOverrides {
+		if !steps[o.Name] {
+			return fmt.Errorf("invalid step override %s: the Task has no step with this name", o.Name)
+		}
+	}
+	sidecars := map[string]bool{}
+	for _, s := range ts.Sidecars {
+		sidecars[s.Name] = true
+	}
+	for _, o := range trs.SidecarOverrides {
+		if !sidecars[o.Name] {
+			return fmt.Errorf("invalid sidecar override %s: the Task has no sidecar with this name", o.Name)
+		}
+	}
+	return nil
+}
+
+// validateBreakpoints ensures the steps the TaskRun pauses before or after are steps of the Task.
+func validateBreakpoints(ts *v1beta1.TaskSpec, trs *v1beta1.TaskRunSpec) error {
+	if trs.Debug == nil {
+		return nil
+	}
+	steps := map[string]bool{}
+	for _, s := range ts.Steps {
+		steps[s.Name] = true
+	}
+	for _, name := range append(append([]string{}, trs.Debug.BeforeSteps...), trs.Debug.AfterSteps...) {
+		if !steps[name] {
+			return fmt.Errorf("invalid breakpoint at step %s: the Task has no step with this name", name)
+		}
+	}
+	return nil
+}
@@ -81,6 +81,29 @@ func TestValidateResolvedTaskResources_ValidParams(t *testing.T) {
 	}
 }
 
+func TestValidateResolvedTaskResources_ValidObjectParams(t *testing.T) {
+	rtr := &resources.ResolvedTaskResources{
+		TaskSpec: &v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name: "git",
+				Type: v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{
+					"url":      {Type: v1beta1.ParamTypeString},
+					"revision": {Type: v1beta1.ParamTypeString},
+				},
+				Default: v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main"}),
+			}},
+		},
+	}
+	p := []v1beta1.Param{{
+		Name:  "git",
+		Value: *v1beta1.NewObject(map[string]string{"revision": "v0.1.0"}),
+	}}
+	if err := taskrun.ValidateResolvedTaskResources(p, rtr); err != nil {
+		t.Fatalf("Did not expect to see error when validating TaskRun with keys provided by the default value but saw %v", err)
+	}
+}
+
 func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 	task := tb.Task("foo", tb.TaskSpec(
 		tb.Step("myimage", tb.StepCommand("mycmd")),
@@ -111,6 +134,24 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 			Name:  "extra",
 			Value: *v1beta1.NewArrayOrString("i am an extra param"),
 		}},
+	}, {
+		name: "missing-object-param-keys",
+		rtr: &resources.ResolvedTaskResources{
+			TaskSpec: &v1beta1.TaskSpec{
+				Params: []v1beta1.ParamSpec{{
+					Name: "git",
+					Type: v1beta1.ParamTypeObject,
+					Properties: map[string]v1beta1.PropertySpec{
+						"url":      {Type: v1beta1.ParamTypeString},
+						"revision": {Type: v1beta1.ParamTypeString},
+					},
+				}},
+			},
+		},
+		params: []v1beta1.Param{{
+			Name:  "git",
+			Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+		}},
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
+	runreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/run"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+const (
+	// ReasonWaiting indicates that the Wait custom task is waiting
+	ReasonWaiting = "Waiting"
+	// ReasonDurationElapsed indicates that the duration the Wait custom task waited for elapsed
+	ReasonDurationElapsed = "DurationElapsed"
+	// ReasonConditionMet indicates that the object the Wait custom task waited for matched the condition
+	ReasonConditionMet = "ConditionMet"
+	// ReasonInvalidWait indicates that the params of the Wait custom task are invalid
+	ReasonInvalidWait = "InvalidWait"
+	// ReasonObjectUnavailable indicates that the object the Wait custom task waits for can't be read
+	ReasonObjectUnavailable = "ObjectUnavailable"
+)
+
+// Reconciler implements controller.Reconciler for the Runs of Wait custom tasks.
+type Reconciler struct {
+	// RESTMapper maps the kinds of the objects to wait for to their resources. It caches
+	// the discovery of the resources the API server serves.
+	RESTMapper       meta.RESTMapper
+	DynamicClientSet dynamic.Interface
+}
+
+// Check that our Reconciler implements runreconciler.Interface
+var _ runreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind marks the Run of a Wait custom task succeeded once the duration it waits for
+// elapsed, or once the object it waits for matches the condition, and requeues it until then.
+// The Run fails when it is cancelled or times out before.
+func (c *Reconciler) ReconcileKind(ctx context.Context, run *v1alpha1.Run) pkgreconciler.Event {
+	if run.IsDone() {
+		return nil
+	}
+	if !run.HasStarted() {
+		run.Status.InitializeConditions()
+	}
+
+	if run.IsCancelled() {
+		run.Status.MarkRunFailed(v1alpha1.RunReasonCancelled, "Run %s/%s was cancelled", run.Namespace, run.Name)
+		return nil
+	}
+	if run.HasTimedOut() {
+		run.Status.MarkRunFailed(v1alpha1.RunReasonTimedOut, "Run %s/%s timed out after %s", run.Namespace, run.Name, run.GetTimeout())
+		return nil
+	}
+
+	spec, err := run.GetWaitSpec()
+	if err != nil {
+		run.Status.MarkRunFailed(ReasonInvalidWait, "Run %s/%s is invalid: %v", run.Namespace, run.Name, err)
+		return nil
+	}
+	if spec.Object == nil {
+		remaining := spec.Duration - time.Since(run.Status.StartTime.Time)
+		if remaining <= 0 {
+			run.Status.MarkRunSucceeded(ReasonDurationElapsed, "Run %s/%s waited for %s", run.Namespace, run.Name, spec.Duration)
+			return nil
+		}
+		run.Status.MarkRunRunning(ReasonWaiting, "Run %s/%s is waiting for %s", run.Namespace, run.Name, spec.Duration)
+		return requeueAfter(run, remaining)
+	}
+	return c.waitForObject(ctx, run, spec.Object)
+}
+
+// requeueAfter requeues the Run after the delay, or once it times out if that is earlier.
+func requeueAfter(run *v1alpha1.Run, delay time.Duration) pkgreconciler.Event {
+	if timeout := run.GetTimeout(); timeout != apisconfig.NoTimeoutDuration {
+		if remaining := timeout - time.Since(run.Status.StartTime.Time); remaining < delay {
+			delay = remaining
+		}
+	}
+	return controller.NewRequeueAfter(delay)
+}
+
+// waitForObject checks whether the object the Run waits for matches the condition, and emits
+// the results from the object once it does. Otherwise the Run is requeued after the poll interval.
+func (c *Reconciler) waitForObject(ctx context.Context, run *v1alpha1.Run, o *v1alpha1.WaitObject) pkgreconciler.Event {
+	logger := logging.FromContext(ctx)
+	description := fmt.Sprintf("%s %s", o.Kind, o.Name)
+
+	obj, err := c.getObject(ctx, run.Namespace, o)
+	switch {
+	case k8serrors.IsNotFound(err):
+		run.Status.MarkRunRunning(ReasonWaiting, "Run %s/%s is waiting for %s to be created", run.Namespace, run.Name, description)
+		return requeueAfter(run, o.PollInterval)
+	case k8serrors.IsForbidden(err):
+		run.Status.MarkRunFailed(ReasonObjectUnavailable, "Run %s/%s can't read %s, the controller must be allowed to get it: %v", run.Namespace, run.Name, description, err)
+		return nil
+	case err != nil:
+		if _, ok := err.(kindNotFoundError); ok {
+			run.Status.MarkRunFailed(ReasonInvalidWait, "Run %s/%s is invalid: %v", run.Namespace, run.Name, err)
+			return nil
+		}
+		logger.Errorf("Failed to get %s for Run %s/%s: %v", description, run.Namespace, run.Name, err)
+		return err
+	}
+
+	matched, err := matches(obj, o)
+	if err != nil {
+		run.Status.MarkRunFailed(ReasonInvalidWait, "Run %s/%s is invalid: %v", run.Namespace, run.Name, err)
+		return nil
+	}
+	if !matched {
+		run.Status.MarkRunRunning(ReasonWaiting, "Run %s/%s is waiting for %s to match %s", run.Namespace, run.Name, description, o.String())
+		return requeueAfter(run, o.PollInterval)
+	}
+
+	results := make([]v1alpha1.RunResult, 0, len(o.Results))
+	for _, r := range o.Results {
+		value, err := evaluate(obj, r.JSONPath)
+		if err != nil {
+			run.Status.MarkRunFailed(ReasonInvalidWait, "Run %s/%s failed to emit result %q: %v", run.Namespace, run.Name, r.Name, err)
+			return nil
+		}
+		results = append(results, v1alpha1.RunResult{Name: r.Name, Value: *runv1alpha1.NewRunResultValue(value)})
+	}
+	if len(results) > 0 {
+		run.Status.Results = results
+	}
+	run.Status.MarkRunSucceeded(ReasonConditionMet, "Run %s/%s waited for %s to match %s", run.Namespace, run.Name, description, o.String())
+	return nil
+}
+
+// waitableResources are the only resources the Wait custom task reads. The controller is allowed
+// to read secrets, which must never be copied to the status of a Run by whoever can create one.
+var waitableResources = map[schema.GroupResource]bool{
+	{Group: "", Resource: "pods"}:                   true,
+	{Group: "", Resource: "persistentvolumeclaims"}: true,
+	{Group: "apps", Resource: "deployments"}:        true,
+	{Group: "apps", Resource: "statefulsets"}:       true,
+	{Group: "batch", Resource: "jobs"}:              true,
+	{Group: "tekton.dev", Resource: "taskruns"}:     true,
+	{Group: "tekton.dev", Resource: "pipelineruns"}: true,
+	{Group: "tekton.dev", Resource: "runs"}:         true,
+}
+
+// kindNotFoundError is returned when the API server doesn't serve the kind of the object to wait
+// for, or when it isn't one of the kinds the Wait custom task can wait for
+type kindNotFoundError struct {
+	apiVersion, kind string
+	notAllowed       bool
+}
+
+func (e kindNotFoundError) Error() string {
+	if e.notAllowed {
+		return fmt.Sprintf("objects of kind %s in %s can't be waited for", e.kind, e.apiVersion)
+	}
+	return fmt.Sprintf("the server doesn't have a resource of kind %s in %s", e.kind, e.apiVersion)
+}
+
+// getObject gets the object to wait for, mapping its kind to its resource
+func (c *Reconciler) getObject(ctx context.Context, namespace string, o *v1alpha1.WaitObject) (*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(o.APIVersion)
+	if err != nil {
+		return nil, kindNotFoundError{apiVersion: o.APIVersion, kind: o.Kind}
+	}
+	mapping, err := c.RESTMapper.RESTMapping(gv.WithKind(o.Kind).GroupKind(), gv.Version)
+	if meta.IsNoMatchError(err) {
+		return nil, kindNotFoundError{apiVersion: o.APIVersion, kind: o.Kind}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !waitableResources[mapping.Resource.GroupResource()] {
+		return nil, kindNotFoundError{apiVersion: o.APIVersion, kind: o.Kind, notAllowed: true}
+	}
+	client := c.DynamicClientSet.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return client.Namespace(namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	}
+	return client.Get(ctx, o.Name, metav1.GetOptions{})
+}
+
+// matches returns true if the object matches the condition of the Wait custom task
+func matches(obj *unstructured.Unstructured, o *v1alpha1.WaitObject) (bool, error) {
+	if o.JSONPath != "" {
+		j, err := parseJSONPath(o.JSONPath)
+		if err != nil {
+			return false, err
+		}
+		var b bytes.Buffer
+		if err := j.Execute(&b, obj.Object); err != nil {
+			// The field may not be set until the object progresses
+			return false, nil
+		}
+		return b.String() == o.Value, nil
+	}
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, nil
+	}
+	for _, condition := range conditions {
+		c, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, _ := c["type"].(string); strings.EqualFold(t, o.Condition) {
+			status, _ := c["status"].(string)
+			return strings.EqualFold(status, o.Value), nil
+		}
+	}
+	return false, nil
+}
+
+func parseJSONPath(expression string) (*jsonpath.JSONPath, error) {
+	j := jsonpath.New("wait")
+	if err := j.Parse(expression); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath expression %q: %w", expression, err)
+	}
+	return j, nil
+}
+
+// evaluate returns the value of the JSONPath expression in the object, like kubectl get -o jsonpath
+func evaluate(obj *unstructured.Unstructured, expression string) (string, error) {
+	j, err := parseJSONPath(expression)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := j.Execute(&b, obj.Object); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	fakekube "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/restmapper"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+// fakeDynamicClient serves the objects keyed by resource, namespace and name
+type fakeDynamicClient struct {
+	dynamic.Interface
+	objects map[string]*unstructured.Unstructured
+	err     error
+}
+
+func (f *fakeDynamicClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &fakeResourceClient{client: f, gvr: gvr}
+}
+
+type fakeResourceClient struct {
+	dynamic.NamespaceableResourceInterface
+	client    *fakeDynamicClient
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+func (f *fakeResourceClient) Namespace(namespace string) dynamic.ResourceInterface {
+	return &fakeResourceClient{client: f.client, gvr: f.gvr, namespace: namespace}
+}
+
+func (f *fakeResourceClient) Get(_ context.Context, name string, _ metav1.GetOptions, _ ...string) (*unstructured.Unstructured, error) {
+	if f.client.err != nil {
+		return nil, f.client.err
+	}
+	if obj, ok := f.client.objects[f.gvr.String()+"/"+f.namespace+"/"+name]; ok {
+		return obj, nil
+	}
+	return nil, k8serrors.NewNotFound(f.gvr.GroupResource(), name)
+}
+
+func newReconciler(objects map[string]*unstructured.Unstructured, err error) *Reconciler {
+	kube := fakekube.NewSimpleClientset()
+	kube.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+			{Name: "deployments/status", Kind: "Deployment", Namespaced: true},
+		},
+	}, {
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true},
+			{Name: "secrets", Kind: "Secret", Namespaced: true},
+		},
+	}}
+	return &Reconciler{
+		RESTMapper:       restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kube.Discovery())),
+		DynamicClientSet: &fakeDynamicClient{objects: objects, err: err},
+	}
+}
+
+func waitRun(params ...v1beta1.Param) *v1alpha1.Run {
+	return &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{Name: "wait", Namespace: "ns"},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{
+				APIVersion: "tekton.dev/v1alpha1",
+				Kind:       v1alpha1.WaitKind,
+			},
+			Params:  params,
+			Timeout: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+}
+
+func param(name string, value ...string) v1beta1.Param {
+	return v1beta1.Param{Name: name, Value: *v1beta1.NewArrayOrString(value[0], value[1:]...)}
+}
+
+func waitForObjectRun(kind, name, waitFor string, params ...v1beta1.Param) *v1alpha1.Run {
+	apiVersion := "apps/v1"
+	if kind == "Pod" || kind == "Secret" {
+		apiVersion = "v1"
+	}
+	return waitRun(append([]v1beta1.Param{
+		param("apiVersion", apiVersion),
+		param("kind", kind),
+		param("name", name),
+		param("for", waitFor),
+	}, params...)...)
+}
+
+func deployment(available string, readyReplicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "app", "namespace": "ns"},
+		"status": map[string]interface{}{
+			"readyReplicas": readyReplicas,
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Progressing", "status": "True"},
+				map[string]interface{}{"type": "Available", "status": available},
+			},
+		},
+	}}
+}
+
+func TestReconcileKind_Duration(t *testing.T) {
+	run := waitRun(param("duration", "30s"))
+	err := newReconciler(nil, nil).ReconcileKind(context.Background(), run)
+	if ok, delay := controller.IsRequeueKey(err); !ok || delay <= 0 || delay > 30*time.Second {
+		t.Errorf("expected the Run to be requeued within 30s but got %v", err)
+	}
+	assertCondition(t, run, corev1.ConditionUnknown, ReasonWaiting)
+
+	run.Status.StartTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	if err := newReconciler(nil, nil).ReconcileKind(context.Background(), run); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertCondition(t, run, corev1.ConditionTrue, ReasonDurationElapsed)
+}
+
+func TestReconcileKind_RequeuedUntilTimeout(t *testing.T) {
+	for _, run := range []*v1alpha1.Run{
+		waitRun(param("duration", "1h")),
+		waitForObjectRun("Deployment", "app", "condition=Available", param("pollInterval", "1h")),
+	} {
+		run.Spec.Timeout = &metav1.Duration{Duration: time.Minute}
+		err := newReconciler(nil, nil).ReconcileKind(context.Background(), run)
+		if ok, delay := controller.IsRequeueKey(err); !ok || delay <= 0 || delay > time.Minute {
+			t.Errorf("expected the Run to be requeued once it times out, within 1m, but got %v", err)
+		}
+	}
+}
+
+func TestReconcileKind_Object(t *testing.T) {
+	deployments := "apps/v1, Resource=deployments/ns/app"
+	for _, tc := range []struct {
+		name        string
+		run         *v1alpha1.Run
+		objects     map[string]*unstructured.Unstructured
+		err         error
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+		wantResults []v1alpha1.RunResult
+	}{{
+		name:       "object not created yet",
+		run:        waitForObjectRun("Deployment", "app", "condition=Available"),
+		wantStatus: corev1.ConditionUnknown,
+		wantReason: ReasonWaiting,
+	}, {
+		name:       "condition not met",
+		run:        waitForObjectRun("Deployment", "app", "condition=Available"),
+		objects:    map[string]*unstructured.Unstructured{deployments: deployment("False", 1)},
+		wantStatus: corev1.ConditionUnknown,
+		wantReason: ReasonWaiting,
+	}, {
+		name: "condition met",
+		run: waitForObjectRun("Deployment", "app", "condition=available",
+			param("results", "replicas={.status.readyReplicas}", "name={.metadata.name}")),
+		objects:    map[string]*unstructured.Unstructured{deployments: deployment("True", 3)},
+		wantStatus: corev1.ConditionTrue,
+		wantReason: ReasonConditionMet,
+		wantResults: []v1alpha1.RunResult{
+			{Name: "replicas", Value: *runv1alpha1.NewRunResultValue("3")},
+			{Name: "name", Value: *runv1alpha1.NewRunResultValue("app")},
+		},
+	}, {
+		name:       "jsonpath not matched",
+		run:        waitForObjectRun("Deployment", "app", "jsonpath={.status.readyReplicas}=3"),
+		objects:    map[string]*unstructured.Unstructured{deployments: deployment("True", 2)},
+		wantStatus: corev1.ConditionUnknown,
+		wantReason: ReasonWaiting,
+	}, {
+		name:       "jsonpath matched",
+		run:        waitForObjectRun("Deployment", "app", "jsonpath={.status.readyReplicas}=3"),
+		objects:    map[string]*unstructured.Unstructured{deployments: deployment("False", 3)},
+		wantStatus: corev1.ConditionTrue,
+		wantReason: ReasonConditionMet,
+	}, {
+		name: "core object",
+		run:  waitForObjectRun("Pod", "pod", "condition=Ready"),
+		objects: map[string]*unstructured.Unstructured{"/v1, Resource=pods/ns/pod": {Object: map[string]interface{}{
+			"status": map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}}},
+		}}},
+		wantStatus: corev1.ConditionTrue,
+		wantReason: ReasonConditionMet,
+	}, {
+		name: "kind not allowed",
+		run:  waitForObjectRun("Secret", "credentials", "jsonpath={.type}=Opaque", param("results", "password={.data.password}")),
+		objects: map[string]*unstructured.Unstructured{"/v1, Resource=secrets/ns/credentials": {Object: map[string]interface{}{
+			"type": "Opaque",
+			"data": map[string]interface{}{"password": "c2VjcmV0"},
+		}}},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonInvalidWait,
+	}, {
+		name:       "unknown kind",
+		run:        waitForObjectRun("StatefulSet", "app", "condition=Available"),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonInvalidWait,
+	}, {
+		name:       "forbidden",
+		run:        waitForObjectRun("Deployment", "app", "condition=Available"),
+		err:        k8serrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "app", nil),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonObjectUnavailable,
+	}, {
+		name:       "invalid params",
+		run:        waitForObjectRun("Deployment", "app", "ready"),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonInvalidWait,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := newReconciler(tc.objects, tc.err).ReconcileKind(context.Background(), tc.run)
+			if tc.wantStatus == corev1.ConditionUnknown {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay != v1alpha1.DefaultWaitPollInterval {
+					t.Errorf("expected the Run to be requeued after the poll interval but got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertCondition(t, tc.run, tc.wantStatus, tc.wantReason)
+			if d := cmp.Diff(tc.wantResults, tc.run.Status.Results); d != "" {
+				t.Errorf("results %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileKind_CancelledOrTimedOut(t *testing.T) {
+	cancelled := waitRun(param("duration", "2h"))
+	cancelled.Spec.Status = v1alpha1.RunSpecStatusCancelled
+	if err := newReconciler(nil, nil).ReconcileKind(context.Background(), cancelled); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertCondition(t, cancelled, corev1.ConditionFalse, v1alpha1.RunReasonCancelled)
+
+	timedOut := waitRun(param("duration", "2h"))
+	timedOut.Status.StartTime = &metav1.Time{Time: time.Now().Add(-90 * time.Minute)}
+	if err := newReconciler(nil, nil).ReconcileKind(context.Background(), timedOut); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertCondition(t, timedOut, corev1.ConditionFalse, v1alpha1.RunReasonTimedOut)
+}
+
+func assertCondition(t *testing.T, run *v1alpha1.Run, status corev1.ConditionStatus, reason string) {
+	t.Helper()
+	c := run.Status.GetCondition(apis.ConditionSucceeded)
+	if c == nil || c.Status != status || c.Reason != reason {
+		t.Errorf("expected condition %s with reason %s but got %v", status, reason, c)
+	}
+}
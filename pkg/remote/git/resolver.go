@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	"github.com/tektoncd/pipeline/pkg/git"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// cacheTTL is how long the content of a file is cached. The content of a file at
+// a given commit never changes, the TTL only bounds the memory held by the cache.
+const cacheTTL = 24 * time.Hour
+
+// fetchMu serializes the fetches since git.Fetch changes the working directory
+// of the process.
+var fetchMu sync.Mutex
+
+// Cache holds the content of the files fetched from git repositories by commit, so
+// that a file is cloned once for each commit of its repository. It is safe for
+// concurrent use.
+type Cache struct {
+	lru *cache.LRUExpireCache
+}
+
+// NewCache returns a Cache holding the content of up to size files.
+func NewCache(size int) *Cache {
+	return &Cache{lru: cache.NewLRUExpireCache(size)}
+}
+
+func (c *Cache) get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	data, ok := c.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return data.([]byte), true
+}
+
+func (c *Cache) add(key string, data []byte) {
+	if c != nil {
+		c.lru.Add(key, data, cacheTTL)
+	}
+}
+
+// Resolver implements the Resolver interface for a Tekton resource stored in a
+// file of a git repository at a given revision.
+type Resolver struct {
+	logger    *zap.SugaredLogger
+	cache     *Cache
+	url       string
+	revision  string
+	path      string
+	sslVerify bool
+}
+
+var _ remote.Resolver = (*Resolver)(nil)
+
+// NewResolver returns a Resolver for the file at path in the git repository at url.
+// The revision is a branch, a tag or a commit SHA and defaults to the HEAD of the
+// repository. The fetched content is stored in the cache, which may be nil.
+func NewResolver(logger *zap.SugaredLogger, cache *Cache, url, revision, path string, sslVerify bool) *Resolver {
+	if revision == "" {
+		revision = "HEAD"
+	}
+	return &Resolver{
+		logger:    logger,
+		cache:     cache,
+		url:       url,
+		revision:  revision,
+		path:      path,
+		sslVerify: sslVerify,
+	}
+}
+
+// List returns the resource stored in the file.
+func (r *Resolver) List() ([]remote.ResolvedObject, error) {
+	obj, err := r.object()
+	if err != nil {
+		return nil, err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return []remote.ResolvedObject{{
+		Kind:       strings.ToLower(gvk.Kind),
+		APIVersion: gvk.GroupVersion().String(),
+		Name:       accessor.GetName(),
+	}}, nil
+}
+
+// Get returns the resource stored in the file if it is of the given kind and, unless the name
+// is empty, has the given name.
+func (r *Resolver) Get(kind, name string) (runtime.Object, error) {
+	obj, err := r.object()
+	if err != nil {
+		return nil, err
+	}
+	if resolvedKind := obj.GetObjectKind().GroupVersionKind().Kind; strings.ToLower(resolvedKind) != kind {
+		return nil, fmt.Errorf("%s in %s is a %s, expected a %s", r.path, r.url, resolvedKind, kind)
+	}
+	if name != "" {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		if accessor.GetName() != name {
+			return nil, fmt.Errorf("%s in %s is named %q, expected %q", r.path, r.url, accessor.GetName(), name)
+		}
+	}
+	return obj, nil
+}
+
+func (r *Resolver) object() (runtime.Object, error) {
+	data, err := r.Fetch()
+	if err != nil {
+		return nil, err
+	}
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s in %s: %w", r.path, r.url, err)
+	}
+	return obj, nil
+}
+
+// Fetch returns the content of the file. The revision is resolved to a commit first, so that
+// the file is only cloned if the cache doesn't hold its content at that commit already.
+func (r *Resolver) Fetch() ([]byte, error) {
+	commit, err := git.LsRemote(r.logger, r.url, r.revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve revision %q of %s: %w", r.revision, r.url, err)
+	}
+	key := fmt.Sprintf("%s@%s:%s", r.url, commit, r.path)
+	if data, ok := r.cache.get(key); ok {
+		return data, nil
+	}
+	data, err := r.fetchFile(commit)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.add(key, data)
+	return data, nil
+}
+
+// fetchFile clones the file at the given commit in a temporary directory and reads it.
+func (r *Resolver) fetchFile(commit string) ([]byte, error) {
+	fetchMu.Lock()
+	defer fetchMu.Unlock()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.Chdir(cwd); err != nil {
+			r.logger.Errorf("Failed to restore the working directory %s: %v", cwd, err)
+		}
+	}()
+	dir, err := ioutil.TempDir("", "git-resolver-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// Clean the path as an absolute path so that it can't point outside of the repository.
+	path := filepath.Clean("/" + r.path)
+	if err := git.Fetch(r.logger, git.FetchSpec{
+		URL:                       r.url,
+		Revision:                  commit,
+		Path:                      dir,
+		Depth:                     1,
+		SSLVerify:                 r.sslVerify,
+		SparseCheckoutDirectories: path,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s at %s: %w", r.url, commit, err)
+	}
+
+	file := filepath.Join(dir, path)
+	// A symlink could point outside of the repository.
+	if info, err := os.Lstat(file); err != nil {
+		return nil, fmt.Errorf("%s not found in %s at %s", r.path, r.url, commit)
+	} else if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s in %s at %s is not a regular file", r.path, r.url, commit)
+	}
+	return ioutil.ReadFile(file)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+)
+
+const taskYAML = `apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: hello
+spec:
+  steps:
+  - image: busybox
+    script: echo %s
+`
+
+// createBareRepo creates a bare git repository in a temporary directory and returns its
+// path, which is used as its URL. The repository holds a Task in task/hello.yaml and
+// returns the commit of each version of the Task, "first" being tagged v1.
+func createBareRepo(t *testing.T) (string, []string) {
+	t.Helper()
+	work, err := ioutil.TempDir("", "git-resolver-work-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(work) })
+	bare, err := ioutil.TempDir("", "git-resolver-bare-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(bare) })
+
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		args = append([]string{"-c", "user.email=tester@tekton.dev", "-c", "user.name=Tekton Test"}, args...)
+		c := exec.Command("git", args...)
+		c.Dir = dir
+		out, err := c.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	git(work, "init")
+	git(work, "checkout", "-b", "main")
+	if err := os.MkdirAll(filepath.Join(work, "task"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	var commits []string
+	for _, message := range []string{"first", "second"} {
+		content := strings.Replace(taskYAML, "%s", message, 1)
+		if err := ioutil.WriteFile(filepath.Join(work, "task", "hello.yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git(work, "add", ".")
+		git(work, "commit", "-m", message)
+		commits = append(commits, git(work, "rev-parse", "HEAD"))
+		if message == "first" {
+			git(work, "tag", "v1")
+		}
+	}
+	git(work, "clone", "--bare", work, bare)
+	return bare, commits
+}
+
+func script(t *testing.T, r *Resolver) string {
+	t.Helper()
+	obj, err := r.Get("task", "hello")
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	task, ok := obj.(*v1beta1.Task)
+	if !ok {
+		t.Fatalf("expected a Task but got %T", obj)
+	}
+	return task.Spec.Steps[0].Script
+}
+
+func TestResolver_Get(t *testing.T) {
+	url, commits := createBareRepo(t)
+	logger := zap.NewNop().Sugar()
+
+	for _, tc := range []struct {
+		name     string
+		revision string
+		want     string
+	}{{
+		name: "default revision",
+		want: "echo second",
+	}, {
+		name:     "branch",
+		revision: "main",
+		want:     "echo second",
+	}, {
+		name:     "tag",
+		revision: "v1",
+		want:     "echo first",
+	}, {
+		name:     "commit",
+		revision: commits[0],
+		want:     "echo first",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewResolver(logger, nil, url, tc.revision, "task/hello.yaml", false)
+			if got := script(t, r); got != tc.want {
+				t.Errorf("expected script %q but got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestResolver_List(t *testing.T) {
+	url, _ := createBareRepo(t)
+	r := NewResolver(zap.NewNop().Sugar(), nil, url, "main", "task/hello.yaml", false)
+	got, err := r.List()
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	want := []remote.ResolvedObject{{Kind: "task", APIVersion: "tekton.dev/v1beta1", Name: "hello"}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("List() diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolver_GetErrors(t *testing.T) {
+	url, _ := createBareRepo(t)
+	logger := zap.NewNop().Sugar()
+
+	for _, tc := range []struct {
+		name     string
+		revision string
+		path     string
+		kind     string
+		objName  string
+	}{{
+		name:     "missing revision",
+		revision: "missing",
+		path:     "task/hello.yaml",
+		kind:     "task",
+	}, {
+		name:     "missing file",
+		revision: "main",
+		path:     "task/missing.yaml",
+		kind:     "task",
+	}, {
+		name:     "path outside of the repository",
+		revision: "main",
+		path:     "../../etc/passwd",
+		kind:     "task",
+	}, {
+		name:     "wrong kind",
+		revision: "main",
+		path:     "task/hello.yaml",
+		kind:     "pipeline",
+	}, {
+		name:     "wrong name",
+		revision: "main",
+		path:     "task/hello.yaml",
+		kind:     "task",
+		objName:  "goodbye",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewResolver(logger, nil, url, tc.revision, tc.path, false)
+			if _, err := r.Get(tc.kind, tc.objName); err == nil {
+				t.Error("expected an error but got none")
+			}
+		})
+	}
+}
+
+func TestResolver_Cache(t *testing.T) {
+	url, commits := createBareRepo(t)
+	logger := zap.NewNop().Sugar()
+	cache := NewCache(10)
+
+	r := NewResolver(logger, cache, url, "main", "task/hello.yaml", false)
+	if got := script(t, r); got != "echo second" {
+		t.Fatalf("expected script %q but got %q", "echo second", got)
+	}
+	key := url + "@" + commits[1] + ":task/hello.yaml"
+	if _, ok := cache.get(key); !ok {
+		t.Fatalf("expected the content of the file to be cached under %q", key)
+	}
+
+	// The content cached for a commit is returned without cloning the repository again.
+	cache.add(key, []byte(strings.Replace(taskYAML, "%s", "cached", 1)))
+	if got := script(t, NewResolver(logger, cache, url, commits[1], "task/hello.yaml", false)); got != "echo cached" {
+		t.Errorf("expected the cached script but got %q", got)
+	}
+	// Another commit isn't cached yet.
+	if got := script(t, NewResolver(logger, cache, url, "v1", "task/hello.yaml", false)); got != "echo first" {
+		t.Errorf("expected script %q but got %q", "echo first", got)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolution
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
+)
+
+var (
+	// ErrorRequestInProgress is returned when the ResolutionRequest for a remote
+	// resource has been created but hasn't been fulfilled by its resolver yet.
+	ErrorRequestInProgress = errors.New("resource request is still in-progress")
+	// ErrorRequestFailed is returned when the resolver failed to fulfill the
+	// ResolutionRequest for a remote resource.
+	ErrorRequestFailed = errors.New("resource request failed")
+)
+
+// Resolver implements the Resolver interface by creating a ResolutionRequest that is
+// fulfilled out of band by the resolver it names, so that fetching the resource never
+// blocks the reconciler. The ResolutionRequest is owned by the object that references
+// the resource and its name is derived from the owner, the resolver and the params, so
+// that each reconcile of the owner finds the same request.
+type Resolver struct {
+	ctx          context.Context
+	tekton       clientset.Interface
+	owner        kmeta.OwnerRefableAccessor
+	resolverName string
+	params       []v1beta1.Param
+}
+
+var _ remote.Resolver = (*Resolver)(nil)
+
+// NewResolver returns a remote.Resolver that requests the resource identified by the
+// params from the named resolver on behalf of owner.
+func NewResolver(ctx context.Context, tekton clientset.Interface, owner kmeta.OwnerRefableAccessor, resolverName string, params []v1beta1.Param) remote.Resolver {
+	return &Resolver{
+		ctx:          ctx,
+		tekton:       tekton,
+		owner:        owner,
+		resolverName: resolverName,
+		params:       params,
+	}
+}
+
+// List is not supported since a resolver returns a single resource.
+func (r *Resolver) List() ([]remote.ResolvedObject, error) {
+	return nil, fmt.Errorf("listing the resources of resolver %q is not supported", r.resolverName)
+}
+
+// Get returns the resource resolved by the resolver if its ResolutionRequest succeeded.
+// It creates the ResolutionRequest if it doesn't exist yet and returns ErrorRequestInProgress
+// until the resolver fulfills it. The name is ignored since the params identify the resource,
+// but the resolved resource must be of the given kind.
+func (r *Resolver) Get(kind, _ string) (runtime.Object, error) {
+	name, err := r.requestName()
+	if err != nil {
+		return nil, err
+	}
+	namespace := r.owner.GetNamespace()
+	rr, err := r.tekton.TektonV1alpha1().ResolutionRequests(namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, r.createRequest(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ResolutionRequest %s/%s: %w", namespace, name, err)
+	}
+
+	condition := rr.Status.GetCondition(apis.ConditionSucceeded)
+	switch {
+	case condition.IsUnknown():
+		return nil, ErrorRequestInProgress
+	case condition.IsFalse():
+		return nil, fmt.Errorf("%w: resolver %q: %s", ErrorRequestFailed, r.resolverName, condition.Message)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(rr.Status.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the data of ResolutionRequest %s/%s: %w", namespace, name, err)
+	}
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the resource resolved by resolver %q: %w", r.resolverName, err)
+	}
+	if resolvedKind := obj.GetObjectKind().GroupVersionKind().Kind; strings.ToLower(resolvedKind) != kind {
+		return nil, fmt.Errorf("resolver %q returned a %s, expected a %s", r.resolverName, resolvedKind, kind)
+	}
+	return obj, nil
+}
+
+// createRequest creates the ResolutionRequest with the given name and returns
+// ErrorRequestInProgress, since the resolver hasn't fulfilled it yet.
+func (r *Resolver) createRequest(name string) error {
+	rr := &v1alpha1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       r.owner.GetNamespace(),
+			Labels:          map[string]string{v1alpha1.ResolverTypeLabelKey: r.resolverName},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(r.owner)},
+		},
+		Spec: v1alpha1.ResolutionRequestSpec{
+			Params: r.params,
+		},
+	}
+	if _, err := r.tekton.TektonV1alpha1().ResolutionRequests(rr.Namespace).Create(r.ctx, rr, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create ResolutionRequest %s/%s: %w", rr.Namespace, name, err)
+	}
+	return ErrorRequestInProgress
+}
+
+// requestName returns the name of the ResolutionRequest made on behalf of the owner.
+func (r *Resolver) requestName() (string, error) {
+	params, err := json.Marshal(r.params)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize the params of resolver %q: %w", r.resolverName, err)
+	}
+	h := sha256.New()
+	h.Write([]byte(r.resolverName))
+	h.Write(params)
+	hash := fmt.Sprintf("%x", h.Sum(nil))[:8]
+	return kmeta.ChildName(r.owner.GetName(), fmt.Sprintf("-%s-%s", r.resolverName, hash)), nil
+}
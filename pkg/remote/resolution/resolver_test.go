@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolution_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/remote/resolution"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const taskYAML = `apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: hello
+spec:
+  steps:
+  - image: busybox
+    script: echo hello
+`
+
+func TestResolver_Get(t *testing.T) {
+	ctx := context.Background()
+	owner := &v1beta1.TaskRun{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "TaskRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "foo", UID: "uid"},
+	}
+	params := []v1beta1.Param{{Name: "path", Value: *v1beta1.NewArrayOrString("task.yaml")}}
+	tekton := fake.NewSimpleClientset()
+	resolver := resolution.NewResolver(ctx, tekton, owner, "git", params)
+
+	// The first Get creates the ResolutionRequest.
+	if _, err := resolver.Get("task", "hello"); !errors.Is(err, resolution.ErrorRequestInProgress) {
+		t.Fatalf("expected ErrorRequestInProgress but got %v", err)
+	}
+	rrs, err := tekton.TektonV1alpha1().ResolutionRequests("foo").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rrs.Items) != 1 {
+		t.Fatalf("expected 1 ResolutionRequest but got %d", len(rrs.Items))
+	}
+	rr := &rrs.Items[0]
+	if d := cmp.Diff(map[string]string{v1alpha1.ResolverTypeLabelKey: "git"}, rr.Labels); d != "" {
+		t.Errorf("ResolutionRequest labels diff %s", diff.PrintWantGot(d))
+	}
+	if len(rr.OwnerReferences) != 1 || rr.OwnerReferences[0].Name != "run" || rr.OwnerReferences[0].Kind != "TaskRun" {
+		t.Errorf("expected the ResolutionRequest to be owned by the TaskRun but got %v", rr.OwnerReferences)
+	}
+	if d := cmp.Diff(params, rr.Spec.Params); d != "" {
+		t.Errorf("ResolutionRequest params diff %s", diff.PrintWantGot(d))
+	}
+
+	// The ResolutionRequest hasn't been fulfilled yet.
+	if _, err := resolver.Get("task", "hello"); !errors.Is(err, resolution.ErrorRequestInProgress) {
+		t.Fatalf("expected ErrorRequestInProgress but got %v", err)
+	}
+
+	// A ResolutionRequest with the same owner, resolver and params is reused.
+	rr.Status.InitializeConditions()
+	rr.Status.Data = base64.StdEncoding.EncodeToString([]byte(taskYAML))
+	rr.Status.MarkSucceeded()
+	if _, err := tekton.TektonV1alpha1().ResolutionRequests("foo").UpdateStatus(ctx, rr, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	obj, err := resolution.NewResolver(ctx, tekton, owner, "git", params).Get("task", "hello")
+	if err != nil {
+		t.Fatalf("expected the Task to be resolved but got %v", err)
+	}
+	task, ok := obj.(*v1beta1.Task)
+	if !ok {
+		t.Fatalf("expected a Task but got %T", obj)
+	}
+	if task.Name != "hello" || len(task.Spec.Steps) != 1 || task.Spec.Steps[0].Script != "echo hello" {
+		t.Errorf("unexpected resolved Task %v", task)
+	}
+
+	// The resolved resource must be of the requested kind.
+	if _, err := resolver.Get("pipeline", "hello"); err == nil {
+		t.Error("expected an error when resolving a Task as a Pipeline but got none")
+	}
+}
+
+func TestResolver_GetFailed(t *testing.T) {
+	ctx := context.Background()
+	owner := &v1beta1.PipelineRun{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "foo"},
+	}
+	tekton := fake.NewSimpleClientset()
+	resolver := resolution.NewResolver(ctx, tekton, owner, "git", nil)
+	if _, err := resolver.Get("pipeline", ""); !errors.Is(err, resolution.ErrorRequestInProgress) {
+		t.Fatalf("expected ErrorRequestInProgress but got %v", err)
+	}
+	rrs, err := tekton.TektonV1alpha1().ResolutionRequests("foo").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := &rrs.Items[0]
+	rr.Status.MarkFailed(v1alpha1.ResolutionRequestReasonFailed, "repository not found")
+	if _, err := tekton.TektonV1alpha1().ResolutionRequests("foo").UpdateStatus(ctx, rr, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resolver.Get("pipeline", ""); !errors.Is(err, resolution.ErrorRequestFailed) {
+		t.Fatalf("expected ErrorRequestFailed but got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	resolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/resolutionrequest"
+	resolutionrequestreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/resolutionrequest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// NewController returns a constructor for the controller that fulfills the
+// ResolutionRequests of the given resolver, to be passed to sharedmain.
+func NewController(resolver Resolver) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		if err := resolver.Initialize(ctx); err != nil {
+			logger.Fatalf("Error initializing resolver: %v", err)
+		}
+		name := resolver.GetName(ctx)
+		resolutionRequestInformer := resolutionrequestinformer.Get(ctx)
+
+		c := &Reconciler{
+			resolver: resolver,
+			timeout:  defaultResolutionTimeout,
+		}
+		// Only the ResolutionRequests labeled with the name of the resolver are its own.
+		filter := func(obj interface{}) bool {
+			object, ok := obj.(metav1.Object)
+			return ok && object.GetLabels()[v1alpha1.ResolverTypeLabelKey] == name
+		}
+		impl := resolutionrequestreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
+			return controller.Options{
+				AgentName:         name + "-resolver",
+				PromoteFilterFunc: filter,
+			}
+		})
+
+		c.enqueue = impl.EnqueueKey
+
+		resolutionRequestInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: filter,
+			Handler:    controller.HandleAll(impl.Enqueue),
+		})
+
+		return impl
+	}
+}
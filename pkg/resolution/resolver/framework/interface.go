@@ -0,0 +1,42 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+)
+
+// Resolver is the interface implemented by a resolver to fetch the remote
+// resources requested by the ResolutionRequests labeled with its name.
+type Resolver interface {
+	// Initialize is called once when the controller of the resolver
+	// is created, before any ResolutionRequest is reconciled.
+	Initialize(ctx context.Context) error
+
+	// GetName returns the name of the resolver, which is the value of
+	// the resolver field of the TaskRefs and PipelineRefs it resolves.
+	GetName(ctx context.Context) string
+
+	// ValidateParams returns an error if the params of a ResolutionRequest
+	// don't identify a resource for this resolver.
+	ValidateParams(ctx context.Context, params map[string]string) error
+
+	// Resolve returns the content of the resource identified by the params,
+	// typically the YAML of a Task or a Pipeline. The context is cancelled
+	// when the resolution times out.
+	Resolve(ctx context.Context, params map[string]string) ([]byte, error)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutionrequestreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/resolutionrequest"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+// defaultResolutionTimeout is the time a resolver is given to resolve a resource
+// before its ResolutionRequest fails.
+const defaultResolutionTimeout = time.Minute
+
+// Reconciler fulfills the ResolutionRequests of a Resolver. Resources are
+// resolved in the background, so that a slow resolver doesn't hold a worker of
+// the controller: a ResolutionRequest is enqueued again once its resource is
+// resolved, and its status is written then.
+//
+// The status of a ResolutionRequest records that its resolution started, and
+// when. A ResolutionRequest in progress that isn't being resolved by this
+// process, because the resolver restarted or another replica was leading when it
+// started, is resolved again within what is left of its timeout.
+type Reconciler struct {
+	resolver Resolver
+	timeout  time.Duration
+	// enqueue enqueues the ResolutionRequest with the given key.
+	enqueue func(types.NamespacedName)
+	// resolutions holds the *resolution of each ResolutionRequest being resolved
+	// by this process, or resolved but whose status isn't written yet, by UID.
+	resolutions sync.Map
+}
+
+// resolution is the result of the resolution of a resource, set before done is closed.
+type resolution struct {
+	done     chan struct{}
+	data     []byte
+	err      error
+	timedOut bool
+}
+
+// Check that our Reconciler implements resolutionrequestreconciler.Interface
+var _ resolutionrequestreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind resolves the resource requested by rr and writes it to its status.
+// The status of a ResolutionRequest is final: once it has succeeded or failed,
+// it is not resolved again.
+func (r *Reconciler) ReconcileKind(ctx context.Context, rr *v1alpha1.ResolutionRequest) reconciler.Event {
+	logger := logging.FromContext(ctx)
+	name := r.resolver.GetName(ctx)
+	if rr.Labels[v1alpha1.ResolverTypeLabelKey] != name || rr.IsDone() {
+		return nil
+	}
+	if rr.Status.GetCondition(apis.ConditionSucceeded) == nil {
+		rr.Status.InitializeConditions()
+	}
+
+	params, err := paramsToMap(rr.Spec.Params)
+	if err == nil {
+		err = r.resolver.ValidateParams(ctx, params)
+	}
+	if err != nil {
+		logger.Infof("ResolutionRequest %s/%s has invalid params: %v", rr.Namespace, rr.Name, err)
+		rr.Status.MarkFailed(v1alpha1.ResolutionRequestReasonInvalidParams, "invalid params for resolver %q: %v", name, err)
+		return nil
+	}
+
+	v, started := r.resolutions.LoadOrStore(rr.UID, &resolution{done: make(chan struct{})})
+	res := v.(*resolution)
+	if !started {
+		if !rr.Status.IsResolving() {
+			rr.Status.MarkResolving()
+		}
+		// The resolution started when the status was first marked, which may
+		// have been before the resolver restarted.
+		timeout := r.timeout - time.Since(rr.Status.GetCondition(apis.ConditionSucceeded).LastTransitionTime.Inner.Time)
+		if timeout > 0 {
+			go r.resolve(ctx, res, params, types.NamespacedName{Namespace: rr.Namespace, Name: rr.Name}, timeout)
+			return nil
+		}
+		res.timedOut = true
+		close(res.done)
+	}
+	select {
+	case <-res.done:
+	default:
+		// The ResolutionRequest is enqueued again once it is resolved.
+		return nil
+	}
+	r.resolutions.Delete(rr.UID)
+
+	switch {
+	case res.timedOut:
+		rr.Status.MarkFailed(v1alpha1.ResolutionRequestReasonTimedOut, "resolver %q didn't resolve the resource in %s", name, r.timeout)
+	case res.err != nil:
+		rr.Status.MarkFailed(v1alpha1.ResolutionRequestReasonFailed, "resolver %q failed: %v", name, res.err)
+	default:
+		rr.Status.Data = base64.StdEncoding.EncodeToString(res.data)
+		rr.Status.MarkSucceeded()
+	}
+	return nil
+}
+
+// resolve resolves the resource identified by the params within the given timeout,
+// records the result in res and enqueues the ResolutionRequest with the given key,
+// so that its status is written.
+func (r *Reconciler) resolve(ctx context.Context, res *resolution, params map[string]string, key types.NamespacedName, timeout time.Duration) {
+	resolveCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	res.data, res.err = r.resolver.Resolve(resolveCtx, params)
+	res.timedOut = errors.Is(resolveCtx.Err(), context.DeadlineExceeded)
+	close(res.done)
+	r.enqueue(key)
+}
+
+// paramsToMap returns the values of the params by name. Resolvers only accept
+// string params.
+func paramsToMap(params []v1beta1.Param) (map[string]string, error) {
+	m := make(map[string]string, len(params))
+	for _, p := range params {
+		if p.Value.Type != v1beta1.ParamTypeString {
+			return nil, fmt.Errorf("param %q must be a string", p.Name)
+		}
+		m[p.Name] = p.Value.StringVal
+	}
+	return m, nil
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+)
+
+type fakeResolver struct {
+	resolve func(ctx context.Context, params map[string]string) ([]byte, error)
+}
+
+func (*fakeResolver) Initialize(context.Context) error { return nil }
+
+func (*fakeResolver) GetName(context.Context) string { return "fake" }
+
+func (*fakeResolver) ValidateParams(_ context.Context, params map[string]string) error {
+	if _, ok := params["path"]; !ok {
+		return errors.New("missing path")
+	}
+	return nil
+}
+
+func (r *fakeResolver) Resolve(ctx context.Context, params map[string]string) ([]byte, error) {
+	return r.resolve(ctx, params)
+}
+
+func request(resolver string, params ...v1beta1.Param) *v1alpha1.ResolutionRequest {
+	return &v1alpha1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rr",
+			Namespace: "foo",
+			UID:       "uid",
+			Labels:    map[string]string{v1alpha1.ResolverTypeLabelKey: resolver},
+		},
+		Spec: v1alpha1.ResolutionRequestSpec{Params: params},
+	}
+}
+
+func TestReconcileKind(t *testing.T) {
+	pathParam := v1beta1.Param{Name: "path", Value: *v1beta1.NewArrayOrString("task.yaml")}
+	resolveContent := func(_ context.Context, params map[string]string) ([]byte, error) {
+		return []byte("content of " + params["path"]), nil
+	}
+
+	for _, tc := range []struct {
+		name       string
+		rr         *v1alpha1.ResolutionRequest
+		resolve    func(ctx context.Context, params map[string]string) ([]byte, error)
+		timeout    time.Duration
+		wantStatus corev1.ConditionStatus
+		wantReason string
+		wantData   string
+	}{{
+		name:       "resolved",
+		rr:         request("fake", pathParam),
+		resolve:    resolveContent,
+		wantStatus: corev1.ConditionTrue,
+		wantData:   "content of task.yaml",
+	}, {
+		name:       "invalid params",
+		rr:         request("fake"),
+		resolve:    resolveContent,
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ResolutionRequestReasonInvalidParams,
+	}, {
+		name:       "array param",
+		rr:         request("fake", v1beta1.Param{Name: "path", Value: *v1beta1.NewArrayOrString("a", "b")}),
+		resolve:    resolveContent,
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ResolutionRequestReasonInvalidParams,
+	}, {
+		name: "resolution failed",
+		rr:   request("fake", pathParam),
+		resolve: func(context.Context, map[string]string) ([]byte, error) {
+			return nil, errors.New("not found")
+		},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ResolutionRequestReasonFailed,
+	}, {
+		name: "resolution timed out",
+		rr:   request("fake", pathParam),
+		resolve: func(ctx context.Context, _ map[string]string) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		timeout:    time.Millisecond,
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ResolutionRequestReasonTimedOut,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			timeout := tc.timeout
+			if timeout == 0 {
+				timeout = time.Minute
+			}
+			enqueued := make(chan types.NamespacedName, 1)
+			r := &Reconciler{
+				resolver: &fakeResolver{resolve: tc.resolve},
+				timeout:  timeout,
+				enqueue:  func(key types.NamespacedName) { enqueued <- key },
+			}
+			if err := r.ReconcileKind(context.Background(), tc.rr); err != nil {
+				t.Fatalf("ReconcileKind: %v", err)
+			}
+			// The resource is resolved in the background, and the request is
+			// enqueued again to write its status.
+			if !tc.rr.IsDone() {
+				select {
+				case key := <-enqueued:
+					if want := (types.NamespacedName{Namespace: "foo", Name: "rr"}); key != want {
+						t.Errorf("expected %s to be enqueued but got %s", want, key)
+					}
+				case <-time.After(10 * time.Second):
+					t.Fatal("expected the request to be enqueued once resolved")
+				}
+				if err := r.ReconcileKind(context.Background(), tc.rr); err != nil {
+					t.Fatalf("ReconcileKind: %v", err)
+				}
+			}
+			condition := tc.rr.Status.GetCondition(apis.ConditionSucceeded)
+			if condition == nil {
+				t.Fatal("expected the Succeeded condition to be set")
+			}
+			if condition.Status != tc.wantStatus || condition.Reason != tc.wantReason {
+				t.Errorf("expected condition %s with reason %q but got %s with reason %q", tc.wantStatus, tc.wantReason, condition.Status, condition.Reason)
+			}
+			data, err := base64.StdEncoding.DecodeString(tc.rr.Status.Data)
+			if err != nil {
+				t.Fatalf("failed to decode data: %v", err)
+			}
+			if string(data) != tc.wantData {
+				t.Errorf("expected data %q but got %q", tc.wantData, data)
+			}
+		})
+	}
+}
+
+func TestReconcileKind_InProgress(t *testing.T) {
+	release := make(chan struct{})
+	enqueued := make(chan types.NamespacedName, 1)
+	resolved := 0
+	r := &Reconciler{
+		resolver: &fakeResolver{resolve: func(context.Context, map[string]string) ([]byte, error) {
+			resolved++
+			<-release
+			return []byte("content"), nil
+		}},
+		timeout: time.Minute,
+		enqueue: func(key types.NamespacedName) { enqueued <- key },
+	}
+	rr := request("fake", v1beta1.Param{Name: "path", Value: *v1beta1.NewArrayOrString("task.yaml")})
+
+	// Reconciling the request while its resource is resolved doesn't block,
+	// nor resolve it again.
+	for i := 0; i < 2; i++ {
+		if err := r.ReconcileKind(context.Background(), rr); err != nil {
+			t.Fatalf("ReconcileKind: %v", err)
+		}
+		if !rr.Status.IsResolving() {
+			t.Fatalf("expected the request to be in progress but got %v", rr.Status.GetCondition(apis.ConditionSucceeded))
+		}
+	}
+
+	close(release)
+	<-enqueued
+	if err := r.ReconcileKind(context.Background(), rr); err != nil {
+		t.Fatalf("ReconcileKind: %v", err)
+	}
+	if !rr.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("expected the request to succeed but got %v", rr.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if resolved != 1 {
+		t.Errorf("expected the resource to be resolved once but it was resolved %d times", resolved)
+	}
+}
+
+func TestReconcileKind_InProgressElsewhere(t *testing.T) {
+	pathParam := v1beta1.Param{Name: "path", Value: *v1beta1.NewArrayOrString("task.yaml")}
+	for _, tc := range []struct {
+		name         string
+		startedAgo   time.Duration
+		wantResolved bool
+		wantStatus   corev1.ConditionStatus
+		wantReason   string
+	}{{
+		name:         "resolved again",
+		startedAgo:   10 * time.Second,
+		wantResolved: true,
+		wantStatus:   corev1.ConditionTrue,
+	}, {
+		name:       "timed out",
+		startedAgo: 2 * time.Minute,
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ResolutionRequestReasonTimedOut,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The request was being resolved when the resolver restarted, so
+			// this Reconciler doesn't hold its resolution.
+			rr := request("fake", pathParam)
+			rr.Status.MarkResolving()
+			rr.Status.Conditions[0].LastTransitionTime = apis.VolatileTime{Inner: metav1.NewTime(time.Now().Add(-tc.startedAgo))}
+
+			enqueued := make(chan types.NamespacedName, 1)
+			var timeout time.Duration
+			r := &Reconciler{
+				resolver: &fakeResolver{resolve: func(ctx context.Context, _ map[string]string) ([]byte, error) {
+					deadline, _ := ctx.Deadline()
+					timeout = time.Until(deadline)
+					return []byte("content"), nil
+				}},
+				timeout: time.Minute,
+				enqueue: func(key types.NamespacedName) { enqueued <- key },
+			}
+			if err := r.ReconcileKind(context.Background(), rr); err != nil {
+				t.Fatalf("ReconcileKind: %v", err)
+			}
+			if tc.wantResolved {
+				select {
+				case <-enqueued:
+				case <-time.After(10 * time.Second):
+					t.Fatal("expected the request to be enqueued once resolved")
+				}
+				// The resolution is given what is left of the timeout.
+				if timeout > 50*time.Second {
+					t.Errorf("expected the resolution to be given at most 50s but got %s", timeout)
+				}
+				if err := r.ReconcileKind(context.Background(), rr); err != nil {
+					t.Fatalf("ReconcileKind: %v", err)
+				}
+			}
+			condition := rr.Status.GetCondition(apis.ConditionSucceeded)
+			if condition.Status != tc.wantStatus || condition.Reason != tc.wantReason {
+				t.Errorf("expected condition %s with reason %q but got %s with reason %q", tc.wantStatus, tc.wantReason, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestReconcileKind_Skipped(t *testing.T) {
+	done := request("fake")
+	done.Status.MarkFailed(v1alpha1.ResolutionRequestReasonFailed, "failed")
+	for _, tc := range []struct {
+		name string
+		rr   *v1alpha1.ResolutionRequest
+	}{{
+		name: "other resolver",
+		rr:   request("other"),
+	}, {
+		name: "done",
+		rr:   done,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			before := tc.rr.DeepCopy()
+			r := &Reconciler{
+				resolver: &fakeResolver{resolve: func(context.Context, map[string]string) ([]byte, error) {
+					t.Fatal("expected the request not to be resolved")
+					return nil, nil
+				}},
+				timeout: time.Minute,
+			}
+			if err := r.ReconcileKind(context.Background(), tc.rr); err != nil {
+				t.Fatalf("ReconcileKind: %v", err)
+			}
+			if tc.rr.Status.GetCondition(apis.ConditionSucceeded).GetReason() != before.Status.GetCondition(apis.ConditionSucceeded).GetReason() {
+				t.Errorf("expected the status of the request not to change")
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	remotegit "github.com/tektoncd/pipeline/pkg/remote/git"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// ResolverName is the name of the git resolver, used in the resolver
+	// field of TaskRefs and PipelineRefs.
+	ResolverName = "git"
+
+	// URLParam is the URL of the git repository.
+	URLParam = "url"
+	// RevisionParam is the branch, tag or commit SHA to fetch, defaulting
+	// to the HEAD of the repository.
+	RevisionParam = "revision"
+	// PathParam is the path of the file holding the resource in the repository.
+	PathParam = "pathInRepo"
+
+	// cacheSize is the number of files whose content is cached by commit.
+	cacheSize = 1000
+)
+
+var (
+	// urlSchemes are the schemes of the urls the resolver fetches from. The other ones, such
+	// as file:// or ext::, would let the url read the files of the resolver or run commands.
+	urlSchemes = []string{"https://", "ssh://", "git://"}
+	// scpURLRegexFormat matches the scp-like urls of ssh git repositories, such as
+	// git@github.com:tektoncd/catalog.git.
+	scpURLRegexFormat = regexp.MustCompile(`^([\w.~-]+@)?\w[\w.-]*:[^:]`)
+)
+
+// Resolver fetches Tasks and Pipelines from git repositories.
+type Resolver struct {
+	logger *zap.SugaredLogger
+	cache  *remotegit.Cache
+}
+
+var _ framework.Resolver = (*Resolver)(nil)
+
+// NewResolver returns a new git Resolver.
+func NewResolver() *Resolver {
+	return &Resolver{}
+}
+
+// Initialize sets up the cache of the fetched files.
+func (r *Resolver) Initialize(ctx context.Context) error {
+	r.logger = logging.FromContext(ctx)
+	r.cache = remotegit.NewCache(cacheSize)
+	return nil
+}
+
+// GetName returns the name of the git resolver.
+func (r *Resolver) GetName(context.Context) string {
+	return ResolverName
+}
+
+// ValidateParams checks that the url and the path of the file are set, that no
+// unknown param is, and that the url and the revision can't be taken for options
+// of git.
+func (r *Resolver) ValidateParams(_ context.Context, params map[string]string) error {
+	var missing, unknown []string
+	for _, name := range []string{URLParam, PathParam} {
+		if params[name] == "" {
+			missing = append(missing, name)
+		}
+	}
+	for name := range params {
+		if name != URLParam && name != RevisionParam && name != PathParam {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	switch {
+	case len(missing) > 0:
+		return fmt.Errorf("missing params %v", missing)
+	case len(unknown) > 0:
+		return fmt.Errorf("unknown params %v", unknown)
+	}
+	if revision := params[RevisionParam]; strings.HasPrefix(revision, "-") {
+		return fmt.Errorf("invalid %s %q: it must not start with \"-\"", RevisionParam, revision)
+	}
+	return validateURL(strings.TrimSpace(params[URLParam]))
+}
+
+// validateURL checks that the url is an https, ssh or git url, or an scp-like ssh url.
+func validateURL(url string) error {
+	if strings.HasPrefix(url, "-") {
+		return fmt.Errorf("invalid %s %q: it must not start with \"-\"", URLParam, url)
+	}
+	if strings.Contains(url, "://") {
+		for _, scheme := range urlSchemes {
+			if strings.HasPrefix(url, scheme) {
+				return nil
+			}
+		}
+	} else if scpURLRegexFormat.MatchString(url) {
+		return nil
+	}
+	return fmt.Errorf("invalid %s %q: it must start with one of %v or be of the form [user@]host:path", URLParam, url, urlSchemes)
+}
+
+// Resolve fetches the file from the git repository, or returns its content from the cache
+// if the revision points to a commit that was fetched already.
+func (r *Resolver) Resolve(ctx context.Context, params map[string]string) ([]byte, error) {
+	resolver := remotegit.NewResolver(r.logger, r.cache, params[URLParam], params[RevisionParam], params[PathParam], true)
+
+	type result struct {
+		data []byte
+		err  error
+	}
+	// Fetching doesn't take a context, so stop waiting for it when the context is done.
+	done := make(chan result, 1)
+	go func() {
+		data, err := resolver.Fetch()
+		done <- result{data: data, err: err}
+	}()
+	select {
+	case res := <-done:
+		return res.data, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
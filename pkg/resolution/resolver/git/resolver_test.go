@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/git"
+)
+
+const pipelineYAML = `apiVersion: tekton.dev/v1beta1
+kind: Pipeline
+metadata:
+  name: build
+`
+
+// createBareRepo returns the path of a bare git repository holding
+// pipelineYAML in pipelines/build.yaml.
+func createBareRepo(t *testing.T) string {
+	t.Helper()
+	work, err := ioutil.TempDir("", "git-resolver-work-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(work) })
+	bare, err := ioutil.TempDir("", "git-resolver-bare-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(bare) })
+
+	if err := os.MkdirAll(filepath.Join(work, "pipelines"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(work, "pipelines", "build.yaml"), []byte(pipelineYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init"},
+		{"add", "."},
+		{"-c", "user.email=tester@tekton.dev", "-c", "user.name=Tekton Test", "commit", "-m", "build pipeline"},
+		{"clone", "--bare", work, bare},
+	} {
+		c := exec.Command("git", args...)
+		c.Dir = work
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return bare
+}
+
+func TestResolver_ValidateParams(t *testing.T) {
+	ctx := context.Background()
+	r := git.NewResolver()
+	if err := r.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name    string
+		params  map[string]string
+		wantErr bool
+	}{{
+		name:   "url and path",
+		params: map[string]string{git.URLParam: "https://github.com/tektoncd/catalog", git.PathParam: "task.yaml"},
+	}, {
+		name:   "with revision",
+		params: map[string]string{git.URLParam: "https://github.com/tektoncd/catalog", git.RevisionParam: "main", git.PathParam: "task.yaml"},
+	}, {
+		name:    "missing url",
+		params:  map[string]string{git.PathParam: "task.yaml"},
+		wantErr: true,
+	}, {
+		name:    "missing path",
+		params:  map[string]string{git.URLParam: "https://github.com/tektoncd/catalog"},
+		wantErr: true,
+	}, {
+		name:    "unknown param",
+		params:  map[string]string{git.URLParam: "https://github.com/tektoncd/catalog", git.PathParam: "task.yaml", "branch": "main"},
+		wantErr: true,
+	}, {
+		name:   "ssh url",
+		params: map[string]string{git.URLParam: "ssh://git@github.com/tektoncd/catalog.git", git.PathParam: "task.yaml"},
+	}, {
+		name:   "git url",
+		params: map[string]string{git.URLParam: "git://github.com/tektoncd/catalog.git", git.PathParam: "task.yaml"},
+	}, {
+		name:   "scp-like url",
+		params: map[string]string{git.URLParam: "git@github.com:tektoncd/catalog.git", git.PathParam: "task.yaml"},
+	}, {
+		name:    "url taken for an option",
+		params:  map[string]string{git.URLParam: "--upload-pack=touch /tmp/pwned; false", git.PathParam: "task.yaml"},
+		wantErr: true,
+	}, {
+		name:    "revision taken for an option",
+		params:  map[string]string{git.URLParam: "https://github.com/tektoncd/catalog", git.RevisionParam: "--output=/tmp/pwned", git.PathParam: "task.yaml"},
+		wantErr: true,
+	}, {
+		name:    "file url",
+		params:  map[string]string{git.URLParam: "file:///etc", git.PathParam: "task.yaml"},
+		wantErr: true,
+	}, {
+		name:    "ext url",
+		params:  map[string]string{git.URLParam: "ext::sh -c touch% /tmp/pwned", git.PathParam: "task.yaml"},
+		wantErr: true,
+	}, {
+		name:    "local path",
+		params:  map[string]string{git.URLParam: "/var/run/secrets", git.PathParam: "task.yaml"},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := r.ValidateParams(ctx, tc.params); (err != nil) != tc.wantErr {
+				t.Errorf("ValidateParams() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	ctx := context.Background()
+	url := createBareRepo(t)
+	r := git.NewResolver()
+	if err := r.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := r.Resolve(ctx, map[string]string{git.URLParam: url, git.PathParam: "pipelines/build.yaml"})
+	if err != nil {
+		t.Fatalf("Resolve() = %v", err)
+	}
+	if string(data) != pipelineYAML {
+		t.Errorf("expected %q but got %q", pipelineYAML, data)
+	}
+
+	if _, err := r.Resolve(ctx, map[string]string{git.URLParam: url, git.PathParam: "pipelines/missing.yaml"}); err == nil {
+		t.Error("expected an error resolving a missing file but got none")
+	}
+}
+
+func TestResolver_ResolveURLTakenForAnOption(t *testing.T) {
+	ctx := context.Background()
+	r := git.NewResolver()
+	if err := r.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "git-resolver-upload-pack-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	marker := filepath.Join(dir, "pwned")
+
+	// Even when the params aren't validated first, the url is never passed to git as an option.
+	if _, err := r.Resolve(ctx, map[string]string{git.URLParam: "--upload-pack=touch " + marker + "; false", git.PathParam: "task.yaml"}); err == nil {
+		t.Error("expected an error resolving a url taken for an option but got none")
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("expected the --upload-pack command not to run, but %s exists", marker)
+	}
+}
@@ -130,6 +130,37 @@ func ValidateVariableIsolatedP(value, prefix string, vars sets.String) *apis.Fie
 	return nil
 }
 
+// ValidateObjectKeysP verifies that the variables in value which reference one of the object variables in objectKeys,
+// e.g. "$(params.foo.key)", use one of the keys declared for that object. References to a whole object, e.g.
+// "$(params.foo)" or "$(params.foo[*])", are prohibited.
+func ValidateObjectKeysP(value, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	pattern := fmt.Sprintf(braceMatchingRegex, prefix, parameterSubstitution)
+	re := regexp.MustCompile(pattern)
+	for _, match := range re.FindAllStringSubmatch(value, -1) {
+		v := strings.TrimSuffix(matchGroups(match, re)["var"], "[*]")
+		parts := strings.SplitN(v, ".", 2)
+		keys, ok := objectKeys[parts[0]]
+		if !ok {
+			continue
+		}
+		if len(parts) == 1 {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("variable type invalid in %q", value),
+				// Empty path is required to make the `ViaField`, … work
+				Paths: []string{""},
+			}
+		}
+		if !keys.Has(parts[1]) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("non-existent variable in %q", value),
+				// Empty path is required to make the `ViaField`, … work
+				Paths: []string{""},
+			}
+		}
+	}
+	return nil
+}
+
 // Extract a the first full string expressions found (e.g "$(input.params.foo)"). Return
 // "" and false if nothing is found.
 func extractExpressionFromString(s, prefix string) (string, bool) {
@@ -116,6 +116,49 @@ func TestValidateVariables(t *testing.T) {
 	}
 }
 
+func TestValidateObjectKeysP(t *testing.T) {
+	objectKeys := map[string]sets.String{"git": sets.NewString("url", "revision")}
+	for _, tc := range []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "declared keys",
+		input: "git clone $(params.git.url) --branch $(params.git.revision)",
+	}, {
+		name:  "variables that are not objects",
+		input: "--flag=$(params.foo) $(params.bar.baz) $(params.arr[*])",
+	}, {
+		name:  "undeclared key",
+		input: "--depth=$(params.git.depth)",
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "--depth=$(params.git.depth)"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:  "whole object",
+		input: "$(params.git)",
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.git)"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:  "whole object with star",
+		input: "$(params.git[*])",
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.git[*])"`,
+			Paths:   []string{""},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ValidateObjectKeysP(tc.input, "params", objectKeys)
+			if d := cmp.Diff(tc.expectedError, got, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateObjectKeysP() error did not match expected error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestApplyReplacements(t *testing.T) {
 	type args struct {
 		input        string
@@ -64,6 +64,10 @@ var (
 	cloudEvents = stats.Int64("cloudevent_count",
 		"number of cloud events sent including retries",
 		stats.UnitDimensionless)
+
+	trPrunedCount = stats.Float64("taskrun_pruned_count",
+		"number of taskruns deleted by the pruner",
+		stats.UnitDimensionless)
 )
 
 type Recorder struct {
@@ -76,6 +80,7 @@ type Recorder struct {
 	pipeline    tag.Key
 	pipelineRun tag.Key
 	pod         tag.Key
+	reason      tag.Key
 
 	ReportingPeriod time.Duration
 }
@@ -143,6 +148,12 @@ func NewRecorder() (*Recorder, error) {
 		}
 		r.pod = pod
 
+		reason, recorderErr := tag.NewKey("reason")
+		if recorderErr != nil {
+			return
+		}
+		r.reason = reason
+
 		recorderErr = view.Register(
 			&view.View{
 				Description: trDuration.Description(),
@@ -179,6 +190,12 @@ func NewRecorder() (*Recorder, error) {
 				Aggregation: view.Sum(),
 				TagKeys:     []tag.Key{r.task, r.taskRun, r.namespace, r.status, r.pipeline, r.pipelineRun},
 			},
+			&view.View{
+				Description: trPrunedCount.Description(),
+				Measure:     trPrunedCount,
+				Aggregation: view.Count(),
+				TagKeys:     []tag.Key{r.namespace, r.reason},
+			},
 		)
 
 		if recorderErr != nil {
@@ -403,3 +420,23 @@ func getScheduledTime(pod *corev1.Pod) metav1.Time {
 
 	return metav1.Time{}
 }
+
+// Pruned logs the deletion of a finished TaskRun by the pruner for the given reason
+// returns an error if its failed to log the metrics
+func (r *Recorder) Pruned(tr *v1beta1.TaskRun, reason string) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.namespace, tr.Namespace),
+		tag.Insert(r.reason, reason),
+	)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, trPrunedCount.M(1))
+	return nil
+}
@@ -55,6 +55,9 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.CloudEvents(&v1beta1.TaskRun{}); err == nil {
 		t.Error("Cloud Events recording expected to return error but got nil")
 	}
+	if err := metrics.Pruned(&v1beta1.TaskRun{}, "ttl"); err == nil {
+		t.Error("Pruned recording expected to return error but got nil")
+	}
 }
 
 func TestRecordTaskRunDurationCount(t *testing.T) {
@@ -452,8 +455,27 @@ func TestRecordCloudEvents(t *testing.T) {
 	}
 }
 
+func TestRecordPruned(t *testing.T) {
+	for _, reason := range []string{"ttl", "keep"} {
+		t.Run(reason, func(t *testing.T) {
+			unregisterMetrics()
+
+			metrics, err := NewRecorder()
+			if err != nil {
+				t.Fatalf("NewRecorder: %v", err)
+			}
+
+			tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-1", Namespace: "ns"}}
+			if err := metrics.Pruned(tr, reason); err != nil {
+				t.Errorf("Pruned: %v", err)
+			}
+			metricstest.CheckCountData(t, "taskrun_pruned_count", map[string]string{"namespace": "ns", "reason": reason}, 1)
+		})
+	}
+}
+
 func unregisterMetrics() {
-	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count")
+	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count", "taskrun_pruned_count")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
@@ -39,6 +39,18 @@ func TestParseMessage(t *testing.T) {
 			Key:   "foo",
 			Value: "bar",
 		}},
+	}, {
+		desc: "array and object results are kept as JSON",
+		msg:  `[{"key":"array","value":"[\"a\",\"b\"]","type":"TaskRunResult"},{"key":"object","value":"{\"url\":\"abc\"}","type":"TaskRunResult"}]`,
+		want: []v1beta1.PipelineResourceResult{{
+			Key:        "array",
+			Value:      `["a","b"]`,
+			ResultType: v1beta1.TaskRunResultType,
+		}, {
+			Key:        "object",
+			Value:      `{"url":"abc"}`,
+			ResultType: v1beta1.TaskRunResultType,
+		}},
 	}, {
 		desc: "empty message",
 		msg:  "",
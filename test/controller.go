@@ -30,6 +30,7 @@ import (
 	informersv1beta1 "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1beta1"
 	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
 	fakeconditioninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/condition/fake"
+	fakeresolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/resolutionrequest/fake"
 	fakeruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run/fake"
 	fakeclustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/clustertask/fake"
 	fakepipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline/fake"
@@ -54,6 +55,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	fakeconfigmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
+	fakenamespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
 	fakefilteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered/fake"
 	fakeserviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount/fake"
 	"knative.dev/pkg/controller"
@@ -62,18 +64,19 @@ import (
 // Data represents the desired state of the system (i.e. existing resources) to seed controllers
 // with.
 type Data struct {
-	PipelineRuns      []*v1beta1.PipelineRun
-	Pipelines         []*v1beta1.Pipeline
-	TaskRuns          []*v1beta1.TaskRun
-	Tasks             []*v1beta1.Task
-	ClusterTasks      []*v1beta1.ClusterTask
-	PipelineResources []*v1alpha1.PipelineResource
-	Conditions        []*v1alpha1.Condition
-	Runs              []*v1alpha1.Run
-	Pods              []*corev1.Pod
-	Namespaces        []*corev1.Namespace
-	ConfigMaps        []*corev1.ConfigMap
-	ServiceAccounts   []*corev1.ServiceAccount
+	PipelineRuns       []*v1beta1.PipelineRun
+	Pipelines          []*v1beta1.Pipeline
+	TaskRuns           []*v1beta1.TaskRun
+	Tasks              []*v1beta1.Task
+	ClusterTasks       []*v1beta1.ClusterTask
+	PipelineResources  []*v1alpha1.PipelineResource
+	Conditions         []*v1alpha1.Condition
+	Runs               []*v1alpha1.Run
+	ResolutionRequests []*v1alpha1.ResolutionRequest
+	Pods               []*corev1.Pod
+	Namespaces         []*corev1.Namespace
+	ConfigMaps         []*corev1.ConfigMap
+	ServiceAccounts    []*corev1.ServiceAccount
 }
 
 // Clients holds references to clients which are useful for reconciler tests.
@@ -86,17 +89,19 @@ type Clients struct {
 
 // Informers holds references to informers which are useful for reconciler tests.
 type Informers struct {
-	PipelineRun      informersv1beta1.PipelineRunInformer
-	Pipeline         informersv1beta1.PipelineInformer
-	TaskRun          informersv1beta1.TaskRunInformer
-	Run              informersv1alpha1.RunInformer
-	Task             informersv1beta1.TaskInformer
-	ClusterTask      informersv1beta1.ClusterTaskInformer
-	PipelineResource resourceinformersv1alpha1.PipelineResourceInformer
-	Condition        informersv1alpha1.ConditionInformer
-	Pod              coreinformers.PodInformer
-	ConfigMap        coreinformers.ConfigMapInformer
-	ServiceAccount   coreinformers.ServiceAccountInformer
+	PipelineRun       informersv1beta1.PipelineRunInformer
+	Pipeline          informersv1beta1.PipelineInformer
+	TaskRun           informersv1beta1.TaskRunInformer
+	Run               informersv1alpha1.RunInformer
+	Task              informersv1beta1.TaskInformer
+	ClusterTask       informersv1beta1.ClusterTaskInformer
+	PipelineResource  resourceinformersv1alpha1.PipelineResourceInformer
+	Condition         informersv1alpha1.ConditionInformer
+	ResolutionRequest informersv1alpha1.ResolutionRequestInformer
+	Pod               coreinformers.PodInformer
+	ConfigMap         coreinformers.ConfigMapInformer
+	ServiceAccount    coreinformers.ServiceAccountInformer
+	Namespace         coreinformers.NamespaceInformer
 }
 
 // Assets holds references to the controller, logs, clients, and informers.
@@ -166,17 +171,19 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 	PrependResourceVersionReactor(&c.Pipeline.Fake)
 
 	i := Informers{
-		PipelineRun:      fakepipelineruninformer.Get(ctx),
-		Pipeline:         fakepipelineinformer.Get(ctx),
-		TaskRun:          faketaskruninformer.Get(ctx),
-		Run:              fakeruninformer.Get(ctx),
-		Task:             faketaskinformer.Get(ctx),
-		ClusterTask:      fakeclustertaskinformer.Get(ctx),
-		PipelineResource: fakeresourceinformer.Get(ctx),
-		Condition:        fakeconditioninformer.Get(ctx),
-		Pod:              fakefilteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey),
-		ConfigMap:        fakeconfigmapinformer.Get(ctx),
-		ServiceAccount:   fakeserviceaccountinformer.Get(ctx),
+		PipelineRun:       fakepipelineruninformer.Get(ctx),
+		Pipeline:          fakepipelineinformer.Get(ctx),
+		TaskRun:           faketaskruninformer.Get(ctx),
+		Run:               fakeruninformer.Get(ctx),
+		Task:              faketaskinformer.Get(ctx),
+		ClusterTask:       fakeclustertaskinformer.Get(ctx),
+		PipelineResource:  fakeresourceinformer.Get(ctx),
+		Condition:         fakeconditioninformer.Get(ctx),
+		ResolutionRequest: fakeresolutionrequestinformer.Get(ctx),
+		Pod:               fakefilteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey),
+		ConfigMap:         fakeconfigmapinformer.Get(ctx),
+		ServiceAccount:    fakeserviceaccountinformer.Get(ctx),
+		Namespace:         fakenamespaceinformer.Get(ctx),
 	}
 
 	// Attach reactors that add resource mutations to the appropriate
@@ -238,6 +245,13 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Pipeline.PrependReactor("*", "resolutionrequests", AddToInformer(t, i.ResolutionRequest.Informer().GetIndexer()))
+	for _, rr := range d.ResolutionRequests {
+		rr := rr.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().ResolutionRequests(rr.Namespace).Create(ctx, rr, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 	c.Kube.PrependReactor("*", "pods", AddToInformer(t, i.Pod.Informer().GetIndexer()))
 	for _, p := range d.Pods {
 		p := p.DeepCopy() // Avoid assumptions that the informer's copy is modified.
@@ -245,6 +259,7 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Kube.PrependReactor("*", "namespaces", AddToInformer(t, i.Namespace.Informer().GetIndexer()))
 	for _, n := range d.Namespaces {
 		n := n.DeepCopy() // Avoid assumptions that the informer's copy is modified.
 		if _, err := c.Kube.CoreV1().Namespaces().Create(ctx, n, metav1.CreateOptions{}); err != nil {
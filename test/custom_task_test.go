@@ -29,6 +29,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
 	"github.com/tektoncd/pipeline/test/diff"
 	"go.opencensus.io/trace"
 	corev1 "k8s.io/api/core/v1"
@@ -107,10 +108,10 @@ func TestCustomTask(t *testing.T) {
 					}},
 					Results: []v1beta1.PipelineResult{{
 						Name:  "prResult-ref",
-						Value: "$(tasks.custom-task-ref.results.runResult)",
+						Value: *v1beta1.NewArrayOrString("$(tasks.custom-task-ref.results.runResult)"),
 					}, {
 						Name:  "prResult-spec",
-						Value: "$(tasks.custom-task-spec.results.runResult)",
+						Value: *v1beta1.NewArrayOrString("$(tasks.custom-task-spec.results.runResult)"),
 					}},
 				},
 			},
@@ -156,7 +157,7 @@ func TestCustomTask(t *testing.T) {
 			RunStatusFields: v1alpha1.RunStatusFields{
 				Results: []v1alpha1.RunResult{{
 					Name:  "runResult",
-					Value: "aResultValue",
+					Value: *runv1alpha1.NewRunResultValue("aResultValue"),
 				}},
 			},
 		}
@@ -225,10 +226,10 @@ func TestCustomTask(t *testing.T) {
 
 	expectedPipelineResults := []v1beta1.PipelineRunResult{{
 		Name:  "prResult-ref",
-		Value: "aResultValue",
+		Value: *v1beta1.NewArrayOrString("aResultValue"),
 	}, {
 		Name:  "prResult-spec",
-		Value: "aResultValue",
+		Value: *v1beta1.NewArrayOrString("aResultValue"),
 	}}
 
 	if len(pr.Status.PipelineResults) != 2 {